@@ -0,0 +1,101 @@
+package main
+
+import (
+	"backup_server/internal/backup"
+	"backup_server/internal/config"
+	"backup_server/internal/database"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+)
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage:")
+	fmt.Fprintln(os.Stderr, "  backup create -o bundle.tar.gz [-blobs]")
+	fmt.Fprintln(os.Stderr, "  backup restore -i bundle.tar.gz [-blobs]")
+	os.Exit(2)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	cfg := config.Load()
+
+	switch os.Args[1] {
+	case "create":
+		create(cfg, os.Args[2:])
+	case "restore":
+		restore(cfg, os.Args[2:])
+	default:
+		usage()
+	}
+}
+
+func create(cfg *config.Config, args []string) {
+	fs := flag.NewFlagSet("create", flag.ExitOnError)
+	output := fs.String("o", "", "path of the bundle to write")
+	withBlobs := fs.Bool("blobs", false, "include the managed storage directory")
+	fs.Parse(args)
+
+	if *output == "" {
+		usage()
+	}
+
+	db, err := database.InitDB(cfg.DatabaseDSN)
+	if err != nil {
+		log.Fatal("Failed to open database:", err)
+	}
+	defer db.Close()
+
+	out, err := os.Create(*output)
+	if err != nil {
+		log.Fatal("Failed to create bundle:", err)
+	}
+
+	blobDir := ""
+	if *withBlobs {
+		blobDir = cfg.StorageDir
+	}
+
+	if err := backup.Write(out, db, blobDir); err != nil {
+		out.Close()
+		os.Remove(*output)
+		log.Fatal("Backup failed: ", err)
+	}
+	if err := out.Close(); err != nil {
+		log.Fatal("Failed to write bundle:", err)
+	}
+
+	log.Printf("Backup written to %s", *output)
+}
+
+func restore(cfg *config.Config, args []string) {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	input := fs.String("i", "", "path of the bundle to restore")
+	withBlobs := fs.Bool("blobs", false, "also restore the managed storage directory")
+	fs.Parse(args)
+
+	if *input == "" {
+		usage()
+	}
+
+	dbPath, ok := database.SQLitePath(cfg.DatabaseDSN)
+	if !ok {
+		log.Fatal("Restore is only supported for SQLite databases; use pg_restore for PostgreSQL")
+	}
+
+	blobDir := ""
+	if *withBlobs {
+		blobDir = cfg.StorageDir
+	}
+
+	log.Println("Make sure the server is stopped before restoring.")
+	if err := backup.Restore(*input, dbPath, blobDir); err != nil {
+		log.Fatal("Restore failed: ", err)
+	}
+
+	log.Printf("Restored %s from %s; the previous copy was kept with a .pre-restore suffix", dbPath, *input)
+}
@@ -1,7 +1,10 @@
 package main
 
 import (
+	"backup_server/internal/auth"
+	"backup_server/internal/config"
 	"backup_server/internal/database"
+	"backup_server/internal/integrity"
 	"log"
 	"os"
 )
@@ -16,14 +19,18 @@ func addFile(
 
 	if _, err := os.Stat(filePath); err == nil {
 		log.Printf("Adding file: %s (%s) \n", fileName, filePath)
-		db.AddFile(fileName, filePath, groupID, description)
+		if fileID, err := db.AddFile(fileName, filePath, groupID, description); err == nil {
+			integrity.Record(db, fileID, filePath)
+		}
 	} else {
 		log.Printf("Skipping file: %s (%s) %v\n", fileName, filePath, err)
 	}
 }
 
 func main() {
-	db, err := database.InitDB("backup_server.db")
+	cfg := config.Load()
+
+	db, err := database.InitDB(cfg.DatabaseDSN)
 	if err != nil {
 		log.Fatal("Failed to initialize database:", err)
 	}
@@ -31,11 +38,17 @@ func main() {
 
 	log.Println("Creating groups...")
 	adminGroupID, _ := db.CreateGroup("admins")
+	if role, err := db.GetRoleByName(auth.AdminRoleName); err == nil {
+		db.AssignRoleToGroup(role.ID, int(adminGroupID))
+	}
 
 	log.Println("Creating users...")
 	db.CreateUser("admin", "admin", []int{int(adminGroupID)})
+	if admin, err := db.GetUserByUsername("admin"); err == nil {
+		db.SetMustChangePassword(admin.ID, true)
+	}
 
 	log.Println("Database initialized successfully!")
 	log.Println("Default users:")
-	log.Println("  admin/admin (admins group)")
+	log.Println("  admin/admin (admins group with the admin role, must be changed at first login)")
 }
@@ -2,57 +2,193 @@ package main
 
 import (
 	"backup_server/internal/auth"
+	"backup_server/internal/config"
 	"backup_server/internal/database"
 	"backup_server/internal/handlers"
+	"backup_server/internal/password"
+	"context"
+	"errors"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 )
 
+// shutdownTimeout bounds how long requests in progress may take to finish
+// once the server is asked to stop.
+const shutdownTimeout = 30 * time.Second
+
 func main() {
-	db, err := database.InitDB("backup_server.db")
+	cfg := config.Load()
+
+	db, err := database.InitDB(cfg.DatabaseDSN)
 	if err != nil {
 		log.Fatal("Failed to initialize database:", err)
 	}
 	defer db.Close()
 
+	policy := &password.Policy{
+		MinLength:        cfg.PasswordMinLength,
+		MinClasses:       cfg.PasswordMinClasses,
+		DisallowUsername: cfg.PasswordDisallowUsername,
+		HistorySize:      cfg.PasswordHistory,
+	}
+	if cfg.BreachedPasswordsFile != "" {
+		policy.Breached, err = password.OpenBreachedList(cfg.BreachedPasswordsFile)
+		if err != nil {
+			log.Fatal("Failed to open breached password list:", err)
+		}
+		defer policy.Breached.Close()
+	}
+	db.SetPasswordPolicy(policy)
+
 	sessions := auth.NewSessionStore()
-	handler := handlers.NewHandler(db, sessions)
+	handler := handlers.NewHandler(db, sessions, cfg)
+	if handler.Watcher != nil {
+		if err := handler.Watcher.CheckGroup(); err != nil {
+			log.Fatalf("Cannot watch save directories: %v (set BACKUP_SERVER_WATCH_GROUP to an existing group)", err)
+		}
+	}
+
+	// The background workers stop when the server is asked to, and are
+	// waited for before the database is closed.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	var workers sync.WaitGroup
+	start := func(run func(context.Context)) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			run(ctx)
+		}()
+	}
+	start(handler.Chests.Run)
+	if handler.Watcher != nil {
+		start(handler.Watcher.Run)
+	}
+	start(handler.Jobs.Run)
+	start(handler.Scrub.Run)
 
 	r := chi.NewRouter()
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
 
 	r.Get("/", handler.LoginPage)
-	r.Post("/login", handler.Login)
-	r.Get("/logout", handler.Logout)
+	r.With(handler.CSRFMiddleware).Post("/login", handler.Login)
+	r.Get("/s/{token}", handler.PublicShare)
+	r.With(handler.CSRFMiddleware).Post("/s/{token}", handler.PublicShareUnlock)
+	r.Get("/invite/{token}", handler.InvitePage)
+	r.With(handler.CSRFMiddleware).Post("/invite/{token}", handler.AcceptInvite)
+	r.Get("/oidc/login", handler.OIDCLogin)
+	r.Get("/oidc/callback", handler.OIDCCallback)
 
 	fileServer := http.FileServer(http.Dir("static/terramap"))
 	r.Handle("/terramap/*", http.StripPrefix("/terramap", fileServer))
 
 	r.Group(func(r chi.Router) {
 		r.Use(handler.AuthMiddleware)
+		r.Use(handler.CSRFMiddleware)
+		r.Post("/logout", handler.Logout)
 		r.Get("/files", handler.FilesPage)
 		r.Get("/download", handler.DownloadFile)
 		r.Get("/worldfile", handler.ServeWorldFile)
+		r.Get("/worldfile/map.png", handler.WorldMap)
+		r.Get("/worlds/diff", handler.WorldDiffPage)
+		r.Get("/worlds/diff.png", handler.WorldDiffImage)
 		r.Get("/viewer/terramap", handler.TerraMapViewer)
-		r.Get("/admin/files", handler.AdminPage)
-		r.Post("/admin/files/add", handler.AdminAddFile)
-		r.Post("/admin/files/edit", handler.AdminEditFile)
-		r.Post("/admin/files/delete", handler.AdminDeleteFile)
-		r.Get("/admin/users", handler.AdminUsersPage)
-		r.Post("/admin/users/add", handler.AdminAddUser)
-		r.Post("/admin/users/edit", handler.AdminEditUser)
-		r.Post("/admin/users/password", handler.AdminChangeUserPassword)
-		r.Post("/admin/users/delete", handler.AdminDeleteUser)
-		r.Get("/admin/groups", handler.AdminGroupsPage)
-		r.Post("/admin/groups/add", handler.AdminAddGroup)
-		r.Post("/admin/groups/edit", handler.AdminEditGroup)
-		r.Post("/admin/groups/delete", handler.AdminDeleteGroup)
+		r.Get("/api/files", handler.APIFiles)
+		r.Get("/api/world", handler.APIWorld)
+		r.Get("/api/world/diff", handler.APIWorldDiff)
+		r.Get("/api/player", handler.APIPlayer)
+		r.Get("/players", handler.PlayerPage)
+		r.Get("/api/chests/search", handler.APIChestSearch)
+		r.Get("/search/chests", handler.ChestSearchPage)
+		r.Get("/files/share", handler.FileSharingPage)
+		r.Post("/files/share/grant", handler.FileGrant)
+		r.Post("/files/share/revoke", handler.FileRevokeGrant)
+		r.Get("/links", handler.ShareLinksPage)
+		r.Post("/links/create", handler.CreateShareLink)
+		r.Post("/links/revoke", handler.RevokeShareLink)
+		r.Get("/groups/manage", handler.ManagedGroupsPage)
+		r.Post("/groups/manage/members/add", handler.ManagedGroupAddMember)
+		r.Post("/groups/manage/members/remove", handler.ManagedGroupRemoveMember)
+		r.Post("/groups/manage/files/edit", handler.ManagedGroupEditFile)
+		r.Get("/account", handler.AccountPage)
+		r.Get("/account/password", handler.ChangePasswordPage)
+		r.Post("/account/password", handler.AccountChangePassword)
+		r.Post("/account/sessions/revoke", handler.AccountRevokeSession)
+		r.Post("/account/identities/link", handler.AccountLinkIdentity)
+		r.Post("/account/identities/unlink", handler.AccountUnlinkIdentity)
+
+		r.Group(func(r chi.Router) {
+			r.Use(handler.RequirePermission(auth.PermManageFiles))
+			r.Get("/admin/files", handler.AdminPage)
+			r.Post("/admin/files/add", handler.AdminAddFile)
+			r.Post("/admin/files/edit", handler.AdminEditFile)
+			r.Post("/admin/files/delete", handler.AdminDeleteFile)
+			r.Post("/admin/files/scrub", handler.AdminScrubFiles)
+			r.Post("/admin/files/checksum", handler.AdminAcceptChecksum)
+			r.Get("/admin/jobs", handler.AdminJobsPage)
+			r.Post("/admin/jobs/save", handler.AdminSaveJob)
+			r.Post("/admin/jobs/delete", handler.AdminDeleteJob)
+			r.Post("/admin/jobs/run", handler.AdminRunJob)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(handler.RequirePermission(auth.PermManageUsers))
+			r.Get("/admin/backup", handler.AdminDownloadBackup)
+			r.Get("/admin/users", handler.AdminUsersPage)
+			r.Post("/admin/users/add", handler.AdminAddUser)
+			r.Post("/admin/users/edit", handler.AdminEditUser)
+			r.Post("/admin/users/password", handler.AdminChangeUserPassword)
+			r.Post("/admin/users/delete", handler.AdminDeleteUser)
+			r.Post("/admin/users/signout", handler.AdminSignOutUser)
+			r.Post("/admin/lockouts/clear", handler.AdminClearLockout)
+			r.Get("/admin/roles", handler.AdminRolesPage)
+			r.Post("/admin/roles/add", handler.AdminAddRole)
+			r.Post("/admin/roles/edit", handler.AdminEditRole)
+			r.Post("/admin/roles/delete", handler.AdminDeleteRole)
+			r.Get("/admin/invitations", handler.AdminInvitationsPage)
+			r.Post("/admin/invitations/create", handler.AdminCreateInvitation)
+			r.Post("/admin/invitations/revoke", handler.AdminRevokeInvitation)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(handler.RequirePermission(auth.PermManageGroups))
+			r.Get("/admin/groups", handler.AdminGroupsPage)
+			r.Post("/admin/groups/add", handler.AdminAddGroup)
+			r.Post("/admin/groups/edit", handler.AdminEditGroup)
+			r.Post("/admin/groups/delete", handler.AdminDeleteGroup)
+		})
+
+		r.With(handler.RequirePermission(auth.PermViewAudit)).Get("/admin/audit", handler.AdminAuditPage)
 	})
 
-	log.Println("Server starting on http://localhost:8090")
-	log.Fatal(http.ListenAndServe(":8090", r))
+	server := &http.Server{Addr: cfg.ListenAddr, Handler: r}
+	// ListenAndServe returns as soon as Shutdown is called; the requests in
+	// progress are waited for before the database is closed.
+	shutdown := make(chan struct{})
+	go func() {
+		defer close(shutdown)
+		<-ctx.Done()
+		log.Printf("Shutting down")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Failed to shut down cleanly: %v", err)
+		}
+	}()
+
+	log.Printf("Server starting on %s", cfg.ListenAddr)
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+	<-shutdown
+	workers.Wait()
 }
@@ -3,7 +3,24 @@ module backup_server
 go 1.21
 
 require (
+	github.com/coreos/go-oidc/v3 v3.9.0
+	github.com/fsnotify/fsnotify v1.7.0
 	github.com/go-chi/chi/v5 v5.0.11
+	github.com/go-ldap/ldap/v3 v3.4.6
+	github.com/lib/pq v1.10.9
 	github.com/mattn/go-sqlite3 v1.14.19
-	golang.org/x/crypto v0.18.0
+	github.com/robfig/cron/v3 v3.0.1
+	golang.org/x/crypto v0.19.0
+	golang.org/x/oauth2 v0.16.0
+)
+
+require (
+	github.com/Azure/go-ntlmssp v0.0.0-20221128193559-754e69321358 // indirect
+	github.com/go-asn1-ber/asn1-ber v1.5.5 // indirect
+	github.com/go-jose/go-jose/v3 v3.0.4 // indirect
+	github.com/golang/protobuf v1.5.3 // indirect
+	github.com/google/uuid v1.3.1 // indirect
+	golang.org/x/sys v0.17.0 // indirect
+	google.golang.org/appengine v1.6.8 // indirect
+	google.golang.org/protobuf v1.31.0 // indirect
 )
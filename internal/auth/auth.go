@@ -2,17 +2,30 @@ package auth
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"net/http"
+	"sort"
 	"sync"
 	"time"
 )
 
 type Session struct {
-	UserID   int
-	Username string
-	GroupIDs []int
-	Expires  time.Time
+	UserID    int
+	Username  string
+	GroupIDs  []int
+	CSRFToken string
+	// Handle identifies the session to its owner without exposing the
+	// secret session ID, e.g. when listing or revoking active sessions.
+	Handle    string
+	IP        string
+	UserAgent string
+	// MustChangePassword restricts the session to the change-password page
+	// until the user picks a new password.
+	MustChangePassword bool
+	Created            time.Time
+	Expires            time.Time
 }
 
 type SessionStore struct {
@@ -28,20 +41,41 @@ func NewSessionStore() *SessionStore {
 	return store
 }
 
-func (s *SessionStore) Create(userID int, username string, groupIDs []int) (string, error) {
+// NewToken returns a random URL-safe token suitable for session IDs and
+// CSRF tokens.
+func NewToken() (string, error) {
 	token := make([]byte, 32)
 	if _, err := rand.Read(token); err != nil {
 		return "", err
 	}
+	return base64.URLEncoding.EncodeToString(token), nil
+}
+
+func (s *SessionStore) Create(userID int, username string, groupIDs []int, ip, userAgent string) (string, error) {
+	sessionID, err := NewToken()
+	if err != nil {
+		return "", err
+	}
+
+	csrfToken, err := NewToken()
+	if err != nil {
+		return "", err
+	}
 
-	sessionID := base64.URLEncoding.EncodeToString(token)
+	handle := sha256.Sum256([]byte(sessionID))
+	now := time.Now()
 
 	s.mu.Lock()
 	s.sessions[sessionID] = &Session{
-		UserID:   userID,
-		Username: username,
-		GroupIDs: groupIDs,
-		Expires:  time.Now().Add(24 * time.Hour),
+		UserID:    userID,
+		Username:  username,
+		GroupIDs:  groupIDs,
+		CSRFToken: csrfToken,
+		Handle:    hex.EncodeToString(handle[:8]),
+		IP:        ip,
+		UserAgent: userAgent,
+		Created:   now,
+		Expires:   now.Add(24 * time.Hour),
 	}
 	s.mu.Unlock()
 
@@ -57,7 +91,10 @@ func (s *SessionStore) Get(sessionID string) (*Session, bool) {
 		return nil, false
 	}
 
-	return session, true
+	// Hand out a copy so the store can update sessions without racing
+	// requests that are reading them.
+	copied := *session
+	return &copied, true
 }
 
 func (s *SessionStore) Delete(sessionID string) {
@@ -66,6 +103,122 @@ func (s *SessionStore) Delete(sessionID string) {
 	s.mu.Unlock()
 }
 
+// ListByUser returns copies of the user's active sessions, newest first.
+func (s *SessionStore) ListByUser(userID int) []Session {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	var sessions []Session
+	for _, session := range s.sessions {
+		if session.UserID == userID && session.Expires.After(now) {
+			sessions = append(sessions, *session)
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].Created.After(sessions[j].Created)
+	})
+	return sessions
+}
+
+// DeleteByHandle revokes the session with the given handle if it belongs to
+// userID. It reports whether a session was removed.
+func (s *SessionStore) DeleteByHandle(userID int, handle string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, session := range s.sessions {
+		if session.UserID == userID && session.Handle == handle {
+			delete(s.sessions, id)
+			return true
+		}
+	}
+	return false
+}
+
+// DeleteOthers revokes every session of userID except the one with the given
+// handle.
+func (s *SessionStore) DeleteOthers(userID int, keepHandle string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, session := range s.sessions {
+		if session.UserID == userID && session.Handle != keepHandle {
+			delete(s.sessions, id)
+		}
+	}
+}
+
+// DeleteByUser revokes every session of userID and returns how many were
+// removed.
+func (s *SessionStore) DeleteByUser(userID int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := 0
+	for id, session := range s.sessions {
+		if session.UserID == userID {
+			delete(s.sessions, id)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Refresh updates the username and groups recorded on all of the user's
+// sessions after an admin changes them.
+func (s *SessionStore) Refresh(userID int, username string, groupIDs []int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, session := range s.sessions {
+		if session.UserID == userID {
+			session.Username = username
+			session.GroupIDs = groupIDs
+		}
+	}
+}
+
+// CountByUser returns the number of active sessions of each signed-in user.
+func (s *SessionStore) CountByUser() map[int]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	counts := make(map[int]int)
+	for _, session := range s.sessions {
+		if session.Expires.After(now) {
+			counts[session.UserID]++
+		}
+	}
+	return counts
+}
+
+// SetMustChangePassword updates the restriction on all of the user's
+// sessions.
+func (s *SessionStore) SetMustChangePassword(userID int, must bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, session := range s.sessions {
+		if session.UserID == userID {
+			session.MustChangePassword = must
+		}
+	}
+}
+
+// SetSessionMustChangePassword updates the restriction on one session, such
+// as the one just created for a user whose password must change.
+func (s *SessionStore) SetSessionMustChangePassword(sessionID string, must bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if session, ok := s.sessions[sessionID]; ok {
+		session.MustChangePassword = must
+	}
+}
+
 func (s *SessionStore) cleanupExpired() {
 	ticker := time.NewTicker(1 * time.Hour)
 	for range ticker.C {
@@ -108,3 +261,57 @@ func ClearSessionCookie(w http.ResponseWriter) {
 		MaxAge:   -1,
 	})
 }
+
+// GetCSRFCookie returns the double-submit CSRF token used by forms that are
+// shown before a session exists, such as the login page.
+func GetCSRFCookie(r *http.Request) (string, error) {
+	cookie, err := r.Cookie("csrf_token")
+	if err != nil {
+		return "", err
+	}
+	return cookie.Value, nil
+}
+
+func SetCSRFCookie(w http.ResponseWriter, token string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     "csrf_token",
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+// The OIDC state cookie ties a sign-in at the identity provider to the
+// browser that started it. It is Lax rather than Strict because the
+// provider sends the browser back with a cross-site redirect.
+const oidcStateCookie = "oidc_state"
+
+func SetOIDCStateCookie(w http.ResponseWriter, state string, maxAge time.Duration) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		Path:     "/oidc/",
+		HttpOnly: true,
+		MaxAge:   int(maxAge.Seconds()),
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func GetOIDCStateCookie(r *http.Request) (string, error) {
+	cookie, err := r.Cookie(oidcStateCookie)
+	if err != nil {
+		return "", err
+	}
+	return cookie.Value, nil
+}
+
+func ClearOIDCStateCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    "",
+		Path:     "/oidc/",
+		HttpOnly: true,
+		MaxAge:   -1,
+	})
+}
@@ -0,0 +1,29 @@
+package auth
+
+import "testing"
+
+func TestSetSessionMustChangePassword(t *testing.T) {
+	s := NewSessionStore()
+	first, err := s.Create(1, "alice", nil, "10.0.0.1", "laptop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := s.Create(1, "alice", nil, "10.0.0.2", "phone")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.SetSessionMustChangePassword(second, true)
+	if session, _ := s.Get(first); session.MustChangePassword {
+		t.Error("signing in again restricted the user's other session")
+	}
+	if session, _ := s.Get(second); !session.MustChangePassword {
+		t.Error("new session not restricted")
+	}
+
+	// Changing the password lifts the restriction everywhere.
+	s.SetMustChangePassword(1, false)
+	if session, _ := s.Get(second); session.MustChangePassword {
+		t.Error("restriction kept after SetMustChangePassword")
+	}
+}
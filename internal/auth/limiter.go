@@ -0,0 +1,224 @@
+package auth
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	backoffBase = 1 * time.Second
+	backoffMax  = 1 * time.Minute
+)
+
+// Lockout kinds reported by LoginLimiter. Share link lockouts are keyed by
+// the link's id.
+const (
+	LockoutUser      = "user"
+	LockoutIP        = "ip"
+	LockoutShareLink = "share_link"
+)
+
+// Lockout describes a username, client address or share link that is
+// currently locked out. Failures is how many failed attempts led to it.
+type Lockout struct {
+	Kind        string
+	Key         string
+	Failures    int
+	LockedUntil time.Time
+}
+
+type attempts struct {
+	failures     int
+	lastFailure  time.Time
+	blockedUntil time.Time
+	lockedUntil  time.Time
+}
+
+// LoginLimiter throttles password attempts per client address and per
+// username, and attempts at share link passwords per link. Every failure adds
+// an exponentially growing delay before the next attempt is accepted, and
+// reaching the failure limit locks the key out entirely for the lockout
+// duration. Usernames are tracked whether or not they exist so responses
+// never reveal which accounts are real. Share links are limited like
+// usernames but tracked apart, so they are never mistaken for one.
+type LoginLimiter struct {
+	mu              sync.Mutex
+	users           map[string]*attempts
+	ips             map[string]*attempts
+	shareLinks      map[string]*attempts
+	maxUserFailures int
+	maxIPFailures   int
+	lockout         time.Duration
+}
+
+func NewLoginLimiter(maxUserFailures, maxIPFailures int, lockout time.Duration) *LoginLimiter {
+	l := &LoginLimiter{
+		users:           make(map[string]*attempts),
+		ips:             make(map[string]*attempts),
+		shareLinks:      make(map[string]*attempts),
+		maxUserFailures: maxUserFailures,
+		maxIPFailures:   maxIPFailures,
+		lockout:         lockout,
+	}
+	go l.cleanupStale()
+	return l
+}
+
+// Allow reports whether a login attempt for username from ip may proceed. If
+// not, it returns how long the caller has to wait.
+func (l *LoginLimiter) Allow(ip, username string) (time.Duration, bool) {
+	return l.allow(ip, l.users, username)
+}
+
+// AllowShareLink is Allow for an attempt at the password of a share link.
+func (l *LoginLimiter) AllowShareLink(ip, linkKey string) (time.Duration, bool) {
+	return l.allow(ip, l.shareLinks, linkKey)
+}
+
+func (l *LoginLimiter) allow(ip string, m map[string]*attempts, key string) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	var wait time.Duration
+	for _, a := range []*attempts{l.ips[ip], m[key]} {
+		if a == nil {
+			continue
+		}
+		for _, until := range []time.Time{a.blockedUntil, a.lockedUntil} {
+			if d := until.Sub(now); d > wait {
+				wait = d
+			}
+		}
+	}
+	return wait, wait <= 0
+}
+
+// RecordFailure registers a failed attempt and reports whether it caused the
+// username or the address to become locked out.
+func (l *LoginLimiter) RecordFailure(ip, username string) (userLocked, ipLocked bool) {
+	return l.recordFailure(ip, l.users, username)
+}
+
+// RecordShareLinkFailure registers a wrong share link password. It counts
+// against the address as well, like a failed login.
+func (l *LoginLimiter) RecordShareLinkFailure(ip, linkKey string) (linkLocked, ipLocked bool) {
+	return l.recordFailure(ip, l.shareLinks, linkKey)
+}
+
+func (l *LoginLimiter) recordFailure(ip string, m map[string]*attempts, key string) (keyLocked, ipLocked bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	keyLocked = l.fail(m, key, l.maxUserFailures, now)
+	ipLocked = l.fail(l.ips, ip, l.maxIPFailures, now)
+	return keyLocked, ipLocked
+}
+
+func (l *LoginLimiter) fail(m map[string]*attempts, key string, max int, now time.Time) bool {
+	a := m[key]
+	// A key starts over once its failures are older than the lockout, or
+	// a lockout has run out.
+	if a == nil || now.Sub(a.lastFailure) > l.lockout || (!a.lockedUntil.IsZero() && !now.Before(a.lockedUntil)) {
+		a = &attempts{}
+		m[key] = a
+	}
+
+	a.failures++
+	a.lastFailure = now
+
+	if a.failures >= max {
+		a.lockedUntil = now.Add(l.lockout)
+		return true
+	}
+
+	delay := backoffBase << (a.failures - 1)
+	if delay > backoffMax || delay <= 0 {
+		delay = backoffMax
+	}
+	a.blockedUntil = now.Add(delay)
+	return false
+}
+
+// RecordSuccess clears the failure history for username after a successful
+// login. The address history is left to expire on its own so one valid
+// account cannot be used to reset throttling for guesses at others.
+func (l *LoginLimiter) RecordSuccess(username string) {
+	l.mu.Lock()
+	delete(l.users, username)
+	l.mu.Unlock()
+}
+
+// RecordShareLinkSuccess clears the failure history of a share link once
+// its password was given.
+func (l *LoginLimiter) RecordShareLinkSuccess(linkKey string) {
+	l.mu.Lock()
+	delete(l.shareLinks, linkKey)
+	l.mu.Unlock()
+}
+
+// Lockouts lists every username, address and share link that is currently
+// locked out.
+func (l *LoginLimiter) Lockouts() []Lockout {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	var lockouts []Lockout
+	collect := func(kind string, m map[string]*attempts) {
+		for key, a := range m {
+			if a.lockedUntil.After(now) {
+				lockouts = append(lockouts, Lockout{
+					Kind:        kind,
+					Key:         key,
+					Failures:    a.failures,
+					LockedUntil: a.lockedUntil,
+				})
+			}
+		}
+	}
+	collect(LockoutUser, l.users)
+	collect(LockoutIP, l.ips)
+	collect(LockoutShareLink, l.shareLinks)
+
+	sort.Slice(lockouts, func(i, j int) bool {
+		if lockouts[i].Kind != lockouts[j].Kind {
+			return lockouts[i].Kind > lockouts[j].Kind
+		}
+		return lockouts[i].Key < lockouts[j].Key
+	})
+	return lockouts
+}
+
+// Clear removes any lockout and failure history for the given key.
+func (l *LoginLimiter) Clear(kind, key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	switch kind {
+	case LockoutUser:
+		delete(l.users, key)
+	case LockoutIP:
+		delete(l.ips, key)
+	case LockoutShareLink:
+		delete(l.shareLinks, key)
+	}
+}
+
+func (l *LoginLimiter) cleanupStale() {
+	ticker := time.NewTicker(10 * time.Minute)
+	for range ticker.C {
+		l.mu.Lock()
+		now := time.Now()
+		for _, m := range []map[string]*attempts{l.users, l.ips, l.shareLinks} {
+			for key, a := range m {
+				if a.lockedUntil.Before(now) && now.Sub(a.lastFailure) > l.lockout {
+					delete(m, key)
+				}
+			}
+		}
+		l.mu.Unlock()
+	}
+}
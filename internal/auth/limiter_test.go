@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLockoutsReportFailures(t *testing.T) {
+	l := NewLoginLimiter(3, 100, time.Hour)
+	for i := 0; i < 3; i++ {
+		l.RecordFailure("10.0.0.1", "alice")
+	}
+
+	var user, ip *Lockout
+	for _, lo := range l.Lockouts() {
+		lo := lo
+		switch lo.Kind {
+		case LockoutUser:
+			user = &lo
+		case LockoutIP:
+			ip = &lo
+		}
+	}
+	if user == nil || user.Key != "alice" || user.Failures != 3 {
+		t.Errorf("user lockout = %+v, want alice with 3 failures", user)
+	}
+	if ip != nil {
+		t.Errorf("address locked after 3 of 100 failures: %+v", ip)
+	}
+}
+
+func TestShareLinkLockoutsAreSeparate(t *testing.T) {
+	l := NewLoginLimiter(2, 100, time.Hour)
+	l.RecordShareLinkFailure("10.0.0.1", "7")
+	if linkLocked, _ := l.RecordShareLinkFailure("10.0.0.1", "7"); !linkLocked {
+		t.Fatal("share link not locked after reaching the limit")
+	}
+
+	lockouts := l.Lockouts()
+	if len(lockouts) != 1 || lockouts[0].Kind != LockoutShareLink || lockouts[0].Key != "7" || lockouts[0].Failures != 2 {
+		t.Fatalf("lockouts = %+v, want only share link 7 with 2 failures", lockouts)
+	}
+	if _, ok := l.Allow("10.0.0.2", "7"); !ok {
+		t.Error("a user named like the link id was locked out by the link")
+	}
+	if _, ok := l.AllowShareLink("10.0.0.3", "7"); ok {
+		t.Error("locked share link accepted an attempt")
+	}
+
+	l.Clear(LockoutShareLink, "7")
+	if len(l.Lockouts()) != 0 {
+		t.Error("share link lockout not cleared")
+	}
+}
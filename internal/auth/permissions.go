@@ -0,0 +1,31 @@
+package auth
+
+// Permissions that roles can grant. They are stored by name, so the values
+// must not change once released.
+const (
+	PermManageUsers  = "manage_users"
+	PermManageGroups = "manage_groups"
+	PermManageFiles  = "manage_files"
+	PermViewAudit    = "view_audit"
+)
+
+// RetiredPermissions were offered by earlier versions without ever being
+// enforced. They are removed from roles when the database is opened.
+var RetiredPermissions = []string{"upload"}
+
+// PermissionInfo describes a permission for the role editor.
+type PermissionInfo struct {
+	Name        string
+	Description string
+}
+
+// AllPermissions lists every permission in display order.
+var AllPermissions = []PermissionInfo{
+	{PermManageUsers, "Manage users, roles, lockouts and server backups"},
+	{PermManageGroups, "Manage groups"},
+	{PermManageFiles, "Manage files"},
+	{PermViewAudit, "View the audit log"},
+}
+
+// AdminRoleName is the role seeded with every permission.
+const AdminRoleName = "admin"
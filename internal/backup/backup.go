@@ -0,0 +1,224 @@
+package backup
+
+import (
+	"archive/tar"
+	"backup_server/internal/database"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	dbEntryName = "backup_server.db"
+	blobPrefix  = "blobs/"
+)
+
+// Write streams a gzip-compressed tar bundle to w containing a consistent
+// snapshot of the database and, when blobDir is non-empty, every regular
+// file in the managed blob directory.
+func Write(w io.Writer, db database.Repository, blobDir string) error {
+	tmpDir, err := os.MkdirTemp("", "backup_server-snapshot-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	snapshotPath := filepath.Join(tmpDir, dbEntryName)
+	if err := db.Snapshot(snapshotPath); err != nil {
+		return fmt.Errorf("snapshot database: %w", err)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	if err := addFile(tw, snapshotPath, dbEntryName); err != nil {
+		return err
+	}
+
+	if blobDir != "" {
+		err := filepath.WalkDir(blobDir, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			rel, err := filepath.Rel(blobDir, p)
+			if err != nil {
+				return err
+			}
+			return addFile(tw, p, blobPrefix+filepath.ToSlash(rel))
+		})
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("archive blob directory: %w", err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func addFile(tw *tar.Writer, srcPath, name string) error {
+	f, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	hdr, err := tar.FileInfoHeader(stat, "")
+	if err != nil {
+		return err
+	}
+	hdr.Name = name
+
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// Restore validates the bundle at archivePath and swaps its database in
+// place of the SQLite file at dbPath. When blobDir is non-empty and the
+// bundle carries blobs, the blob directory is replaced too. Anything that is
+// replaced is kept next to the original with a ".pre-restore-<time>" suffix.
+// The server must not be running while a restore takes place.
+func Restore(archivePath, dbPath, blobDir string) error {
+	dbStaging, err := os.MkdirTemp(filepath.Dir(dbPath), ".restore-db-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dbStaging)
+
+	var blobStaging string
+	if blobDir != "" {
+		if err := os.MkdirAll(filepath.Dir(filepath.Clean(blobDir)), 0755); err != nil {
+			return err
+		}
+		blobStaging, err = os.MkdirTemp(filepath.Dir(filepath.Clean(blobDir)), ".restore-blobs-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(blobStaging)
+	}
+
+	hasBlobs, err := extract(archivePath, dbStaging, blobStaging)
+	if err != nil {
+		return err
+	}
+
+	stagedDB := filepath.Join(dbStaging, dbEntryName)
+	if _, err := os.Stat(stagedDB); err != nil {
+		return fmt.Errorf("bundle does not contain %s", dbEntryName)
+	}
+	if err := database.ValidateSnapshot(stagedDB); err != nil {
+		return fmt.Errorf("snapshot rejected: %w", err)
+	}
+
+	suffix := ".pre-restore-" + time.Now().Format("20060102-150405")
+	if err := swap(stagedDB, dbPath, suffix); err != nil {
+		return fmt.Errorf("swap database: %w", err)
+	}
+	if hasBlobs {
+		if err := swap(blobStaging, blobDir, suffix); err != nil {
+			return fmt.Errorf("swap blob directory: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// extract unpacks the bundle, writing the database entry into dbDir and
+// blob entries into blobDir. Blob entries are skipped when blobDir is empty.
+// It reports whether any blobs were extracted.
+func extract(archivePath, dbDir, blobDir string) (bool, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return false, fmt.Errorf("not a backup bundle: %w", err)
+	}
+	defer gz.Close()
+
+	hasBlobs := false
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return false, fmt.Errorf("read bundle: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		var dest string
+		switch {
+		case hdr.Name == dbEntryName:
+			dest = filepath.Join(dbDir, dbEntryName)
+		case strings.HasPrefix(hdr.Name, blobPrefix) && blobDir != "":
+			rel := path.Clean(strings.TrimPrefix(hdr.Name, blobPrefix))
+			if rel == "." || rel == ".." || strings.HasPrefix(rel, "../") || path.IsAbs(rel) {
+				return false, fmt.Errorf("bundle entry %q escapes the blob directory", hdr.Name)
+			}
+			dest = filepath.Join(blobDir, filepath.FromSlash(rel))
+			hasBlobs = true
+		default:
+			continue
+		}
+
+		if err := writeEntry(tr, dest, hdr.FileInfo().Mode().Perm()); err != nil {
+			return false, err
+		}
+	}
+
+	return hasBlobs, nil
+}
+
+func writeEntry(r io.Reader, dest string, perm fs.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// swap moves staged into place at live, first renaming any existing live
+// copy aside with the given suffix.
+func swap(staged, live, suffix string) error {
+	if _, err := os.Stat(live); err == nil {
+		if err := os.Rename(live, live+suffix); err != nil {
+			return err
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return os.Rename(staged, live)
+}
@@ -0,0 +1,221 @@
+package backup
+
+import (
+	"archive/tar"
+	"backup_server/internal/database"
+	"bytes"
+	"compress/gzip"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// bundle writes a gzip-compressed tar with the given entries, in order.
+func bundle(t *testing.T, entries [][2]string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e[0], Mode: 0o644, Size: int64(len(e[1])), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(e[1])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func writeFile(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeTemp(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "snapshot.db")
+	writeFile(t, path, contents)
+	return path
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestWriteRestore(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "backup_server.db")
+	blobDir := filepath.Join(dir, "storage")
+
+	db, err := database.InitDB(dbPath)
+	if err != nil {
+		t.Fatalf("InitDB: %v", err)
+	}
+	if _, err := db.CreateGroup("backed up"); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(blobDir, "world.wld"), "world")
+	writeFile(t, filepath.Join(blobDir, "snapshots", "1", "world.wld"), "older world")
+
+	archive := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	f, err := os.Create(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Write(f, db, blobDir); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Changes after the backup are undone by the restore.
+	if _, err := db.CreateGroup("added later"); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(blobDir, "world.wld"), "changed world")
+	writeFile(t, filepath.Join(blobDir, "new.wld"), "new world")
+	db.Close()
+
+	if err := Restore(archive, dbPath, blobDir); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+
+	db, err = database.InitDB(dbPath)
+	if err != nil {
+		t.Fatalf("InitDB of the restored database: %v", err)
+	}
+	defer db.Close()
+	groups, err := db.GetAllGroups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, g := range groups {
+		names = append(names, g.Name)
+	}
+	if strings.Join(names, ",") != "backed up" {
+		t.Errorf("restored groups = %q, want [backed up]", names)
+	}
+
+	if got := readFile(t, filepath.Join(blobDir, "world.wld")); got != "world" {
+		t.Errorf("restored world.wld = %q", got)
+	}
+	if got := readFile(t, filepath.Join(blobDir, "snapshots", "1", "world.wld")); got != "older world" {
+		t.Errorf("restored snapshot = %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(blobDir, "new.wld")); err == nil {
+		t.Error("file added after the backup survived the restore")
+	}
+
+	// What was replaced is kept aside.
+	for _, pattern := range []string{dbPath + ".pre-restore-*", blobDir + ".pre-restore-*"} {
+		if matches, _ := filepath.Glob(pattern); len(matches) != 1 {
+			t.Errorf("%s: %d copies kept, want 1", filepath.Base(pattern), len(matches))
+		}
+	}
+	aside, _ := filepath.Glob(blobDir + ".pre-restore-*")
+	if len(aside) == 1 {
+		if got := readFile(t, filepath.Join(aside[0], "new.wld")); got != "new world" {
+			t.Errorf("kept new.wld = %q", got)
+		}
+	}
+}
+
+func TestRestoreRejectsBadSnapshots(t *testing.T) {
+	foreign := filepath.Join(t.TempDir(), "foreign.db")
+	raw, err := sql.Open("sqlite3", foreign)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := raw.Exec("CREATE TABLE notes (body TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+	raw.Close()
+
+	valid := filepath.Join(t.TempDir(), "valid.db")
+	db, err := database.InitDB(valid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+	corrupt := []byte(readFile(t, valid))
+	// Keep the header so the file is still taken for SQLite, and damage
+	// the pages after it.
+	for i := 100; i < len(corrupt); i++ {
+		corrupt[i] ^= 0x5a
+	}
+
+	tests := []struct {
+		name, db string
+	}{
+		{"not a database", "just some text, long enough to be more than a header"},
+		{"another application's database", readFile(t, foreign)},
+		{"corrupt database", string(corrupt)},
+	}
+	for _, tt := range tests {
+		if err := database.ValidateSnapshot(writeTemp(t, tt.db)); err == nil {
+			t.Errorf("%s: ValidateSnapshot accepted it", tt.name)
+		}
+
+		dir := t.TempDir()
+		dbPath := filepath.Join(dir, "backup_server.db")
+		writeFile(t, dbPath, "live database")
+		archive := bundle(t, [][2]string{{dbEntryName, tt.db}})
+		if err := Restore(archive, dbPath, ""); err == nil {
+			t.Errorf("%s: restored", tt.name)
+		}
+		if got := readFile(t, dbPath); got != "live database" {
+			t.Errorf("%s: live database replaced", tt.name)
+		}
+	}
+
+	if err := Restore(bundle(t, [][2]string{{"blobs/world.wld", "world"}}), filepath.Join(t.TempDir(), "backup_server.db"), ""); err == nil {
+		t.Error("bundle without a database restored")
+	}
+}
+
+func TestRestoreRefusesEscapingBlobs(t *testing.T) {
+	for _, name := range []string{"blobs/../../escaped", "blobs/a/../../escaped", "blobs/..", "blobs//../escaped"} {
+		dir := t.TempDir()
+		blobDir := filepath.Join(dir, "store", "blobs")
+		archive := bundle(t, [][2]string{{name, "escaped"}})
+
+		err := Restore(archive, filepath.Join(dir, "backup_server.db"), blobDir)
+		if err == nil || !strings.Contains(err.Error(), "escapes the blob directory") {
+			t.Errorf("%s: err = %v, want an escape error", name, err)
+		}
+		for _, p := range []string{filepath.Join(dir, "escaped"), filepath.Join(dir, "store", "escaped")} {
+			if _, err := os.Stat(p); err == nil {
+				t.Errorf("%s: wrote %s", name, p)
+			}
+		}
+		if _, err := os.Stat(blobDir); err == nil {
+			t.Errorf("%s: blob directory replaced", name)
+		}
+	}
+}
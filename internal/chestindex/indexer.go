@@ -0,0 +1,164 @@
+// Package chestindex keeps a searchable index of the items in the chests of
+// every stored world.
+package chestindex
+
+import (
+	"backup_server/internal/database"
+	"backup_server/internal/terraria"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Indexer parses the chests of world files in the background and stores
+// them in the database. A world is parsed again only when its path, size or
+// modification time changes.
+type Indexer struct {
+	db       database.Repository
+	interval time.Duration
+	wake     chan struct{}
+
+	// mu guards the state shown to administrators.
+	mu       sync.Mutex
+	lastRun  time.Time
+	indexing bool
+}
+
+// New returns an indexer that, once Run, checks every interval, and
+// whenever Refresh is called, for worlds that changed.
+func New(db database.Repository, interval time.Duration) *Indexer {
+	return &Indexer{
+		db:       db,
+		interval: interval,
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Refresh asks for an index pass soon, for example after a file record was
+// added or pointed at another file.
+func (ix *Indexer) Refresh() {
+	select {
+	case ix.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Status reports when the last pass finished and whether one is running.
+func (ix *Indexer) Status() (time.Time, bool) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	return ix.lastRun, ix.indexing
+}
+
+// Run indexes worlds until ctx is cancelled.
+func (ix *Indexer) Run(ctx context.Context) {
+	ticker := time.NewTicker(ix.interval)
+	defer ticker.Stop()
+
+	for {
+		ix.indexAll(ctx)
+		select {
+		case <-ticker.C:
+		case <-ix.wake:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// indexAll indexes the worlds that changed, stopping early if ctx is
+// cancelled.
+func (ix *Indexer) indexAll(ctx context.Context) {
+	ix.mu.Lock()
+	ix.indexing = true
+	ix.mu.Unlock()
+	defer func() {
+		ix.mu.Lock()
+		ix.indexing = false
+		ix.lastRun = time.Now()
+		ix.mu.Unlock()
+	}()
+
+	files, err := ix.db.GetAllFiles()
+	if err != nil {
+		log.Printf("Chest index: failed to list files: %v", err)
+		return
+	}
+	states, err := ix.db.GetChestIndexStates()
+	if err != nil {
+		log.Printf("Chest index: failed to load index state: %v", err)
+		return
+	}
+
+	for _, f := range files {
+		if ctx.Err() != nil {
+			return
+		}
+		state, indexed := states[f.ID]
+		if !strings.HasSuffix(f.Name, ".wld") {
+			if indexed {
+				if err := ix.db.ClearChestIndex(f.ID); err != nil {
+					log.Printf("Chest index: failed to clear %s: %v", f.Name, err)
+				}
+			}
+			continue
+		}
+
+		next := database.ChestIndexState{FileID: f.ID, FilePath: f.FilePath, Size: -1}
+		if info, err := os.Stat(f.FilePath); err == nil {
+			next.Size, next.ModTime = info.Size(), info.ModTime()
+		}
+		if indexed && state.FilePath == next.FilePath && state.Size == next.Size && state.ModTime.Equal(next.ModTime) {
+			continue
+		}
+
+		if err := ix.index(f, next); err != nil {
+			log.Printf("Chest index: failed to store %s: %v", f.Name, err)
+		}
+	}
+}
+
+// index parses one world and stores its chests. A world that cannot be read
+// is stored with the reason and no chests, and is tried again once it
+// changes.
+func (ix *Indexer) index(f database.File, state database.ChestIndexState) error {
+	state.IndexedAt = time.Now()
+
+	var items []database.ChestItem
+	if state.Size < 0 {
+		state.Error = "file missing"
+	} else if _, chests, err := terraria.ReadChestsFile(f.FilePath); err != nil {
+		state.Error = problem(err)
+		log.Printf("Chest index: failed to read %s: %v", f.Name, err)
+	} else {
+		for _, c := range chests {
+			for id, stack := range c.Totals() {
+				items = append(items, database.ChestItem{
+					X:         int(c.X),
+					Y:         int(c.Y),
+					ChestName: c.Name,
+					ItemID:    int(id),
+					Stack:     stack,
+				})
+			}
+		}
+	}
+
+	return ix.db.ReplaceChestIndex(state, items)
+}
+
+func problem(err error) string {
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return "file missing"
+	case errors.Is(err, terraria.ErrNotWorld):
+		return "not a Terraria world"
+	case errors.Is(err, terraria.ErrUnsupportedVersion):
+		return "unsupported world version"
+	}
+	return "unreadable"
+}
@@ -0,0 +1,287 @@
+package config
+
+import (
+	"log"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds the runtime settings shared by the server and the command-line
+// tools. Every value can be overridden with an environment variable.
+type Config struct {
+	// DatabaseDSN selects the storage backend. A postgres:// or
+	// postgresql:// URL uses PostgreSQL, anything else is treated as a
+	// SQLite database path.
+	DatabaseDSN string
+	ListenAddr  string
+	// PublicURL is the address people reach the server at, such as
+	// https://backups.example.com, without a trailing slash. Share link and
+	// invitation URLs are built from it rather than from the request, whose
+	// Host header the client chooses. It defaults to http://localhost on
+	// the ListenAddr port.
+	PublicURL string
+	// StorageDir is the managed blob directory the server owns. It is
+	// included in backup bundles when requested.
+	StorageDir string
+	// MapCacheDir holds rendered world map PNGs. It can be deleted at any
+	// time; maps are drawn again on demand. The least recently used maps
+	// are removed once it holds more than MapCacheMB megabytes.
+	MapCacheDir string
+	MapCacheMB  int
+	// ChestIndexInterval is how often world files are checked for changes
+	// to re-index their chests.
+	ChestIndexInterval time.Duration
+
+	// WatchDirs is a list of directories, separated like PATH, whose
+	// Terraria saves are snapshotted into StorageDir whenever they change.
+	// Saves seen for the first time are registered for WatchGroup, a group
+	// name that must be set when WatchDirs is. A save is copied once it has not changed for WatchSettle;
+	// directories that cannot be watched for events are scanned every
+	// WatchPollInterval instead.
+	WatchDirs         string
+	WatchGroup        string
+	WatchSettle       time.Duration
+	WatchPollInterval time.Duration
+
+	// JobRetryDelay is how long a failed backup job run waits before it is
+	// retried. AlertWebhook, when set, is sent a JSON POST whenever a job
+	// has not succeeded within its window.
+	JobRetryDelay time.Duration
+	AlertWebhook  string
+
+	// ScrubInterval is how often each stored file is hashed again and
+	// compared with the checksum recorded when it was registered.
+	ScrubInterval time.Duration
+
+	// Login throttling: failures allowed per username and per client
+	// address before a lockout, and how long a lockout lasts.
+	LoginMaxUserFailures int
+	LoginMaxIPFailures   int
+	LoginLockout         time.Duration
+
+	// Password policy applied to every new or changed password.
+	PasswordMinLength        int
+	PasswordMinClasses       int
+	PasswordDisallowUsername bool
+	PasswordHistory          int
+	// PasswordMaxAgeDays forces a password change once a password is this
+	// old. Zero disables expiry; users can have their own max age.
+	PasswordMaxAgeDays int
+	// BreachedPasswordsFile is an optional sorted file of SHA-1 password
+	// hashes, such as the Have I Been Pwned "ordered by hash" download.
+	BreachedPasswordsFile string
+
+	// LDAP sign-in. Leaving LDAPURL empty disables it; otherwise users not
+	// found locally are looked up with LDAPUserFilter under LDAPBaseDN and
+	// signed in by binding as them. {username} in the user filter and
+	// {username} and {dn} in the group filter are replaced with the
+	// escaped values.
+	LDAPURL          string
+	LDAPStartTLS     bool
+	LDAPBindDN       string
+	LDAPBindPassword string
+	LDAPBaseDN       string
+	LDAPUserFilter   string
+	// LDAPUsernameAttribute holds the directory's spelling of the username,
+	// which is used for the local account.
+	LDAPUsernameAttribute string
+	LDAPGroupBaseDN       string
+	LDAPGroupFilter       string
+	// LDAPGroupAttribute is the attribute holding a directory group's name.
+	// Names are matched to local groups of the same name unless
+	// LDAPGroupMap, a comma-separated list of directory=local pairs, says
+	// otherwise.
+	LDAPGroupAttribute string
+	LDAPGroupMap       string
+	// LDAPDefaultGroup is given to directory users none of whose groups
+	// match a local group. When empty such users cannot sign in.
+	LDAPDefaultGroup string
+
+	// OpenID Connect sign-in. Leaving OIDCIssuer empty disables it. The
+	// redirect URL must point at /oidc/callback on this server and be
+	// registered with the provider.
+	OIDCIssuer       string
+	OIDCClientID     string
+	OIDCClientSecret string
+	OIDCRedirectURL  string
+	// OIDCName labels the sign-in button.
+	OIDCName   string
+	OIDCScopes string
+	// OIDCUsernameClaim names the ID token claim used as the username of
+	// accounts created on first sign-in.
+	OIDCUsernameClaim string
+	// OIDCGroupsClaim names the claim listing the user's groups. They are
+	// mapped to local groups like directory groups, with OIDCGroupMap and
+	// OIDCDefaultGroup playing the parts of the LDAP settings.
+	OIDCGroupsClaim  string
+	OIDCGroupMap     string
+	OIDCDefaultGroup string
+}
+
+func Load() *Config {
+	listenAddr := getEnv("BACKUP_SERVER_ADDR", ":8090")
+	return &Config{
+		DatabaseDSN: getEnv("BACKUP_SERVER_DB", "backup_server.db"),
+		ListenAddr:  listenAddr,
+		PublicURL:   getEnvURL("BACKUP_SERVER_PUBLIC_URL", defaultPublicURL(listenAddr)),
+		StorageDir:  getEnv("BACKUP_SERVER_STORAGE_DIR", "storage"),
+		MapCacheDir: getEnv("BACKUP_SERVER_MAP_CACHE_DIR", "cache/maps"),
+		MapCacheMB:  getEnvInt("BACKUP_SERVER_MAP_CACHE_MB", 1024),
+
+		ChestIndexInterval: getEnvInterval("BACKUP_SERVER_CHEST_INDEX_INTERVAL", 10*time.Minute),
+
+		WatchDirs:         getEnv("BACKUP_SERVER_WATCH_DIRS", ""),
+		WatchGroup:        getEnv("BACKUP_SERVER_WATCH_GROUP", ""),
+		WatchSettle:       getEnvDelay("BACKUP_SERVER_WATCH_SETTLE", 10*time.Second),
+		WatchPollInterval: getEnvInterval("BACKUP_SERVER_WATCH_POLL_INTERVAL", time.Minute),
+
+		JobRetryDelay: getEnvInterval("BACKUP_SERVER_JOB_RETRY_DELAY", 5*time.Minute),
+		AlertWebhook:  getEnv("BACKUP_SERVER_ALERT_WEBHOOK", ""),
+
+		ScrubInterval: getEnvInterval("BACKUP_SERVER_SCRUB_INTERVAL", 24*time.Hour),
+
+		LoginMaxUserFailures: getEnvPositiveInt("BACKUP_SERVER_LOGIN_MAX_FAILURES", 5),
+		LoginMaxIPFailures:   getEnvPositiveInt("BACKUP_SERVER_LOGIN_MAX_IP_FAILURES", 20),
+		LoginLockout:         getEnvDuration("BACKUP_SERVER_LOGIN_LOCKOUT", 15*time.Minute),
+
+		PasswordMinLength:        getEnvInt("BACKUP_SERVER_PASSWORD_MIN_LENGTH", 8),
+		PasswordMinClasses:       getEnvInt("BACKUP_SERVER_PASSWORD_MIN_CLASSES", 2),
+		PasswordDisallowUsername: getEnvBool("BACKUP_SERVER_PASSWORD_DISALLOW_USERNAME", true),
+		PasswordHistory:          getEnvInt("BACKUP_SERVER_PASSWORD_HISTORY", 5),
+		PasswordMaxAgeDays:       getEnvInt("BACKUP_SERVER_PASSWORD_MAX_AGE_DAYS", 0),
+		BreachedPasswordsFile:    getEnv("BACKUP_SERVER_BREACHED_PASSWORDS", ""),
+
+		LDAPURL:               getEnv("BACKUP_SERVER_LDAP_URL", ""),
+		LDAPStartTLS:          getEnvBool("BACKUP_SERVER_LDAP_STARTTLS", false),
+		LDAPBindDN:            getEnv("BACKUP_SERVER_LDAP_BIND_DN", ""),
+		LDAPBindPassword:      getEnv("BACKUP_SERVER_LDAP_BIND_PASSWORD", ""),
+		LDAPBaseDN:            getEnv("BACKUP_SERVER_LDAP_BASE_DN", ""),
+		LDAPUserFilter:        getEnv("BACKUP_SERVER_LDAP_USER_FILTER", "(uid={username})"),
+		LDAPUsernameAttribute: getEnv("BACKUP_SERVER_LDAP_USERNAME_ATTRIBUTE", "uid"),
+		LDAPGroupBaseDN:       getEnv("BACKUP_SERVER_LDAP_GROUP_BASE_DN", ""),
+		LDAPGroupFilter:       getEnv("BACKUP_SERVER_LDAP_GROUP_FILTER", "(|(member={dn})(uniqueMember={dn})(memberUid={username}))"),
+		LDAPGroupAttribute:    getEnv("BACKUP_SERVER_LDAP_GROUP_ATTRIBUTE", "cn"),
+		LDAPGroupMap:          getEnv("BACKUP_SERVER_LDAP_GROUP_MAP", ""),
+		LDAPDefaultGroup:      getEnv("BACKUP_SERVER_LDAP_DEFAULT_GROUP", ""),
+
+		OIDCIssuer:        getEnv("BACKUP_SERVER_OIDC_ISSUER", ""),
+		OIDCClientID:      getEnv("BACKUP_SERVER_OIDC_CLIENT_ID", ""),
+		OIDCClientSecret:  getEnv("BACKUP_SERVER_OIDC_CLIENT_SECRET", ""),
+		OIDCRedirectURL:   getEnv("BACKUP_SERVER_OIDC_REDIRECT_URL", ""),
+		OIDCName:          getEnv("BACKUP_SERVER_OIDC_NAME", "Single Sign-On"),
+		OIDCScopes:        getEnv("BACKUP_SERVER_OIDC_SCOPES", "openid profile email"),
+		OIDCUsernameClaim: getEnv("BACKUP_SERVER_OIDC_USERNAME_CLAIM", "preferred_username"),
+		OIDCGroupsClaim:   getEnv("BACKUP_SERVER_OIDC_GROUPS_CLAIM", "groups"),
+		OIDCGroupMap:      getEnv("BACKUP_SERVER_OIDC_GROUP_MAP", ""),
+		OIDCDefaultGroup:  getEnv("BACKUP_SERVER_OIDC_DEFAULT_GROUP", ""),
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+// getEnvURL reads an absolute http or https URL, dropping any trailing
+// slash so paths can be appended to it.
+func getEnvURL(key, fallback string) string {
+	value := getEnv(key, "")
+	if value == "" {
+		return fallback
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		log.Printf("Ignoring invalid %s=%q: want an http:// or https:// URL", key, value)
+		return fallback
+	}
+	return strings.TrimRight(value, "/")
+}
+
+func defaultPublicURL(listenAddr string) string {
+	host, port, err := net.SplitHostPort(listenAddr)
+	if err != nil {
+		return "http://localhost"
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	return "http://" + net.JoinHostPort(host, port)
+}
+
+func getEnvInt(key string, fallback int) int {
+	value := getEnv(key, "")
+	if value == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Ignoring invalid %s=%q: %v", key, value, err)
+		return fallback
+	}
+	return n
+}
+
+// getEnvPositiveInt reads a count that must be at least one, such as a
+// limit that zero would turn into a lockout on the first attempt.
+func getEnvPositiveInt(key string, fallback int) int {
+	n := getEnvInt(key, fallback)
+	if n <= 0 {
+		log.Printf("Ignoring invalid %s=%q: must be positive", key, getEnv(key, ""))
+		return fallback
+	}
+	return n
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	value := getEnv(key, "")
+	if value == "" {
+		return fallback
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Ignoring invalid %s=%q: %v", key, value, err)
+		return fallback
+	}
+	return b
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value := getEnv(key, "")
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Ignoring invalid %s=%q: %v", key, value, err)
+		return fallback
+	}
+	return d
+}
+
+// getEnvDelay reads a duration that may be zero but not negative, such as
+// how long to wait before acting.
+func getEnvDelay(key string, fallback time.Duration) time.Duration {
+	d := getEnvDuration(key, fallback)
+	if d < 0 {
+		log.Printf("Ignoring invalid %s=%q: must not be negative", key, getEnv(key, ""))
+		return fallback
+	}
+	return d
+}
+
+// getEnvInterval reads a duration that must be positive, such as how often
+// a background task runs.
+func getEnvInterval(key string, fallback time.Duration) time.Duration {
+	d := getEnvDuration(key, fallback)
+	if d <= 0 {
+		log.Printf("Ignoring invalid %s=%q: must be positive", key, getEnv(key, ""))
+		return fallback
+	}
+	return d
+}
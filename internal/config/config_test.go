@@ -0,0 +1,95 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPublicURL(t *testing.T) {
+	tests := []struct {
+		listen, env, want string
+	}{
+		{":8090", "", "http://localhost:8090"},
+		{"0.0.0.0:80", "", "http://localhost:80"},
+		{"192.0.2.7:8443", "", "http://192.0.2.7:8443"},
+		{":8090", "https://backups.example.com/", "https://backups.example.com"},
+		{":8090", "https://example.com/backups", "https://example.com/backups"},
+		{":8090", "backups.example.com", "http://localhost:8090"},
+		{":8090", "ftp://backups.example.com", "http://localhost:8090"},
+	}
+	for _, tt := range tests {
+		t.Setenv("BACKUP_SERVER_ADDR", tt.listen)
+		t.Setenv("BACKUP_SERVER_PUBLIC_URL", tt.env)
+		if got := Load().PublicURL; got != tt.want {
+			t.Errorf("listen %q, BACKUP_SERVER_PUBLIC_URL=%q: PublicURL = %q, want %q", tt.listen, tt.env, got, tt.want)
+		}
+	}
+}
+
+func TestIntervals(t *testing.T) {
+	settings := []struct {
+		key      string
+		fallback time.Duration
+		get      func(*Config) time.Duration
+	}{
+		{"BACKUP_SERVER_CHEST_INDEX_INTERVAL", 10 * time.Minute, func(c *Config) time.Duration { return c.ChestIndexInterval }},
+		{"BACKUP_SERVER_JOB_RETRY_DELAY", 5 * time.Minute, func(c *Config) time.Duration { return c.JobRetryDelay }},
+	}
+	for _, setting := range settings {
+		tests := []struct {
+			env  string
+			want time.Duration
+		}{
+			{"", setting.fallback},
+			{"1h", time.Hour},
+			{"0", setting.fallback},
+			{"-5m", setting.fallback},
+			{"soon", setting.fallback},
+		}
+		for _, tt := range tests {
+			t.Setenv(setting.key, tt.env)
+			if got := setting.get(Load()); got != tt.want {
+				t.Errorf("%s=%q: got %v, want %v", setting.key, tt.env, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestLoginLimits(t *testing.T) {
+	tests := []struct {
+		env        string
+		user, addr int
+	}{
+		{"", 5, 20},
+		{"3", 3, 3},
+		{"0", 5, 20},
+		{"-1", 5, 20},
+		{"many", 5, 20},
+	}
+	for _, tt := range tests {
+		t.Setenv("BACKUP_SERVER_LOGIN_MAX_FAILURES", tt.env)
+		t.Setenv("BACKUP_SERVER_LOGIN_MAX_IP_FAILURES", tt.env)
+		cfg := Load()
+		if cfg.LoginMaxUserFailures != tt.user || cfg.LoginMaxIPFailures != tt.addr {
+			t.Errorf("limits %q: got %d per user and %d per address, want %d and %d", tt.env, cfg.LoginMaxUserFailures, cfg.LoginMaxIPFailures, tt.user, tt.addr)
+		}
+	}
+}
+
+func TestWatchSettle(t *testing.T) {
+	tests := []struct {
+		env  string
+		want time.Duration
+	}{
+		{"", 10 * time.Second},
+		{"0", 0},
+		{"30s", 30 * time.Second},
+		{"-1s", 10 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Setenv("BACKUP_SERVER_WATCH_SETTLE", tt.env)
+		if got := Load().WatchSettle; got != tt.want {
+			t.Errorf("BACKUP_SERVER_WATCH_SETTLE=%q: WatchSettle = %v, want %v", tt.env, got, tt.want)
+		}
+	}
+}
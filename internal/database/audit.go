@@ -0,0 +1,39 @@
+package database
+
+import "time"
+
+// AuditEntry records a security-relevant event such as a lockout or an
+// administrative change.
+type AuditEntry struct {
+	ID        int
+	CreatedAt time.Time
+	Actor     string
+	Action    string
+	Detail    string
+}
+
+func (db *DB) AddAuditEntry(actor, action, detail string) error {
+	_, err := db.Exec("INSERT INTO audit_log (created_at, actor, action, detail) VALUES (?, ?, ?, ?)",
+		time.Now().UTC(), actor, action, detail)
+	return err
+}
+
+// GetAuditEntries returns the most recent audit entries, newest first.
+func (db *DB) GetAuditEntries(limit int) ([]AuditEntry, error) {
+	rows, err := db.Query("SELECT id, created_at, actor, action, detail FROM audit_log ORDER BY id DESC LIMIT ?", limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []AuditEntry
+	for rows.Next() {
+		var e AuditEntry
+		if err := rows.Scan(&e.ID, &e.CreatedAt, &e.Actor, &e.Action, &e.Detail); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, rows.Err()
+}
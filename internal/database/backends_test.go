@@ -0,0 +1,125 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// postgresDSN is the PostgreSQL server the suite runs against, if any. It
+// comes from BACKUP_SERVER_TEST_POSTGRES, or from a throwaway cluster that
+// TestMain starts when initdb and pg_ctl are on the PATH.
+var postgresDSN string
+
+// postgresProblem says why the PostgreSQL runs are skipped.
+var postgresProblem string
+
+var schemaCounter atomic.Int64
+
+func TestMain(m *testing.M) {
+	stop := startPostgres()
+	code := m.Run()
+	stop()
+	os.Exit(code)
+}
+
+func startPostgres() (stop func()) {
+	stop = func() {}
+	if dsn := os.Getenv("BACKUP_SERVER_TEST_POSTGRES"); dsn != "" {
+		postgresDSN = dsn
+		return stop
+	}
+
+	initdb, err1 := exec.LookPath("initdb")
+	pgctl, err2 := exec.LookPath("pg_ctl")
+	if err1 != nil || err2 != nil {
+		postgresProblem = "set BACKUP_SERVER_TEST_POSTGRES or put initdb and pg_ctl on the PATH"
+		return stop
+	}
+
+	dir, err := os.MkdirTemp("", "backup-server-pg-")
+	if err != nil {
+		postgresProblem = err.Error()
+		return stop
+	}
+	data := filepath.Join(dir, "data")
+	out, err := exec.Command(initdb, "-D", data, "-U", "postgres", "--auth=trust").CombinedOutput()
+	if err != nil {
+		os.RemoveAll(dir)
+		postgresProblem = fmt.Sprintf("initdb: %v: %s", err, out)
+		return stop
+	}
+	// Only the socket in dir is listened on, so the cluster cannot clash
+	// with a server already running on the machine.
+	opts := fmt.Sprintf("-c listen_addresses='' -k %s -p 54329", dir)
+	out, err = exec.Command(pgctl, "-D", data, "-o", opts, "-w", "-l", filepath.Join(dir, "log"), "start").CombinedOutput()
+	if err != nil {
+		os.RemoveAll(dir)
+		postgresProblem = fmt.Sprintf("pg_ctl start: %v: %s", err, out)
+		return stop
+	}
+
+	postgresDSN = fmt.Sprintf("postgres://postgres@/postgres?host=%s&port=54329&sslmode=disable", url.QueryEscape(dir))
+	return func() {
+		exec.Command(pgctl, "-D", data, "-m", "immediate", "stop").Run()
+		os.RemoveAll(dir)
+	}
+}
+
+// forEachBackend runs test against a fresh SQLite database and, when one is
+// available, a fresh schema on PostgreSQL.
+func forEachBackend(t *testing.T, test func(t *testing.T, db *DB)) {
+	t.Helper()
+
+	t.Run("sqlite", func(t *testing.T) {
+		db, err := InitDB(filepath.Join(t.TempDir(), "test.db"))
+		if err != nil {
+			t.Fatalf("InitDB: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+		test(t, db)
+	})
+
+	t.Run("postgres", func(t *testing.T) {
+		if postgresDSN == "" {
+			t.Skip(postgresProblem)
+		}
+		db := openPostgresSchema(t)
+		test(t, db)
+	})
+}
+
+// openPostgresSchema opens the database in a schema of its own, dropped
+// when the test ends, so tests do not see each other's rows.
+func openPostgresSchema(t *testing.T) *DB {
+	t.Helper()
+
+	admin, err := sql.Open("postgres", postgresDSN)
+	if err != nil {
+		t.Fatalf("open postgres: %v", err)
+	}
+	t.Cleanup(func() { admin.Close() })
+
+	schema := fmt.Sprintf("test_%d_%d", os.Getpid(), schemaCounter.Add(1))
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() { admin.Exec("DROP SCHEMA " + schema + " CASCADE") })
+
+	sep := "?"
+	if strings.Contains(postgresDSN, "?") {
+		sep = "&"
+	}
+	db, err := InitDB(postgresDSN + sep + "search_path=" + schema)
+	if err != nil {
+		t.Fatalf("InitDB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
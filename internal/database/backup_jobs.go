@@ -0,0 +1,192 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// Backup job run statuses.
+const (
+	JobRunRunning   = "running"
+	JobRunSucceeded = "succeeded"
+	JobRunFailed    = "failed"
+)
+
+// BackupJob copies the files matching Source into managed storage on a
+// cron Schedule. Files without a record get one owned by GroupID.
+type BackupJob struct {
+	ID       int
+	Name     string
+	Source   string
+	Schedule string
+	GroupID  int
+	// Retries is how many more times a failed run is attempted.
+	Retries int
+	// Window is how long the job may go without succeeding before it is
+	// reported as overdue. Zero means twice the schedule's interval.
+	Window    time.Duration
+	Enabled   bool
+	CreatedAt time.Time
+
+	// LastRun and LastSuccess are filled in by GetBackupJobs. LastRun is
+	// nil and LastSuccess zero when the job has not run or succeeded.
+	LastRun     *BackupJobRun
+	LastSuccess time.Time
+}
+
+// BackupJobRun is one attempt at running a job.
+type BackupJobRun struct {
+	ID         int
+	JobID      int
+	JobName    string
+	Attempt    int
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Status     string
+	// Files and Bytes count what was copied; unchanged files are skipped.
+	Files int
+	Bytes int64
+	Error string
+}
+
+// Duration is how long the run took, or has taken so far.
+func (r *BackupJobRun) Duration() time.Duration {
+	if r.FinishedAt.IsZero() {
+		return time.Since(r.StartedAt).Round(time.Second)
+	}
+	return r.FinishedAt.Sub(r.StartedAt).Round(time.Millisecond)
+}
+
+const backupJobColumns = "id, name, source, schedule, group_id, retries, window_minutes, enabled, created_at"
+
+func scanBackupJob(row interface{ Scan(...interface{}) error }) (*BackupJob, error) {
+	j := &BackupJob{}
+	var window int
+	err := row.Scan(&j.ID, &j.Name, &j.Source, &j.Schedule, &j.GroupID, &j.Retries, &window, &j.Enabled, &j.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	j.Window = time.Duration(window) * time.Minute
+	return j, nil
+}
+
+func (db *DB) CreateBackupJob(job BackupJob) error {
+	_, err := db.Exec("INSERT INTO backup_jobs (name, source, schedule, group_id, retries, window_minutes, enabled, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		job.Name, job.Source, job.Schedule, job.GroupID, job.Retries, int(job.Window/time.Minute), job.Enabled, time.Now().UTC())
+	return err
+}
+
+func (db *DB) GetBackupJob(jobID int) (*BackupJob, error) {
+	return scanBackupJob(db.QueryRow("SELECT "+backupJobColumns+" FROM backup_jobs WHERE id = ?", jobID))
+}
+
+// GetBackupJobs returns every job with its latest run and when it last
+// succeeded.
+func (db *DB) GetBackupJobs() ([]BackupJob, error) {
+	rows, err := db.Query("SELECT " + backupJobColumns + " FROM backup_jobs ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []BackupJob
+	for rows.Next() {
+		j, err := scanBackupJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *j)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range jobs {
+		run, err := scanBackupJobRun(db.QueryRow(backupJobRunQuery+" WHERE r.job_id = ? ORDER BY r.id DESC LIMIT 1", jobs[i].ID))
+		if err == nil {
+			jobs[i].LastRun = run
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+
+		var success sql.NullTime
+		err = db.QueryRow("SELECT finished_at FROM backup_job_runs WHERE job_id = ? AND status = ? ORDER BY id DESC LIMIT 1",
+			jobs[i].ID, JobRunSucceeded).Scan(&success)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		jobs[i].LastSuccess = success.Time
+	}
+
+	return jobs, nil
+}
+
+func (db *DB) UpdateBackupJob(job BackupJob) error {
+	_, err := db.Exec("UPDATE backup_jobs SET name = ?, source = ?, schedule = ?, group_id = ?, retries = ?, window_minutes = ?, enabled = ? WHERE id = ?",
+		job.Name, job.Source, job.Schedule, job.GroupID, job.Retries, int(job.Window/time.Minute), job.Enabled, job.ID)
+	return err
+}
+
+func (db *DB) DeleteBackupJob(jobID int) error {
+	_, err := db.Exec("DELETE FROM backup_jobs WHERE id = ?", jobID)
+	return err
+}
+
+// StartBackupJobRun records that an attempt at a job has started and
+// returns the run's id.
+func (db *DB) StartBackupJobRun(jobID, attempt int, startedAt time.Time) (int, error) {
+	var id int
+	err := db.QueryRow("INSERT INTO backup_job_runs (job_id, attempt, started_at, status) VALUES (?, ?, ?, ?) RETURNING id",
+		jobID, attempt, startedAt.UTC(), JobRunRunning).Scan(&id)
+	return id, err
+}
+
+// FinishBackupJobRun records the outcome of a run.
+func (db *DB) FinishBackupJobRun(run BackupJobRun) error {
+	_, err := db.Exec("UPDATE backup_job_runs SET finished_at = ?, status = ?, files = ?, bytes = ?, error = ? WHERE id = ?",
+		run.FinishedAt.UTC(), run.Status, run.Files, run.Bytes, run.Error, run.ID)
+	return err
+}
+
+// AbandonBackupJobRuns marks runs left running by a server that stopped as
+// failed.
+func (db *DB) AbandonBackupJobRuns() error {
+	_, err := db.Exec("UPDATE backup_job_runs SET finished_at = started_at, status = ?, error = ? WHERE status = ?",
+		JobRunFailed, "interrupted by a server restart", JobRunRunning)
+	return err
+}
+
+const backupJobRunQuery = `SELECT r.id, r.job_id, j.name, r.attempt, r.started_at, r.finished_at, r.status, r.files, r.bytes, r.error
+	FROM backup_job_runs r JOIN backup_jobs j ON j.id = r.job_id`
+
+func scanBackupJobRun(row interface{ Scan(...interface{}) error }) (*BackupJobRun, error) {
+	r := &BackupJobRun{}
+	var finished sql.NullTime
+	err := row.Scan(&r.ID, &r.JobID, &r.JobName, &r.Attempt, &r.StartedAt, &finished, &r.Status, &r.Files, &r.Bytes, &r.Error)
+	if err != nil {
+		return nil, err
+	}
+	r.FinishedAt = finished.Time
+	return r, nil
+}
+
+// GetBackupJobRuns returns the most recent runs of all jobs, newest first.
+func (db *DB) GetBackupJobRuns(limit int) ([]BackupJobRun, error) {
+	rows, err := db.Query(backupJobRunQuery+" ORDER BY r.id DESC LIMIT ?", limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []BackupJobRun
+	for rows.Next() {
+		r, err := scanBackupJobRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, *r)
+	}
+
+	return runs, rows.Err()
+}
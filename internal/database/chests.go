@@ -0,0 +1,129 @@
+package database
+
+import "time"
+
+// ChestIndexState records which version of a world file the chest index
+// was built from, so unchanged worlds are not parsed again.
+type ChestIndexState struct {
+	FileID   int
+	FilePath string
+	Size     int64
+	// ModTime is kept to the nanosecond so it compares equal to a fresh
+	// stat of an unchanged file.
+	ModTime   time.Time
+	IndexedAt time.Time
+	// Error is why the world could not be indexed, if it could not.
+	Error string
+}
+
+// ChestItem is the total stack of one item in one chest of a world.
+type ChestItem struct {
+	FileID    int
+	FileName  string
+	X, Y      int
+	ChestName string
+	ItemID    int
+	Stack     int
+}
+
+func (db *DB) GetChestIndexStates() (map[int]ChestIndexState, error) {
+	rows, err := db.Query("SELECT file_id, file_path, size, mod_time, indexed_at, error FROM chest_index")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	states := make(map[int]ChestIndexState)
+	for rows.Next() {
+		var s ChestIndexState
+		var modTime int64
+		if err := rows.Scan(&s.FileID, &s.FilePath, &s.Size, &modTime, &s.IndexedAt, &s.Error); err != nil {
+			return nil, err
+		}
+		s.ModTime = time.Unix(0, modTime)
+		states[s.FileID] = s
+	}
+
+	return states, rows.Err()
+}
+
+// ReplaceChestIndex swaps the indexed chests of a file for items.
+func (db *DB) ReplaceChestIndex(state ChestIndexState, items []ChestItem) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM chest_items WHERE file_id = ?", state.FileID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM chest_index WHERE file_id = ?", state.FileID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("INSERT INTO chest_index (file_id, file_path, size, mod_time, indexed_at, error) VALUES (?, ?, ?, ?, ?, ?)",
+		state.FileID, state.FilePath, state.Size, state.ModTime.UnixNano(), state.IndexedAt.UTC(), state.Error); err != nil {
+		return err
+	}
+	for _, item := range items {
+		if _, err := tx.Exec("INSERT INTO chest_items (file_id, x, y, chest_name, item_id, stack) VALUES (?, ?, ?, ?, ?, ?)",
+			state.FileID, item.X, item.Y, item.ChestName, item.ItemID, item.Stack); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ClearChestIndex drops a file from the chest index, for files that are no
+// longer worlds.
+func (db *DB) ClearChestIndex(fileID int) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM chest_items WHERE file_id = ?", fileID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM chest_index WHERE file_id = ?", fileID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SearchChestItems returns the chests in the given files that hold any of
+// the items, largest stacks first.
+func (db *DB) SearchChestItems(itemIDs, fileIDs []int, limit int) ([]ChestItem, error) {
+	if len(itemIDs) == 0 || len(fileIDs) == 0 {
+		return []ChestItem{}, nil
+	}
+
+	itemPlaceholders, itemArgs := inPlaceholders(itemIDs)
+	filePlaceholders, fileArgs := inPlaceholders(fileIDs)
+	args := append(itemArgs, fileArgs...)
+	args = append(args, limit)
+
+	rows, err := db.Query(`SELECT ci.file_id, f.name, ci.x, ci.y, ci.chest_name, ci.item_id, ci.stack
+		FROM chest_items ci JOIN files f ON f.id = ci.file_id
+		WHERE ci.item_id IN (`+itemPlaceholders+`) AND ci.file_id IN (`+filePlaceholders+`)
+		ORDER BY ci.stack DESC, f.name, ci.file_id, ci.x, ci.y
+		LIMIT ?`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []ChestItem{}
+	for rows.Next() {
+		var item ChestItem
+		if err := rows.Scan(&item.FileID, &item.FileName, &item.X, &item.Y, &item.ChestName, &item.ItemID, &item.Stack); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
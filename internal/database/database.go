@@ -1,21 +1,95 @@
 package database
 
 import (
+	"backup_server/internal/password"
 	"database/sql"
+	"errors"
 	"fmt"
-	_ "github.com/mattn/go-sqlite3"
+	"path/filepath"
+	"strings"
+	"time"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
+// DB is the SQL implementation of Repository. Queries are written with "?"
+// placeholders and rewritten for the active dialect before they are sent to
+// the driver.
 type DB struct {
 	*sql.DB
+	dialect        dialect
+	passwordPolicy *password.Policy
+}
+
+// Tx wraps *sql.Tx so statements inside a transaction are rebound for the
+// active dialect as well.
+type Tx struct {
+	*sql.Tx
+	dialect dialect
 }
 
+// ErrSnapshotUnsupported is returned by Snapshot for backends that cannot
+// produce an online copy of themselves; use the backend's own tooling
+// (pg_dump for PostgreSQL) instead.
+var ErrSnapshotUnsupported = errors.New("online snapshots are only supported for SQLite databases")
+
+// requiredTables are the tables a database snapshot must contain to be
+// restored.
+var requiredTables = []string{"groups", "users", "user_groups", "files"}
+
 type User struct {
-	ID       int
-	Username string
-	Password string
-	GroupIDs []int
+	ID                 int
+	Username           string
+	Password           string
+	GroupIDs           []int
+	MustChangePassword bool
+	PasswordChangedAt  time.Time
+	// PasswordMaxAgeDays overrides the server-wide password max age for
+	// this user; zero means use the server default.
+	PasswordMaxAgeDays int
+	// AuthSource names where the user's password is checked: AuthSourceLocal
+	// for the password stored here, or a directory such as AuthSourceLDAP.
+	AuthSource string
+}
+
+const (
+	AuthSourceLocal = "local"
+	AuthSourceLDAP  = "ldap"
+	AuthSourceOIDC  = "oidc"
+)
+
+// IsLocal reports whether the user signs in with a password stored in this
+// database, as opposed to one held by a directory.
+func (u *User) IsLocal() bool {
+	return u.AuthSource == AuthSourceLocal
+}
+
+const userColumns = "id, username, password_hash, must_change_password, password_changed_at, password_max_age_days, auth_source"
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanUser(row rowScanner, u *User) error {
+	var changedAt sql.NullTime
+	if err := row.Scan(&u.ID, &u.Username, &u.Password, &u.MustChangePassword, &changedAt, &u.PasswordMaxAgeDays, &u.AuthSource); err != nil {
+		return err
+	}
+	u.PasswordChangedAt = changedAt.Time
+	return nil
+}
+
+// PasswordExpired reports whether the user's password is older than their
+// max age, or defaultMaxAgeDays when they have none. Zero disables expiry.
+func (u *User) PasswordExpired(defaultMaxAgeDays int) bool {
+	maxAge := u.PasswordMaxAgeDays
+	if maxAge <= 0 {
+		maxAge = defaultMaxAgeDays
+	}
+	if maxAge <= 0 || u.PasswordChangedAt.IsZero() {
+		return false
+	}
+	return time.Since(u.PasswordChangedAt) > time.Duration(maxAge)*24*time.Hour
 }
 
 type Group struct {
@@ -23,32 +97,146 @@ type Group struct {
 	Name string
 }
 
+// File is a file offered for download. GroupID is the group that owns it;
+// who may see it is decided by its grants. Access holds the caller's
+// strongest grant level when the file was loaded for a particular user.
 type File struct {
 	ID          int
 	Name        string
 	FilePath    string
 	GroupID     int
 	Description string
+	Access      string
+
+	// SHA256 and Size are what the file held when it was registered, or
+	// empty when it could not be read then. Integrity is the result of the
+	// last check against them, made at CheckedAt.
+	SHA256    string
+	Size      int64
+	Integrity string
+	CheckedAt time.Time
+}
+
+// Integrity check results.
+const (
+	IntegrityOK         = "ok"
+	IntegrityMismatch   = "mismatch"
+	IntegrityMissing    = "missing"
+	IntegrityUnreadable = "unreadable"
+)
+
+// fileColumns selects a file from the files table aliased f; scanFile reads
+// them.
+const fileColumns = "f.id, f.name, f.file_path, f.group_id, f.description, f.sha256, f.size, f.integrity, f.checked_at"
+
+func scanFile(row interface{ Scan(...interface{}) error }, f *File, extra ...interface{}) error {
+	var checked sql.NullTime
+	dest := append([]interface{}{&f.ID, &f.Name, &f.FilePath, &f.GroupID, &f.Description, &f.SHA256, &f.Size, &f.Integrity, &checked}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return err
+	}
+	f.CheckedAt = checked.Time
+	return nil
+}
+
+func (f File) CanDownload() bool { return GrantAllows(f.Access, GrantDownload) }
+
+func (f File) CanManage() bool { return GrantAllows(f.Access, GrantManage) }
+
+// sidecarExts maps Terraria saves to the extension of the sidecar tModLoader
+// keeps next to them with the modded part of the save.
+var sidecarExts = map[string]string{".wld": ".twld", ".plr": ".tplr"}
+
+// Sidecar returns the path and display name of the tModLoader sidecar that
+// belongs with the file, or empty strings for files that cannot have one.
+// The file and its sidecar are one backup: they are shown and downloaded
+// together. Vanilla saves have no sidecar, so the path may not exist.
+func (f File) Sidecar() (path, name string) {
+	ext, ok := sidecarExts[filepath.Ext(f.FilePath)]
+	if !ok {
+		return "", ""
+	}
+	path = strings.TrimSuffix(f.FilePath, filepath.Ext(f.FilePath)) + ext
+	name = strings.TrimSuffix(f.Name, filepath.Ext(f.Name)) + ext
+	return path, name
 }
 
-func InitDB(dbPath string) (*DB, error) {
-	db, err := sql.Open("sqlite3", dbPath)
+// SidecarSave returns the path of the save a tModLoader sidecar belongs
+// with, or an empty string if path is not a sidecar.
+func SidecarSave(path string) string {
+	for save, sidecar := range sidecarExts {
+		if filepath.Ext(path) == sidecar {
+			return strings.TrimSuffix(path, sidecar) + save
+		}
+	}
+	return ""
+}
+
+// InitDB opens the database described by dsn and creates any missing tables.
+// postgres:// and postgresql:// URLs select PostgreSQL; anything else is
+// treated as a SQLite database path.
+func InitDB(dsn string) (*DB, error) {
+	d, driverDSN := dialectFor(dsn)
+
+	db, err := sql.Open(d.driverName(), driverDSN)
 	if err != nil {
 		return nil, err
 	}
 
 	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	hadGrants, err := d.hasColumn(db, "file_grants", "file_id")
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	if err := createTables(db, d); err != nil {
+		db.Close()
 		return nil, err
 	}
 
-	if err := createTables(db); err != nil {
+	if !hadGrants {
+		if err := backfillFileGrants(db); err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+
+	if err := migrate(db, d); err != nil {
+		db.Close()
 		return nil, err
 	}
 
-	return &DB{db}, nil
+	if err := d.removeOrphans(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("remove orphaned rows: %w", err)
+	}
+
+	if err := seedRoles(db, d); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	if err := removeRetiredPermissions(db, d); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("remove retired permissions: %w", err)
+	}
+
+	return &DB{DB: db, dialect: d}, nil
+}
+
+func dialectFor(dsn string) (dialect, string) {
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		return postgresDialect{}, dsn
+	}
+	return sqliteDialect{}, sqliteDSN(dsn)
 }
 
-func createTables(db *sql.DB) error {
+func createTables(db *sql.DB, d dialect) error {
 	schema := `
 	CREATE TABLE IF NOT EXISTS groups (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -58,7 +246,20 @@ func createTables(db *sql.DB) error {
 	CREATE TABLE IF NOT EXISTS users (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
 		username TEXT UNIQUE NOT NULL,
-		password_hash TEXT NOT NULL
+		password_hash TEXT NOT NULL,
+		must_change_password BOOLEAN NOT NULL DEFAULT FALSE,
+		password_changed_at TIMESTAMP,
+		password_max_age_days INTEGER NOT NULL DEFAULT 0,
+		auth_source TEXT NOT NULL DEFAULT 'local'
+	);
+
+	CREATE TABLE IF NOT EXISTS user_identities (
+		issuer TEXT NOT NULL,
+		subject TEXT NOT NULL,
+		user_id INTEGER NOT NULL,
+		linked_at TIMESTAMP NOT NULL,
+		PRIMARY KEY (issuer, subject),
+		FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 	);
 
 	CREATE TABLE IF NOT EXISTS user_groups (
@@ -69,26 +270,292 @@ func createTables(db *sql.DB) error {
 		FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE
 	);
 
+	CREATE TABLE IF NOT EXISTS group_nesting (
+		parent_id INTEGER NOT NULL,
+		child_id INTEGER NOT NULL,
+		PRIMARY KEY (parent_id, child_id),
+		FOREIGN KEY (parent_id) REFERENCES groups(id) ON DELETE CASCADE,
+		FOREIGN KEY (child_id) REFERENCES groups(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS group_managers (
+		group_id INTEGER NOT NULL,
+		user_id INTEGER NOT NULL,
+		PRIMARY KEY (group_id, user_id),
+		FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE,
+		FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+	);
+
 	CREATE TABLE IF NOT EXISTS files (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
 		name TEXT NOT NULL,
 		file_path TEXT NOT NULL,
 		group_id INTEGER NOT NULL,
 		description TEXT,
+		sha256 TEXT NOT NULL DEFAULT '',
+		size BIGINT NOT NULL DEFAULT 0,
+		integrity TEXT NOT NULL DEFAULT '',
+		checked_at TIMESTAMP,
 		FOREIGN KEY (group_id) REFERENCES groups(id)
 	);
+
+	CREATE TABLE IF NOT EXISTS file_grants (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		file_id INTEGER NOT NULL,
+		group_id INTEGER,
+		user_id INTEGER,
+		level TEXT NOT NULL,
+		CHECK ((group_id IS NULL) <> (user_id IS NULL)),
+		FOREIGN KEY (file_id) REFERENCES files(id) ON DELETE CASCADE,
+		FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE,
+		FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS share_links (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		token_hash TEXT UNIQUE NOT NULL,
+		file_id INTEGER NOT NULL,
+		created_by INTEGER NOT NULL,
+		created_at TIMESTAMP NOT NULL,
+		expires_at TIMESTAMP,
+		max_downloads INTEGER NOT NULL DEFAULT 0,
+		downloads INTEGER NOT NULL DEFAULT 0,
+		password_hash TEXT NOT NULL DEFAULT '',
+		FOREIGN KEY (file_id) REFERENCES files(id) ON DELETE CASCADE,
+		FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS invitations (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		token_hash TEXT UNIQUE NOT NULL,
+		created_by INTEGER NOT NULL,
+		created_at TIMESTAMP NOT NULL,
+		expires_at TIMESTAMP NOT NULL,
+		max_uses INTEGER NOT NULL DEFAULT 1,
+		uses INTEGER NOT NULL DEFAULT 0,
+		note TEXT NOT NULL DEFAULT '',
+		FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS invitation_groups (
+		invitation_id INTEGER NOT NULL,
+		group_id INTEGER NOT NULL,
+		PRIMARY KEY (invitation_id, group_id),
+		FOREIGN KEY (invitation_id) REFERENCES invitations(id) ON DELETE CASCADE,
+		FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS roles (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT UNIQUE NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS role_permissions (
+		role_id INTEGER NOT NULL,
+		permission TEXT NOT NULL,
+		PRIMARY KEY (role_id, permission),
+		FOREIGN KEY (role_id) REFERENCES roles(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS group_roles (
+		group_id INTEGER NOT NULL,
+		role_id INTEGER NOT NULL,
+		PRIMARY KEY (group_id, role_id),
+		FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE,
+		FOREIGN KEY (role_id) REFERENCES roles(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS user_roles (
+		user_id INTEGER NOT NULL,
+		role_id INTEGER NOT NULL,
+		PRIMARY KEY (user_id, role_id),
+		FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+		FOREIGN KEY (role_id) REFERENCES roles(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS audit_log (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		created_at TIMESTAMP NOT NULL,
+		actor TEXT NOT NULL,
+		action TEXT NOT NULL,
+		detail TEXT NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS login_history (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id INTEGER NOT NULL,
+		created_at TIMESTAMP NOT NULL,
+		ip TEXT NOT NULL,
+		user_agent TEXT NOT NULL,
+		success BOOLEAN NOT NULL,
+		FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS chest_index (
+		file_id INTEGER PRIMARY KEY,
+		file_path TEXT NOT NULL,
+		size BIGINT NOT NULL,
+		mod_time BIGINT NOT NULL,
+		indexed_at TIMESTAMP NOT NULL,
+		error TEXT NOT NULL DEFAULT '',
+		FOREIGN KEY (file_id) REFERENCES files(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS chest_items (
+		file_id INTEGER NOT NULL,
+		x INTEGER NOT NULL,
+		y INTEGER NOT NULL,
+		chest_name TEXT NOT NULL,
+		item_id INTEGER NOT NULL,
+		stack INTEGER NOT NULL,
+		FOREIGN KEY (file_id) REFERENCES files(id) ON DELETE CASCADE
+	);
+
+	CREATE INDEX IF NOT EXISTS chest_items_item_id ON chest_items (item_id);
+	CREATE INDEX IF NOT EXISTS chest_items_file_id ON chest_items (file_id);
+
+	CREATE TABLE IF NOT EXISTS file_versions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		file_id INTEGER NOT NULL,
+		source_path TEXT NOT NULL,
+		blob_path TEXT NOT NULL,
+		size BIGINT NOT NULL,
+		sha256 TEXT NOT NULL,
+		sidecar_sha256 TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMP NOT NULL,
+		FOREIGN KEY (file_id) REFERENCES files(id) ON DELETE CASCADE
+	);
+
+	CREATE INDEX IF NOT EXISTS file_versions_source_path ON file_versions (source_path);
+	CREATE INDEX IF NOT EXISTS file_versions_file_id ON file_versions (file_id);
+
+	CREATE TABLE IF NOT EXISTS backup_jobs (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT UNIQUE NOT NULL,
+		source TEXT NOT NULL,
+		schedule TEXT NOT NULL,
+		group_id INTEGER NOT NULL,
+		retries INTEGER NOT NULL DEFAULT 0,
+		window_minutes INTEGER NOT NULL DEFAULT 0,
+		enabled BOOLEAN NOT NULL DEFAULT TRUE,
+		created_at TIMESTAMP NOT NULL,
+		FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS backup_job_runs (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		job_id INTEGER NOT NULL,
+		attempt INTEGER NOT NULL,
+		started_at TIMESTAMP NOT NULL,
+		finished_at TIMESTAMP,
+		status TEXT NOT NULL,
+		files INTEGER NOT NULL DEFAULT 0,
+		bytes BIGINT NOT NULL DEFAULT 0,
+		error TEXT NOT NULL DEFAULT '',
+		FOREIGN KEY (job_id) REFERENCES backup_jobs(id) ON DELETE CASCADE
+	);
+
+	CREATE INDEX IF NOT EXISTS backup_job_runs_job_id ON backup_job_runs (job_id);
+
+	CREATE TABLE IF NOT EXISTS password_history (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		user_id INTEGER NOT NULL,
+		password_hash TEXT NOT NULL,
+		created_at TIMESTAMP NOT NULL,
+		FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+	);
 	`
 
-	_, err := db.Exec(schema)
+	_, err := db.Exec(d.translateSchema(schema))
 	return err
 }
 
-func (db *DB) CreateGroup(name string) (int64, error) {
-	result, err := db.Exec("INSERT INTO groups (name) VALUES (?)", name)
+// column is a column added to a table after it was first released. migrate
+// adds it to databases created by older versions.
+type column struct {
+	table      string
+	name       string
+	definition string
+	// backfill optionally initializes existing rows after the column is
+	// added.
+	backfill string
+}
+
+var addedColumns = []column{
+	{"users", "must_change_password", "BOOLEAN NOT NULL DEFAULT FALSE", ""},
+	{"users", "password_changed_at", "TIMESTAMP", "UPDATE users SET password_changed_at = CURRENT_TIMESTAMP"},
+	{"users", "password_max_age_days", "INTEGER NOT NULL DEFAULT 0", ""},
+	{"users", "auth_source", "TEXT NOT NULL DEFAULT 'local'", ""},
+	{"files", "sha256", "TEXT NOT NULL DEFAULT ''", ""},
+	{"files", "size", "BIGINT NOT NULL DEFAULT 0", ""},
+	{"files", "integrity", "TEXT NOT NULL DEFAULT ''", ""},
+	{"files", "checked_at", "TIMESTAMP", ""},
+}
+
+func migrate(db *sql.DB, d dialect) error {
+	for _, c := range addedColumns {
+		exists, err := d.hasColumn(db, c.table, c.name)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+
+		if _, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", c.table, c.name, d.translateSchema(c.definition))); err != nil {
+			return fmt.Errorf("add column %s.%s: %w", c.table, c.name, err)
+		}
+		if c.backfill != "" {
+			if _, err := db.Exec(c.backfill); err != nil {
+				return fmt.Errorf("backfill column %s.%s: %w", c.table, c.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (db *DB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return db.DB.Exec(db.dialect.rebind(query), args...)
+}
+
+func (db *DB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return db.DB.Query(db.dialect.rebind(query), args...)
+}
+
+func (db *DB) QueryRow(query string, args ...interface{}) *sql.Row {
+	return db.DB.QueryRow(db.dialect.rebind(query), args...)
+}
+
+func (db *DB) Begin() (*Tx, error) {
+	tx, err := db.DB.Begin()
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	return result.LastInsertId()
+	return &Tx{Tx: tx, dialect: db.dialect}, nil
+}
+
+func (tx *Tx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return tx.Tx.Exec(tx.dialect.rebind(query), args...)
+}
+
+func (tx *Tx) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return tx.Tx.Query(tx.dialect.rebind(query), args...)
+}
+
+func (tx *Tx) QueryRow(query string, args ...interface{}) *sql.Row {
+	return tx.Tx.QueryRow(tx.dialect.rebind(query), args...)
+}
+
+// Snapshot writes a consistent copy of the database to destPath without
+// blocking other readers or writers.
+func (db *DB) Snapshot(destPath string) error {
+	return db.dialect.snapshot(db.DB, destPath)
+}
+
+func (db *DB) CreateGroup(name string) (int64, error) {
+	var id int64
+	err := db.QueryRow("INSERT INTO groups (name) VALUES (?) RETURNING id", name).Scan(&id)
+	return id, err
 }
 
 func (db *DB) GetGroupByID(groupID int) (*Group, error) {
@@ -100,14 +567,31 @@ func (db *DB) GetGroupByID(groupID int) (*Group, error) {
 	return group, nil
 }
 
-func (db *DB) UpdateGroup(groupID int, name string) error {
-	_, err := db.Exec("UPDATE groups SET name = ? WHERE id = ?", name, groupID)
-	return err
+// EditGroup renames groupID and replaces its nested groups and managers in
+// one transaction, so a refused change leaves the group as it was. It fails
+// with ErrGroupCycle or ErrLastUserManager like SetGroupChildren.
+func (db *DB) EditGroup(groupID int, name string, childIDs, managerIDs []int) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("UPDATE groups SET name = ? WHERE id = ?", name, groupID); err != nil {
+		return err
+	}
+	if err := setGroupChildren(tx, groupID, childIDs); err != nil {
+		return err
+	}
+	if err := setGroupManagers(tx, groupID, managerIDs); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (db *DB) DeleteGroup(groupID int) error {
-	_, err := db.Exec("DELETE FROM groups WHERE id = ?", groupID)
-	return err
+	return db.deleteGuarded("DELETE FROM groups WHERE id = ?", groupID)
 }
 
 func (db *DB) GetGroupMemberCount(groupID int) (int, error) {
@@ -122,7 +606,22 @@ func (db *DB) GetGroupFileCount(groupID int) (int, error) {
 	return count, err
 }
 
+// SetPasswordPolicy makes CreateUser and UpdateUserPassword reject passwords
+// that break policy. Without a policy any password is accepted, which the
+// init tool relies on to seed the default account.
+func (db *DB) SetPasswordPolicy(policy *password.Policy) {
+	db.passwordPolicy = policy
+}
+
+func (db *DB) PasswordPolicy() *password.Policy {
+	return db.passwordPolicy
+}
+
 func (db *DB) CreateUser(username, password string, groupIDs []int) error {
+	if err := db.passwordPolicy.Check(username, password); err != nil {
+		return err
+	}
+
 	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
 		return err
@@ -134,14 +633,14 @@ func (db *DB) CreateUser(username, password string, groupIDs []int) error {
 	}
 	defer tx.Rollback()
 
-	result, err := tx.Exec("INSERT INTO users (username, password_hash) VALUES (?, ?)",
-		username, string(hash))
+	var userID int64
+	err = tx.QueryRow("INSERT INTO users (username, password_hash, password_changed_at) VALUES (?, ?, ?) RETURNING id",
+		username, string(hash), time.Now().UTC()).Scan(&userID)
 	if err != nil {
 		return err
 	}
 
-	userID, err := result.LastInsertId()
-	if err != nil {
+	if err := db.recordPasswordHash(tx, int(userID), string(hash)); err != nil {
 		return err
 	}
 
@@ -156,10 +655,37 @@ func (db *DB) CreateUser(username, password string, groupIDs []int) error {
 	return tx.Commit()
 }
 
+// CreateExternalUser adds a user whose password is checked by a directory
+// rather than stored here, so the password policy does not apply.
+func (db *DB) CreateExternalUser(username, source string, groupIDs []int) (*User, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var userID int
+	err = tx.QueryRow("INSERT INTO users (username, password_hash, auth_source) VALUES (?, '', ?) RETURNING id",
+		username, source).Scan(&userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, groupID := range groupIDs {
+		if _, err := tx.Exec("INSERT INTO user_groups (user_id, group_id) VALUES (?, ?)", userID, groupID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return db.GetUserByID(userID)
+}
+
 func (db *DB) GetUserByUsername(username string) (*User, error) {
 	user := &User{}
-	err := db.QueryRow("SELECT id, username, password_hash FROM users WHERE username = ?",
-		username).Scan(&user.ID, &user.Username, &user.Password)
+	err := scanUser(db.QueryRow("SELECT "+userColumns+" FROM users WHERE username = ?", username), user)
 	if err != nil {
 		return nil, err
 	}
@@ -183,12 +709,22 @@ func (db *DB) GetUserByUsername(username string) (*User, error) {
 	return user, rows.Err()
 }
 
+// dummyHash is compared against when a username does not exist so that
+// unknown and known usernames take the same time to reject.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("not-a-real-password"), bcrypt.DefaultCost)
+
 func (db *DB) ValidateUser(username, password string) (*User, error) {
 	user, err := db.GetUserByUsername(username)
 	if err != nil {
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
 		return nil, err
 	}
 
+	if !user.IsLocal() {
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return nil, fmt.Errorf("user %s signs in through %s", username, user.AuthSource)
+	}
+
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
 		return nil, fmt.Errorf("invalid password")
 	}
@@ -196,78 +732,64 @@ func (db *DB) ValidateUser(username, password string) (*User, error) {
 	return user, nil
 }
 
-func (db *DB) AddFile(name, filePath string, groupID int, description string) error {
-	_, err := db.Exec("INSERT INTO files (name, file_path, group_id, description) VALUES (?, ?, ?, ?)",
-		name, filePath, groupID, description)
-	return err
-}
+// AddFile adds a file owned by groupID, gives that group download access
+// and returns the file's id.
+func (db *DB) AddFile(name, filePath string, groupID int, description string) (int, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
 
-func (db *DB) GetFilesByGroupID(groupID int) ([]File, error) {
-	rows, err := db.Query("SELECT id, name, file_path, group_id, description FROM files WHERE group_id = ?", groupID)
+	var fileID int
+	err = tx.QueryRow("INSERT INTO files (name, file_path, group_id, description) VALUES (?, ?, ?, ?) RETURNING id",
+		name, filePath, groupID, description).Scan(&fileID)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	defer rows.Close()
 
-	var files []File
-	for rows.Next() {
-		var f File
-		if err := rows.Scan(&f.ID, &f.Name, &f.FilePath, &f.GroupID, &f.Description); err != nil {
-			return nil, err
-		}
-		files = append(files, f)
+	_, err = tx.Exec("INSERT INTO file_grants (file_id, group_id, level) VALUES (?, ?, ?)",
+		fileID, groupID, GrantDownload)
+	if err != nil {
+		return 0, err
 	}
 
-	return files, rows.Err()
+	return fileID, tx.Commit()
 }
 
+func (db *DB) GetFilesByGroupID(groupID int) ([]File, error) {
+	return db.GetFilesByGroupIDs([]int{groupID})
+}
+
+// GetFilesByGroupIDs returns the files shared with any of the groups, with
+// Access set to the strongest level those groups hold.
 func (db *DB) GetFilesByGroupIDs(groupIDs []int) ([]File, error) {
 	if len(groupIDs) == 0 {
 		return []File{}, nil
 	}
 
-	query := "SELECT DISTINCT id, name, file_path, group_id, description FROM files WHERE group_id IN ("
-	args := make([]interface{}, len(groupIDs))
-	for i, id := range groupIDs {
-		if i > 0 {
-			query += ","
-		}
-		query += "?"
-		args[i] = id
-	}
-	query += ")"
-
-	rows, err := db.Query(query, args...)
+	placeholders, args := inPlaceholders(groupIDs)
+	rows, err := db.Query(fileAccessColumns+" WHERE fg.group_id IN ("+placeholders+") ORDER BY f.name, f.id", args...)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	var files []File
-	for rows.Next() {
-		var f File
-		if err := rows.Scan(&f.ID, &f.Name, &f.FilePath, &f.GroupID, &f.Description); err != nil {
-			return nil, err
-		}
-		files = append(files, f)
-	}
-
-	return files, rows.Err()
+	return scanFileAccess(rows)
 }
 
 func (db *DB) GetFileByID(fileID int) (*File, error) {
 	file := &File{}
-	err := db.QueryRow("SELECT id, name, file_path, group_id, description FROM files WHERE id = ?",
-		fileID).Scan(&file.ID, &file.Name, &file.FilePath, &file.GroupID, &file.Description)
+	err := scanFile(db.QueryRow("SELECT "+fileColumns+" FROM files f WHERE f.id = ?", fileID), file)
 	if err != nil {
 		return nil, err
 	}
 	return file, nil
 }
 
+// UserHasAccessToGroup reports whether the user belongs to the group directly
+// or through a group nested inside it.
 func (db *DB) UserHasAccessToGroup(userID, groupID int) (bool, error) {
 	var count int
-	err := db.QueryRow("SELECT COUNT(*) FROM user_groups WHERE user_id = ? AND group_id = ?",
+	err := db.QueryRow(memberGroupsCTE+" SELECT COUNT(*) FROM member_groups WHERE user_id = ? AND group_id = ?",
 		userID, groupID).Scan(&count)
 	if err != nil {
 		return false, err
@@ -276,7 +798,7 @@ func (db *DB) UserHasAccessToGroup(userID, groupID int) (bool, error) {
 }
 
 func (db *DB) GetAllFiles() ([]File, error) {
-	rows, err := db.Query("SELECT id, name, file_path, group_id, description FROM files ORDER BY name")
+	rows, err := db.Query("SELECT " + fileColumns + " FROM files f ORDER BY f.name")
 	if err != nil {
 		return nil, err
 	}
@@ -285,7 +807,7 @@ func (db *DB) GetAllFiles() ([]File, error) {
 	var files []File
 	for rows.Next() {
 		var f File
-		if err := rows.Scan(&f.ID, &f.Name, &f.FilePath, &f.GroupID, &f.Description); err != nil {
+		if err := scanFile(rows, &f); err != nil {
 			return nil, err
 		}
 		files = append(files, f)
@@ -313,9 +835,66 @@ func (db *DB) GetAllGroups() ([]Group, error) {
 	return groups, rows.Err()
 }
 
+// UpdateFile changes a file's details. When the owning group changes, the old
+// owner's grant moves to the new owner, replacing any grant it already had.
 func (db *DB) UpdateFile(fileID int, name, filePath string, groupID int, description string) error {
-	_, err := db.Exec("UPDATE files SET name = ?, file_path = ?, group_id = ?, description = ? WHERE id = ?",
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var oldGroupID int
+	if err := tx.QueryRow("SELECT group_id FROM files WHERE id = ?", fileID).Scan(&oldGroupID); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("UPDATE files SET name = ?, file_path = ?, group_id = ?, description = ? WHERE id = ?",
 		name, filePath, groupID, description, fileID)
+	if err != nil {
+		return err
+	}
+
+	if oldGroupID != groupID {
+		var moved int
+		err := tx.QueryRow("SELECT COUNT(*) FROM file_grants WHERE file_id = ? AND group_id = ?", fileID, oldGroupID).Scan(&moved)
+		if err != nil {
+			return err
+		}
+		if moved > 0 {
+			if _, err := tx.Exec("DELETE FROM file_grants WHERE file_id = ? AND group_id = ?", fileID, groupID); err != nil {
+				return err
+			}
+			if _, err := tx.Exec("UPDATE file_grants SET group_id = ? WHERE file_id = ? AND group_id = ?", groupID, fileID, oldGroupID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// UpdateFileDetails changes only a file's name and description, leaving its
+// path and owner alone.
+func (db *DB) UpdateFileDetails(fileID int, name, description string) error {
+	_, err := db.Exec("UPDATE files SET name = ?, description = ? WHERE id = ?", name, description, fileID)
+	return err
+}
+
+// SetFileChecksum records what a file holds, for example when it is
+// registered, along with the status of that first check.
+func (db *DB) SetFileChecksum(fileID int, sha256 string, size int64, status string, checkedAt time.Time) error {
+	_, err := db.Exec("UPDATE files SET sha256 = ?, size = ?, integrity = ?, checked_at = ? WHERE id = ?",
+		sha256, size, status, checkedAt.UTC(), fileID)
+	return err
+}
+
+// SetFileIntegrity records the result of checking a file against its
+// checksum. Nothing is recorded if the file was pointed elsewhere since
+// filePath was checked.
+func (db *DB) SetFileIntegrity(fileID int, filePath, status string, checkedAt time.Time) error {
+	_, err := db.Exec("UPDATE files SET integrity = ?, checked_at = ? WHERE id = ? AND file_path = ?",
+		status, checkedAt.UTC(), fileID, filePath)
 	return err
 }
 
@@ -325,7 +904,7 @@ func (db *DB) DeleteFile(fileID int) error {
 }
 
 func (db *DB) GetAllUsers() ([]User, error) {
-	rows, err := db.Query("SELECT id, username, password_hash FROM users ORDER BY username")
+	rows, err := db.Query("SELECT " + userColumns + " FROM users ORDER BY username")
 	if err != nil {
 		return nil, err
 	}
@@ -334,7 +913,7 @@ func (db *DB) GetAllUsers() ([]User, error) {
 	var users []User
 	for rows.Next() {
 		var u User
-		if err := rows.Scan(&u.ID, &u.Username, &u.Password); err != nil {
+		if err := scanUser(rows, &u); err != nil {
 			return nil, err
 		}
 
@@ -363,8 +942,7 @@ func (db *DB) GetAllUsers() ([]User, error) {
 
 func (db *DB) GetUserByID(userID int) (*User, error) {
 	user := &User{}
-	err := db.QueryRow("SELECT id, username, password_hash FROM users WHERE id = ?",
-		userID).Scan(&user.ID, &user.Username, &user.Password)
+	err := scanUser(db.QueryRow("SELECT "+userColumns+" FROM users WHERE id = ?", userID), user)
 	if err != nil {
 		return nil, err
 	}
@@ -395,37 +973,154 @@ func (db *DB) UpdateUser(userID int, username string, groupIDs []int) error {
 	}
 	defer tx.Rollback()
 
-	_, err = tx.Exec("UPDATE users SET username = ? WHERE id = ?", username, userID)
+	err = guardUserManagers(tx, func() error {
+		_, err := tx.Exec("UPDATE users SET username = ? WHERE id = ?", username, userID)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec("DELETE FROM user_groups WHERE user_id = ?", userID)
+		if err != nil {
+			return err
+		}
+
+		for _, groupID := range groupIDs {
+			_, err = tx.Exec("INSERT INTO user_groups (user_id, group_id) VALUES (?, ?)", userID, groupID)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (db *DB) UpdateUserPassword(userID int, password string) error {
+	if db.passwordPolicy != nil {
+		user, err := db.GetUserByID(userID)
+		if err != nil {
+			return err
+		}
+		if err := db.passwordPolicy.Check(user.Username, password); err != nil {
+			return err
+		}
+		if err := db.checkPasswordHistory(userID, user.Password, password); err != nil {
+			return err
+		}
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
 		return err
 	}
 
-	_, err = tx.Exec("DELETE FROM user_groups WHERE user_id = ?", userID)
+	tx, err := db.Begin()
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	for _, groupID := range groupIDs {
-		_, err = tx.Exec("INSERT INTO user_groups (user_id, group_id) VALUES (?, ?)", userID, groupID)
-		if err != nil {
+	_, err = tx.Exec("UPDATE users SET password_hash = ?, password_changed_at = ?, must_change_password = ? WHERE id = ?",
+		string(hash), time.Now().UTC(), false, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := db.recordPasswordHash(tx, userID, string(hash)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// checkPasswordHistory rejects candidate if it matches the current password
+// or one of the previous ones covered by the policy's history size.
+func (db *DB) checkPasswordHistory(userID int, currentHash, candidate string) error {
+	limit := db.passwordPolicy.HistorySize
+	if limit <= 0 {
+		return nil
+	}
+
+	// Accounts created before history was kept have no entries yet.
+	if bcrypt.CompareHashAndPassword([]byte(currentHash), []byte(candidate)) == nil {
+		return password.ErrReused
+	}
+
+	rows, err := db.Query("SELECT password_hash FROM password_history WHERE user_id = ? ORDER BY id DESC LIMIT ?", userID, limit)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
 			return err
 		}
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(candidate)) == nil {
+			return password.ErrReused
+		}
 	}
 
-	return tx.Commit()
+	return rows.Err()
 }
 
-func (db *DB) UpdateUserPassword(userID int, password string) error {
-	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+// recordPasswordHash appends hash to the user's password history and trims
+// entries that are older than the policy needs.
+func (db *DB) recordPasswordHash(tx *Tx, userID int, hash string) error {
+	_, err := tx.Exec("INSERT INTO password_history (user_id, password_hash, created_at) VALUES (?, ?, ?)",
+		userID, hash, time.Now().UTC())
 	if err != nil {
 		return err
 	}
 
-	_, err = db.Exec("UPDATE users SET password_hash = ? WHERE id = ?", string(hash), userID)
+	keep := 1
+	if db.passwordPolicy != nil && db.passwordPolicy.HistorySize > keep {
+		keep = db.passwordPolicy.HistorySize
+	}
+
+	_, err = tx.Exec(`DELETE FROM password_history WHERE user_id = ? AND id NOT IN (
+		SELECT id FROM password_history WHERE user_id = ? ORDER BY id DESC LIMIT ?)`,
+		userID, userID, keep)
 	return err
 }
 
-func (db *DB) DeleteUser(userID int) error {
-	_, err := db.Exec("DELETE FROM users WHERE id = ?", userID)
+// SetMustChangePassword flags the user so their next session is restricted
+// to choosing a new password. UpdateUserPassword clears the flag.
+func (db *DB) SetMustChangePassword(userID int, must bool) error {
+	_, err := db.Exec("UPDATE users SET must_change_password = ? WHERE id = ?", must, userID)
+	return err
+}
+
+func (db *DB) SetPasswordMaxAge(userID, days int) error {
+	_, err := db.Exec("UPDATE users SET password_max_age_days = ? WHERE id = ?", days, userID)
 	return err
 }
+
+func (db *DB) DeleteUser(userID int) error {
+	return db.deleteGuarded("DELETE FROM users WHERE id = ?", userID)
+}
+
+// deleteGuarded runs a delete that must not remove the last user able to
+// manage users.
+func (db *DB) deleteGuarded(query string, args ...interface{}) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = guardUserManagers(tx, func() error {
+		_, err := tx.Exec(query, args...)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,17 @@
+package database
+
+import "database/sql"
+
+// dialect isolates the SQL differences between the supported backends. The
+// schema and queries are written in SQLite syntax with "?" placeholders and
+// translated by the dialect when needed.
+type dialect interface {
+	driverName() string
+	rebind(query string) string
+	translateSchema(schema string) string
+	snapshot(db *sql.DB, destPath string) error
+	hasColumn(db *sql.DB, table, column string) (bool, error)
+	// removeOrphans deletes rows whose parent row is gone, which only
+	// databases that did not always enforce foreign keys can have.
+	removeOrphans(db *sql.DB) error
+}
@@ -0,0 +1,119 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// FileVersion is one snapshot of a watched save, copied into managed
+// storage. The file record it belongs to points at its newest snapshot.
+type FileVersion struct {
+	ID     int
+	FileID int
+	// SourcePath is where the save was copied from; BlobPath the copy.
+	SourcePath string
+	BlobPath   string
+	Size       int64
+	SHA256     string
+	// SidecarSHA256 is the hash of the tModLoader sidecar copied with the
+	// save, or empty when it had none.
+	SidecarSHA256 string
+	CreatedAt     time.Time
+}
+
+// FileVersionSummary describes the snapshots of one file record.
+type FileVersionSummary struct {
+	Count      int
+	SourcePath string
+	Latest     time.Time
+}
+
+const fileVersionColumns = "id, file_id, source_path, blob_path, size, sha256, sidecar_sha256, created_at"
+
+// GetLatestFileVersion returns the newest snapshot taken of sourcePath, or
+// nil if there is none.
+func (db *DB) GetLatestFileVersion(sourcePath string) (*FileVersion, error) {
+	v := &FileVersion{}
+	err := db.QueryRow("SELECT "+fileVersionColumns+" FROM file_versions WHERE source_path = ? ORDER BY id DESC LIMIT 1",
+		sourcePath).Scan(&v.ID, &v.FileID, &v.SourcePath, &v.BlobPath, &v.Size, &v.SHA256, &v.SidecarSHA256, &v.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// AddFileVersion records a snapshot and points its file record at the copy,
+// whose checksum the record is verified against from then on. The record is
+// the one earlier snapshots of the same source went to, or else one
+// registered with the source path itself. When there is neither, a record
+// called name is created for groupID. It returns the record's id and
+// whether it was created.
+func (db *DB) AddFileVersion(v FileVersion, name string, groupID int) (int, bool, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, false, err
+	}
+	defer tx.Rollback()
+
+	var fileID int
+	created := false
+	err = tx.QueryRow("SELECT file_id FROM file_versions WHERE source_path = ? ORDER BY id DESC LIMIT 1", v.SourcePath).Scan(&fileID)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = tx.QueryRow("SELECT id FROM files WHERE file_path = ? ORDER BY id LIMIT 1", v.SourcePath).Scan(&fileID)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		created = true
+		err = tx.QueryRow("INSERT INTO files (name, file_path, group_id, description) VALUES (?, ?, ?, ?) RETURNING id",
+			name, v.BlobPath, groupID, "Snapshots of "+v.SourcePath).Scan(&fileID)
+		if err == nil {
+			_, err = tx.Exec("INSERT INTO file_grants (file_id, group_id, level) VALUES (?, ?, ?)",
+				fileID, groupID, GrantDownload)
+		}
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	_, err = tx.Exec("INSERT INTO file_versions (file_id, source_path, blob_path, size, sha256, sidecar_sha256, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		fileID, v.SourcePath, v.BlobPath, v.Size, v.SHA256, v.SidecarSHA256, v.CreatedAt.UTC())
+	if err != nil {
+		return 0, false, err
+	}
+	_, err = tx.Exec("UPDATE files SET file_path = ?, sha256 = ?, size = ?, integrity = ?, checked_at = ? WHERE id = ?",
+		v.BlobPath, v.SHA256, v.Size, IntegrityOK, v.CreatedAt.UTC(), fileID)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, false, err
+	}
+	return fileID, created, nil
+}
+
+// GetFileVersionSummaries returns, by file id, how many snapshots each file
+// record has, where the newest came from and when it was taken.
+func (db *DB) GetFileVersionSummaries() (map[int]FileVersionSummary, error) {
+	rows, err := db.Query("SELECT file_id, source_path, created_at FROM file_versions ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summaries := make(map[int]FileVersionSummary)
+	for rows.Next() {
+		var fileID int
+		var s FileVersionSummary
+		if err := rows.Scan(&fileID, &s.SourcePath, &s.Latest); err != nil {
+			return nil, err
+		}
+		s.Count = summaries[fileID].Count + 1
+		summaries[fileID] = s
+	}
+
+	return summaries, rows.Err()
+}
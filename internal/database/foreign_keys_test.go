@@ -0,0 +1,86 @@
+package database
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+)
+
+func TestForeignKeysEnforced(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, db *DB) {
+		_, players, _, bob := seed(t, db)
+		fileID, err := db.AddFile("world.wld", "/srv/world.wld", players, "")
+		if err != nil {
+			t.Fatalf("AddFile: %v", err)
+		}
+
+		if err := db.GrantFileToGroup(fileID, 9999, GrantView); err == nil {
+			t.Error("granted a file to a group that does not exist")
+		}
+
+		if err := db.AddLoginEvent(bob, "127.0.0.1", "test", true); err != nil {
+			t.Fatalf("AddLoginEvent: %v", err)
+		}
+		if err := db.DeleteUser(bob); err != nil {
+			t.Fatalf("DeleteUser: %v", err)
+		}
+		for _, table := range []string{"user_groups", "login_history", "password_history"} {
+			var n int
+			if err := db.QueryRow("SELECT COUNT(*) FROM "+table+" WHERE user_id = ?", bob).Scan(&n); err != nil {
+				t.Fatalf("count %s: %v", table, err)
+			}
+			if n != 0 {
+				t.Errorf("%d %s rows left after deleting the user", n, table)
+			}
+		}
+	})
+}
+
+// TestRemoveOrphans opens a SQLite database written without foreign key
+// enforcement, as versions before it were.
+func TestRemoveOrphans(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.db")
+	db, err := InitDB(path)
+	if err != nil {
+		t.Fatalf("InitDB: %v", err)
+	}
+	_, players, _, bob := seed(t, db)
+	fileID, err := db.AddFile("world.wld", "/srv/world.wld", players, "")
+	if err != nil {
+		t.Fatalf("AddFile: %v", err)
+	}
+	db.Close()
+
+	raw, err := sql.Open("sqlite3", path+"?_foreign_keys=off")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if _, err := raw.Exec("DELETE FROM users WHERE id = ?", bob); err != nil {
+		t.Fatalf("delete user: %v", err)
+	}
+	if _, err := raw.Exec("DELETE FROM groups WHERE id = ?", players); err != nil {
+		t.Fatalf("delete group: %v", err)
+	}
+	raw.Close()
+
+	db, err = InitDB(path)
+	if err != nil {
+		t.Fatalf("InitDB with orphans: %v", err)
+	}
+	defer db.Close()
+
+	var n int
+	if err := db.QueryRow("SELECT COUNT(*) FROM user_groups WHERE user_id = ? OR group_id = ?", bob, players).Scan(&n); err != nil {
+		t.Fatalf("count user_groups: %v", err)
+	}
+	if n != 0 {
+		t.Errorf("%d orphaned user_groups rows kept", n)
+	}
+	if grants, _ := db.GetFileGrants(fileID); len(grants) != 0 {
+		t.Errorf("grants to the deleted group kept: %+v", grants)
+	}
+	// The file itself does not cascade from its group and is kept.
+	if _, err := db.GetFileByID(fileID); err != nil {
+		t.Errorf("file of the deleted group removed: %v", err)
+	}
+}
@@ -0,0 +1,187 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Grant levels, from weakest to strongest. Each level includes the ones
+// before it: view lists the file and opens it in the map viewer, download
+// adds downloading, and manage adds changing who the file is shared with.
+const (
+	GrantView     = "view"
+	GrantDownload = "download"
+	GrantManage   = "manage"
+)
+
+// GrantLevels lists every grant level from weakest to strongest.
+var GrantLevels = []string{GrantView, GrantDownload, GrantManage}
+
+var grantRank = map[string]int{GrantView: 1, GrantDownload: 2, GrantManage: 3}
+
+// GrantAllows reports whether holding level have satisfies a check for need.
+func GrantAllows(have, need string) bool {
+	return grantRank[have] > 0 && grantRank[have] >= grantRank[need]
+}
+
+// ValidGrantLevel reports whether level is one of GrantLevels.
+func ValidGrantLevel(level string) bool {
+	return grantRank[level] > 0
+}
+
+// FileGrant shares a file with either a group or a single user. Exactly one
+// of GroupID and UserID is set; the other is zero.
+type FileGrant struct {
+	ID        int
+	FileID    int
+	GroupID   int
+	GroupName string
+	UserID    int
+	Username  string
+	Level     string
+}
+
+// backfillFileGrants gives each file's group download access. It runs once,
+// when file_grants is first created, so files from before grants existed
+// stay visible to the same people.
+func backfillFileGrants(db *sql.DB) error {
+	_, err := db.Exec("INSERT INTO file_grants (file_id, group_id, level) SELECT id, group_id, '" + GrantDownload + "' FROM files")
+	return err
+}
+
+// fileAccessColumns selects a file together with one grant level that applies
+// to it. A file reached through several grants appears once per grant.
+const fileAccessColumns = "SELECT " + fileColumns + ", fg.level FROM files f JOIN file_grants fg ON fg.file_id = f.id"
+
+// scanFileAccess reads rows selected with fileAccessColumns, keeping one
+// entry per file with the strongest level in Access.
+func scanFileAccess(rows *sql.Rows) ([]File, error) {
+	defer rows.Close()
+
+	var files []File
+	index := make(map[int]int)
+	for rows.Next() {
+		var f File
+		if err := scanFile(rows, &f, &f.Access); err != nil {
+			return nil, err
+		}
+		if i, ok := index[f.ID]; ok {
+			if grantRank[f.Access] > grantRank[files[i].Access] {
+				files[i].Access = f.Access
+			}
+			continue
+		}
+		index[f.ID] = len(files)
+		files = append(files, f)
+	}
+
+	return files, rows.Err()
+}
+
+// GetFilesForUser returns every file shared with the user directly or through
+// one of their groups, including groups that contain theirs, with Access set
+// to the strongest level they hold.
+func (db *DB) GetFilesForUser(userID int) ([]File, error) {
+	rows, err := db.Query(memberGroupsCTE+fileAccessColumns+`
+		WHERE fg.user_id = ?
+		OR fg.group_id IN (SELECT group_id FROM member_groups WHERE user_id = ?)
+		ORDER BY f.name, f.id`, userID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return scanFileAccess(rows)
+}
+
+// GetFileAccessLevel returns the strongest grant level the user holds on the
+// file, or "" if the file is not shared with them.
+func (db *DB) GetFileAccessLevel(userID, fileID int) (string, error) {
+	levels, err := db.queryStrings(memberGroupsCTE+`
+		SELECT level FROM file_grants
+		WHERE file_id = ? AND (user_id = ? OR group_id IN (SELECT group_id FROM member_groups WHERE user_id = ?))`,
+		fileID, userID, userID)
+	if err != nil {
+		return "", err
+	}
+
+	best := ""
+	for _, level := range levels {
+		if grantRank[level] > grantRank[best] {
+			best = level
+		}
+	}
+	return best, nil
+}
+
+func (db *DB) GetFileGrants(fileID int) ([]FileGrant, error) {
+	rows, err := db.Query(`
+		SELECT fg.id, fg.file_id, COALESCE(fg.group_id, 0), COALESCE(g.name, ''),
+			COALESCE(fg.user_id, 0), COALESCE(u.username, ''), fg.level
+		FROM file_grants fg
+		LEFT JOIN groups g ON g.id = fg.group_id
+		LEFT JOIN users u ON u.id = fg.user_id
+		WHERE fg.file_id = ?
+		ORDER BY g.name, u.username`, fileID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var grants []FileGrant
+	for rows.Next() {
+		var g FileGrant
+		if err := rows.Scan(&g.ID, &g.FileID, &g.GroupID, &g.GroupName, &g.UserID, &g.Username, &g.Level); err != nil {
+			return nil, err
+		}
+		grants = append(grants, g)
+	}
+
+	return grants, rows.Err()
+}
+
+// GrantFileToGroup shares a file with a group, replacing any level the group
+// already had on it.
+func (db *DB) GrantFileToGroup(fileID, groupID int, level string) error {
+	return db.setFileGrant(fileID, "group_id", groupID, level)
+}
+
+// GrantFileToUser shares a file with a single user, replacing any level the
+// user was already granted directly.
+func (db *DB) GrantFileToUser(fileID, userID int, level string) error {
+	return db.setFileGrant(fileID, "user_id", userID, level)
+}
+
+func (db *DB) setFileGrant(fileID int, column string, id int, level string) error {
+	if !ValidGrantLevel(level) {
+		return fmt.Errorf("unknown grant level %q", level)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM file_grants WHERE file_id = ? AND "+column+" = ?", fileID, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("INSERT INTO file_grants (file_id, "+column+", level) VALUES (?, ?, ?)", fileID, id, level); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (db *DB) RevokeFileGrant(fileID, grantID int) error {
+	_, err := db.Exec("DELETE FROM file_grants WHERE id = ? AND file_id = ?", grantID, fileID)
+	return err
+}
+
+// inPlaceholders returns "?,?,..." with n placeholders and ids as arguments.
+func inPlaceholders(ids []int) (string, []interface{}) {
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return strings.TrimSuffix(strings.Repeat("?,", len(ids)), ","), args
+}
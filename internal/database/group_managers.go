@@ -0,0 +1,133 @@
+package database
+
+import "errors"
+
+// ErrGroupGrantsRoles is returned to group managers who try to change the
+// membership of a group that carries a role, which would let them hand out
+// admin permissions.
+var ErrGroupGrantsRoles = errors.New("membership of this group grants roles and can only be changed by an administrator")
+
+// GetGroupManagers returns the users designated to manage each group, keyed
+// by group ID.
+func (db *DB) GetGroupManagers() (map[int][]int, error) {
+	rows, err := db.Query("SELECT group_id, user_id FROM group_managers ORDER BY group_id, user_id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	managers := make(map[int][]int)
+	for rows.Next() {
+		var groupID, userID int
+		if err := rows.Scan(&groupID, &userID); err != nil {
+			return nil, err
+		}
+		managers[groupID] = append(managers[groupID], userID)
+	}
+
+	return managers, rows.Err()
+}
+
+// SetGroupManagers replaces the users who manage groupID.
+func (db *DB) SetGroupManagers(groupID int, userIDs []int) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := setGroupManagers(tx, groupID, userIDs); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func setGroupManagers(tx *Tx, groupID int, userIDs []int) error {
+	if _, err := tx.Exec("DELETE FROM group_managers WHERE group_id = ?", groupID); err != nil {
+		return err
+	}
+
+	for _, userID := range userIDs {
+		if _, err := tx.Exec("INSERT INTO group_managers (group_id, user_id) VALUES (?, ?)", groupID, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetManagedGroups returns the groups the user has been designated to
+// manage.
+func (db *DB) GetManagedGroups(userID int) ([]Group, error) {
+	rows, err := db.Query(`
+		SELECT g.id, g.name FROM groups g
+		JOIN group_managers gm ON gm.group_id = g.id
+		WHERE gm.user_id = ?
+		ORDER BY g.name`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []Group
+	for rows.Next() {
+		var g Group
+		if err := rows.Scan(&g.ID, &g.Name); err != nil {
+			return nil, err
+		}
+		groups = append(groups, g)
+	}
+
+	return groups, rows.Err()
+}
+
+func (db *DB) IsGroupManager(userID, groupID int) (bool, error) {
+	var count int
+	err := db.QueryRow("SELECT COUNT(*) FROM group_managers WHERE user_id = ? AND group_id = ?",
+		userID, groupID).Scan(&count)
+	return count > 0, err
+}
+
+// GetGroupMembers returns the users who belong to the group directly.
+func (db *DB) GetGroupMembers(groupID int) ([]User, error) {
+	rows, err := db.Query("SELECT "+userColumns+" FROM users WHERE id IN (SELECT user_id FROM user_groups WHERE group_id = ?) ORDER BY username", groupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []User
+	for rows.Next() {
+		var u User
+		if err := scanUser(rows, &u); err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+
+	return users, rows.Err()
+}
+
+// GroupGrantsRoles reports whether joining the group gives a user any role,
+// through the group itself or any group that contains it.
+func (db *DB) GroupGrantsRoles(groupID int) (bool, error) {
+	var count int
+	err := db.QueryRow(`
+		WITH RECURSIVE ancestors(id) AS (
+			SELECT CAST(? AS INTEGER)
+			UNION
+			SELECT gn.parent_id FROM group_nesting gn
+			JOIN ancestors a ON a.id = gn.child_id
+		)
+		SELECT COUNT(*) FROM group_roles WHERE group_id IN (SELECT id FROM ancestors)`, groupID).Scan(&count)
+	return count > 0, err
+}
+
+func (db *DB) AddGroupMember(groupID, userID int) error {
+	_, err := db.Exec("INSERT INTO user_groups (user_id, group_id) VALUES (?, ?) ON CONFLICT DO NOTHING", userID, groupID)
+	return err
+}
+
+func (db *DB) RemoveGroupMember(groupID, userID int) error {
+	return db.deleteGuarded("DELETE FROM user_groups WHERE group_id = ? AND user_id = ?", groupID, userID)
+}
@@ -0,0 +1,103 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrGroupCycle is returned when nesting a group would make it contain
+// itself, directly or through other groups.
+var ErrGroupCycle = errors.New("a group cannot contain itself, directly or through other groups")
+
+// memberGroupsCTE defines member_groups(user_id, group_id): every group a
+// user belongs to directly, plus every group that contains one of those,
+// transitively. UNION discards rows already produced, so the recursion stops
+// even if the nesting somehow contains a cycle.
+const memberGroupsCTE = `
+	WITH RECURSIVE member_groups(user_id, group_id) AS (
+		SELECT user_id, group_id FROM user_groups
+		UNION
+		SELECT mg.user_id, gn.parent_id FROM group_nesting gn
+		JOIN member_groups mg ON mg.group_id = gn.child_id
+	)`
+
+// GetEffectiveGroupIDs returns every group the user belongs to, directly or
+// through a group nested inside another.
+func (db *DB) GetEffectiveGroupIDs(userID int) ([]int, error) {
+	return db.queryInts(memberGroupsCTE+" SELECT group_id FROM member_groups WHERE user_id = ? ORDER BY group_id", userID)
+}
+
+// GetGroupChildren returns the groups directly contained in each group,
+// keyed by the containing group's ID.
+func (db *DB) GetGroupChildren() (map[int][]int, error) {
+	rows, err := db.Query("SELECT parent_id, child_id FROM group_nesting ORDER BY parent_id, child_id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	children := make(map[int][]int)
+	for rows.Next() {
+		var parentID, childID int
+		if err := rows.Scan(&parentID, &childID); err != nil {
+			return nil, err
+		}
+		children[parentID] = append(children[parentID], childID)
+	}
+
+	return children, rows.Err()
+}
+
+// SetGroupChildren replaces the groups directly contained in groupID. Members
+// of a contained group count as members of groupID. It fails with
+// ErrGroupCycle if any child already contains groupID.
+func (db *DB) SetGroupChildren(groupID int, childIDs []int) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := setGroupChildren(tx, groupID, childIDs); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func setGroupChildren(tx *Tx, groupID int, childIDs []int) error {
+	return guardUserManagers(tx, func() error {
+		if _, err := tx.Exec("DELETE FROM group_nesting WHERE parent_id = ?", groupID); err != nil {
+			return err
+		}
+
+		for _, childID := range childIDs {
+			cycle, err := containsGroup(tx, childID, groupID)
+			if err != nil {
+				return err
+			}
+			if cycle {
+				return ErrGroupCycle
+			}
+
+			if _, err := tx.Exec("INSERT INTO group_nesting (parent_id, child_id) VALUES (?, ?)", groupID, childID); err != nil {
+				return fmt.Errorf("nest group %d in %d: %w", childID, groupID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// containsGroup reports whether outer is inner or contains it at any depth.
+func containsGroup(tx *Tx, outer, inner int) (bool, error) {
+	var count int
+	err := tx.QueryRow(`
+		WITH RECURSIVE descendants(id) AS (
+			SELECT CAST(? AS INTEGER)
+			UNION
+			SELECT gn.child_id FROM group_nesting gn
+			JOIN descendants d ON d.id = gn.parent_id
+		)
+		SELECT COUNT(*) FROM descendants WHERE id = ?`, outer, inner).Scan(&count)
+	return count > 0, err
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrIdentityLinked is returned when an external identity is already linked
+// to another user.
+var ErrIdentityLinked = errors.New("this identity is already linked to another account")
+
+// Identity links an account at an external identity provider, named by its
+// issuer and the provider's subject ID for the account, to a local user.
+type Identity struct {
+	Issuer   string
+	Subject  string
+	UserID   int
+	LinkedAt time.Time
+}
+
+// GetUserByIdentity returns the user the identity is linked to.
+func (db *DB) GetUserByIdentity(issuer, subject string) (*User, error) {
+	var userID int
+	err := db.QueryRow("SELECT user_id FROM user_identities WHERE issuer = ? AND subject = ?",
+		issuer, subject).Scan(&userID)
+	if err != nil {
+		return nil, err
+	}
+	return db.GetUserByID(userID)
+}
+
+func (db *DB) LinkIdentity(userID int, issuer, subject string) error {
+	var linkedTo int
+	err := db.QueryRow("SELECT user_id FROM user_identities WHERE issuer = ? AND subject = ?",
+		issuer, subject).Scan(&linkedTo)
+	if err == nil {
+		if linkedTo == userID {
+			return nil
+		}
+		return ErrIdentityLinked
+	}
+
+	_, err = db.Exec("INSERT INTO user_identities (issuer, subject, user_id, linked_at) VALUES (?, ?, ?, ?)",
+		issuer, subject, userID, time.Now().UTC())
+	return err
+}
+
+func (db *DB) GetIdentitiesByUser(userID int) ([]Identity, error) {
+	rows, err := db.Query(`SELECT issuer, subject, user_id, linked_at FROM user_identities
+		WHERE user_id = ? ORDER BY linked_at`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var identities []Identity
+	for rows.Next() {
+		var i Identity
+		if err := rows.Scan(&i.Issuer, &i.Subject, &i.UserID, &i.LinkedAt); err != nil {
+			return nil, err
+		}
+		identities = append(identities, i)
+	}
+
+	return identities, rows.Err()
+}
+
+// UnlinkIdentity removes one of the user's identities. It reports false if
+// the user has no such identity.
+func (db *DB) UnlinkIdentity(userID int, issuer, subject string) (bool, error) {
+	result, err := db.Exec("DELETE FROM user_identities WHERE user_id = ? AND issuer = ? AND subject = ?",
+		userID, issuer, subject)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
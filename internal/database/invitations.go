@@ -0,0 +1,128 @@
+package database
+
+import (
+	"time"
+)
+
+// Invitation lets people create their own account in a set of groups. Like
+// share links, only a hash of the token is stored.
+type Invitation struct {
+	ID        int
+	CreatedBy int
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	MaxUses   int
+	Uses      int
+	Note      string
+	GroupIDs  []int
+}
+
+func (i *Invitation) Expired() bool {
+	return time.Now().After(i.ExpiresAt)
+}
+
+func (i *Invitation) UsedUp() bool {
+	return i.Uses >= i.MaxUses
+}
+
+// Active reports whether the invitation can still be redeemed.
+func (i *Invitation) Active() bool {
+	return !i.Expired() && !i.UsedUp()
+}
+
+const invitationColumns = "id, created_by, created_at, expires_at, max_uses, uses, note"
+
+func scanInvitation(row rowScanner, i *Invitation) error {
+	return row.Scan(&i.ID, &i.CreatedBy, &i.CreatedAt, &i.ExpiresAt, &i.MaxUses, &i.Uses, &i.Note)
+}
+
+func (db *DB) CreateInvitation(token string, createdBy int, groupIDs []int, expiresAt time.Time, maxUses int, note string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var invitationID int
+	err = tx.QueryRow(`INSERT INTO invitations (token_hash, created_by, created_at, expires_at, max_uses, note)
+		VALUES (?, ?, ?, ?, ?, ?) RETURNING id`,
+		hashShareToken(token), createdBy, time.Now().UTC(), expiresAt.UTC(), maxUses, note).Scan(&invitationID)
+	if err != nil {
+		return err
+	}
+
+	for _, groupID := range groupIDs {
+		if _, err := tx.Exec("INSERT INTO invitation_groups (invitation_id, group_id) VALUES (?, ?)", invitationID, groupID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (db *DB) GetInvitationByToken(token string) (*Invitation, error) {
+	invitation := &Invitation{}
+	err := scanInvitation(db.QueryRow("SELECT "+invitationColumns+" FROM invitations WHERE token_hash = ?", hashShareToken(token)), invitation)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation.GroupIDs, err = db.queryInts("SELECT group_id FROM invitation_groups WHERE invitation_id = ?", invitation.ID)
+	return invitation, err
+}
+
+// GetAllInvitations returns every invitation, newest first.
+func (db *DB) GetAllInvitations() ([]Invitation, error) {
+	rows, err := db.Query("SELECT " + invitationColumns + " FROM invitations ORDER BY created_at DESC, id DESC")
+	if err != nil {
+		return nil, err
+	}
+
+	var invitations []Invitation
+	for rows.Next() {
+		var i Invitation
+		if err := scanInvitation(rows, &i); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		invitations = append(invitations, i)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range invitations {
+		invitations[i].GroupIDs, err = db.queryInts("SELECT group_id FROM invitation_groups WHERE invitation_id = ?", invitations[i].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return invitations, nil
+}
+
+func (db *DB) DeleteInvitation(invitationID int) error {
+	_, err := db.Exec("DELETE FROM invitations WHERE id = ?", invitationID)
+	return err
+}
+
+// ClaimInvitation takes one use of the invitation. It reports false, without
+// taking anything, if the invitation has expired or is used up, so two
+// people cannot redeem the last use at the same time.
+func (db *DB) ClaimInvitation(invitationID int) (bool, error) {
+	result, err := db.Exec("UPDATE invitations SET uses = uses + 1 WHERE id = ? AND uses < max_uses AND expires_at > ?",
+		invitationID, time.Now().UTC())
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+// ReleaseInvitation gives back a use taken by ClaimInvitation when creating
+// the account failed.
+func (db *DB) ReleaseInvitation(invitationID int) error {
+	_, err := db.Exec("UPDATE invitations SET uses = uses - 1 WHERE id = ? AND uses > 0", invitationID)
+	return err
+}
@@ -0,0 +1,40 @@
+package database
+
+import "time"
+
+// LoginEvent is one password login attempt against an existing account.
+type LoginEvent struct {
+	ID        int
+	UserID    int
+	CreatedAt time.Time
+	IP        string
+	UserAgent string
+	Success   bool
+}
+
+func (db *DB) AddLoginEvent(userID int, ip, userAgent string, success bool) error {
+	_, err := db.Exec("INSERT INTO login_history (user_id, created_at, ip, user_agent, success) VALUES (?, ?, ?, ?, ?)",
+		userID, time.Now().UTC(), ip, userAgent, success)
+	return err
+}
+
+// GetLoginHistory returns the user's most recent login attempts, newest first.
+func (db *DB) GetLoginHistory(userID, limit int) ([]LoginEvent, error) {
+	rows, err := db.Query("SELECT id, user_id, created_at, ip, user_agent, success FROM login_history WHERE user_id = ? ORDER BY id DESC LIMIT ?",
+		userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []LoginEvent
+	for rows.Next() {
+		var e LoginEvent
+		if err := rows.Scan(&e.ID, &e.UserID, &e.CreatedAt, &e.IP, &e.UserAgent, &e.Success); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
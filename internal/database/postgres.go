@@ -0,0 +1,90 @@
+package database
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+
+	_ "github.com/lib/pq"
+)
+
+type postgresDialect struct{}
+
+var postgresSchemaReplacer = strings.NewReplacer(
+	"INTEGER PRIMARY KEY AUTOINCREMENT", "SERIAL PRIMARY KEY",
+)
+
+func (postgresDialect) driverName() string {
+	return "postgres"
+}
+
+// rebind rewrites "?" placeholders into PostgreSQL's numbered "$n" form. A
+// "?" inside a quoted string or identifier, or in a comment, is left alone.
+func (postgresDialect) rebind(query string) string {
+	if !strings.Contains(query, "?") {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for i := 0; i < len(query); {
+		if end := skipLiteral(query, i); end > i {
+			b.WriteString(query[i:end])
+			i = end
+			continue
+		}
+		if query[i] == '?' {
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+		} else {
+			b.WriteByte(query[i])
+		}
+		i++
+	}
+	return b.String()
+}
+
+// skipLiteral returns where the quoted string, quoted identifier or comment
+// starting at query[i] ends, or i if none starts there. A doubled quote
+// inside a string reads as two strings back to back, which comes to the
+// same thing. An unterminated one runs to the end of the query.
+func skipLiteral(query string, i int) int {
+	rest := query[i:]
+	var open, closing string
+	switch {
+	case rest[0] == '\'' || rest[0] == '"':
+		open, closing = rest[:1], rest[:1]
+	case strings.HasPrefix(rest, "--"):
+		open, closing = "--", "\n"
+	case strings.HasPrefix(rest, "/*"):
+		open, closing = "/*", "*/"
+	default:
+		return i
+	}
+	n := strings.Index(rest[len(open):], closing)
+	if n < 0 {
+		return len(query)
+	}
+	return i + len(open) + n + len(closing)
+}
+
+func (postgresDialect) translateSchema(schema string) string {
+	return postgresSchemaReplacer.Replace(schema)
+}
+
+func (postgresDialect) snapshot(db *sql.DB, destPath string) error {
+	return ErrSnapshotUnsupported
+}
+
+func (postgresDialect) removeOrphans(db *sql.DB) error {
+	return nil
+}
+
+func (postgresDialect) hasColumn(db *sql.DB, table, column string) (bool, error) {
+	var count int
+	err := db.QueryRow(
+		"SELECT COUNT(*) FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = $1 AND column_name = $2",
+		table, column).Scan(&count)
+	return count > 0, err
+}
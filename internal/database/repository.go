@@ -0,0 +1,157 @@
+package database
+
+import (
+	"backup_server/internal/password"
+	"time"
+)
+
+// Repository is the storage interface the HTTP handlers and tools depend on.
+// DB implements it for both SQLite and PostgreSQL.
+type Repository interface {
+	UserRepository
+	GroupRepository
+	FileRepository
+	MembershipRepository
+	AuditRepository
+	LoginHistoryRepository
+	RoleRepository
+	ShareLinkRepository
+	InvitationRepository
+	IdentityRepository
+	ChestIndexRepository
+	FileVersionRepository
+	BackupJobRepository
+	Snapshot(destPath string) error
+	Close() error
+}
+
+type UserRepository interface {
+	CreateUser(username, password string, groupIDs []int) error
+	CreateExternalUser(username, source string, groupIDs []int) (*User, error)
+	GetUserByID(userID int) (*User, error)
+	GetUserByUsername(username string) (*User, error)
+	GetAllUsers() ([]User, error)
+	ValidateUser(username, password string) (*User, error)
+	UpdateUser(userID int, username string, groupIDs []int) error
+	UpdateUserPassword(userID int, password string) error
+	SetMustChangePassword(userID int, must bool) error
+	SetPasswordMaxAge(userID, days int) error
+	DeleteUser(userID int) error
+	PasswordPolicy() *password.Policy
+}
+
+type GroupRepository interface {
+	CreateGroup(name string) (int64, error)
+	GetGroupByID(groupID int) (*Group, error)
+	GetAllGroups() ([]Group, error)
+	EditGroup(groupID int, name string, childIDs, managerIDs []int) error
+	DeleteGroup(groupID int) error
+	GetGroupMemberCount(groupID int) (int, error)
+	GetGroupFileCount(groupID int) (int, error)
+	GetGroupChildren() (map[int][]int, error)
+	SetGroupChildren(groupID int, childIDs []int) error
+	GetGroupManagers() (map[int][]int, error)
+	SetGroupManagers(groupID int, userIDs []int) error
+	GetManagedGroups(userID int) ([]Group, error)
+	IsGroupManager(userID, groupID int) (bool, error)
+	GetGroupMembers(groupID int) ([]User, error)
+	GroupGrantsRoles(groupID int) (bool, error)
+	AddGroupMember(groupID, userID int) error
+	RemoveGroupMember(groupID, userID int) error
+}
+
+type FileRepository interface {
+	AddFile(name, filePath string, groupID int, description string) (int, error)
+	GetFileByID(fileID int) (*File, error)
+	GetAllFiles() ([]File, error)
+	GetFilesByGroupID(groupID int) ([]File, error)
+	GetFilesByGroupIDs(groupIDs []int) ([]File, error)
+	UpdateFile(fileID int, name, filePath string, groupID int, description string) error
+	UpdateFileDetails(fileID int, name, description string) error
+	SetFileChecksum(fileID int, sha256 string, size int64, status string, checkedAt time.Time) error
+	SetFileIntegrity(fileID int, filePath, status string, checkedAt time.Time) error
+	DeleteFile(fileID int) error
+	GetFilesForUser(userID int) ([]File, error)
+	GetFileAccessLevel(userID, fileID int) (string, error)
+	GetFileGrants(fileID int) ([]FileGrant, error)
+	GrantFileToGroup(fileID, groupID int, level string) error
+	GrantFileToUser(fileID, userID int, level string) error
+	RevokeFileGrant(fileID, grantID int) error
+}
+
+type MembershipRepository interface {
+	UserHasAccessToGroup(userID, groupID int) (bool, error)
+	GetEffectiveGroupIDs(userID int) ([]int, error)
+}
+
+type AuditRepository interface {
+	AddAuditEntry(actor, action, detail string) error
+	GetAuditEntries(limit int) ([]AuditEntry, error)
+}
+
+type LoginHistoryRepository interface {
+	AddLoginEvent(userID int, ip, userAgent string, success bool) error
+	GetLoginHistory(userID, limit int) ([]LoginEvent, error)
+}
+
+type RoleRepository interface {
+	GetAllRoles() ([]Role, error)
+	GetRoleByName(name string) (*Role, error)
+	CreateRole(name string, permissions []string, groupIDs, userIDs []int) error
+	UpdateRole(roleID int, name string, permissions []string, groupIDs, userIDs []int) error
+	DeleteRole(roleID int) error
+	AssignRoleToGroup(roleID, groupID int) error
+	GetUserPermissions(userID int) (map[string]bool, error)
+	UserHasPermission(userID int, permission string) (bool, error)
+}
+
+type ShareLinkRepository interface {
+	CreateShareLink(token string, fileID, createdBy int, expiresAt time.Time, maxDownloads int, password string) error
+	GetShareLinkByToken(token string) (*ShareLink, error)
+	GetShareLinksByUser(userID int) ([]ShareLink, error)
+	DeleteShareLink(linkID, userID int) (bool, error)
+	RecordShareDownload(linkID int) (bool, error)
+}
+
+type IdentityRepository interface {
+	GetUserByIdentity(issuer, subject string) (*User, error)
+	LinkIdentity(userID int, issuer, subject string) error
+	GetIdentitiesByUser(userID int) ([]Identity, error)
+	UnlinkIdentity(userID int, issuer, subject string) (bool, error)
+}
+
+type ChestIndexRepository interface {
+	GetChestIndexStates() (map[int]ChestIndexState, error)
+	ReplaceChestIndex(state ChestIndexState, items []ChestItem) error
+	ClearChestIndex(fileID int) error
+	SearchChestItems(itemIDs, fileIDs []int, limit int) ([]ChestItem, error)
+}
+
+type FileVersionRepository interface {
+	GetLatestFileVersion(sourcePath string) (*FileVersion, error)
+	AddFileVersion(v FileVersion, name string, groupID int) (int, bool, error)
+	GetFileVersionSummaries() (map[int]FileVersionSummary, error)
+}
+
+type BackupJobRepository interface {
+	CreateBackupJob(job BackupJob) error
+	GetBackupJob(jobID int) (*BackupJob, error)
+	GetBackupJobs() ([]BackupJob, error)
+	UpdateBackupJob(job BackupJob) error
+	DeleteBackupJob(jobID int) error
+	StartBackupJobRun(jobID, attempt int, startedAt time.Time) (int, error)
+	FinishBackupJobRun(run BackupJobRun) error
+	AbandonBackupJobRuns() error
+	GetBackupJobRuns(limit int) ([]BackupJobRun, error)
+}
+
+type InvitationRepository interface {
+	CreateInvitation(token string, createdBy int, groupIDs []int, expiresAt time.Time, maxUses int, note string) error
+	GetInvitationByToken(token string) (*Invitation, error)
+	GetAllInvitations() ([]Invitation, error)
+	DeleteInvitation(invitationID int) error
+	ClaimInvitation(invitationID int) (bool, error)
+	ReleaseInvitation(invitationID int) error
+}
+
+var _ Repository = (*DB)(nil)
@@ -0,0 +1,686 @@
+package database
+
+import (
+	"backup_server/internal/auth"
+	"backup_server/internal/password"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRebind(t *testing.T) {
+	tests := []struct {
+		query, want string
+	}{
+		{"SELECT 1", "SELECT 1"},
+		{"SELECT * FROM t WHERE a = ? AND b = ?", "SELECT * FROM t WHERE a = $1 AND b = $2"},
+		{"SELECT '?' FROM t WHERE a = ?", "SELECT '?' FROM t WHERE a = $1"},
+		{"SELECT 'it''s ?' , ? FROM t", "SELECT 'it''s ?' , $1 FROM t"},
+		{`SELECT "odd?name" FROM t WHERE a = ?`, `SELECT "odd?name" FROM t WHERE a = $1`},
+		{"SELECT a -- why?\nFROM t WHERE a = ?", "SELECT a -- why?\nFROM t WHERE a = $1"},
+		{"SELECT a /* why? */ FROM t WHERE a = ?", "SELECT a /* why? */ FROM t WHERE a = $1"},
+		{"SELECT a - ? / ?", "SELECT a - $1 / $2"},
+		{"SELECT 'unterminated ?", "SELECT 'unterminated ?"},
+	}
+	for _, tt := range tests {
+		if got := (postgresDialect{}).rebind(tt.query); got != tt.want {
+			t.Errorf("rebind(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+// seed creates the groups and users most tests need: an admins group holding
+// the admin role with user alice, and a players group with user bob.
+func seed(t *testing.T, db *DB) (admins, players, alice, bob int) {
+	t.Helper()
+
+	adminsID, err := db.CreateGroup("admins")
+	if err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+	playersID, err := db.CreateGroup("players")
+	if err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+	role, err := db.GetRoleByName(auth.AdminRoleName)
+	if err != nil {
+		t.Fatalf("GetRoleByName: %v", err)
+	}
+	if err := db.AssignRoleToGroup(role.ID, int(adminsID)); err != nil {
+		t.Fatalf("AssignRoleToGroup: %v", err)
+	}
+
+	for _, u := range []struct {
+		name  string
+		group int64
+	}{{"alice", adminsID}, {"bob", playersID}} {
+		if err := db.CreateUser(u.name, "correct horse", []int{int(u.group)}); err != nil {
+			t.Fatalf("CreateUser(%s): %v", u.name, err)
+		}
+	}
+	a, err := db.GetUserByUsername("alice")
+	if err != nil {
+		t.Fatalf("GetUserByUsername: %v", err)
+	}
+	b, err := db.GetUserByUsername("bob")
+	if err != nil {
+		t.Fatalf("GetUserByUsername: %v", err)
+	}
+	return int(adminsID), int(playersID), a.ID, b.ID
+}
+
+func TestUsers(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, db *DB) {
+		_, players, _, bob := seed(t, db)
+
+		if _, err := db.ValidateUser("bob", "correct horse"); err != nil {
+			t.Errorf("ValidateUser with the right password: %v", err)
+		}
+		if _, err := db.ValidateUser("bob", "wrong"); err == nil {
+			t.Error("ValidateUser accepted a wrong password")
+		}
+		if _, err := db.ValidateUser("nobody", "correct horse"); err == nil {
+			t.Error("ValidateUser accepted an unknown user")
+		}
+
+		ext, err := db.CreateExternalUser("carol", AuthSourceLDAP, []int{players})
+		if err != nil {
+			t.Fatalf("CreateExternalUser: %v", err)
+		}
+		if ext.IsLocal() || !reflect.DeepEqual(ext.GroupIDs, []int{players}) {
+			t.Errorf("external user = %+v", ext)
+		}
+		if _, err := db.ValidateUser("carol", ""); err == nil {
+			t.Error("ValidateUser signed in a directory user with a local password")
+		}
+
+		if err := db.UpdateUser(bob, "robert", nil); err != nil {
+			t.Fatalf("UpdateUser: %v", err)
+		}
+		u, err := db.GetUserByID(bob)
+		if err != nil {
+			t.Fatalf("GetUserByID: %v", err)
+		}
+		if u.Username != "robert" || len(u.GroupIDs) != 0 {
+			t.Errorf("updated user = %+v", u)
+		}
+
+		if err := db.SetMustChangePassword(bob, true); err != nil {
+			t.Fatalf("SetMustChangePassword: %v", err)
+		}
+		if err := db.UpdateUserPassword(bob, "battery staple"); err != nil {
+			t.Fatalf("UpdateUserPassword: %v", err)
+		}
+		if u, _ := db.GetUserByID(bob); u.MustChangePassword {
+			t.Error("changing the password did not clear must_change_password")
+		}
+
+		if err := db.DeleteUser(bob); err != nil {
+			t.Fatalf("DeleteUser: %v", err)
+		}
+		if _, err := db.GetUserByID(bob); err == nil {
+			t.Error("deleted user still found")
+		}
+	})
+}
+
+func TestPasswordHistory(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, db *DB) {
+		_, _, _, bob := seed(t, db)
+		db.SetPasswordPolicy(&password.Policy{MinLength: 4, HistorySize: 2})
+
+		for _, p := range []string{"first one", "second one"} {
+			if err := db.UpdateUserPassword(bob, p); err != nil {
+				t.Fatalf("UpdateUserPassword(%q): %v", p, err)
+			}
+		}
+		if err := db.UpdateUserPassword(bob, "first one"); !errors.Is(err, password.ErrReused) {
+			t.Errorf("reusing a recent password: err = %v, want ErrReused", err)
+		}
+		if err := db.UpdateUserPassword(bob, "third one"); err != nil {
+			t.Errorf("UpdateUserPassword with a new password: %v", err)
+		}
+		if err := db.UpdateUserPassword(bob, "third one"); !errors.Is(err, password.ErrReused) {
+			t.Errorf("keeping the current password: err = %v, want ErrReused", err)
+		}
+		// Only the last two are remembered.
+		if err := db.UpdateUserPassword(bob, "first one"); err != nil {
+			t.Errorf("reusing a password older than the history: %v", err)
+		}
+
+		db.SetPasswordPolicy(&password.Policy{MinLength: 4})
+		if err := db.UpdateUserPassword(bob, "first one"); err != nil {
+			t.Errorf("reusing with no history kept: %v", err)
+		}
+	})
+}
+
+func TestAuditAndLoginHistory(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, db *DB) {
+		_, _, _, bob := seed(t, db)
+
+		if err := db.AddAuditEntry("bob", "file.create", "what?"); err != nil {
+			t.Fatalf("AddAuditEntry: %v", err)
+		}
+		entries, err := db.GetAuditEntries(10)
+		if err != nil {
+			t.Fatalf("GetAuditEntries: %v", err)
+		}
+		if len(entries) != 1 || entries[0].Detail != "what?" {
+			t.Errorf("audit entries = %+v", entries)
+		}
+
+		if err := db.AddLoginEvent(bob, "127.0.0.1", "test", false); err != nil {
+			t.Fatalf("AddLoginEvent: %v", err)
+		}
+		events, err := db.GetLoginHistory(bob, 10)
+		if err != nil {
+			t.Fatalf("GetLoginHistory: %v", err)
+		}
+		if len(events) != 1 || events[0].Success {
+			t.Errorf("login history = %+v", events)
+		}
+	})
+}
+
+func TestGroupNesting(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, db *DB) {
+		_, players, _, bob := seed(t, db)
+		everyone, err := db.CreateGroup("everyone")
+		if err != nil {
+			t.Fatalf("CreateGroup: %v", err)
+		}
+
+		if err := db.SetGroupChildren(int(everyone), []int{players}); err != nil {
+			t.Fatalf("SetGroupChildren: %v", err)
+		}
+		ids, err := db.GetEffectiveGroupIDs(bob)
+		if err != nil {
+			t.Fatalf("GetEffectiveGroupIDs: %v", err)
+		}
+		if !reflect.DeepEqual(ids, []int{players, int(everyone)}) {
+			t.Errorf("effective groups = %v, want [%d %d]", ids, players, everyone)
+		}
+		if ok, _ := db.UserHasAccessToGroup(bob, int(everyone)); !ok {
+			t.Error("member of a nested group has no access to the outer group")
+		}
+
+		if err := db.SetGroupChildren(players, []int{int(everyone)}); !errors.Is(err, ErrGroupCycle) {
+			t.Errorf("nesting a group in itself: err = %v, want ErrGroupCycle", err)
+		}
+		children, err := db.GetGroupChildren()
+		if err != nil {
+			t.Fatalf("GetGroupChildren: %v", err)
+		}
+		if !reflect.DeepEqual(children, map[int][]int{int(everyone): {players}}) {
+			t.Errorf("nesting after a refused cycle = %v", children)
+		}
+	})
+}
+
+func TestEditGroup(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, db *DB) {
+		_, players, _, bob := seed(t, db)
+		everyone, err := db.CreateGroup("everyone")
+		if err != nil {
+			t.Fatalf("CreateGroup: %v", err)
+		}
+		if err := db.SetGroupChildren(int(everyone), []int{players}); err != nil {
+			t.Fatalf("SetGroupChildren: %v", err)
+		}
+
+		// Nesting everyone in players is a cycle, so the rename and the
+		// new manager must not be kept either.
+		if err := db.EditGroup(players, "gamers", []int{int(everyone)}, []int{bob}); !errors.Is(err, ErrGroupCycle) {
+			t.Fatalf("EditGroup with a cycle: err = %v, want ErrGroupCycle", err)
+		}
+		if g, _ := db.GetGroupByID(players); g == nil || g.Name != "players" {
+			t.Errorf("refused edit renamed the group to %+v", g)
+		}
+		if managers, _ := db.GetGroupManagers(); len(managers[players]) != 0 {
+			t.Errorf("refused edit set managers %v", managers[players])
+		}
+
+		if err := db.EditGroup(players, "gamers", nil, []int{bob}); err != nil {
+			t.Fatalf("EditGroup: %v", err)
+		}
+		if g, _ := db.GetGroupByID(players); g == nil || g.Name != "gamers" {
+			t.Errorf("group after edit = %+v, want gamers", g)
+		}
+		if managers, _ := db.GetGroupManagers(); !reflect.DeepEqual(managers[players], []int{bob}) {
+			t.Errorf("managers after edit = %v, want [%d]", managers[players], bob)
+		}
+	})
+}
+
+func TestFileGrants(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, db *DB) {
+		admins, players, alice, bob := seed(t, db)
+
+		fileID, err := db.AddFile("world.wld", "/srv/world.wld", players, "a world")
+		if err != nil {
+			t.Fatalf("AddFile: %v", err)
+		}
+		if level, _ := db.GetFileAccessLevel(bob, fileID); level != GrantDownload {
+			t.Errorf("owner group member's level = %q, want %q", level, GrantDownload)
+		}
+		if level, _ := db.GetFileAccessLevel(alice, fileID); level != "" {
+			t.Errorf("outsider's level = %q, want none", level)
+		}
+
+		if err := db.GrantFileToUser(fileID, bob, GrantManage); err != nil {
+			t.Fatalf("GrantFileToUser: %v", err)
+		}
+		files, err := db.GetFilesForUser(bob)
+		if err != nil {
+			t.Fatalf("GetFilesForUser: %v", err)
+		}
+		if len(files) != 1 || files[0].Access != GrantManage {
+			t.Errorf("files for bob = %+v, want one file with manage access", files)
+		}
+		if err := db.GrantFileToUser(fileID, bob, "owner"); err == nil {
+			t.Error("GrantFileToUser accepted an unknown level")
+		}
+
+		// Moving the file to another group moves the owner's grant with it.
+		if err := db.UpdateFile(fileID, "world.wld", "/srv/world.wld", admins, "a world"); err != nil {
+			t.Fatalf("UpdateFile: %v", err)
+		}
+		grants, err := db.GetFileGrants(fileID)
+		if err != nil {
+			t.Fatalf("GetFileGrants: %v", err)
+		}
+		var groupGrants []int
+		for _, g := range grants {
+			if g.GroupID != 0 {
+				groupGrants = append(groupGrants, g.GroupID)
+			}
+		}
+		if !reflect.DeepEqual(groupGrants, []int{admins}) {
+			t.Errorf("group grants after moving the file = %v, want [%d]", groupGrants, admins)
+		}
+		if level, _ := db.GetFileAccessLevel(alice, fileID); level != GrantDownload {
+			t.Errorf("new owner's level = %q, want %q", level, GrantDownload)
+		}
+
+		for _, g := range grants {
+			if g.UserID == bob {
+				if err := db.RevokeFileGrant(fileID, g.ID); err != nil {
+					t.Fatalf("RevokeFileGrant: %v", err)
+				}
+			}
+		}
+		if level, _ := db.GetFileAccessLevel(bob, fileID); level != "" {
+			t.Errorf("level after revoking = %q, want none", level)
+		}
+
+		if err := db.DeleteFile(fileID); err != nil {
+			t.Fatalf("DeleteFile: %v", err)
+		}
+		if grants, _ := db.GetFileGrants(fileID); len(grants) != 0 {
+			t.Errorf("grants of a deleted file = %+v", grants)
+		}
+	})
+}
+
+func TestFileChecksums(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, db *DB) {
+		_, players, _, _ := seed(t, db)
+		fileID, err := db.AddFile("world.wld", "/srv/world.wld", players, "")
+		if err != nil {
+			t.Fatalf("AddFile: %v", err)
+		}
+
+		checked := time.Now().Truncate(time.Second)
+		if err := db.SetFileChecksum(fileID, "abc123", 42, IntegrityOK, checked); err != nil {
+			t.Fatalf("SetFileChecksum: %v", err)
+		}
+		// A check of the path the file used to have is not recorded.
+		if err := db.SetFileIntegrity(fileID, "/srv/other.wld", IntegrityMissing, checked); err != nil {
+			t.Fatalf("SetFileIntegrity: %v", err)
+		}
+		f, err := db.GetFileByID(fileID)
+		if err != nil {
+			t.Fatalf("GetFileByID: %v", err)
+		}
+		if f.SHA256 != "abc123" || f.Size != 42 || f.Integrity != IntegrityOK || !f.CheckedAt.Equal(checked) {
+			t.Errorf("file = %+v", f)
+		}
+
+		if err := db.SetFileIntegrity(fileID, "/srv/world.wld", IntegrityMismatch, checked); err != nil {
+			t.Fatalf("SetFileIntegrity: %v", err)
+		}
+		if f, _ := db.GetFileByID(fileID); f.Integrity != IntegrityMismatch {
+			t.Errorf("integrity = %q, want %q", f.Integrity, IntegrityMismatch)
+		}
+	})
+}
+
+func TestRoles(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, db *DB) {
+		admins, players, alice, bob := seed(t, db)
+
+		if ok, _ := db.UserHasPermission(alice, auth.PermManageUsers); !ok {
+			t.Error("member of admins lacks manage_users")
+		}
+		if ok, _ := db.UserHasPermission(bob, auth.PermManageFiles); ok {
+			t.Error("player has manage_files before any role")
+		}
+
+		if err := db.CreateRole("curators", []string{auth.PermManageFiles}, []int{players}, nil); err != nil {
+			t.Fatalf("CreateRole: %v", err)
+		}
+		if ok, _ := db.UserHasPermission(bob, auth.PermManageFiles); !ok {
+			t.Error("role granted to players did not reach bob")
+		}
+		if ok, _ := db.GroupGrantsRoles(players); !ok {
+			t.Error("GroupGrantsRoles(players) = false")
+		}
+
+		if err := db.DeleteUser(alice); !errors.Is(err, ErrLastUserManager) {
+			t.Errorf("deleting the last user manager: err = %v, want ErrLastUserManager", err)
+		}
+		if err := db.DeleteGroup(admins); !errors.Is(err, ErrLastUserManager) {
+			t.Errorf("deleting the last managers' group: err = %v, want ErrLastUserManager", err)
+		}
+		if _, err := db.GetUserByID(alice); err != nil {
+			t.Errorf("refused delete still removed the user: %v", err)
+		}
+	})
+}
+
+func TestRetiredPermissionsRemoved(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.db")
+	db, err := InitDB(path)
+	if err != nil {
+		t.Fatalf("InitDB: %v", err)
+	}
+	if _, err := db.Exec("INSERT INTO role_permissions (role_id, permission) SELECT id, 'upload' FROM roles WHERE name = ?", auth.AdminRoleName); err != nil {
+		t.Fatalf("grant upload: %v", err)
+	}
+	db.Close()
+
+	db, err = InitDB(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer db.Close()
+
+	var n int
+	if err := db.QueryRow("SELECT COUNT(*) FROM role_permissions WHERE permission = 'upload'").Scan(&n); err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if n != 0 {
+		t.Errorf("%d grants of the retired upload permission kept", n)
+	}
+}
+
+func TestGroupManagers(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, db *DB) {
+		_, players, alice, bob := seed(t, db)
+
+		if err := db.SetGroupManagers(players, []int{bob}); err != nil {
+			t.Fatalf("SetGroupManagers: %v", err)
+		}
+		if ok, _ := db.IsGroupManager(bob, players); !ok {
+			t.Error("IsGroupManager = false for a designated manager")
+		}
+		if err := db.AddGroupMember(players, alice); err != nil {
+			t.Fatalf("AddGroupMember: %v", err)
+		}
+		members, err := db.GetGroupMembers(players)
+		if err != nil {
+			t.Fatalf("GetGroupMembers: %v", err)
+		}
+		if len(members) != 2 {
+			t.Errorf("members = %+v, want alice and bob", members)
+		}
+		if err := db.RemoveGroupMember(players, alice); err != nil {
+			t.Fatalf("RemoveGroupMember: %v", err)
+		}
+		if n, _ := db.GetGroupMemberCount(players); n != 1 {
+			t.Errorf("member count = %d, want 1", n)
+		}
+	})
+}
+
+func TestShareLinks(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, db *DB) {
+		_, players, _, bob := seed(t, db)
+		fileID, err := db.AddFile("world.wld", "/srv/world.wld", players, "")
+		if err != nil {
+			t.Fatalf("AddFile: %v", err)
+		}
+
+		expires := time.Now().Add(time.Hour)
+		if err := db.CreateShareLink("secret-token", fileID, bob, expires, 1, "pw"); err != nil {
+			t.Fatalf("CreateShareLink: %v", err)
+		}
+		link, err := db.GetShareLinkByToken("secret-token")
+		if err != nil {
+			t.Fatalf("GetShareLinkByToken: %v", err)
+		}
+		if link.FileName != "world.wld" || !link.HasPassword() || !link.CheckPassword("pw") || link.CheckPassword("nope") {
+			t.Errorf("link = %+v", link)
+		}
+		if _, err := db.GetShareLinkByToken("other-token"); err == nil {
+			t.Error("an unknown token found a link")
+		}
+
+		if ok, _ := db.RecordShareDownload(link.ID); !ok {
+			t.Error("first download refused")
+		}
+		if ok, _ := db.RecordShareDownload(link.ID); ok {
+			t.Error("download past the limit allowed")
+		}
+
+		if ok, _ := db.DeleteShareLink(link.ID, bob+1); ok {
+			t.Error("another user deleted the link")
+		}
+		if ok, _ := db.DeleteShareLink(link.ID, bob); !ok {
+			t.Error("owner could not delete the link")
+		}
+	})
+}
+
+func TestInvitations(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, db *DB) {
+		_, players, alice, _ := seed(t, db)
+
+		if err := db.CreateInvitation("invite-token", alice, []int{players}, time.Now().Add(time.Hour), 1, "note"); err != nil {
+			t.Fatalf("CreateInvitation: %v", err)
+		}
+		inv, err := db.GetInvitationByToken("invite-token")
+		if err != nil {
+			t.Fatalf("GetInvitationByToken: %v", err)
+		}
+		if !reflect.DeepEqual(inv.GroupIDs, []int{players}) || inv.Note != "note" {
+			t.Errorf("invitation = %+v", inv)
+		}
+
+		if ok, _ := db.ClaimInvitation(inv.ID); !ok {
+			t.Error("first claim refused")
+		}
+		if ok, _ := db.ClaimInvitation(inv.ID); ok {
+			t.Error("claim past the limit allowed")
+		}
+		if err := db.ReleaseInvitation(inv.ID); err != nil {
+			t.Fatalf("ReleaseInvitation: %v", err)
+		}
+		if ok, _ := db.ClaimInvitation(inv.ID); !ok {
+			t.Error("released use could not be claimed again")
+		}
+	})
+}
+
+func TestIdentities(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, db *DB) {
+		_, _, alice, bob := seed(t, db)
+
+		if err := db.LinkIdentity(alice, "https://issuer", "sub-1"); err != nil {
+			t.Fatalf("LinkIdentity: %v", err)
+		}
+		if err := db.LinkIdentity(bob, "https://issuer", "sub-1"); !errors.Is(err, ErrIdentityLinked) {
+			t.Errorf("linking a taken identity: err = %v, want ErrIdentityLinked", err)
+		}
+		u, err := db.GetUserByIdentity("https://issuer", "sub-1")
+		if err != nil || u.ID != alice {
+			t.Errorf("GetUserByIdentity = %+v, %v", u, err)
+		}
+		if ok, _ := db.UnlinkIdentity(alice, "https://issuer", "sub-1"); !ok {
+			t.Error("UnlinkIdentity reported nothing removed")
+		}
+		if ids, _ := db.GetIdentitiesByUser(alice); len(ids) != 0 {
+			t.Errorf("identities after unlinking = %+v", ids)
+		}
+	})
+}
+
+func TestChestIndex(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, db *DB) {
+		_, players, _, _ := seed(t, db)
+		fileID, err := db.AddFile("world.wld", "/srv/world.wld", players, "")
+		if err != nil {
+			t.Fatalf("AddFile: %v", err)
+		}
+
+		state := ChestIndexState{FileID: fileID, FilePath: "/srv/world.wld", Size: 10, ModTime: time.Unix(0, 1700000000123456789), IndexedAt: time.Now()}
+		items := []ChestItem{
+			{X: 1, Y: 2, ChestName: "Loot", ItemID: 29, Stack: 3},
+			{X: 5, Y: 6, ItemID: 29, Stack: 7},
+			{X: 5, Y: 6, ItemID: 30, Stack: 1},
+		}
+		if err := db.ReplaceChestIndex(state, items); err != nil {
+			t.Fatalf("ReplaceChestIndex: %v", err)
+		}
+
+		states, err := db.GetChestIndexStates()
+		if err != nil {
+			t.Fatalf("GetChestIndexStates: %v", err)
+		}
+		if got := states[fileID]; !got.ModTime.Equal(state.ModTime) || got.Size != 10 {
+			t.Errorf("state = %+v, want the stored one to the nanosecond", got)
+		}
+
+		found, err := db.SearchChestItems([]int{29}, []int{fileID}, 10)
+		if err != nil {
+			t.Fatalf("SearchChestItems: %v", err)
+		}
+		if len(found) != 2 || found[0].Stack != 7 || found[0].FileName != "world.wld" {
+			t.Errorf("search = %+v, want the stack of 7 first", found)
+		}
+
+		if err := db.ClearChestIndex(fileID); err != nil {
+			t.Fatalf("ClearChestIndex: %v", err)
+		}
+		if found, _ := db.SearchChestItems([]int{29}, []int{fileID}, 10); len(found) != 0 {
+			t.Errorf("search after clearing = %+v", found)
+		}
+	})
+}
+
+func TestFileVersions(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, db *DB) {
+		_, players, _, _ := seed(t, db)
+
+		v := FileVersion{SourcePath: "/saves/w.wld", BlobPath: "/store/1/w.wld", Size: 5, SHA256: "aa", CreatedAt: time.Now()}
+		fileID, created, err := db.AddFileVersion(v, "w.wld", players)
+		if err != nil || !created {
+			t.Fatalf("AddFileVersion = %d, %v, %v; want a new record", fileID, created, err)
+		}
+
+		v.BlobPath, v.SHA256 = "/store/2/w.wld", "bb"
+		again, created, err := db.AddFileVersion(v, "w.wld", players)
+		if err != nil || created || again != fileID {
+			t.Errorf("second AddFileVersion = %d, %v, %v; want record %d reused", again, created, err, fileID)
+		}
+
+		f, err := db.GetFileByID(fileID)
+		if err != nil {
+			t.Fatalf("GetFileByID: %v", err)
+		}
+		if f.FilePath != "/store/2/w.wld" || f.SHA256 != "bb" || f.Integrity != IntegrityOK {
+			t.Errorf("file after the second snapshot = %+v", f)
+		}
+
+		latest, err := db.GetLatestFileVersion("/saves/w.wld")
+		if err != nil || latest == nil || latest.BlobPath != "/store/2/w.wld" {
+			t.Errorf("GetLatestFileVersion = %+v, %v", latest, err)
+		}
+		if latest, err := db.GetLatestFileVersion("/saves/none.wld"); latest != nil || err != nil {
+			t.Errorf("GetLatestFileVersion of an unknown path = %+v, %v", latest, err)
+		}
+
+		summaries, err := db.GetFileVersionSummaries()
+		if err != nil {
+			t.Fatalf("GetFileVersionSummaries: %v", err)
+		}
+		if summaries[fileID].Count != 2 {
+			t.Errorf("summary = %+v, want 2 snapshots", summaries[fileID])
+		}
+	})
+}
+
+func TestBackupJobs(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, db *DB) {
+		_, players, _, _ := seed(t, db)
+
+		job := BackupJob{Name: "nightly", Source: "/saves/*.wld", Schedule: "@daily", GroupID: players, Retries: 2, Window: 26 * time.Hour, Enabled: true}
+		if err := db.CreateBackupJob(job); err != nil {
+			t.Fatalf("CreateBackupJob: %v", err)
+		}
+		if err := db.CreateBackupJob(job); err == nil {
+			t.Error("a second job with the same name was created")
+		}
+		jobs, err := db.GetBackupJobs()
+		if err != nil || len(jobs) != 1 {
+			t.Fatalf("GetBackupJobs = %+v, %v", jobs, err)
+		}
+		job = jobs[0]
+		if job.Window != 26*time.Hour || job.LastRun != nil || !job.LastSuccess.IsZero() {
+			t.Errorf("new job = %+v", job)
+		}
+
+		start := time.Now().Add(-time.Minute).Truncate(time.Second)
+		runID, err := db.StartBackupJobRun(job.ID, 1, start)
+		if err != nil {
+			t.Fatalf("StartBackupJobRun: %v", err)
+		}
+		run := BackupJobRun{ID: runID, FinishedAt: start.Add(time.Second), Status: JobRunSucceeded, Files: 2, Bytes: 100}
+		if err := db.FinishBackupJobRun(run); err != nil {
+			t.Fatalf("FinishBackupJobRun: %v", err)
+		}
+		if _, err := db.StartBackupJobRun(job.ID, 1, start.Add(2*time.Second)); err != nil {
+			t.Fatalf("StartBackupJobRun: %v", err)
+		}
+		if err := db.AbandonBackupJobRuns(); err != nil {
+			t.Fatalf("AbandonBackupJobRuns: %v", err)
+		}
+
+		jobs, err = db.GetBackupJobs()
+		if err != nil {
+			t.Fatalf("GetBackupJobs: %v", err)
+		}
+		job = jobs[0]
+		if job.LastRun == nil || job.LastRun.Status != JobRunFailed {
+			t.Errorf("last run = %+v, want the abandoned one", job.LastRun)
+		}
+		if !job.LastSuccess.Equal(run.FinishedAt) {
+			t.Errorf("last success = %v, want %v", job.LastSuccess, run.FinishedAt)
+		}
+
+		runs, err := db.GetBackupJobRuns(10)
+		if err != nil || len(runs) != 2 || runs[1].JobName != "nightly" || runs[1].Bytes != 100 {
+			t.Errorf("GetBackupJobRuns = %+v, %v", runs, err)
+		}
+
+		if err := db.DeleteBackupJob(job.ID); err != nil {
+			t.Fatalf("DeleteBackupJob: %v", err)
+		}
+		if runs, _ := db.GetBackupJobRuns(10); len(runs) != 0 {
+			t.Errorf("runs of a deleted job = %+v", runs)
+		}
+	})
+}
@@ -0,0 +1,315 @@
+package database
+
+import (
+	"backup_server/internal/auth"
+	"database/sql"
+	"errors"
+)
+
+// ErrLastUserManager is returned when a change would leave nobody able to
+// manage users, which would lock every administrator out.
+var ErrLastUserManager = errors.New("at least one user must keep the manage_users permission")
+
+// Role is a named set of permissions granted to groups and individual users.
+type Role struct {
+	ID          int
+	Name        string
+	Permissions []string
+	GroupIDs    []int
+	UserIDs     []int
+}
+
+// seedRoles creates the admin role the first time roles exist. Databases
+// from before roles were introduced granted admin powers to the group named
+// "admins", so that group keeps them.
+func seedRoles(db *sql.DB, d dialect) error {
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM roles").Scan(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var roleID int
+	err = tx.QueryRow(d.rebind("INSERT INTO roles (name) VALUES (?) RETURNING id"), auth.AdminRoleName).Scan(&roleID)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range auth.AllPermissions {
+		if _, err := tx.Exec(d.rebind("INSERT INTO role_permissions (role_id, permission) VALUES (?, ?)"), roleID, p.Name); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(d.rebind("INSERT INTO group_roles (group_id, role_id) SELECT id, ? FROM groups WHERE name = ?"), roleID, "admins")
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func removeRetiredPermissions(db *sql.DB, d dialect) error {
+	for _, p := range auth.RetiredPermissions {
+		if _, err := db.Exec(d.rebind("DELETE FROM role_permissions WHERE permission = ?"), p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (db *DB) GetAllRoles() ([]Role, error) {
+	rows, err := db.Query("SELECT id, name FROM roles ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+
+	var roles []Role
+	for rows.Next() {
+		var role Role
+		if err := rows.Scan(&role.ID, &role.Name); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range roles {
+		if err := db.loadRoleMembers(&roles[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return roles, nil
+}
+
+func (db *DB) GetRoleByName(name string) (*Role, error) {
+	role := &Role{}
+	err := db.QueryRow("SELECT id, name FROM roles WHERE name = ?", name).Scan(&role.ID, &role.Name)
+	if err != nil {
+		return nil, err
+	}
+	return role, db.loadRoleMembers(role)
+}
+
+func (db *DB) loadRoleMembers(role *Role) error {
+	perms, err := db.queryStrings("SELECT permission FROM role_permissions WHERE role_id = ? ORDER BY permission", role.ID)
+	if err != nil {
+		return err
+	}
+	role.Permissions = perms
+
+	if role.GroupIDs, err = db.queryInts("SELECT group_id FROM group_roles WHERE role_id = ?", role.ID); err != nil {
+		return err
+	}
+	role.UserIDs, err = db.queryInts("SELECT user_id FROM user_roles WHERE role_id = ?", role.ID)
+	return err
+}
+
+func (db *DB) CreateRole(name string, permissions []string, groupIDs, userIDs []int) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var roleID int
+	if err := tx.QueryRow("INSERT INTO roles (name) VALUES (?) RETURNING id", name).Scan(&roleID); err != nil {
+		return err
+	}
+
+	if err := setRoleMembers(tx, roleID, permissions, groupIDs, userIDs); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (db *DB) UpdateRole(roleID int, name string, permissions []string, groupIDs, userIDs []int) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = guardUserManagers(tx, func() error {
+		if _, err := tx.Exec("UPDATE roles SET name = ? WHERE id = ?", name, roleID); err != nil {
+			return err
+		}
+
+		for _, table := range []string{"role_permissions", "group_roles", "user_roles"} {
+			if _, err := tx.Exec("DELETE FROM "+table+" WHERE role_id = ?", roleID); err != nil {
+				return err
+			}
+		}
+
+		return setRoleMembers(tx, roleID, permissions, groupIDs, userIDs)
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func setRoleMembers(tx *Tx, roleID int, permissions []string, groupIDs, userIDs []int) error {
+	for _, p := range permissions {
+		if _, err := tx.Exec("INSERT INTO role_permissions (role_id, permission) VALUES (?, ?)", roleID, p); err != nil {
+			return err
+		}
+	}
+	for _, groupID := range groupIDs {
+		if _, err := tx.Exec("INSERT INTO group_roles (group_id, role_id) VALUES (?, ?)", groupID, roleID); err != nil {
+			return err
+		}
+	}
+	for _, userID := range userIDs {
+		if _, err := tx.Exec("INSERT INTO user_roles (user_id, role_id) VALUES (?, ?)", userID, roleID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (db *DB) DeleteRole(roleID int) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = guardUserManagers(tx, func() error {
+		_, err := tx.Exec("DELETE FROM roles WHERE id = ?", roleID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (db *DB) AssignRoleToGroup(roleID, groupID int) error {
+	_, err := db.Exec("INSERT INTO group_roles (group_id, role_id) VALUES (?, ?) ON CONFLICT DO NOTHING", groupID, roleID)
+	return err
+}
+
+// permissionsQuery selects every permission a user holds, either directly or
+// through one of their groups, including groups that contain theirs.
+const permissionsQuery = memberGroupsCTE + `
+	SELECT rp.permission FROM role_permissions rp
+	JOIN user_roles ur ON ur.role_id = rp.role_id
+	WHERE ur.user_id = ?
+	UNION
+	SELECT rp.permission FROM role_permissions rp
+	JOIN group_roles gr ON gr.role_id = rp.role_id
+	JOIN member_groups mg ON mg.group_id = gr.group_id
+	WHERE mg.user_id = ?`
+
+func (db *DB) GetUserPermissions(userID int) (map[string]bool, error) {
+	perms, err := db.queryStrings(permissionsQuery, userID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]bool, len(perms))
+	for _, p := range perms {
+		result[p] = true
+	}
+	return result, nil
+}
+
+func (db *DB) UserHasPermission(userID int, permission string) (bool, error) {
+	perms, err := db.GetUserPermissions(userID)
+	if err != nil {
+		return false, err
+	}
+	return perms[permission], nil
+}
+
+// guardUserManagers runs change inside tx and fails with ErrLastUserManager
+// if it leaves nobody holding the manage_users permission when somebody held
+// it before.
+func guardUserManagers(tx *Tx, change func() error) error {
+	before, err := countUserManagers(tx)
+	if err != nil {
+		return err
+	}
+
+	if err := change(); err != nil {
+		return err
+	}
+
+	after, err := countUserManagers(tx)
+	if err != nil {
+		return err
+	}
+	if before > 0 && after == 0 {
+		return ErrLastUserManager
+	}
+	return nil
+}
+
+func countUserManagers(tx *Tx) (int, error) {
+	var count int
+	err := tx.QueryRow(memberGroupsCTE+`
+		SELECT COUNT(*) FROM users u WHERE EXISTS (
+			SELECT 1 FROM user_roles ur
+			JOIN role_permissions rp ON rp.role_id = ur.role_id
+			WHERE ur.user_id = u.id AND rp.permission = ?
+		) OR EXISTS (
+			SELECT 1 FROM member_groups mg
+			JOIN group_roles gr ON gr.group_id = mg.group_id
+			JOIN role_permissions rp ON rp.role_id = gr.role_id
+			WHERE mg.user_id = u.id AND rp.permission = ?
+		)`, auth.PermManageUsers, auth.PermManageUsers).Scan(&count)
+	return count, err
+}
+
+func (db *DB) queryStrings(query string, args ...interface{}) ([]string, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, rows.Err()
+}
+
+func (db *DB) queryInts(query string, args ...interface{}) ([]int, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []int
+	for rows.Next() {
+		var v int
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, rows.Err()
+}
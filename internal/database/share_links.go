@@ -0,0 +1,151 @@
+package database
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ShareLink lets someone without an account download one file. Only a hash
+// of the link's token is stored, so the URL cannot be recovered from the
+// database.
+type ShareLink struct {
+	ID        int
+	FileID    int
+	FileName  string
+	CreatedBy int
+	CreatedAt time.Time
+	// ExpiresAt is zero for links that never expire.
+	ExpiresAt time.Time
+	// MaxDownloads is zero for links without a download limit.
+	MaxDownloads int
+	Downloads    int
+	passwordHash string
+}
+
+func (l *ShareLink) HasPassword() bool {
+	return l.passwordHash != ""
+}
+
+func (l *ShareLink) CheckPassword(password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(l.passwordHash), []byte(password)) == nil
+}
+
+func (l *ShareLink) Expired() bool {
+	return !l.ExpiresAt.IsZero() && time.Now().After(l.ExpiresAt)
+}
+
+func (l *ShareLink) Exhausted() bool {
+	return l.MaxDownloads > 0 && l.Downloads >= l.MaxDownloads
+}
+
+// Active reports whether the link can still be used.
+func (l *ShareLink) Active() bool {
+	return !l.Expired() && !l.Exhausted()
+}
+
+func hashShareToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+const shareLinkColumns = `sl.id, sl.file_id, f.name, sl.created_by, sl.created_at, sl.expires_at,
+	sl.max_downloads, sl.downloads, sl.password_hash`
+
+func scanShareLink(row rowScanner, l *ShareLink) error {
+	var expires sql.NullTime
+	err := row.Scan(&l.ID, &l.FileID, &l.FileName, &l.CreatedBy, &l.CreatedAt, &expires,
+		&l.MaxDownloads, &l.Downloads, &l.passwordHash)
+	if err != nil {
+		return err
+	}
+	if expires.Valid {
+		l.ExpiresAt = expires.Time
+	}
+	return nil
+}
+
+// CreateShareLink stores a link for token. A zero expiresAt never expires,
+// a zero maxDownloads is unlimited and an empty password means none is
+// asked for.
+func (db *DB) CreateShareLink(token string, fileID, createdBy int, expiresAt time.Time, maxDownloads int, password string) error {
+	var passwordHash string
+	if password != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			return err
+		}
+		passwordHash = string(hash)
+	}
+
+	var expires sql.NullTime
+	if !expiresAt.IsZero() {
+		expires = sql.NullTime{Time: expiresAt.UTC(), Valid: true}
+	}
+
+	_, err := db.Exec(`INSERT INTO share_links
+		(token_hash, file_id, created_by, created_at, expires_at, max_downloads, password_hash)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		hashShareToken(token), fileID, createdBy, time.Now().UTC(), expires, maxDownloads, passwordHash)
+	return err
+}
+
+func (db *DB) GetShareLinkByToken(token string) (*ShareLink, error) {
+	link := &ShareLink{}
+	err := scanShareLink(db.QueryRow("SELECT "+shareLinkColumns+`
+		FROM share_links sl JOIN files f ON f.id = sl.file_id
+		WHERE sl.token_hash = ?`, hashShareToken(token)), link)
+	if err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// GetShareLinksByUser returns the links a user created, newest first.
+func (db *DB) GetShareLinksByUser(userID int) ([]ShareLink, error) {
+	rows, err := db.Query("SELECT "+shareLinkColumns+`
+		FROM share_links sl JOIN files f ON f.id = sl.file_id
+		WHERE sl.created_by = ?
+		ORDER BY sl.created_at DESC, sl.id DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []ShareLink
+	for rows.Next() {
+		var l ShareLink
+		if err := scanShareLink(rows, &l); err != nil {
+			return nil, err
+		}
+		links = append(links, l)
+	}
+
+	return links, rows.Err()
+}
+
+// DeleteShareLink revokes one of the user's links. It reports false if the
+// user has no such link.
+func (db *DB) DeleteShareLink(linkID, userID int) (bool, error) {
+	result, err := db.Exec("DELETE FROM share_links WHERE id = ? AND created_by = ?", linkID, userID)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+// RecordShareDownload counts a download against the link's limit. It reports
+// false, without counting, if the limit has already been reached.
+func (db *DB) RecordShareDownload(linkID int) (bool, error) {
+	result, err := db.Exec(`UPDATE share_links SET downloads = downloads + 1
+		WHERE id = ? AND (max_downloads = 0 OR downloads < max_downloads)`, linkID)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
@@ -0,0 +1,167 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+type sqliteDialect struct{}
+
+// sqliteDSN turns a plain database path into a go-sqlite3 DSN with foreign
+// key enforcement switched on, matching the PostgreSQL behaviour: deleting a
+// user, group or file removes the rows that refer to it, and rows cannot
+// refer to ones that do not exist. Databases from before enforcement are
+// cleaned up by removeOrphans when they are opened.
+func sqliteDSN(dsn string) string {
+	dsn = strings.TrimPrefix(dsn, "sqlite://")
+	if strings.Contains(dsn, "_foreign_keys") || strings.Contains(dsn, "_fk=") {
+		return dsn
+	}
+	if strings.Contains(dsn, "?") {
+		return dsn + "&_foreign_keys=on"
+	}
+	return dsn + "?_foreign_keys=on"
+}
+
+func (sqliteDialect) driverName() string {
+	return "sqlite3"
+}
+
+func (sqliteDialect) rebind(query string) string {
+	return query
+}
+
+func (sqliteDialect) translateSchema(schema string) string {
+	return schema
+}
+
+// snapshot writes a consistent copy of the live database to destPath using
+// VACUUM INTO, which is safe to run while the server is serving requests.
+func (sqliteDialect) snapshot(db *sql.DB, destPath string) error {
+	_, err := db.Exec("VACUUM INTO ?", destPath)
+	return err
+}
+
+func (sqliteDialect) hasColumn(db *sql.DB, table, column string) (bool, error) {
+	var count int
+	err := db.QueryRow("SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?", table, column).Scan(&count)
+	return count > 0, err
+}
+
+// removeOrphans deletes the rows that refer to a deleted row through a
+// foreign key declared ON DELETE CASCADE, as the delete would have with
+// enforcement on. Versions before enforcement left them behind. Other
+// dangling references, such as a file whose group is gone, are kept and
+// logged, as removing them would lose data.
+func (sqliteDialect) removeOrphans(db *sql.DB) error {
+	rows, err := db.Query("PRAGMA foreign_key_check")
+	if err != nil {
+		return err
+	}
+	type orphan struct {
+		table, parent string
+		rowid         sql.NullInt64
+		fkid          int
+	}
+	var orphans []orphan
+	for rows.Next() {
+		var o orphan
+		if err := rows.Scan(&o.table, &o.rowid, &o.parent, &o.fkid); err != nil {
+			rows.Close()
+			return err
+		}
+		orphans = append(orphans, o)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	removed := make(map[string]int)
+	for _, o := range orphans {
+		cascades, err := sqliteCascades(db, o.table, o.fkid)
+		if err != nil {
+			return err
+		}
+		if !cascades || !o.rowid.Valid {
+			log.Printf("Database: %s row %d refers to a missing %s row; leaving it", o.table, o.rowid.Int64, o.parent)
+			continue
+		}
+		if _, err := db.Exec("DELETE FROM "+o.table+" WHERE rowid = ?", o.rowid.Int64); err != nil {
+			return fmt.Errorf("remove orphaned %s row: %w", o.table, err)
+		}
+		removed[o.table]++
+	}
+	for table, n := range removed {
+		log.Printf("Database: removed %d %s rows that referred to deleted rows", n, table)
+	}
+	return nil
+}
+
+// sqliteCascades reports whether foreign key fkid of table is declared ON
+// DELETE CASCADE.
+func sqliteCascades(db *sql.DB, table string, fkid int) (bool, error) {
+	rows, err := db.Query("SELECT on_delete FROM pragma_foreign_key_list(?) WHERE id = ?", table, fkid)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	cascades := false
+	for rows.Next() {
+		var onDelete string
+		if err := rows.Scan(&onDelete); err != nil {
+			return false, err
+		}
+		cascades = onDelete == "CASCADE"
+	}
+	return cascades, rows.Err()
+}
+
+// SQLitePath returns the database file behind a SQLite DSN. It reports false
+// for PostgreSQL DSNs.
+func SQLitePath(dsn string) (string, bool) {
+	if d, _ := dialectFor(dsn); d.driverName() != "sqlite3" {
+		return "", false
+	}
+	path := strings.TrimPrefix(strings.TrimPrefix(dsn, "sqlite://"), "file:")
+	if i := strings.Index(path, "?"); i >= 0 {
+		path = path[:i]
+	}
+	return path, true
+}
+
+// ValidateSnapshot checks that the SQLite file at path is intact and carries
+// the tables the server needs before it is swapped in as the live database.
+func ValidateSnapshot(path string) error {
+	db, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	var result string
+	if err := db.QueryRow("PRAGMA integrity_check").Scan(&result); err != nil {
+		return fmt.Errorf("integrity check failed: %w", err)
+	}
+	if result != "ok" {
+		return fmt.Errorf("integrity check failed: %s", result)
+	}
+
+	for _, table := range requiredTables {
+		var count int
+		err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", table).Scan(&count)
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return fmt.Errorf("snapshot is missing table %q", table)
+		}
+	}
+
+	return nil
+}
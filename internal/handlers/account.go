@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"backup_server/internal/auth"
+	"log"
+	"net/http"
+	"net/url"
+)
+
+const loginHistorySize = 20
+
+// AccountPage lets any signed-in user manage their own account: change their
+// password, review their groups, link single sign-on identities, and see
+// and revoke their active sessions and recent logins.
+func (h *Handler) AccountPage(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	user, err := h.DB.GetUserByID(session.UserID)
+	if err != nil {
+		http.Error(w, "Failed to load account", http.StatusInternalServerError)
+		return
+	}
+
+	groups, err := h.DB.GetAllGroups()
+	if err != nil {
+		http.Error(w, "Failed to load groups", http.StatusInternalServerError)
+		return
+	}
+
+	groupNames := make(map[int]string)
+	for _, g := range groups {
+		groupNames[g.ID] = g.Name
+	}
+
+	history, err := h.DB.GetLoginHistory(session.UserID, loginHistorySize)
+	if err != nil {
+		http.Error(w, "Failed to load login history", http.StatusInternalServerError)
+		return
+	}
+
+	identities, err := h.DB.GetIdentitiesByUser(session.UserID)
+	if err != nil {
+		http.Error(w, "Failed to load identities", http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Username":      session.Username,
+		"GroupIDs":      user.GroupIDs,
+		"GroupNames":    groupNames,
+		"Sessions":      h.Sessions.ListByUser(session.UserID),
+		"CurrentHandle": session.Handle,
+		"LoginHistory":  history,
+		"PasswordRules": h.DB.PasswordPolicy().Describe(),
+		"Identities":    identities,
+	}
+	if h.OIDC != nil {
+		data["OIDCName"] = h.OIDC.Name()
+	}
+	if !user.IsLocal() {
+		data["DirectorySource"] = user.AuthSource
+	}
+
+	if msg := r.URL.Query().Get("success"); msg != "" {
+		data["Message"] = msg
+		data["Success"] = true
+	} else if msg := r.URL.Query().Get("error"); msg != "" {
+		data["Message"] = msg
+		data["Success"] = false
+	}
+
+	h.render(w, r, "account.html", data)
+}
+
+// ChangePasswordPage is shown instead of every other page while a session is
+// required to choose a new password.
+func (h *Handler) ChangePasswordPage(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	if !session.MustChangePassword {
+		http.Redirect(w, r, "/account", http.StatusSeeOther)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Username":      session.Username,
+		"PasswordRules": h.DB.PasswordPolicy().Describe(),
+	}
+
+	if msg := r.URL.Query().Get("error"); msg != "" {
+		data["Message"] = msg
+	}
+
+	h.render(w, r, "change_password.html", data)
+}
+
+func (h *Handler) AccountChangePassword(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	session := r.Context().Value("session").(*auth.Session)
+
+	// Errors go back to whichever page the form was submitted from.
+	back := "/account"
+	if session.MustChangePassword {
+		back = changePasswordPath
+	}
+
+	if user, err := h.DB.GetUserByID(session.UserID); err == nil && !user.IsLocal() {
+		http.Redirect(w, r, back+"?error=Your+password+is+managed+by+your+directory", http.StatusSeeOther)
+		return
+	}
+
+	currentPassword := r.FormValue("current_password")
+	newPassword := r.FormValue("new_password")
+	confirmPassword := r.FormValue("confirm_password")
+
+	if newPassword != confirmPassword {
+		http.Redirect(w, r, back+"?error=New+passwords+do+not+match", http.StatusSeeOther)
+		return
+	}
+
+	if newPassword == currentPassword {
+		http.Redirect(w, r, back+"?error=New+password+must+differ+from+the+current+one", http.StatusSeeOther)
+		return
+	}
+
+	// Guessing the current password from a hijacked session is throttled
+	// the same way as guessing it at the login form.
+	ip := clientIP(r)
+	if _, ok := h.Limiter.Allow(ip, session.Username); !ok {
+		http.Redirect(w, r, back+"?error=Too+many+failed+attempts.+Try+again+later", http.StatusSeeOther)
+		return
+	}
+
+	if _, err := h.DB.ValidateUser(session.Username, currentPassword); err != nil {
+		h.Limiter.RecordFailure(ip, session.Username)
+		http.Redirect(w, r, back+"?error=Current+password+is+incorrect", http.StatusSeeOther)
+		return
+	}
+
+	if err := h.DB.UpdateUserPassword(session.UserID, newPassword); err != nil {
+		log.Printf("Failed to update password: %v", err)
+		http.Redirect(w, r, back+"?error="+url.QueryEscape(errorMessage(err, "Failed to update password")), http.StatusSeeOther)
+		return
+	}
+
+	// Anyone holding another session may have learned the old password.
+	h.Sessions.DeleteOthers(session.UserID, session.Handle)
+	h.Sessions.SetMustChangePassword(session.UserID, false)
+	h.audit(session.Username, "account.password", "changed own password")
+
+	if session.MustChangePassword {
+		http.Redirect(w, r, "/files", http.StatusSeeOther)
+		return
+	}
+	http.Redirect(w, r, "/account?success=Password+updated+and+other+sessions+signed+out", http.StatusSeeOther)
+}
+
+func (h *Handler) AccountRevokeSession(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	session := r.Context().Value("session").(*auth.Session)
+
+	handle := r.FormValue("handle")
+	if handle == session.Handle {
+		http.Redirect(w, r, "/account?error=Use+Logout+to+end+the+current+session", http.StatusSeeOther)
+		return
+	}
+
+	if !h.Sessions.DeleteByHandle(session.UserID, handle) {
+		http.Redirect(w, r, "/account?error=Session+not+found", http.StatusSeeOther)
+		return
+	}
+
+	http.Redirect(w, r, "/account?success=Session+revoked", http.StatusSeeOther)
+}
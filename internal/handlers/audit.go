@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"backup_server/internal/auth"
+	"log"
+	"net/http"
+)
+
+const auditPageSize = 200
+
+// audit records an entry in the audit log. Failures are logged rather than
+// surfaced so that auditing never blocks the action being audited.
+func (h *Handler) audit(actor, action, detail string) {
+	if err := h.DB.AddAuditEntry(actor, action, detail); err != nil {
+		log.Printf("Failed to write audit entry %s for %s: %v", action, actor, err)
+	}
+}
+
+func (h *Handler) AdminAuditPage(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	entries, err := h.DB.GetAuditEntries(auditPageSize)
+	if err != nil {
+		http.Error(w, "Failed to load audit log", http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Username": session.Username,
+		"Entries":  entries,
+	}
+
+	h.render(w, r, "admin_audit.html", data)
+}
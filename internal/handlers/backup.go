@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"backup_server/internal/backup"
+	"backup_server/internal/database"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// AdminDownloadBackup streams a backup bundle of the server's own database,
+// optionally including the managed storage directory.
+func (h *Handler) AdminDownloadBackup(w http.ResponseWriter, r *http.Request) {
+	if _, ok := database.SQLitePath(h.Config.DatabaseDSN); !ok {
+		http.Redirect(w, r, "/admin/files?error=Online+backups+are+only+supported+for+SQLite+databases", http.StatusSeeOther)
+		return
+	}
+
+	blobDir := ""
+	if r.URL.Query().Get("blobs") != "" {
+		blobDir = h.Config.StorageDir
+	}
+
+	name := fmt.Sprintf("backup_server-%s.tar.gz", time.Now().Format("20060102-150405"))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	w.Header().Set("Content-Type", "application/gzip")
+
+	// Headers are already committed once streaming starts, so a failure part
+	// way through can only be logged; the client sees a truncated archive.
+	if err := backup.Write(w, h.DB, blobDir); err != nil {
+		log.Printf("Failed to write backup: %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"backup_server/internal/auth"
+	"backup_server/internal/terraria"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+)
+
+const (
+	// maxSearchItems bounds how many item IDs a query can match, so a
+	// one-letter search does not become a huge IN list.
+	maxSearchItems = 200
+	// maxSearchResults bounds the chests returned, largest stacks first.
+	maxSearchResults = 500
+)
+
+// chestHit is a chest holding a searched-for item.
+type chestHit struct {
+	FileID    int    `json:"file_id"`
+	World     string `json:"world"`
+	X         int    `json:"x"`
+	Y         int    `json:"y"`
+	Chest     string `json:"chest"`
+	ItemID    int    `json:"item_id"`
+	Item      string `json:"item"`
+	Stack     int    `json:"stack"`
+	ViewerURL string `json:"viewer_url"`
+}
+
+// unindexedWorld is a world the search could not look in.
+type unindexedWorld struct {
+	Name   string
+	Reason string
+}
+
+// searchChests finds the chests holding items whose name matches query in
+// the worlds the user can view. It also returns the worlds that were not
+// searched because they are not indexed yet or could not be read.
+func (h *Handler) searchChests(userID int, query string) ([]chestHit, []unindexedWorld, error) {
+	files, err := h.DB.GetFilesForUser(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	states, err := h.DB.GetChestIndexStates()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var fileIDs []int
+	var unindexed []unindexedWorld
+	for _, f := range files {
+		if !isWorldFile(f.Name) {
+			continue
+		}
+		fileIDs = append(fileIDs, f.ID)
+		state, ok := states[f.ID]
+		switch {
+		case !ok:
+			unindexed = append(unindexed, unindexedWorld{Name: f.Name, Reason: "not indexed yet"})
+		case state.Error != "":
+			unindexed = append(unindexed, unindexedWorld{Name: f.Name, Reason: state.Error})
+		}
+	}
+
+	var itemIDs []int
+	for _, id := range terraria.FindItems(query, maxSearchItems) {
+		itemIDs = append(itemIDs, int(id))
+	}
+
+	items, err := h.DB.SearchChestItems(itemIDs, fileIDs, maxSearchResults)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hits := make([]chestHit, 0, len(items))
+	for _, item := range items {
+		hits = append(hits, chestHit{
+			FileID:    item.FileID,
+			World:     item.FileName,
+			X:         item.X,
+			Y:         item.Y,
+			Chest:     item.ChestName,
+			ItemID:    item.ItemID,
+			Item:      terraria.ItemName(int32(item.ItemID)),
+			Stack:     item.Stack,
+			ViewerURL: fmt.Sprintf("/viewer/terramap?id=%d&x=%d&y=%d", item.FileID, item.X, item.Y),
+		})
+	}
+	return hits, unindexed, nil
+}
+
+// ChestSearchPage searches the chests of every world the user can view.
+func (h *Handler) ChestSearchPage(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+
+	data := map[string]interface{}{
+		"Username": session.Username,
+		"Query":    query,
+	}
+
+	lastRun, indexing := h.Chests.Status()
+	data["IndexedAt"] = lastRun
+	data["Indexing"] = indexing
+
+	if query != "" {
+		hits, unindexed, err := h.searchChests(session.UserID, query)
+		if err != nil {
+			log.Printf("Chest search for %q failed: %v", query, err)
+			h.renderError(w, r, http.StatusInternalServerError, "Chest Search", "Search failed")
+			return
+		}
+		data["Hits"] = hits
+		data["Unindexed"] = unindexed
+		data["Truncated"] = len(hits) == maxSearchResults
+	}
+
+	h.render(w, r, "search_chests.html", data)
+}
+
+// APIChestSearch is ChestSearchPage as JSON.
+func (h *Handler) APIChestSearch(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		writeJSONError(w, http.StatusBadRequest, "Missing search query")
+		return
+	}
+
+	hits, _, err := h.searchChests(session.UserID, query)
+	if err != nil {
+		log.Printf("Chest search for %q failed: %v", query, err)
+		writeJSONError(w, http.StatusInternalServerError, "Search failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, hits)
+}
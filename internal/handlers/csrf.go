@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"backup_server/internal/auth"
+	"crypto/subtle"
+	"log"
+	"net/http"
+)
+
+// CSRFMiddleware rejects state-changing requests that do not carry the
+// expected CSRF token in the csrf_token form field or X-CSRF-Token header.
+// Authenticated requests are checked against the token bound to the session;
+// requests made before login use a double-submit cookie instead.
+func (h *Handler) CSRFMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var expected string
+		if session, ok := r.Context().Value("session").(*auth.Session); ok {
+			expected = session.CSRFToken
+		} else if token, err := auth.GetCSRFCookie(r); err == nil {
+			expected = token
+		}
+
+		submitted := r.Header.Get("X-CSRF-Token")
+		if submitted == "" {
+			submitted = r.FormValue("csrf_token")
+		}
+
+		if expected == "" || subtle.ConstantTimeCompare([]byte(expected), []byte(submitted)) != 1 {
+			log.Printf("CSRF token mismatch for %s %s from %s", r.Method, r.URL.Path, clientIP(r))
+			h.renderError(w, r, http.StatusForbidden, "Request could not be verified",
+				"This form has expired or was submitted from another site. Go back, reload the page and try again.")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// loginCSRFToken returns the double-submit token for pre-login forms,
+// issuing a new cookie if the client does not have one yet.
+func (h *Handler) loginCSRFToken(w http.ResponseWriter, r *http.Request) string {
+	if token, err := auth.GetCSRFCookie(r); err == nil && token != "" {
+		return token
+	}
+
+	token, err := auth.NewToken()
+	if err != nil {
+		log.Printf("Failed to generate CSRF token: %v", err)
+		return ""
+	}
+	auth.SetCSRFCookie(w, token)
+	return token
+}
+
+// render executes a template for an authenticated page, adding the session's
+// CSRF token so forms can include it, the user's permissions as Perms, the
+// first admin page they may open as AdminHome and whether they manage any
+// group as ManagesGroups.
+func (h *Handler) render(w http.ResponseWriter, r *http.Request, name string, data map[string]interface{}) {
+	if session, ok := r.Context().Value("session").(*auth.Session); ok {
+		data["CSRFToken"] = session.CSRFToken
+
+		perms, err := h.DB.GetUserPermissions(session.UserID)
+		if err != nil {
+			log.Printf("Failed to load permissions for %s: %v", session.Username, err)
+			perms = map[string]bool{}
+		}
+		data["Perms"] = perms
+		data["AdminHome"] = adminHome(perms)
+
+		managed, err := h.DB.GetManagedGroups(session.UserID)
+		if err != nil {
+			log.Printf("Failed to load managed groups for %s: %v", session.Username, err)
+		}
+		data["ManagesGroups"] = len(managed) > 0
+	}
+
+	if err := h.Templates.ExecuteTemplate(w, name, data); err != nil {
+		log.Printf("Template error in %s: %v", name, err)
+	}
+}
+
+func (h *Handler) renderError(w http.ResponseWriter, r *http.Request, status int, title, message string) {
+	w.WriteHeader(status)
+	h.render(w, r, "error.html", map[string]interface{}{
+		"Title":   title,
+		"Message": message,
+	})
+}
+
+// adminHome returns the first admin page the permissions give access to, or
+// "" if none.
+func adminHome(perms map[string]bool) string {
+	switch {
+	case perms[auth.PermManageFiles]:
+		return "/admin/files"
+	case perms[auth.PermManageUsers]:
+		return "/admin/users"
+	case perms[auth.PermManageGroups]:
+		return "/admin/groups"
+	case perms[auth.PermViewAudit]:
+		return "/admin/audit"
+	}
+	return ""
+}
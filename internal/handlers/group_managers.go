@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"backup_server/internal/auth"
+	"backup_server/internal/database"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const managedGroupsPath = "/groups/manage"
+
+// managedGroup is one group on the group manager's page.
+type managedGroup struct {
+	Group   database.Group
+	Members []database.User
+	Files   []database.File
+	// GrantsRoles is set when joining the group gives a user a role, in
+	// which case only administrators may change its members.
+	GrantsRoles bool
+}
+
+// requireGroupManager checks that the signed-in user manages groupID,
+// writing an error response if not.
+func (h *Handler) requireGroupManager(w http.ResponseWriter, r *http.Request, groupID int) bool {
+	session := r.Context().Value("session").(*auth.Session)
+
+	manages, err := h.DB.IsGroupManager(session.UserID, groupID)
+	if err != nil {
+		http.Error(w, "Failed to check access", http.StatusInternalServerError)
+		return false
+	}
+	if !manages {
+		h.renderError(w, r, http.StatusForbidden, "Access denied",
+			"You do not manage this group.")
+		return false
+	}
+	return true
+}
+
+func (h *Handler) ManagedGroupsPage(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	groups, err := h.DB.GetManagedGroups(session.UserID)
+	if err != nil {
+		http.Error(w, "Failed to load groups", http.StatusInternalServerError)
+		return
+	}
+	if len(groups) == 0 {
+		h.renderError(w, r, http.StatusForbidden, "Access denied",
+			"You have not been made a manager of any group.")
+		return
+	}
+
+	files, err := h.DB.GetAllFiles()
+	if err != nil {
+		http.Error(w, "Failed to load files", http.StatusInternalServerError)
+		return
+	}
+
+	users, err := h.DB.GetAllUsers()
+	if err != nil {
+		http.Error(w, "Failed to load users", http.StatusInternalServerError)
+		return
+	}
+
+	var managed []managedGroup
+	for _, g := range groups {
+		members, err := h.DB.GetGroupMembers(g.ID)
+		if err != nil {
+			http.Error(w, "Failed to load members", http.StatusInternalServerError)
+			return
+		}
+
+		grantsRoles, err := h.DB.GroupGrantsRoles(g.ID)
+		if err != nil {
+			http.Error(w, "Failed to load groups", http.StatusInternalServerError)
+			return
+		}
+
+		mg := managedGroup{Group: g, Members: members, GrantsRoles: grantsRoles}
+		for _, f := range files {
+			if f.GroupID == g.ID {
+				mg.Files = append(mg.Files, f)
+			}
+		}
+		managed = append(managed, mg)
+	}
+
+	data := map[string]interface{}{
+		"Username": session.Username,
+		"Groups":   managed,
+		"Users":    users,
+	}
+
+	if msg := r.URL.Query().Get("success"); msg != "" {
+		data["Message"] = msg
+		data["Success"] = true
+	} else if msg := r.URL.Query().Get("error"); msg != "" {
+		data["Message"] = msg
+		data["Success"] = false
+	}
+
+	h.render(w, r, "managed_groups.html", data)
+}
+
+// changeMembership applies a group manager's add or remove. Groups that
+// carry a role are refused so that managers cannot make themselves or
+// anyone else an administrator.
+func (h *Handler) changeMembership(w http.ResponseWriter, r *http.Request, add bool) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	groupID, _ := strconv.Atoi(r.FormValue("group_id"))
+	userID, _ := strconv.Atoi(r.FormValue("user_id"))
+
+	if !h.requireGroupManager(w, r, groupID) {
+		return
+	}
+
+	group, err := h.DB.GetGroupByID(groupID)
+	if err != nil {
+		http.Redirect(w, r, managedGroupsPath+"?error=Group+not+found", http.StatusSeeOther)
+		return
+	}
+
+	user, err := h.DB.GetUserByID(userID)
+	if err != nil {
+		http.Redirect(w, r, managedGroupsPath+"?error=User+not+found", http.StatusSeeOther)
+		return
+	}
+
+	grantsRoles, err := h.DB.GroupGrantsRoles(groupID)
+	if err != nil {
+		http.Error(w, "Failed to check group", http.StatusInternalServerError)
+		return
+	}
+	if grantsRoles {
+		err = database.ErrGroupGrantsRoles
+	} else if add {
+		err = h.DB.AddGroupMember(groupID, userID)
+	} else {
+		err = h.DB.RemoveGroupMember(groupID, userID)
+	}
+
+	action, detail := "group.member.add", "added %s to group %s as group manager"
+	if !add {
+		action, detail = "group.member.remove", "removed %s from group %s as group manager"
+	}
+
+	if err != nil {
+		if !errors.Is(err, database.ErrGroupGrantsRoles) {
+			log.Printf("Failed to change membership of group %d: %v", groupID, err)
+		}
+		http.Redirect(w, r, managedGroupsPath+"?error="+url.QueryEscape(errorMessage(err, "Failed to change members")), http.StatusSeeOther)
+		return
+	}
+
+	h.audit(session.Username, action, fmt.Sprintf(detail, user.Username, group.Name))
+	h.refreshSessions(user.ID)
+
+	http.Redirect(w, r, managedGroupsPath+"?success=Members+updated", http.StatusSeeOther)
+}
+
+func (h *Handler) ManagedGroupAddMember(w http.ResponseWriter, r *http.Request) {
+	h.changeMembership(w, r, true)
+}
+
+func (h *Handler) ManagedGroupRemoveMember(w http.ResponseWriter, r *http.Request) {
+	h.changeMembership(w, r, false)
+}
+
+// ManagedGroupEditFile lets a group manager rename or describe a file their
+// group owns. The path and owner stay admin-only, since choosing a path
+// would expose any file on the server.
+func (h *Handler) ManagedGroupEditFile(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	fileID, _ := strconv.Atoi(r.FormValue("id"))
+	file, err := h.DB.GetFileByID(fileID)
+	if err != nil {
+		http.Redirect(w, r, managedGroupsPath+"?error=File+not+found", http.StatusSeeOther)
+		return
+	}
+
+	if !h.requireGroupManager(w, r, file.GroupID) {
+		return
+	}
+
+	name := r.FormValue("name")
+	description := r.FormValue("description")
+	if name == "" {
+		http.Redirect(w, r, managedGroupsPath+"?error=File+name+is+required", http.StatusSeeOther)
+		return
+	}
+
+	if err := h.DB.UpdateFileDetails(file.ID, name, description); err != nil {
+		log.Printf("Failed to update file %d: %v", file.ID, err)
+		http.Redirect(w, r, managedGroupsPath+"?error=Failed+to+update+file", http.StatusSeeOther)
+		return
+	}
+
+	h.audit(session.Username, "file.update", fmt.Sprintf("renamed file %s to %s as group manager", file.Name, name))
+	h.Chests.Refresh()
+
+	http.Redirect(w, r, managedGroupsPath+"?success=File+updated", http.StatusSeeOther)
+}
@@ -2,40 +2,107 @@ package handlers
 
 import (
 	"backup_server/internal/auth"
+	"backup_server/internal/chestindex"
+	"backup_server/internal/config"
 	"backup_server/internal/database"
+	"backup_server/internal/ingest"
+	"backup_server/internal/integrity"
+	"backup_server/internal/jobs"
+	"backup_server/internal/login"
+	"backup_server/internal/password"
+	"backup_server/internal/terraria"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"html/template"
 	"io"
 	"log"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 )
 
 type Handler struct {
-	DB       *database.DB
-	Sessions *auth.SessionStore
+	DB        database.Repository
+	Sessions  *auth.SessionStore
+	Config    *config.Config
+	Limiter   *auth.LoginLimiter
 	Templates *template.Template
+	// Providers check sign-in credentials, in order.
+	Providers []login.Provider
+	// OIDC is nil unless OpenID Connect sign-in is configured.
+	OIDC *login.OIDC
+	// WorldHeaders caches the parsed headers of .wld files.
+	WorldHeaders *terraria.HeaderCache
+	// ModData caches what tModLoader sidecars say about mods.
+	ModData *terraria.ModDataCache
+	// Maps renders and caches world map images.
+	Maps *terraria.MapCache
+	// Chests indexes the contents of world chests for search.
+	Chests *chestindex.Indexer
+	// Watcher snapshots saves from the watched directories. It is nil
+	// unless directories are configured.
+	Watcher *ingest.Watcher
+	// Jobs runs the scheduled backup jobs.
+	Jobs *jobs.Scheduler
+	// Scrub checks stored files against their checksums.
+	Scrub *integrity.Scrubber
 }
 
-func NewHandler(db *database.DB, sessions *auth.SessionStore) *Handler {
+// NewHandler creates the handler and its background workers, which main
+// starts with their Run methods.
+func NewHandler(db database.Repository, sessions *auth.SessionStore, cfg *config.Config) *Handler {
 	funcMap := template.FuncMap{
 		"hasSuffix": func(s, suffix string) bool {
 			return len(s) >= len(suffix) && s[len(s)-len(suffix):] == suffix
 		},
 	}
 	tmpl := template.Must(template.New("").Funcs(funcMap).ParseGlob("templates/*.html"))
-	return &Handler{
-		DB:       db,
-		Sessions: sessions,
-		Templates: tmpl,
+
+	var oidcProvider *login.OIDC
+	if cfg.OIDCIssuer != "" {
+		oidcProvider = login.NewOIDC(db, cfg)
 	}
+	h := &Handler{
+		DB:        db,
+		Sessions:  sessions,
+		Config:    cfg,
+		Limiter:   auth.NewLoginLimiter(cfg.LoginMaxUserFailures, cfg.LoginMaxIPFailures, cfg.LoginLockout),
+		Templates: tmpl,
+		Providers: login.Providers(db, cfg),
+		OIDC:      oidcProvider,
+
+		WorldHeaders: terraria.NewHeaderCache(),
+		ModData:      terraria.NewModDataCache(),
+		Maps:         terraria.NewMapCache(cfg.MapCacheDir, int64(cfg.MapCacheMB)<<20),
+		Chests:       chestindex.New(db, cfg.ChestIndexInterval),
+		Scrub:        integrity.New(db, cfg.ScrubInterval),
+	}
+	store := ingest.NewStore(db, cfg.StorageDir)
+	h.Watcher = ingest.New(db, store, cfg, h.Chests.Refresh)
+	h.Jobs = jobs.New(db, store, cfg, h.Chests.Refresh)
+	return h
 }
 
 func (h *Handler) LoginPage(w http.ResponseWriter, r *http.Request) {
-	h.Templates.ExecuteTemplate(w, "login.html", nil)
+	h.renderLogin(w, r, "")
+}
+
+func (h *Handler) renderLogin(w http.ResponseWriter, r *http.Request, errMsg string) {
+	data := map[string]string{
+		"Error":     errMsg,
+		"CSRFToken": h.loginCSRFToken(w, r),
+	}
+	if h.OIDC != nil {
+		data["OIDCName"] = h.OIDC.Name()
+	}
+	h.Templates.ExecuteTemplate(w, "login.html", data)
 }
 
 func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
@@ -46,23 +113,112 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 
 	username := r.FormValue("username")
 	password := r.FormValue("password")
+	ip := clientIP(r)
 
-	user, err := h.DB.ValidateUser(username, password)
+	// Throttling is keyed on the submitted username whether or not it
+	// exists, and the messages below are identical for both cases.
+	if wait, ok := h.Limiter.Allow(ip, username); !ok {
+		msg := fmt.Sprintf("Too many failed attempts. Try again in %s.", wait.Round(time.Second))
+		h.renderLogin(w, r, msg)
+		return
+	}
+
+	user, err := login.Authenticate(h.Providers, username, password)
 	if err != nil {
-		h.Templates.ExecuteTemplate(w, "login.html", map[string]string{"Error": "Invalid credentials"})
+		userLocked, ipLocked := h.Limiter.RecordFailure(ip, username)
+		if userLocked {
+			h.audit(username, "login.lockout", fmt.Sprintf("username locked out after repeated failures from %s", ip))
+		}
+		if ipLocked {
+			h.audit(username, "login.lockout", fmt.Sprintf("address %s locked out after repeated failures", ip))
+		}
+		if known, err := h.DB.GetUserByUsername(username); err == nil {
+			h.recordLogin(known.ID, r, false)
+		}
+		h.renderLogin(w, r, "Invalid credentials")
 		return
 	}
+	h.Limiter.RecordSuccess(username)
+	h.recordLogin(user.ID, r, true)
 
-	sessionID, err := h.Sessions.Create(user.ID, user.Username, user.GroupIDs)
+	next, err := h.startSession(w, r, user)
 	if err != nil {
 		http.Error(w, "Failed to create session", http.StatusInternalServerError)
 		return
 	}
 
+	http.Redirect(w, r, next, http.StatusSeeOther)
+}
+
+// startSession signs user in on this browser and returns the page to send
+// them to next.
+func (h *Handler) startSession(w http.ResponseWriter, r *http.Request, user *database.User) (string, error) {
+	// The session carries every group the user is in, including groups
+	// that contain one of theirs.
+	groupIDs, err := h.DB.GetEffectiveGroupIDs(user.ID)
+	if err != nil {
+		log.Printf("Failed to resolve groups for %s: %v", user.Username, err)
+		return "", err
+	}
+
+	sessionID, err := h.Sessions.Create(user.ID, user.Username, groupIDs, clientIP(r), r.UserAgent())
+	if err != nil {
+		return "", err
+	}
+
+	// Providers such as LDAP update the user's groups as they sign in, so
+	// the user's other sessions pick up the change as after an admin edit.
+	h.refreshSessions(user.ID)
+
 	auth.SetSessionCookie(w, sessionID)
-	http.Redirect(w, r, "/files", http.StatusSeeOther)
+
+	// Directory users change their password in the directory.
+	if user.IsLocal() && (user.MustChangePassword || user.PasswordExpired(h.Config.PasswordMaxAgeDays)) {
+		// Only the new session: the user's other sessions were signed in
+		// before, and stay as they are.
+		h.Sessions.SetSessionMustChangePassword(sessionID, true)
+		return changePasswordPath, nil
+	}
+
+	return "/files", nil
 }
 
+func (h *Handler) recordLogin(userID int, r *http.Request, success bool) {
+	if err := h.DB.AddLoginEvent(userID, clientIP(r), r.UserAgent(), success); err != nil {
+		log.Printf("Failed to record login for user %d: %v", userID, err)
+	}
+}
+
+// errorMessage returns text for err that is safe to show the user. Password
+// policy violations and changes refused to protect the group or role setup
+// are explained; anything else gets the generic fallback.
+func errorMessage(err error, fallback string) string {
+	var policyErr *password.PolicyError
+	if errors.As(err, &policyErr) {
+		return policyErr.Error()
+	}
+	if errors.Is(err, database.ErrLastUserManager) {
+		return "Not allowed: at least one user must keep the manage_users permission"
+	}
+	if errors.Is(err, database.ErrGroupCycle) || errors.Is(err, database.ErrGroupGrantsRoles) ||
+		errors.Is(err, database.ErrIdentityLinked) {
+		return "Not allowed: " + err.Error()
+	}
+	return fallback
+}
+
+// clientIP returns the address of the directly connected client. Forwarding
+// headers are ignored because they are trivially spoofed.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// Logout ends the session. It only answers POST requests behind the CSRF
+// check, so another site cannot sign users out.
 func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
 	sessionID, err := auth.GetSessionFromRequest(r)
 	if err == nil {
@@ -75,19 +231,23 @@ func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) FilesPage(w http.ResponseWriter, r *http.Request) {
 	session := r.Context().Value("session").(*auth.Session)
 
-	files, err := h.DB.GetFilesByGroupIDs(session.GroupIDs)
+	files, err := h.DB.GetFilesForUser(session.UserID)
 	if err != nil {
 		http.Error(w, "Failed to load files", http.StatusInternalServerError)
 		return
 	}
 
+	worlds, worldProblems := h.worldHeaders(files)
+
 	data := map[string]interface{}{
-		"Username": session.Username,
-		"Files":    files,
-		"IsAdmin":  h.isAdmin(session),
+		"Username":      session.Username,
+		"Files":         files,
+		"Worlds":        worlds,
+		"WorldProblems": worldProblems,
+		"Sidecars":      h.sidecars(files),
 	}
 
-	h.Templates.ExecuteTemplate(w, "files.html", data)
+	h.render(w, r, "files.html", data)
 }
 
 func (h *Handler) DownloadFile(w http.ResponseWriter, r *http.Request) {
@@ -106,17 +266,31 @@ func (h *Handler) DownloadFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	hasAccess, err := h.DB.UserHasAccessToGroup(session.UserID, file.GroupID)
+	level, err := h.DB.GetFileAccessLevel(session.UserID, file.ID)
 	if err != nil {
 		http.Error(w, "Failed to check access", http.StatusInternalServerError)
 		return
 	}
 
-	if !hasAccess {
+	if !database.GrantAllows(level, database.GrantDownload) {
 		http.Error(w, "Access denied", http.StatusForbidden)
 		return
 	}
 
+	sendAttachment(w, file)
+}
+
+// sendAttachment streams a file to the client as a download named after the
+// file's display name. A save with a tModLoader sidecar is sent as a zip of
+// both.
+func sendAttachment(w http.ResponseWriter, file *database.File) {
+	if sidecar, _ := file.Sidecar(); sidecar != "" {
+		if _, err := os.Stat(sidecar); err == nil {
+			sendBundle(w, file)
+			return
+		}
+	}
+
 	f, err := os.Open(file.FilePath)
 	if err != nil {
 		log.Printf("Failed to open file %s: %v", file.FilePath, err)
@@ -134,10 +308,35 @@ func (h *Handler) DownloadFile(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename*=UTF-8''%s", url.PathEscape(filepath.Base(file.Name))))
 	w.Header().Set("Content-Type", "application/octet-stream")
 	w.Header().Set("Content-Length", strconv.FormatInt(stat.Size(), 10))
+	if digest := verifiedDigest(file, stat); digest != "" {
+		w.Header().Set("Digest", "sha-256="+digest)
+		w.Header().Set("Repr-Digest", "sha-256=:"+digest+":")
+	}
 
 	io.Copy(w, f)
 }
 
+// verifiedDigest returns the recorded checksum of the file for digest
+// headers, but only while it still describes the file: the last check
+// matched, and the file has kept its size and not been modified since.
+// Otherwise the headers would promise content the download does not have.
+func verifiedDigest(file *database.File, stat os.FileInfo) string {
+	if file.Integrity != database.IntegrityOK || stat.Size() != file.Size || stat.ModTime().After(file.CheckedAt) {
+		return ""
+	}
+	return contentDigest(file.SHA256)
+}
+
+// contentDigest converts a hex SHA-256 to the base64 form digest headers
+// use, or returns an empty string for a missing or malformed one.
+func contentDigest(sum string) string {
+	raw, err := hex.DecodeString(sum)
+	if err != nil || len(raw) != sha256.Size {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
 // ServeWorldFile serves .wld files for TerraMap with proper authentication
 func (h *Handler) ServeWorldFile(w http.ResponseWriter, r *http.Request) {
 	session := r.Context().Value("session").(*auth.Session)
@@ -155,9 +354,14 @@ func (h *Handler) ServeWorldFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check user has access to this file's group
-	hasAccess, err := h.DB.UserHasAccessToGroup(session.UserID, file.GroupID)
-	if err != nil || !hasAccess {
+	// The map viewer needs the world data, so view access is enough for
+	// worlds. Anything else would be a download by another name.
+	need := database.GrantDownload
+	if isWorldFile(file.Name) {
+		need = database.GrantView
+	}
+	level, err := h.DB.GetFileAccessLevel(session.UserID, file.ID)
+	if err != nil || !database.GrantAllows(level, need) {
 		http.Error(w, "Access denied", http.StatusForbidden)
 		return
 	}
@@ -202,9 +406,14 @@ func (h *Handler) TerraMapViewer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Check user has access to this file's group
-	hasAccess, err := h.DB.UserHasAccessToGroup(session.UserID, file.GroupID)
-	if err != nil || !hasAccess {
+	// The map viewer needs the world data, so view access is enough for
+	// worlds. Anything else would be a download by another name.
+	need := database.GrantDownload
+	if isWorldFile(file.Name) {
+		need = database.GrantView
+	}
+	level, err := h.DB.GetFileAccessLevel(session.UserID, file.ID)
+	if err != nil || !database.GrantAllows(level, need) {
 		http.Error(w, "Access denied", http.StatusForbidden)
 		return
 	}
@@ -215,6 +424,14 @@ func (h *Handler) TerraMapViewer(w http.ResponseWriter, r *http.Request) {
 		"FileName": file.Name,
 	}
 
+	// x and y select a tile once the world has loaded, such as a chest
+	// found by search.
+	x, errX := strconv.Atoi(r.URL.Query().Get("x"))
+	y, errY := strconv.Atoi(r.URL.Query().Get("y"))
+	if errX == nil && errY == nil && x >= 0 && y >= 0 {
+		data["Focus"] = map[string]int{"X": x, "Y": y}
+	}
+
 	err = h.Templates.ExecuteTemplate(w, "terramap.html", data)
 	if err != nil {
 		log.Printf("Template error: %v", err)
@@ -225,24 +442,6 @@ func (h *Handler) TerraMapViewer(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) AdminPage(w http.ResponseWriter, r *http.Request) {
 	session := r.Context().Value("session").(*auth.Session)
 
-	hasAdminAccess := false
-	for _, groupID := range session.GroupIDs {
-		if access, _ := h.DB.UserHasAccessToGroup(session.UserID, groupID); access {
-			groups, _ := h.DB.GetAllGroups()
-			for _, g := range groups {
-				if g.ID == groupID && g.Name == "admins" {
-					hasAdminAccess = true
-					break
-				}
-			}
-		}
-	}
-
-	if !hasAdminAccess {
-		http.Error(w, "Access denied - Admin privileges required", http.StatusForbidden)
-		return
-	}
-
 	files, err := h.DB.GetAllFiles()
 	if err != nil {
 		http.Error(w, "Failed to load files", http.StatusInternalServerError)
@@ -260,11 +459,23 @@ func (h *Handler) AdminPage(w http.ResponseWriter, r *http.Request) {
 		groupNames[g.ID] = g.Name
 	}
 
+	versions, err := h.DB.GetFileVersionSummaries()
+	if err != nil {
+		http.Error(w, "Failed to load snapshots", http.StatusInternalServerError)
+		return
+	}
+
 	data := map[string]interface{}{
-		"Username":   session.Username,
-		"Files":      files,
-		"Groups":     groups,
-		"GroupNames": groupNames,
+		"Username":    session.Username,
+		"Files":       files,
+		"Groups":      groups,
+		"GroupNames":  groupNames,
+		"Versions":    versions,
+		"OverdueJobs": h.overdueJobs(),
+		"Scrub":       h.scrubStatus(files),
+	}
+	if h.Watcher != nil {
+		data["Watcher"] = h.Watcher.Status()
 	}
 
 	if msg := r.URL.Query().Get("success"); msg != "" {
@@ -275,7 +486,7 @@ func (h *Handler) AdminPage(w http.ResponseWriter, r *http.Request) {
 		data["Success"] = false
 	}
 
-	h.Templates.ExecuteTemplate(w, "admin.html", data)
+	h.render(w, r, "admin.html", data)
 }
 
 func (h *Handler) AdminAddFile(w http.ResponseWriter, r *http.Request) {
@@ -284,25 +495,23 @@ func (h *Handler) AdminAddFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	session := r.Context().Value("session").(*auth.Session)
-	hasAdminAccess := h.isAdmin(session)
-	if !hasAdminAccess {
-		http.Error(w, "Access denied", http.StatusForbidden)
-		return
-	}
-
 	name := r.FormValue("name")
 	filePath := r.FormValue("file_path")
 	groupID, _ := strconv.Atoi(r.FormValue("group_id"))
 	description := r.FormValue("description")
 
-	err := h.DB.AddFile(name, filePath, groupID, description)
+	fileID, err := h.DB.AddFile(name, filePath, groupID, description)
 	if err != nil {
 		log.Printf("Failed to add file: %v", err)
 		http.Redirect(w, r, "/admin/files?error=Failed+to+add+file", http.StatusSeeOther)
 		return
 	}
 
+	if err := integrity.Record(h.DB, fileID, filePath); err != nil {
+		log.Printf("Failed to record checksum of file %d: %v", fileID, err)
+	}
+
+	h.Chests.Refresh()
 	http.Redirect(w, r, "/admin/files?success=File+added+successfully", http.StatusSeeOther)
 }
 
@@ -312,26 +521,33 @@ func (h *Handler) AdminEditFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	session := r.Context().Value("session").(*auth.Session)
-	hasAdminAccess := h.isAdmin(session)
-	if !hasAdminAccess {
-		http.Error(w, "Access denied", http.StatusForbidden)
-		return
-	}
-
 	fileID, _ := strconv.Atoi(r.FormValue("id"))
 	name := r.FormValue("name")
 	filePath := r.FormValue("file_path")
 	groupID, _ := strconv.Atoi(r.FormValue("group_id"))
 	description := r.FormValue("description")
 
-	err := h.DB.UpdateFile(fileID, name, filePath, groupID, description)
+	old, err := h.DB.GetFileByID(fileID)
+	if err != nil {
+		http.Redirect(w, r, "/admin/files?error=File+not+found", http.StatusSeeOther)
+		return
+	}
+
+	err = h.DB.UpdateFile(fileID, name, filePath, groupID, description)
 	if err != nil {
 		log.Printf("Failed to update file: %v", err)
 		http.Redirect(w, r, "/admin/files?error=Failed+to+update+file", http.StatusSeeOther)
 		return
 	}
 
+	// A new path is a new file to verify against.
+	if filePath != old.FilePath {
+		if err := integrity.Record(h.DB, fileID, filePath); err != nil {
+			log.Printf("Failed to record checksum of file %d: %v", fileID, err)
+		}
+	}
+
+	h.Chests.Refresh()
 	http.Redirect(w, r, "/admin/files?success=File+updated+successfully", http.StatusSeeOther)
 }
 
@@ -341,13 +557,6 @@ func (h *Handler) AdminDeleteFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	session := r.Context().Value("session").(*auth.Session)
-	hasAdminAccess := h.isAdmin(session)
-	if !hasAdminAccess {
-		http.Error(w, "Access denied", http.StatusForbidden)
-		return
-	}
-
 	fileID, _ := strconv.Atoi(r.FormValue("id"))
 
 	err := h.DB.DeleteFile(fileID)
@@ -360,30 +569,9 @@ func (h *Handler) AdminDeleteFile(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/admin/files?success=File+deleted+successfully", http.StatusSeeOther)
 }
 
-func (h *Handler) isAdmin(session *auth.Session) bool {
-	groups, err := h.DB.GetAllGroups()
-	if err != nil {
-		return false
-	}
-
-	for _, groupID := range session.GroupIDs {
-		for _, g := range groups {
-			if g.ID == groupID && g.Name == "admins" {
-				return true
-			}
-		}
-	}
-	return false
-}
-
 func (h *Handler) AdminUsersPage(w http.ResponseWriter, r *http.Request) {
 	session := r.Context().Value("session").(*auth.Session)
 
-	if !h.isAdmin(session) {
-		http.Error(w, "Access denied - Admin privileges required", http.StatusForbidden)
-		return
-	}
-
 	users, err := h.DB.GetAllUsers()
 	if err != nil {
 		http.Error(w, "Failed to load users", http.StatusInternalServerError)
@@ -401,11 +589,23 @@ func (h *Handler) AdminUsersPage(w http.ResponseWriter, r *http.Request) {
 		groupNames[g.ID] = g.Name
 	}
 
+	lockouts := h.Limiter.Lockouts()
+	lockedUsers := make(map[string]string)
+	for _, l := range lockouts {
+		if l.Kind == auth.LockoutUser {
+			lockedUsers[l.Key] = l.LockedUntil.Format("15:04:05")
+		}
+	}
+
 	data := map[string]interface{}{
-		"Username":   session.Username,
-		"Users":      users,
-		"Groups":     groups,
-		"GroupNames": groupNames,
+		"Username":      session.Username,
+		"Users":         users,
+		"Groups":        groups,
+		"GroupNames":    groupNames,
+		"Lockouts":      lockouts,
+		"LockedUsers":   lockedUsers,
+		"PasswordRules": h.DB.PasswordPolicy().Describe(),
+		"SessionCounts": h.Sessions.CountByUser(),
 	}
 
 	if msg := r.URL.Query().Get("success"); msg != "" {
@@ -416,7 +616,7 @@ func (h *Handler) AdminUsersPage(w http.ResponseWriter, r *http.Request) {
 		data["Success"] = false
 	}
 
-	h.Templates.ExecuteTemplate(w, "admin_users.html", data)
+	h.render(w, r, "admin_users.html", data)
 }
 
 func (h *Handler) AdminAddUser(w http.ResponseWriter, r *http.Request) {
@@ -425,12 +625,6 @@ func (h *Handler) AdminAddUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	session := r.Context().Value("session").(*auth.Session)
-	if !h.isAdmin(session) {
-		http.Error(w, "Access denied", http.StatusForbidden)
-		return
-	}
-
 	username := r.FormValue("username")
 	password := r.FormValue("password")
 	
@@ -450,10 +644,22 @@ func (h *Handler) AdminAddUser(w http.ResponseWriter, r *http.Request) {
 	err := h.DB.CreateUser(username, password, groupIDs)
 	if err != nil {
 		log.Printf("Failed to add user: %v", err)
-		http.Redirect(w, r, "/admin/users?error=Failed+to+add+user", http.StatusSeeOther)
+		http.Redirect(w, r, "/admin/users?error="+url.QueryEscape(errorMessage(err, "Failed to add user")), http.StatusSeeOther)
 		return
 	}
 
+	maxAgeDays, _ := strconv.Atoi(r.FormValue("password_max_age_days"))
+	if user, err := h.DB.GetUserByUsername(username); err == nil {
+		if r.FormValue("require_password_change") != "" {
+			if err := h.DB.SetMustChangePassword(user.ID, true); err != nil {
+				log.Printf("Failed to flag password change: %v", err)
+			}
+		}
+		if err := h.DB.SetPasswordMaxAge(user.ID, maxAgeDays); err != nil {
+			log.Printf("Failed to set password max age: %v", err)
+		}
+	}
+
 	http.Redirect(w, r, "/admin/users?success=User+added+successfully", http.StatusSeeOther)
 }
 
@@ -463,12 +669,6 @@ func (h *Handler) AdminEditUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	session := r.Context().Value("session").(*auth.Session)
-	if !h.isAdmin(session) {
-		http.Error(w, "Access denied", http.StatusForbidden)
-		return
-	}
-
 	userID, _ := strconv.Atoi(r.FormValue("id"))
 	username := r.FormValue("username")
 	
@@ -488,10 +688,19 @@ func (h *Handler) AdminEditUser(w http.ResponseWriter, r *http.Request) {
 	err := h.DB.UpdateUser(userID, username, groupIDs)
 	if err != nil {
 		log.Printf("Failed to update user: %v", err)
-		http.Redirect(w, r, "/admin/users?error=Failed+to+update+user", http.StatusSeeOther)
+		http.Redirect(w, r, "/admin/users?error="+url.QueryEscape(errorMessage(err, "Failed to update user")), http.StatusSeeOther)
 		return
 	}
 
+	maxAgeDays, _ := strconv.Atoi(r.FormValue("password_max_age_days"))
+	if err := h.DB.SetPasswordMaxAge(userID, maxAgeDays); err != nil {
+		log.Printf("Failed to set password max age: %v", err)
+		http.Redirect(w, r, "/admin/users?error=Failed+to+update+password+max+age", http.StatusSeeOther)
+		return
+	}
+
+	h.refreshSessions(userID)
+
 	http.Redirect(w, r, "/admin/users?success=User+updated+successfully", http.StatusSeeOther)
 }
 
@@ -501,22 +710,30 @@ func (h *Handler) AdminChangeUserPassword(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	session := r.Context().Value("session").(*auth.Session)
-	if !h.isAdmin(session) {
-		http.Error(w, "Access denied", http.StatusForbidden)
-		return
-	}
-
 	userID, _ := strconv.Atoi(r.FormValue("id"))
 	password := r.FormValue("password")
 
+	if user, err := h.DB.GetUserByID(userID); err == nil && !user.IsLocal() {
+		http.Redirect(w, r, "/admin/users?error="+url.QueryEscape("The password of "+user.Username+" is managed by the "+user.AuthSource+" directory"), http.StatusSeeOther)
+		return
+	}
+
 	err := h.DB.UpdateUserPassword(userID, password)
 	if err != nil {
 		log.Printf("Failed to update password: %v", err)
-		http.Redirect(w, r, "/admin/users?error=Failed+to+update+password", http.StatusSeeOther)
+		http.Redirect(w, r, "/admin/users?error="+url.QueryEscape(errorMessage(err, "Failed to update password")), http.StatusSeeOther)
 		return
 	}
 
+	// Passwords chosen by an admin are temporary unless the admin says
+	// otherwise.
+	if r.FormValue("require_password_change") != "" {
+		if err := h.DB.SetMustChangePassword(userID, true); err != nil {
+			log.Printf("Failed to flag password change: %v", err)
+		}
+		h.Sessions.SetMustChangePassword(userID, true)
+	}
+
 	http.Redirect(w, r, "/admin/users?success=Password+updated+successfully", http.StatusSeeOther)
 }
 
@@ -527,10 +744,6 @@ func (h *Handler) AdminDeleteUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	session := r.Context().Value("session").(*auth.Session)
-	if !h.isAdmin(session) {
-		http.Error(w, "Access denied", http.StatusForbidden)
-		return
-	}
 
 	userID, _ := strconv.Atoi(r.FormValue("id"))
 
@@ -542,27 +755,69 @@ func (h *Handler) AdminDeleteUser(w http.ResponseWriter, r *http.Request) {
 	err := h.DB.DeleteUser(userID)
 	if err != nil {
 		log.Printf("Failed to delete user: %v", err)
-		http.Redirect(w, r, "/admin/users?error=Failed+to+delete+user", http.StatusSeeOther)
+		http.Redirect(w, r, "/admin/users?error="+url.QueryEscape(errorMessage(err, "Failed to delete user")), http.StatusSeeOther)
 		return
 	}
 
+	h.Sessions.DeleteByUser(userID)
+
 	http.Redirect(w, r, "/admin/users?success=User+deleted+successfully", http.StatusSeeOther)
 }
 
+func (h *Handler) AdminClearLockout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	session := r.Context().Value("session").(*auth.Session)
+
+	kind := r.FormValue("kind")
+	key := r.FormValue("key")
+
+	h.Limiter.Clear(kind, key)
+	h.audit(session.Username, "login.unlock", fmt.Sprintf("cleared %s lockout for %s", kind, key))
+
+	http.Redirect(w, r, "/admin/users?success=Lockout+cleared", http.StatusSeeOther)
+}
+
 func (h *Handler) AdminGroupsPage(w http.ResponseWriter, r *http.Request) {
 	session := r.Context().Value("session").(*auth.Session)
 
-	if !h.isAdmin(session) {
-		http.Error(w, "Access denied - Admin privileges required", http.StatusForbidden)
+	groups, err := h.DB.GetAllGroups()
+	if err != nil {
+		http.Error(w, "Failed to load groups", http.StatusInternalServerError)
 		return
 	}
 
-	groups, err := h.DB.GetAllGroups()
+	children, err := h.DB.GetGroupChildren()
 	if err != nil {
-		http.Error(w, "Failed to load groups", http.StatusInternalServerError)
+		http.Error(w, "Failed to load group hierarchy", http.StatusInternalServerError)
+		return
+	}
+
+	managers, err := h.DB.GetGroupManagers()
+	if err != nil {
+		http.Error(w, "Failed to load group managers", http.StatusInternalServerError)
 		return
 	}
 
+	users, err := h.DB.GetAllUsers()
+	if err != nil {
+		http.Error(w, "Failed to load users", http.StatusInternalServerError)
+		return
+	}
+
+	userNames := make(map[int]string)
+	for _, u := range users {
+		userNames[u.ID] = u.Username
+	}
+
+	groupNames := make(map[int]string)
+	for _, g := range groups {
+		groupNames[g.ID] = g.Name
+	}
+
 	memberCounts := make(map[int]int)
 	fileCounts := make(map[int]int)
 
@@ -578,6 +833,12 @@ func (h *Handler) AdminGroupsPage(w http.ResponseWriter, r *http.Request) {
 		"Groups":       groups,
 		"MemberCounts": memberCounts,
 		"FileCounts":   fileCounts,
+		"GroupNames":   groupNames,
+		"Children":     children,
+		"Hierarchy":    groupHierarchy(groups, children),
+		"Managers":     managers,
+		"Users":        users,
+		"UserNames":    userNames,
 	}
 
 	if msg := r.URL.Query().Get("success"); msg != "" {
@@ -588,7 +849,54 @@ func (h *Handler) AdminGroupsPage(w http.ResponseWriter, r *http.Request) {
 		data["Success"] = false
 	}
 
-	h.Templates.ExecuteTemplate(w, "admin_groups.html", data)
+	h.render(w, r, "admin_groups.html", data)
+}
+
+// groupTreeRow is one line of the group hierarchy: a group indented by its
+// nesting depth.
+type groupTreeRow struct {
+	Group database.Group
+	Depth int
+}
+
+// groupHierarchy flattens the nesting into rows in display order, starting
+// from groups no other group contains. A group nested in several groups
+// appears under each of them.
+func groupHierarchy(groups []database.Group, children map[int][]int) []groupTreeRow {
+	byID := make(map[int]database.Group)
+	contained := make(map[int]bool)
+	for _, g := range groups {
+		byID[g.ID] = g
+	}
+	for _, childIDs := range children {
+		for _, id := range childIDs {
+			contained[id] = true
+		}
+	}
+
+	var rows []groupTreeRow
+	onPath := make(map[int]bool)
+	var walk func(id, depth int)
+	walk = func(id, depth int) {
+		// Cycles are refused when nesting is saved; this only keeps a
+		// corrupt database from looping forever.
+		if onPath[id] {
+			return
+		}
+		onPath[id] = true
+		rows = append(rows, groupTreeRow{Group: byID[id], Depth: depth})
+		for _, childID := range children[id] {
+			walk(childID, depth+1)
+		}
+		onPath[id] = false
+	}
+
+	for _, g := range groups {
+		if !contained[g.ID] {
+			walk(g.ID, 0)
+		}
+	}
+	return rows
 }
 
 func (h *Handler) AdminAddGroup(w http.ResponseWriter, r *http.Request) {
@@ -597,12 +905,6 @@ func (h *Handler) AdminAddGroup(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	session := r.Context().Value("session").(*auth.Session)
-	if !h.isAdmin(session) {
-		http.Error(w, "Access denied", http.StatusForbidden)
-		return
-	}
-
 	name := r.FormValue("name")
 
 	_, err := h.DB.CreateGroup(name)
@@ -621,22 +923,30 @@ func (h *Handler) AdminEditGroup(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	session := r.Context().Value("session").(*auth.Session)
-	if !h.isAdmin(session) {
-		http.Error(w, "Access denied", http.StatusForbidden)
-		return
-	}
-
 	groupID, _ := strconv.Atoi(r.FormValue("id"))
 	name := r.FormValue("name")
 
-	err := h.DB.UpdateGroup(groupID, name)
-	if err != nil {
+	r.ParseForm()
+	var childIDs, managerIDs []int
+	for _, idStr := range r.Form["child_ids"] {
+		if id, err := strconv.Atoi(idStr); err == nil {
+			childIDs = append(childIDs, id)
+		}
+	}
+	for _, idStr := range r.Form["manager_ids"] {
+		if id, err := strconv.Atoi(idStr); err == nil {
+			managerIDs = append(managerIDs, id)
+		}
+	}
+
+	if err := h.DB.EditGroup(groupID, name, childIDs, managerIDs); err != nil {
 		log.Printf("Failed to update group: %v", err)
-		http.Redirect(w, r, "/admin/groups?error=Failed+to+update+group", http.StatusSeeOther)
+		http.Redirect(w, r, "/admin/groups?error="+url.QueryEscape(errorMessage(err, "Failed to update group")), http.StatusSeeOther)
 		return
 	}
 
+	h.refreshAllSessions()
+
 	http.Redirect(w, r, "/admin/groups?success=Group+updated+successfully", http.StatusSeeOther)
 }
 
@@ -646,12 +956,6 @@ func (h *Handler) AdminDeleteGroup(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	session := r.Context().Value("session").(*auth.Session)
-	if !h.isAdmin(session) {
-		http.Error(w, "Access denied", http.StatusForbidden)
-		return
-	}
-
 	groupID, _ := strconv.Atoi(r.FormValue("id"))
 
 	fileCount, _ := h.DB.GetGroupFileCount(groupID)
@@ -663,9 +967,11 @@ func (h *Handler) AdminDeleteGroup(w http.ResponseWriter, r *http.Request) {
 	err := h.DB.DeleteGroup(groupID)
 	if err != nil {
 		log.Printf("Failed to delete group: %v", err)
-		http.Redirect(w, r, "/admin/groups?error=Failed+to+delete+group", http.StatusSeeOther)
+		http.Redirect(w, r, "/admin/groups?error="+url.QueryEscape(errorMessage(err, "Failed to delete group")), http.StatusSeeOther)
 		return
 	}
 
+	h.refreshAllSessions()
+
 	http.Redirect(w, r, "/admin/groups?success=Group+deleted+successfully", http.StatusSeeOther)
 }
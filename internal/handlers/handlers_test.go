@@ -0,0 +1,327 @@
+package handlers
+
+import (
+	"backup_server/internal/auth"
+	"backup_server/internal/chestindex"
+	"backup_server/internal/config"
+	"backup_server/internal/database"
+	"context"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestVerifiedDigest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "world.wld")
+	if err := os.WriteFile(path, []byte("contents"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stat, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The SHA-256 of "contents".
+	const sum = "d1b2a59fbea7e20077af9f91b27e95e865061b270be03ff539ab3b73587882e8"
+	verified := database.File{SHA256: sum, Size: stat.Size(), Integrity: database.IntegrityOK, CheckedAt: stat.ModTime().Add(time.Second)}
+	if got, want := verifiedDigest(&verified, stat), "0bKln76n4gB3r5+Rsn6V6GUGGycL4D/1Oas7c1h4gug="; got != want {
+		t.Errorf("digest of a verified file = %q, want %q", got, want)
+	}
+
+	tests := []struct {
+		name   string
+		change func(f *database.File)
+	}{
+		{"mismatch", func(f *database.File) { f.Integrity = database.IntegrityMismatch }},
+		{"unchecked", func(f *database.File) { f.Integrity = "" }},
+		{"other size", func(f *database.File) { f.Size++ }},
+		{"modified since the check", func(f *database.File) { f.CheckedAt = stat.ModTime().Add(-time.Second) }},
+		{"no checksum", func(f *database.File) { f.SHA256 = "" }},
+	}
+	for _, tt := range tests {
+		f := verified
+		tt.change(&f)
+		if got := verifiedDigest(&f, stat); got != "" {
+			t.Errorf("%s: digest %q, want none", tt.name, got)
+		}
+	}
+}
+
+func TestServeWorldFileNeedsDownloadForOtherFiles(t *testing.T) {
+	db, err := database.InitDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	h := &Handler{DB: db, Sessions: auth.NewSessionStore(), Config: &config.Config{}}
+
+	groupID, err := db.CreateGroup("owners")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.CreateUser("viewer", "correct horse battery", nil); err != nil {
+		t.Fatal(err)
+	}
+	viewer, err := db.GetUserByUsername("viewer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	session := &auth.Session{UserID: viewer.ID, Username: viewer.Username}
+
+	dir := t.TempDir()
+	serve := func(name, level string) int {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("contents"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		fileID, err := db.AddFile(name, path, int(groupID), "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := db.GrantFileToUser(fileID, viewer.ID, level); err != nil {
+			t.Fatal(err)
+		}
+		r := httptest.NewRequest("GET", "/worldfile?id="+strconv.Itoa(fileID), nil)
+		r = r.WithContext(context.WithValue(r.Context(), "session", session))
+		rec := httptest.NewRecorder()
+		h.ServeWorldFile(rec, r)
+		return rec.Code
+	}
+
+	tests := []struct {
+		name, level string
+		want        int
+	}{
+		{"world.wld", database.GrantView, http.StatusOK},
+		{"player.plr", database.GrantView, http.StatusForbidden},
+		{"notes.txt", database.GrantView, http.StatusForbidden},
+		{"other.plr", database.GrantDownload, http.StatusOK},
+	}
+	for _, tt := range tests {
+		if got := serve(tt.name, tt.level); got != tt.want {
+			t.Errorf("%s with %s access: status %d, want %d", tt.name, tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestPublicShareCountsOnlyDownloads(t *testing.T) {
+	db, err := database.InitDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	h := &Handler{
+		DB:        db,
+		Config:    &config.Config{},
+		Templates: template.Must(template.ParseFiles("../../templates/share_password.html")),
+	}
+
+	groupID, err := db.CreateGroup("owners")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.CreateUser("owner", "correct horse battery", []int{int(groupID)}); err != nil {
+		t.Fatal(err)
+	}
+	owner, err := db.GetUserByUsername("owner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "world.wld")
+	if err := os.WriteFile(path, []byte("contents"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fileID, err := db.AddFile("world.wld", path, int(groupID), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.GrantFileToUser(fileID, owner.ID, database.GrantDownload); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.CreateShareLink("token", fileID, owner.ID, time.Now().Add(time.Hour), 2, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	router := chi.NewRouter()
+	router.Get("/s/{token}", h.PublicShare)
+	router.Post("/s/{token}", h.PublicShareUnlock)
+	fetch := func(method, target string) string {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s %s: status %d", method, target, rec.Code)
+		}
+		return rec.Body.String()
+	}
+	downloads := func() int {
+		link, err := db.GetShareLinkByToken("token")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return link.Downloads
+	}
+
+	// A previewer opening the link gets the form, not the file.
+	for i := 0; i < 3; i++ {
+		if body := fetch("GET", "/s/token"); body == "contents" {
+			t.Fatal("opening the link sent the file")
+		}
+	}
+	if n := downloads(); n != 0 {
+		t.Fatalf("%d downloads counted for opening the link", n)
+	}
+
+	if body := fetch("POST", "/s/token"); body != "contents" {
+		t.Errorf("submitting the form sent %q", body)
+	}
+	if body := fetch("GET", "/s/token?dl=1"); body != "contents" {
+		t.Errorf("?dl=1 sent %q", body)
+	}
+	if n := downloads(); n != 2 {
+		t.Errorf("%d downloads counted, want 2", n)
+	}
+}
+
+func TestStartSessionRefreshesOtherSessions(t *testing.T) {
+	db, err := database.InitDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	h := &Handler{DB: db, Sessions: auth.NewSessionStore(), Config: &config.Config{}}
+
+	before, err := db.CreateGroup("before")
+	if err != nil {
+		t.Fatal(err)
+	}
+	after, err := db.CreateGroup("after")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.CreateUser("ada", "correct horse battery", []int{int(before)}); err != nil {
+		t.Fatal(err)
+	}
+	user, err := db.GetUserByUsername("ada")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signIn := func() string {
+		rec := httptest.NewRecorder()
+		if _, err := h.startSession(rec, httptest.NewRequest("POST", "/login", nil), user); err != nil {
+			t.Fatal(err)
+		}
+		return rec.Result().Cookies()[0].Value
+	}
+	first := signIn()
+
+	// A provider moved the user to another group as they signed in.
+	if err := db.UpdateUser(user.ID, user.Username, []int{int(after)}); err != nil {
+		t.Fatal(err)
+	}
+	second := signIn()
+
+	for _, id := range []string{first, second} {
+		session, ok := h.Sessions.Get(id)
+		if !ok {
+			t.Fatal("session missing")
+		}
+		if !reflect.DeepEqual(session.GroupIDs, []int{int(after)}) {
+			t.Errorf("session groups = %v, want [%d]", session.GroupIDs, after)
+		}
+	}
+}
+
+func TestSearchChestsOnlyInViewableWorlds(t *testing.T) {
+	db, err := database.InitDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	h := &Handler{DB: db, Chests: chestindex.New(db, time.Hour)}
+
+	// A 32x16 world written by the terraria package's test builder, with a
+	// chest named Loot at 4,6 holding 40 torches and 99 dirt blocks, and an
+	// unnamed one at 20,9 holding 5 gold coins.
+	world, err := os.ReadFile("testdata/chests.wld")
+	if err != nil {
+		t.Fatal(err)
+	}
+	groupID, err := db.CreateGroup("owners")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.CreateUser("viewer", "correct horse battery", nil); err != nil {
+		t.Fatal(err)
+	}
+	viewer, err := db.GetUserByUsername("viewer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	addWorld := func(name string) int {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, world, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		fileID, err := db.AddFile(name, path, int(groupID), "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return fileID
+	}
+	shared := addWorld("shared.wld")
+	addWorld("private.wld")
+	if err := db.GrantFileToUser(shared, viewer.ID, database.GrantView); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		h.Chests.Run(ctx)
+		close(done)
+	}()
+	for deadline := time.Now().Add(10 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		if lastRun, _ := h.Chests.Status(); !lastRun.IsZero() {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("chest index pass did not finish")
+		}
+	}
+	cancel()
+	<-done
+
+	hits, unindexed, err := h.searchChests(viewer.ID, "torch")
+	if err != nil {
+		t.Fatalf("searchChests: %v", err)
+	}
+	want := []chestHit{{
+		FileID:    shared,
+		World:     "shared.wld",
+		X:         4,
+		Y:         6,
+		Chest:     "Loot",
+		ItemID:    8,
+		Item:      "Torch",
+		Stack:     40,
+		ViewerURL: fmt.Sprintf("/viewer/terramap?id=%d&x=4&y=6", shared),
+	}}
+	if !reflect.DeepEqual(hits, want) || len(unindexed) != 0 {
+		t.Errorf("search for torch = %+v, unindexed %+v; want %+v", hits, unindexed, want)
+	}
+
+	if hits, _, err := h.searchChests(viewer.ID, "gold coin"); err != nil || len(hits) != 1 || hits[0].FileID != shared || hits[0].Stack != 5 {
+		t.Errorf("search for gold coin = %+v, %v", hits, err)
+	}
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"backup_server/internal/auth"
+	"backup_server/internal/database"
+	"backup_server/internal/integrity"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// scrubStatus is what the files admin page shows about integrity checks.
+type scrubStatus struct {
+	LastRun   time.Time
+	Scrubbing bool
+	Interval  time.Duration
+	// Counts has the number of files with each integrity status; files
+	// not checked yet count under the empty status.
+	Counts map[string]int
+	// Problems are the files that are missing, unreadable or no longer
+	// match their checksum.
+	Problems []database.File
+}
+
+func (h *Handler) scrubStatus(files []database.File) scrubStatus {
+	s := scrubStatus{Interval: h.Config.ScrubInterval, Counts: make(map[string]int)}
+	s.LastRun, s.Scrubbing = h.Scrub.Status()
+	for _, f := range files {
+		s.Counts[f.Integrity]++
+		if f.Integrity != "" && f.Integrity != database.IntegrityOK {
+			s.Problems = append(s.Problems, f)
+		}
+	}
+	return s
+}
+
+// AdminScrubFiles starts checking every file against its checksum.
+func (h *Handler) AdminScrubFiles(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	h.Scrub.Refresh()
+	h.audit(session.Username, "file.scrub", "started an integrity check of all files")
+
+	http.Redirect(w, r, "/admin/files?success=Integrity+check+started", http.StatusSeeOther)
+}
+
+// AdminAcceptChecksum records the file's current content as the one to
+// verify against, after an administrator has confirmed a change is wanted.
+func (h *Handler) AdminAcceptChecksum(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	fileID, _ := strconv.Atoi(r.FormValue("id"))
+	file, err := h.DB.GetFileByID(fileID)
+	if err != nil {
+		http.Redirect(w, r, "/admin/files?error=File+not+found", http.StatusSeeOther)
+		return
+	}
+
+	// The old checksum is kept for a file that cannot be read, in case it
+	// comes back.
+	sum, size, err := integrity.Sum(file.FilePath)
+	if err != nil {
+		http.Redirect(w, r, "/admin/files?error="+url.QueryEscape("Cannot read the file: "+err.Error()), http.StatusSeeOther)
+		return
+	}
+	if err := h.DB.SetFileChecksum(file.ID, sum, size, database.IntegrityOK, time.Now()); err != nil {
+		log.Printf("Failed to record checksum of file %d: %v", file.ID, err)
+		http.Redirect(w, r, "/admin/files?error=Failed+to+record+checksum", http.StatusSeeOther)
+		return
+	}
+
+	h.audit(session.Username, "file.checksum", fmt.Sprintf("accepted the current content of file %s (%d) with SHA-256 %s, was %s", file.Name, file.ID, sum, file.SHA256))
+
+	http.Redirect(w, r, "/admin/files?success=Checksum+updated", http.StatusSeeOther)
+}
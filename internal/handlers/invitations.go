@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"backup_server/internal/auth"
+	"backup_server/internal/database"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const invitationsPath = "/admin/invitations"
+
+func (h *Handler) AdminInvitationsPage(w http.ResponseWriter, r *http.Request) {
+	h.renderInvitations(w, r, "")
+}
+
+// renderInvitations shows the invitation list. newURL is the URL of an
+// invitation that was just created; it is shown once because only its hash
+// is stored.
+func (h *Handler) renderInvitations(w http.ResponseWriter, r *http.Request, newURL string) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	invitations, err := h.DB.GetAllInvitations()
+	if err != nil {
+		http.Error(w, "Failed to load invitations", http.StatusInternalServerError)
+		return
+	}
+
+	groups, err := h.DB.GetAllGroups()
+	if err != nil {
+		http.Error(w, "Failed to load groups", http.StatusInternalServerError)
+		return
+	}
+
+	users, err := h.DB.GetAllUsers()
+	if err != nil {
+		http.Error(w, "Failed to load users", http.StatusInternalServerError)
+		return
+	}
+
+	groupNames := make(map[int]string)
+	for _, g := range groups {
+		groupNames[g.ID] = g.Name
+	}
+
+	userNames := make(map[int]string)
+	for _, u := range users {
+		userNames[u.ID] = u.Username
+	}
+
+	data := map[string]interface{}{
+		"Username":         session.Username,
+		"Invitations":      invitations,
+		"Groups":           groups,
+		"GroupNames":       groupNames,
+		"UserNames":        userNames,
+		"NewInvitationURL": newURL,
+	}
+
+	if msg := r.URL.Query().Get("success"); msg != "" {
+		data["Message"] = msg
+		data["Success"] = true
+	} else if msg := r.URL.Query().Get("error"); msg != "" {
+		data["Message"] = msg
+		data["Success"] = false
+	}
+
+	h.render(w, r, "admin_invitations.html", data)
+}
+
+func (h *Handler) AdminCreateInvitation(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	r.ParseForm()
+	var groupIDs []int
+	for _, idStr := range r.Form["group_ids"] {
+		if id, err := strconv.Atoi(idStr); err == nil {
+			groupIDs = append(groupIDs, id)
+		}
+	}
+	if len(groupIDs) == 0 {
+		http.Redirect(w, r, invitationsPath+"?error=Invitation+must+add+at+least+one+group", http.StatusSeeOther)
+		return
+	}
+
+	expiresHours, _ := strconv.Atoi(r.FormValue("expires_hours"))
+	maxUses, _ := strconv.Atoi(r.FormValue("max_uses"))
+	if expiresHours < 1 || maxUses < 1 {
+		http.Redirect(w, r, invitationsPath+"?error=Expiry+and+number+of+uses+must+be+at+least+1", http.StatusSeeOther)
+		return
+	}
+	note := strings.TrimSpace(r.FormValue("note"))
+
+	token, err := auth.NewToken()
+	if err != nil {
+		http.Error(w, "Failed to create invitation", http.StatusInternalServerError)
+		return
+	}
+
+	expiresAt := time.Now().Add(time.Duration(expiresHours) * time.Hour)
+	if err := h.DB.CreateInvitation(token, session.UserID, groupIDs, expiresAt, maxUses, note); err != nil {
+		log.Printf("Failed to create invitation: %v", err)
+		http.Redirect(w, r, invitationsPath+"?error=Failed+to+create+invitation", http.StatusSeeOther)
+		return
+	}
+
+	h.audit(session.Username, "invite.create", fmt.Sprintf("created invitation for groups %v with %d uses", groupIDs, maxUses))
+
+	h.renderInvitations(w, r, h.absoluteURL("/invite/"+token))
+}
+
+func (h *Handler) AdminRevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	invitationID, _ := strconv.Atoi(r.FormValue("id"))
+
+	if err := h.DB.DeleteInvitation(invitationID); err != nil {
+		log.Printf("Failed to revoke invitation %d: %v", invitationID, err)
+		http.Redirect(w, r, invitationsPath+"?error=Failed+to+revoke+invitation", http.StatusSeeOther)
+		return
+	}
+
+	h.audit(session.Username, "invite.revoke", fmt.Sprintf("revoked invitation %d", invitationID))
+
+	http.Redirect(w, r, invitationsPath+"?success=Invitation+revoked", http.StatusSeeOther)
+}
+
+// activeInvitation looks up the invitation named in the URL, rendering a
+// not-found page and returning nil if it does not exist or can no longer be
+// used.
+func (h *Handler) activeInvitation(w http.ResponseWriter, r *http.Request) *database.Invitation {
+	invitation, err := h.DB.GetInvitationByToken(chi.URLParam(r, "token"))
+	if err != nil || !invitation.Active() {
+		h.renderError(w, r, http.StatusNotFound, "Invitation not available",
+			"This invitation does not exist, has expired or has already been used.")
+		return nil
+	}
+	return invitation
+}
+
+// InvitePage shows the sign-up form for an invitation.
+func (h *Handler) InvitePage(w http.ResponseWriter, r *http.Request) {
+	if h.activeInvitation(w, r) == nil {
+		return
+	}
+	h.renderInvite(w, r, "", "")
+}
+
+func (h *Handler) renderInvite(w http.ResponseWriter, r *http.Request, username, errMsg string) {
+	data := map[string]interface{}{
+		"Username":  username,
+		"Error":     errMsg,
+		"CSRFToken": h.loginCSRFToken(w, r),
+	}
+	if err := h.Templates.ExecuteTemplate(w, "invite.html", data); err != nil {
+		log.Printf("Template error: %v", err)
+	}
+}
+
+// AcceptInvite creates the invitee's account in the invitation's groups. A
+// use is claimed before the account is created and given back if that
+// fails, so a taken username does not burn a single-use invitation.
+func (h *Handler) AcceptInvite(w http.ResponseWriter, r *http.Request) {
+	invitation := h.activeInvitation(w, r)
+	if invitation == nil {
+		return
+	}
+
+	username := strings.TrimSpace(r.FormValue("username"))
+	password := r.FormValue("password")
+
+	if username == "" {
+		h.renderInvite(w, r, username, "Username is required")
+		return
+	}
+	if password != r.FormValue("confirm_password") {
+		h.renderInvite(w, r, username, "Passwords do not match")
+		return
+	}
+	if _, err := h.DB.GetUserByUsername(username); err == nil {
+		h.renderInvite(w, r, username, "That username is already taken")
+		return
+	}
+
+	claimed, err := h.DB.ClaimInvitation(invitation.ID)
+	if err != nil {
+		http.Error(w, "Failed to accept invitation", http.StatusInternalServerError)
+		return
+	}
+	if !claimed {
+		h.renderError(w, r, http.StatusNotFound, "Invitation not available",
+			"This invitation has expired or has already been used.")
+		return
+	}
+
+	if err := h.DB.CreateUser(username, password, invitation.GroupIDs); err != nil {
+		log.Printf("Failed to create user from invitation %d: %v", invitation.ID, err)
+		if err := h.DB.ReleaseInvitation(invitation.ID); err != nil {
+			log.Printf("Failed to release invitation %d: %v", invitation.ID, err)
+		}
+		h.renderInvite(w, r, username, errorMessage(err, "Failed to create account"))
+		return
+	}
+
+	h.audit(username, "invite.accept", fmt.Sprintf("created account %s through invitation %d from %s", username, invitation.ID, clientIP(r)))
+
+	if err := h.Templates.ExecuteTemplate(w, "invite.html", map[string]interface{}{
+		"Username": username,
+		"Created":  true,
+	}); err != nil {
+		log.Printf("Template error: %v", err)
+	}
+}
@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"backup_server/internal/auth"
+	"backup_server/internal/database"
+	"backup_server/internal/jobs"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const jobsPath = "/admin/jobs"
+
+// jobRunsShown is how many recent runs the jobs page lists.
+const jobRunsShown = 50
+
+// jobRow is a backup job as the jobs page shows it.
+type jobRow struct {
+	database.BackupJob
+	NextRun time.Time
+	// EffectiveWindow is the job's window, or the one its schedule implies.
+	EffectiveWindow time.Duration
+	Overdue         bool
+	Running         bool
+	RetryAt         time.Time
+}
+
+func (h *Handler) AdminJobsPage(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	all, err := h.DB.GetBackupJobs()
+	if err != nil {
+		http.Error(w, "Failed to load backup jobs", http.StatusInternalServerError)
+		return
+	}
+
+	runs, err := h.DB.GetBackupJobRuns(jobRunsShown)
+	if err != nil {
+		http.Error(w, "Failed to load backup job runs", http.StatusInternalServerError)
+		return
+	}
+
+	groups, err := h.DB.GetAllGroups()
+	if err != nil {
+		http.Error(w, "Failed to load groups", http.StatusInternalServerError)
+		return
+	}
+
+	groupNames := make(map[int]string)
+	for _, g := range groups {
+		groupNames[g.ID] = g.Name
+	}
+
+	now := time.Now()
+	rows := make([]jobRow, 0, len(all))
+	var editing *database.BackupJob
+	editID, _ := strconv.Atoi(r.URL.Query().Get("edit"))
+	for i, job := range all {
+		row := jobRow{
+			BackupJob:       job,
+			EffectiveWindow: jobs.Window(job),
+			Overdue:         jobs.Overdue(job, now),
+		}
+		if job.Enabled {
+			row.NextRun = jobs.NextRun(job, now)
+		}
+		row.Running, row.RetryAt = h.Jobs.State(job.ID)
+		rows = append(rows, row)
+		if job.ID == editID {
+			editing = &all[i]
+		}
+	}
+
+	data := map[string]interface{}{
+		"Username":   session.Username,
+		"Jobs":       rows,
+		"Runs":       runs,
+		"Groups":     groups,
+		"GroupNames": groupNames,
+		"Editing":    editing,
+	}
+
+	if msg := r.URL.Query().Get("success"); msg != "" {
+		data["Message"] = msg
+		data["Success"] = true
+	} else if msg := r.URL.Query().Get("error"); msg != "" {
+		data["Message"] = msg
+		data["Success"] = false
+	}
+
+	h.render(w, r, "admin_jobs.html", data)
+}
+
+// AdminSaveJob creates a backup job, or updates the one named by the id
+// form value.
+func (h *Handler) AdminSaveJob(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	jobID, _ := strconv.Atoi(r.FormValue("id"))
+	job := database.BackupJob{
+		ID:       jobID,
+		Name:     strings.TrimSpace(r.FormValue("name")),
+		Source:   strings.TrimSpace(r.FormValue("source")),
+		Schedule: strings.TrimSpace(r.FormValue("schedule")),
+		Enabled:  r.FormValue("enabled") != "",
+	}
+	job.GroupID, _ = strconv.Atoi(r.FormValue("group_id"))
+	job.Retries, _ = strconv.Atoi(r.FormValue("retries"))
+
+	back := jobsPath
+	if jobID != 0 {
+		back += "?edit=" + strconv.Itoa(jobID) + "&"
+	} else {
+		back += "?"
+	}
+	fail := func(msg string) {
+		http.Redirect(w, r, back+"error="+url.QueryEscape(msg), http.StatusSeeOther)
+	}
+
+	if job.Name == "" || job.Source == "" || job.Schedule == "" {
+		fail("Name, source and schedule are required")
+		return
+	}
+	if _, err := jobs.ParseSchedule(job.Schedule); err != nil {
+		fail("Invalid schedule: " + err.Error())
+		return
+	}
+	if _, err := h.DB.GetGroupByID(job.GroupID); err != nil {
+		fail("Choose a group for new files")
+		return
+	}
+	if job.Retries < 0 {
+		fail("Retries cannot be negative")
+		return
+	}
+	if window := strings.TrimSpace(r.FormValue("window")); window != "" {
+		d, err := time.ParseDuration(window)
+		if err != nil || d < time.Minute {
+			fail("The alert window must be a duration of at least a minute, such as 26h")
+			return
+		}
+		job.Window = d
+	}
+
+	action, verb := "job.create", "created"
+	var err error
+	if jobID == 0 {
+		err = h.DB.CreateBackupJob(job)
+	} else {
+		action, verb = "job.update", "updated"
+		err = h.DB.UpdateBackupJob(job)
+	}
+	if err != nil {
+		log.Printf("Failed to save backup job %s: %v", job.Name, err)
+		fail("Failed to save job; is the name already taken?")
+		return
+	}
+
+	h.audit(session.Username, action, fmt.Sprintf("%s backup job %s copying %s on %q", verb, job.Name, job.Source, job.Schedule))
+
+	http.Redirect(w, r, jobsPath+"?success="+url.QueryEscape("Job "+job.Name+" saved"), http.StatusSeeOther)
+}
+
+func (h *Handler) AdminDeleteJob(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	jobID, _ := strconv.Atoi(r.FormValue("id"))
+	job, err := h.DB.GetBackupJob(jobID)
+	if err != nil {
+		http.Redirect(w, r, jobsPath+"?error=Job+not+found", http.StatusSeeOther)
+		return
+	}
+
+	if err := h.DB.DeleteBackupJob(jobID); err != nil {
+		log.Printf("Failed to delete backup job %d: %v", jobID, err)
+		http.Redirect(w, r, jobsPath+"?error=Failed+to+delete+job", http.StatusSeeOther)
+		return
+	}
+
+	h.audit(session.Username, "job.delete", fmt.Sprintf("deleted backup job %s", job.Name))
+
+	http.Redirect(w, r, jobsPath+"?success=Job+deleted", http.StatusSeeOther)
+}
+
+// AdminRunJob starts a job now, outside its schedule.
+func (h *Handler) AdminRunJob(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	jobID, _ := strconv.Atoi(r.FormValue("id"))
+	job, err := h.DB.GetBackupJob(jobID)
+	if err != nil {
+		http.Redirect(w, r, jobsPath+"?error=Job+not+found", http.StatusSeeOther)
+		return
+	}
+
+	h.Jobs.RunNow(job.ID)
+	h.audit(session.Username, "job.run", fmt.Sprintf("started backup job %s", job.Name))
+
+	http.Redirect(w, r, jobsPath+"?success="+url.QueryEscape("Job "+job.Name+" started"), http.StatusSeeOther)
+}
+
+// overdueJobs returns the names of the jobs that have not succeeded within
+// their window, for the warning on the files admin page.
+func (h *Handler) overdueJobs() []string {
+	all, err := h.DB.GetBackupJobs()
+	if err != nil {
+		log.Printf("Failed to load backup jobs: %v", err)
+		return nil
+	}
+	var names []string
+	now := time.Now()
+	for _, job := range all {
+		if jobs.Overdue(job, now) {
+			names = append(names, job.Name)
+		}
+	}
+	return names
+}
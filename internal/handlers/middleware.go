@@ -3,9 +3,17 @@ package handlers
 import (
 	"backup_server/internal/auth"
 	"context"
+	"log"
 	"net/http"
 )
 
+// changePasswordPath is the only page a session flagged with
+// MustChangePassword may reach, other than logging out.
+const (
+	changePasswordPath = "/account/password"
+	logoutPath         = "/logout"
+)
+
 func (h *Handler) AuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		sessionID, err := auth.GetSessionFromRequest(r)
@@ -20,7 +28,38 @@ func (h *Handler) AuthMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
+		if session.MustChangePassword && r.URL.Path != changePasswordPath && r.URL.Path != logoutPath {
+			http.Redirect(w, r, changePasswordPath, http.StatusSeeOther)
+			return
+		}
+
 		ctx := context.WithValue(r.Context(), "session", session)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// RequirePermission only lets the request through if the signed-in user
+// holds permission through one of their roles. Permissions are looked up on
+// every request so role changes take effect immediately.
+func (h *Handler) RequirePermission(permission string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			session := r.Context().Value("session").(*auth.Session)
+
+			allowed, err := h.DB.UserHasPermission(session.UserID, permission)
+			if err != nil {
+				log.Printf("Failed to check permission %s for %s: %v", permission, session.Username, err)
+				http.Error(w, "Failed to check access", http.StatusInternalServerError)
+				return
+			}
+
+			if !allowed {
+				h.renderError(w, r, http.StatusForbidden, "Access denied",
+					"You do not have permission to view this page.")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
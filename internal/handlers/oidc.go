@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"backup_server/internal/auth"
+	"backup_server/internal/database"
+	"backup_server/internal/login"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+)
+
+// OIDCLogin sends the browser to the OpenID Connect provider to sign in.
+func (h *Handler) OIDCLogin(w http.ResponseWriter, r *http.Request) {
+	if h.OIDC == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	state, authURL, err := h.OIDC.Start(0)
+	if err != nil {
+		log.Printf("Failed to start %s sign-in: %v", h.OIDC.Name(), err)
+		h.renderLogin(w, r, h.OIDC.Name()+" is unavailable. Try again later.")
+		return
+	}
+
+	auth.SetOIDCStateCookie(w, state, login.OIDCRequestTTL)
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// OIDCCallback is where the provider sends the browser back. It either
+// signs the user in or, for a sign-in started from the account page, links
+// the identity to the account that started it.
+func (h *Handler) OIDCCallback(w http.ResponseWriter, r *http.Request) {
+	if h.OIDC == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	query := r.URL.Query()
+	if providerErr := query.Get("error"); providerErr != "" {
+		log.Printf("%s sign-in refused by the provider: %s %s", h.OIDC.Name(), providerErr, query.Get("error_description"))
+		h.renderLogin(w, r, "Sign-in was cancelled or refused by "+h.OIDC.Name())
+		return
+	}
+
+	state := query.Get("state")
+	cookieState, err := auth.GetOIDCStateCookie(r)
+	auth.ClearOIDCStateCookie(w)
+	if err != nil || state == "" || cookieState != state {
+		h.renderLogin(w, r, "Sign-in request not recognised or expired. Try again.")
+		return
+	}
+
+	identity, linkUserID, err := h.OIDC.Finish(r.Context(), state, query.Get("code"))
+	if err != nil {
+		if errors.Is(err, login.ErrUnknownState) {
+			h.renderLogin(w, r, "Sign-in request not recognised or expired. Try again.")
+			return
+		}
+		log.Printf("%s sign-in failed: %v", h.OIDC.Name(), err)
+		h.renderLogin(w, r, "Sign-in with "+h.OIDC.Name()+" failed")
+		return
+	}
+
+	if linkUserID != 0 {
+		h.linkIdentity(w, r, linkUserID, identity)
+		return
+	}
+
+	user, err := h.OIDC.Resolve(identity)
+	if err != nil {
+		if errors.Is(err, login.ErrNoGroups) || errors.Is(err, login.ErrUsernameTaken) {
+			h.renderLogin(w, r, "Sign-in refused: "+err.Error())
+			return
+		}
+		log.Printf("%s sign-in for %s failed: %v", h.OIDC.Name(), identity.Subject, err)
+		h.renderLogin(w, r, "Sign-in with "+h.OIDC.Name()+" failed")
+		return
+	}
+
+	h.recordLogin(user.ID, r, true)
+	h.audit(user.Username, "login.oidc", fmt.Sprintf("signed in as %s at %s", identity.Subject, identity.Issuer))
+
+	next, err := h.startSession(w, r, user)
+	if err != nil {
+		http.Error(w, "Failed to create session", http.StatusInternalServerError)
+		return
+	}
+	h.continueTo(w, next)
+}
+
+func (h *Handler) linkIdentity(w http.ResponseWriter, r *http.Request, userID int, identity *login.ExternalIdentity) {
+	user, err := h.DB.GetUserByID(userID)
+	if err != nil {
+		h.renderLogin(w, r, "Account not found")
+		return
+	}
+
+	if err := h.DB.LinkIdentity(user.ID, identity.Issuer, identity.Subject); err != nil {
+		log.Printf("Failed to link identity for %s: %v", user.Username, err)
+		h.continueTo(w, "/account?error="+url.QueryEscape(errorMessage(err, "Failed to link identity")))
+		return
+	}
+
+	h.audit(user.Username, "account.identity.link", fmt.Sprintf("linked %s at %s", identity.Subject, identity.Issuer))
+	h.continueTo(w, "/account?success="+url.QueryEscape("Linked your "+h.OIDC.Name()+" identity"))
+}
+
+// continueTo sends the browser to path from a page on this site. A redirect
+// would end a chain that started at the provider, and browsers leave the
+// SameSite=Strict session cookie off such requests.
+func (h *Handler) continueTo(w http.ResponseWriter, path string) {
+	h.Templates.ExecuteTemplate(w, "oidc_continue.html", map[string]string{
+		"Next": path,
+	})
+}
+
+// AccountLinkIdentity starts a sign-in at the provider that links the
+// identity to the signed-in user instead of signing in.
+func (h *Handler) AccountLinkIdentity(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if h.OIDC == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	session := r.Context().Value("session").(*auth.Session)
+
+	state, authURL, err := h.OIDC.Start(session.UserID)
+	if err != nil {
+		log.Printf("Failed to start %s sign-in: %v", h.OIDC.Name(), err)
+		http.Redirect(w, r, "/account?error="+url.QueryEscape(h.OIDC.Name()+" is unavailable. Try again later."), http.StatusSeeOther)
+		return
+	}
+
+	auth.SetOIDCStateCookie(w, state, login.OIDCRequestTTL)
+	http.Redirect(w, r, authURL, http.StatusSeeOther)
+}
+
+func (h *Handler) AccountUnlinkIdentity(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	session := r.Context().Value("session").(*auth.Session)
+
+	user, err := h.DB.GetUserByID(session.UserID)
+	if err != nil {
+		http.Error(w, "Failed to load account", http.StatusInternalServerError)
+		return
+	}
+
+	issuer := r.FormValue("issuer")
+	subject := r.FormValue("subject")
+
+	// Accounts created by single sign-on have no password, so their last
+	// identity is the only way to sign in.
+	if user.AuthSource == database.AuthSourceOIDC {
+		identities, err := h.DB.GetIdentitiesByUser(user.ID)
+		if err != nil {
+			http.Error(w, "Failed to load identities", http.StatusInternalServerError)
+			return
+		}
+		if len(identities) <= 1 {
+			http.Redirect(w, r, "/account?error=You+cannot+unlink+the+only+way+to+sign+in+to+this+account", http.StatusSeeOther)
+			return
+		}
+	}
+
+	removed, err := h.DB.UnlinkIdentity(user.ID, issuer, subject)
+	if err != nil {
+		log.Printf("Failed to unlink identity: %v", err)
+		http.Redirect(w, r, "/account?error=Failed+to+unlink+identity", http.StatusSeeOther)
+		return
+	}
+	if !removed {
+		http.Redirect(w, r, "/account?error=Identity+not+found", http.StatusSeeOther)
+		return
+	}
+
+	h.audit(session.Username, "account.identity.unlink", fmt.Sprintf("unlinked %s at %s", subject, issuer))
+	http.Redirect(w, r, "/account?success=Identity+unlinked", http.StatusSeeOther)
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"backup_server/internal/auth"
+	"backup_server/internal/terraria"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+)
+
+func isPlayerFile(name string) bool {
+	return strings.HasSuffix(name, ".plr")
+}
+
+// playerProblem is worldProblem for character files.
+func playerProblem(err error) string {
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return "file missing"
+	case errors.Is(err, terraria.ErrNotPlayer):
+		return "not a Terraria character"
+	case errors.Is(err, terraria.ErrUnsupportedVersion):
+		return "unsupported character version"
+	}
+	log.Printf("Failed to read player: %v", err)
+	return "unreadable"
+}
+
+// PlayerPage shows the character in a .plr file: stats, equipment,
+// inventory and personal storage.
+func (h *Handler) PlayerPage(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	file, status, msg := h.viewableFile(session.UserID, r.URL.Query().Get("id"), isPlayerFile, "Not a character file")
+	if file == nil {
+		h.renderError(w, r, status, "Character", msg)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Username": session.Username,
+		"File":     file,
+		"Sidecar":  h.sidecar(file),
+	}
+
+	player, err := terraria.ReadPlayerFile(file.FilePath)
+	if err != nil {
+		data["Problem"] = playerProblem(err)
+	} else {
+		data["Player"] = player
+	}
+	h.render(w, r, "player.html", data)
+}
+
+// APIPlayer returns the character in a .plr file as JSON.
+func (h *Handler) APIPlayer(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	file, status, msg := h.viewableFile(session.UserID, r.URL.Query().Get("id"), isPlayerFile, "Not a character file")
+	if file == nil {
+		writeJSONError(w, status, msg)
+		return
+	}
+
+	player, err := terraria.ReadPlayerFile(file.FilePath)
+	if err != nil {
+		writeJSONError(w, http.StatusUnprocessableEntity, "Character details unavailable: "+playerProblem(err))
+		return
+	}
+	writeJSON(w, http.StatusOK, player)
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"backup_server/internal/auth"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+func (h *Handler) AdminRolesPage(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	roles, err := h.DB.GetAllRoles()
+	if err != nil {
+		http.Error(w, "Failed to load roles", http.StatusInternalServerError)
+		return
+	}
+
+	groups, err := h.DB.GetAllGroups()
+	if err != nil {
+		http.Error(w, "Failed to load groups", http.StatusInternalServerError)
+		return
+	}
+
+	users, err := h.DB.GetAllUsers()
+	if err != nil {
+		http.Error(w, "Failed to load users", http.StatusInternalServerError)
+		return
+	}
+
+	groupNames := make(map[int]string)
+	for _, g := range groups {
+		groupNames[g.ID] = g.Name
+	}
+
+	userNames := make(map[int]string)
+	for _, u := range users {
+		userNames[u.ID] = u.Username
+	}
+
+	data := map[string]interface{}{
+		"Username":    session.Username,
+		"Roles":       roles,
+		"Groups":      groups,
+		"GroupNames":  groupNames,
+		"Users":       users,
+		"UserNames":   userNames,
+		"Permissions": auth.AllPermissions,
+	}
+
+	if msg := r.URL.Query().Get("success"); msg != "" {
+		data["Message"] = msg
+		data["Success"] = true
+	} else if msg := r.URL.Query().Get("error"); msg != "" {
+		data["Message"] = msg
+		data["Success"] = false
+	}
+
+	h.render(w, r, "admin_roles.html", data)
+}
+
+// roleForm reads the fields shared by the add and edit role forms. Unknown
+// permission names are dropped.
+func roleForm(r *http.Request) (name string, permissions []string, groupIDs, userIDs []int) {
+	r.ParseForm()
+
+	name = strings.TrimSpace(r.FormValue("name"))
+
+	known := make(map[string]bool)
+	for _, p := range auth.AllPermissions {
+		known[p.Name] = true
+	}
+	for _, p := range r.Form["permissions"] {
+		if known[p] {
+			permissions = append(permissions, p)
+		}
+	}
+
+	for _, idStr := range r.Form["group_ids"] {
+		if id, err := strconv.Atoi(idStr); err == nil {
+			groupIDs = append(groupIDs, id)
+		}
+	}
+	for _, idStr := range r.Form["user_ids"] {
+		if id, err := strconv.Atoi(idStr); err == nil {
+			userIDs = append(userIDs, id)
+		}
+	}
+
+	return name, permissions, groupIDs, userIDs
+}
+
+func (h *Handler) AdminAddRole(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	name, permissions, groupIDs, userIDs := roleForm(r)
+	if name == "" {
+		http.Redirect(w, r, "/admin/roles?error=Role+name+is+required", http.StatusSeeOther)
+		return
+	}
+
+	if err := h.DB.CreateRole(name, permissions, groupIDs, userIDs); err != nil {
+		log.Printf("Failed to add role: %v", err)
+		http.Redirect(w, r, "/admin/roles?error="+url.QueryEscape(errorMessage(err, "Failed to add role")), http.StatusSeeOther)
+		return
+	}
+
+	h.audit(session.Username, "role.create", fmt.Sprintf("created role %s with permissions [%s]", name, strings.Join(permissions, ", ")))
+
+	http.Redirect(w, r, "/admin/roles?success=Role+added+successfully", http.StatusSeeOther)
+}
+
+func (h *Handler) AdminEditRole(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	roleID, _ := strconv.Atoi(r.FormValue("id"))
+	name, permissions, groupIDs, userIDs := roleForm(r)
+	if name == "" {
+		http.Redirect(w, r, "/admin/roles?error=Role+name+is+required", http.StatusSeeOther)
+		return
+	}
+
+	if err := h.DB.UpdateRole(roleID, name, permissions, groupIDs, userIDs); err != nil {
+		log.Printf("Failed to update role: %v", err)
+		http.Redirect(w, r, "/admin/roles?error="+url.QueryEscape(errorMessage(err, "Failed to update role")), http.StatusSeeOther)
+		return
+	}
+
+	h.audit(session.Username, "role.update", fmt.Sprintf("updated role %s with permissions [%s]", name, strings.Join(permissions, ", ")))
+
+	http.Redirect(w, r, "/admin/roles?success=Role+updated+successfully", http.StatusSeeOther)
+}
+
+func (h *Handler) AdminDeleteRole(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	roleID, _ := strconv.Atoi(r.FormValue("id"))
+
+	if err := h.DB.DeleteRole(roleID); err != nil {
+		log.Printf("Failed to delete role: %v", err)
+		http.Redirect(w, r, "/admin/roles?error="+url.QueryEscape(errorMessage(err, "Failed to delete role")), http.StatusSeeOther)
+		return
+	}
+
+	h.audit(session.Username, "role.delete", fmt.Sprintf("deleted role %d", roleID))
+
+	http.Redirect(w, r, "/admin/roles?success=Role+deleted+successfully", http.StatusSeeOther)
+}
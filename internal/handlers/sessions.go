@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"backup_server/internal/auth"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// refreshSessions reloads the username and effective groups recorded on the
+// given users' sessions so membership changes apply without signing in
+// again. Sessions of users that no longer exist are revoked.
+func (h *Handler) refreshSessions(userIDs ...int) {
+	for _, userID := range userIDs {
+		user, err := h.DB.GetUserByID(userID)
+		if errors.Is(err, sql.ErrNoRows) {
+			h.Sessions.DeleteByUser(userID)
+			continue
+		}
+		if err != nil {
+			log.Printf("Failed to refresh sessions of user %d: %v", userID, err)
+			continue
+		}
+
+		groupIDs, err := h.DB.GetEffectiveGroupIDs(userID)
+		if err != nil {
+			log.Printf("Failed to refresh sessions of user %d: %v", userID, err)
+			continue
+		}
+
+		h.Sessions.Refresh(userID, user.Username, groupIDs)
+	}
+}
+
+// refreshAllSessions refreshes every signed-in user, for changes such as
+// group nesting that can affect anyone's effective groups.
+func (h *Handler) refreshAllSessions() {
+	var userIDs []int
+	for userID := range h.Sessions.CountByUser() {
+		userIDs = append(userIDs, userID)
+	}
+	h.refreshSessions(userIDs...)
+}
+
+// AdminSignOutUser revokes all of a user's sessions, for example after a
+// device is lost.
+func (h *Handler) AdminSignOutUser(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	userID, _ := strconv.Atoi(r.FormValue("id"))
+	user, err := h.DB.GetUserByID(userID)
+	if err != nil {
+		http.Redirect(w, r, "/admin/users?error=User+not+found", http.StatusSeeOther)
+		return
+	}
+
+	removed := h.Sessions.DeleteByUser(userID)
+
+	h.audit(session.Username, "user.signout", fmt.Sprintf("signed out %d sessions of %s", removed, user.Username))
+
+	msg := fmt.Sprintf("Signed out %d sessions of %s", removed, user.Username)
+	http.Redirect(w, r, "/admin/users?success="+url.QueryEscape(msg), http.StatusSeeOther)
+}
@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"backup_server/internal/auth"
+	"backup_server/internal/database"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const shareLinksPath = "/links"
+
+func (h *Handler) ShareLinksPage(w http.ResponseWriter, r *http.Request) {
+	h.renderShareLinks(w, r, "")
+}
+
+// renderShareLinks shows the user's links. newURL is the URL of a link that
+// was just created; it is shown once because only its hash is stored.
+func (h *Handler) renderShareLinks(w http.ResponseWriter, r *http.Request, newURL string) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	links, err := h.DB.GetShareLinksByUser(session.UserID)
+	if err != nil {
+		http.Error(w, "Failed to load share links", http.StatusInternalServerError)
+		return
+	}
+
+	files, err := h.DB.GetFilesForUser(session.UserID)
+	if err != nil {
+		http.Error(w, "Failed to load files", http.StatusInternalServerError)
+		return
+	}
+
+	var shareable []database.File
+	for _, f := range files {
+		if f.CanDownload() {
+			shareable = append(shareable, f)
+		}
+	}
+
+	selected, _ := strconv.Atoi(r.URL.Query().Get("file_id"))
+
+	data := map[string]interface{}{
+		"Username":     session.Username,
+		"Links":        links,
+		"Files":        shareable,
+		"SelectedFile": selected,
+		"NewLinkURL":   newURL,
+	}
+
+	if msg := r.URL.Query().Get("success"); msg != "" {
+		data["Message"] = msg
+		data["Success"] = true
+	} else if msg := r.URL.Query().Get("error"); msg != "" {
+		data["Message"] = msg
+		data["Success"] = false
+	}
+
+	h.render(w, r, "share_links.html", data)
+}
+
+func (h *Handler) CreateShareLink(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	fileID, _ := strconv.Atoi(r.FormValue("file_id"))
+	file, err := h.DB.GetFileByID(fileID)
+	if err != nil {
+		http.Redirect(w, r, shareLinksPath+"?error=File+not+found", http.StatusSeeOther)
+		return
+	}
+
+	level, err := h.DB.GetFileAccessLevel(session.UserID, file.ID)
+	if err != nil {
+		http.Error(w, "Failed to check access", http.StatusInternalServerError)
+		return
+	}
+	if !database.GrantAllows(level, database.GrantDownload) {
+		h.renderError(w, r, http.StatusForbidden, "Access denied",
+			"You can only share files you are allowed to download.")
+		return
+	}
+
+	expiresHours, _ := strconv.Atoi(r.FormValue("expires_hours"))
+	maxDownloads, _ := strconv.Atoi(r.FormValue("max_downloads"))
+	if expiresHours < 0 || maxDownloads < 0 {
+		http.Redirect(w, r, shareLinksPath+"?error=Expiry+and+download+limit+cannot+be+negative", http.StatusSeeOther)
+		return
+	}
+
+	var expiresAt time.Time
+	if expiresHours > 0 {
+		expiresAt = time.Now().Add(time.Duration(expiresHours) * time.Hour)
+	}
+
+	token, err := auth.NewToken()
+	if err != nil {
+		http.Error(w, "Failed to create link", http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.DB.CreateShareLink(token, file.ID, session.UserID, expiresAt, maxDownloads, r.FormValue("password")); err != nil {
+		log.Printf("Failed to create share link for file %d: %v", file.ID, err)
+		http.Redirect(w, r, shareLinksPath+"?error=Failed+to+create+link", http.StatusSeeOther)
+		return
+	}
+
+	h.audit(session.Username, "share.create", fmt.Sprintf("created share link for file %s", file.Name))
+
+	h.renderShareLinks(w, r, h.absoluteURL("/s/"+token))
+}
+
+// absoluteURL turns a path into a full URL on the configured public address,
+// for links that are handed to someone else.
+func (h *Handler) absoluteURL(path string) string {
+	return h.Config.PublicURL + path
+}
+
+func (h *Handler) RevokeShareLink(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	linkID, _ := strconv.Atoi(r.FormValue("id"))
+
+	deleted, err := h.DB.DeleteShareLink(linkID, session.UserID)
+	if err != nil {
+		log.Printf("Failed to revoke share link %d: %v", linkID, err)
+		http.Redirect(w, r, shareLinksPath+"?error=Failed+to+revoke+link", http.StatusSeeOther)
+		return
+	}
+	if !deleted {
+		http.Redirect(w, r, shareLinksPath+"?error=Link+not+found", http.StatusSeeOther)
+		return
+	}
+
+	h.audit(session.Username, "share.revoke", fmt.Sprintf("revoked share link %d", linkID))
+
+	http.Redirect(w, r, shareLinksPath+"?success=Link+revoked", http.StatusSeeOther)
+}
+
+// activeShareLink looks up the link named in the URL, rendering a not-found
+// page and returning nil if it does not exist or can no longer be used.
+func (h *Handler) activeShareLink(w http.ResponseWriter, r *http.Request) *database.ShareLink {
+	link, err := h.DB.GetShareLinkByToken(chi.URLParam(r, "token"))
+	if err != nil || !link.Active() {
+		h.renderError(w, r, http.StatusNotFound, "Link not available",
+			"This link does not exist, has expired or has reached its download limit.")
+		return nil
+	}
+	return link
+}
+
+// PublicShare serves a share link to anyone who has it. Link previewers and
+// prefetchers fetch whatever they are shown, so opening the link only shows
+// a form, and the download happens, and is counted, when it is submitted.
+// Scripts can skip the form on links without a password by adding ?dl=1.
+func (h *Handler) PublicShare(w http.ResponseWriter, r *http.Request) {
+	link := h.activeShareLink(w, r)
+	if link == nil {
+		return
+	}
+
+	if !link.HasPassword() && r.URL.Query().Get("dl") == "1" {
+		h.sendShare(w, r, link)
+		return
+	}
+
+	h.renderSharePage(w, r, link, "")
+}
+
+func (h *Handler) renderSharePage(w http.ResponseWriter, r *http.Request, link *database.ShareLink, errMsg string) {
+	data := map[string]interface{}{
+		"FileName":  link.FileName,
+		"Password":  link.HasPassword(),
+		"Error":     errMsg,
+		"CSRFToken": h.loginCSRFToken(w, r),
+	}
+	if err := h.Templates.ExecuteTemplate(w, "share_password.html", data); err != nil {
+		log.Printf("Template error: %v", err)
+	}
+}
+
+// PublicShareUnlock checks the password for a protected link. Failures are
+// throttled per link the same way logins are throttled per username.
+func (h *Handler) PublicShareUnlock(w http.ResponseWriter, r *http.Request) {
+	link := h.activeShareLink(w, r)
+	if link == nil {
+		return
+	}
+
+	if !link.HasPassword() {
+		h.sendShare(w, r, link)
+		return
+	}
+
+	ip := clientIP(r)
+	key := strconv.Itoa(link.ID)
+	if wait, ok := h.Limiter.AllowShareLink(ip, key); !ok {
+		h.renderSharePage(w, r, link, fmt.Sprintf("Too many failed attempts. Try again in %s.", wait.Round(time.Second)))
+		return
+	}
+
+	if !link.CheckPassword(r.FormValue("password")) {
+		h.Limiter.RecordShareLinkFailure(ip, key)
+		h.renderSharePage(w, r, link, "Incorrect password")
+		return
+	}
+	h.Limiter.RecordShareLinkSuccess(key)
+
+	h.sendShare(w, r, link)
+}
+
+// sendShare streams the linked file and counts the download. The link stops
+// working if its creator has since lost download access to the file.
+func (h *Handler) sendShare(w http.ResponseWriter, r *http.Request, link *database.ShareLink) {
+	level, err := h.DB.GetFileAccessLevel(link.CreatedBy, link.FileID)
+	if err != nil {
+		http.Error(w, "Failed to check access", http.StatusInternalServerError)
+		return
+	}
+	if !database.GrantAllows(level, database.GrantDownload) {
+		h.renderError(w, r, http.StatusNotFound, "Link not available",
+			"This link is no longer valid.")
+		return
+	}
+
+	file, err := h.DB.GetFileByID(link.FileID)
+	if err != nil {
+		http.Error(w, "File not found", http.StatusNotFound)
+		return
+	}
+
+	counted, err := h.DB.RecordShareDownload(link.ID)
+	if err != nil {
+		http.Error(w, "Failed to record download", http.StatusInternalServerError)
+		return
+	}
+	if !counted {
+		h.renderError(w, r, http.StatusNotFound, "Link not available",
+			"This link has reached its download limit.")
+		return
+	}
+
+	h.audit("anonymous", "share.download", fmt.Sprintf("downloaded file %s through share link %d from %s", file.Name, link.ID, clientIP(r)))
+
+	sendAttachment(w, file)
+}
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"backup_server/internal/auth"
+	"backup_server/internal/database"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// managedFile loads the file named by the id form value and checks that the
+// signed-in user may change who it is shared with: they hold a manage grant
+// on it, manage the group that owns it, or have the manage_files permission.
+// It writes the error response and returns nil if not.
+func (h *Handler) managedFile(w http.ResponseWriter, r *http.Request) *database.File {
+	session := r.Context().Value("session").(*auth.Session)
+
+	fileID, err := strconv.Atoi(r.FormValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid file ID", http.StatusBadRequest)
+		return nil
+	}
+
+	file, err := h.DB.GetFileByID(fileID)
+	if err != nil {
+		http.Error(w, "File not found", http.StatusNotFound)
+		return nil
+	}
+
+	level, err := h.DB.GetFileAccessLevel(session.UserID, file.ID)
+	if err != nil {
+		http.Error(w, "Failed to check access", http.StatusInternalServerError)
+		return nil
+	}
+
+	if !database.GrantAllows(level, database.GrantManage) {
+		allowed, err := h.DB.IsGroupManager(session.UserID, file.GroupID)
+		if err == nil && !allowed {
+			allowed, err = h.DB.UserHasPermission(session.UserID, auth.PermManageFiles)
+		}
+		if err != nil {
+			http.Error(w, "Failed to check access", http.StatusInternalServerError)
+			return nil
+		}
+		if !allowed {
+			h.renderError(w, r, http.StatusForbidden, "Access denied",
+				"You do not have permission to manage sharing for this file.")
+			return nil
+		}
+	}
+
+	return file
+}
+
+func (h *Handler) FileSharingPage(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	file := h.managedFile(w, r)
+	if file == nil {
+		return
+	}
+
+	grants, err := h.DB.GetFileGrants(file.ID)
+	if err != nil {
+		http.Error(w, "Failed to load sharing", http.StatusInternalServerError)
+		return
+	}
+
+	groups, err := h.DB.GetAllGroups()
+	if err != nil {
+		http.Error(w, "Failed to load groups", http.StatusInternalServerError)
+		return
+	}
+
+	users, err := h.DB.GetAllUsers()
+	if err != nil {
+		http.Error(w, "Failed to load users", http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Username": session.Username,
+		"File":     file,
+		"Grants":   grants,
+		"Groups":   groups,
+		"Users":    users,
+		"Levels":   database.GrantLevels,
+	}
+
+	if msg := r.URL.Query().Get("success"); msg != "" {
+		data["Message"] = msg
+		data["Success"] = true
+	} else if msg := r.URL.Query().Get("error"); msg != "" {
+		data["Message"] = msg
+		data["Success"] = false
+	}
+
+	h.render(w, r, "file_sharing.html", data)
+}
+
+func (h *Handler) FileGrant(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	file := h.managedFile(w, r)
+	if file == nil {
+		return
+	}
+
+	back := fmt.Sprintf("/files/share?id=%d", file.ID)
+	level := r.FormValue("level")
+	if !database.ValidGrantLevel(level) {
+		http.Redirect(w, r, back+"&error=Unknown+access+level", http.StatusSeeOther)
+		return
+	}
+
+	groupID, _ := strconv.Atoi(r.FormValue("group_id"))
+	userID, _ := strconv.Atoi(r.FormValue("user_id"))
+
+	var err error
+	var target string
+	switch {
+	case groupID > 0:
+		group, lookupErr := h.DB.GetGroupByID(groupID)
+		if lookupErr != nil {
+			http.Redirect(w, r, back+"&error=Group+not+found", http.StatusSeeOther)
+			return
+		}
+		err = h.DB.GrantFileToGroup(file.ID, group.ID, level)
+		target = "group " + group.Name
+	case userID > 0:
+		user, lookupErr := h.DB.GetUserByID(userID)
+		if lookupErr != nil {
+			http.Redirect(w, r, back+"&error=User+not+found", http.StatusSeeOther)
+			return
+		}
+		err = h.DB.GrantFileToUser(file.ID, user.ID, level)
+		target = "user " + user.Username
+	default:
+		http.Redirect(w, r, back+"&error=Choose+a+group+or+a+user", http.StatusSeeOther)
+		return
+	}
+
+	if err != nil {
+		log.Printf("Failed to share file %d: %v", file.ID, err)
+		http.Redirect(w, r, back+"&error=Failed+to+share+file", http.StatusSeeOther)
+		return
+	}
+
+	h.audit(session.Username, "file.grant", fmt.Sprintf("granted %s access to %s on file %s", level, target, file.Name))
+
+	http.Redirect(w, r, back+"&success=File+shared", http.StatusSeeOther)
+}
+
+func (h *Handler) FileRevokeGrant(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	file := h.managedFile(w, r)
+	if file == nil {
+		return
+	}
+
+	back := fmt.Sprintf("/files/share?id=%d", file.ID)
+	grantID, _ := strconv.Atoi(r.FormValue("grant_id"))
+
+	if err := h.DB.RevokeFileGrant(file.ID, grantID); err != nil {
+		log.Printf("Failed to revoke grant %d on file %d: %v", grantID, file.ID, err)
+		http.Redirect(w, r, back+"&error=Failed+to+remove+access", http.StatusSeeOther)
+		return
+	}
+
+	h.audit(session.Username, "file.revoke", fmt.Sprintf("removed grant %d on file %s", grantID, file.Name))
+
+	http.Redirect(w, r, back+"&success=Access+removed", http.StatusSeeOther)
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"backup_server/internal/database"
+	"backup_server/internal/terraria"
+	"errors"
+	"log"
+	"os"
+)
+
+// sidecarInfo describes the tModLoader sidecar kept with a save.
+type sidecarInfo struct {
+	Name    string
+	Mods    *terraria.ModData
+	Problem string
+}
+
+// sidecars finds the tModLoader sidecars of the files and reads the mods
+// they record. Files without a sidecar are left out.
+func (h *Handler) sidecars(files []database.File) map[int]*sidecarInfo {
+	infos := make(map[int]*sidecarInfo)
+	for _, f := range files {
+		path, name := f.Sidecar()
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+
+		info := &sidecarInfo{Name: name}
+		mods, err := h.ModData.Get(path)
+		if err != nil {
+			info.Problem = modDataProblem(err)
+		} else {
+			info.Mods = mods
+		}
+		infos[f.ID] = info
+	}
+	return infos
+}
+
+// sidecar is sidecars for one file, or nil if it has none.
+func (h *Handler) sidecar(file *database.File) *sidecarInfo {
+	return h.sidecars([]database.File{*file})[file.ID]
+}
+
+func modDataProblem(err error) string {
+	if errors.Is(err, terraria.ErrNotNBT) {
+		return "not a tModLoader file"
+	}
+	log.Printf("Failed to read tModLoader data: %v", err)
+	return "unreadable"
+}
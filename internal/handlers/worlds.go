@@ -0,0 +1,380 @@
+package handlers
+
+import (
+	"archive/zip"
+	"backup_server/internal/auth"
+	"backup_server/internal/database"
+	"backup_server/internal/terraria"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+func isWorldFile(name string) bool {
+	return strings.HasSuffix(name, ".wld")
+}
+
+// worldHeaders reads the headers of the world files among files. Files that
+// cannot be read get a short reason in the second map instead.
+func (h *Handler) worldHeaders(files []database.File) (map[int]*terraria.WorldHeader, map[int]string) {
+	headers := make(map[int]*terraria.WorldHeader)
+	problems := make(map[int]string)
+	for _, f := range files {
+		if !isWorldFile(f.Name) {
+			continue
+		}
+		header, err := h.WorldHeaders.Get(f.FilePath)
+		if err != nil {
+			problems[f.ID] = worldProblem(err)
+			continue
+		}
+		headers[f.ID] = header
+	}
+	return headers, problems
+}
+
+// worldProblem describes why a world's header could not be read without
+// exposing server paths.
+func worldProblem(err error) string {
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return "file missing"
+	case errors.Is(err, terraria.ErrNotWorld):
+		return "not a Terraria world"
+	case errors.Is(err, terraria.ErrUnsupportedVersion):
+		return "unsupported world version"
+	}
+	log.Printf("Failed to read world header: %v", err)
+	return "unreadable"
+}
+
+// apiFile is a file as listed by the JSON API.
+type apiFile struct {
+	ID          int                   `json:"id"`
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	Access      string                `json:"access"`
+	World       *terraria.WorldHeader `json:"world,omitempty"`
+	WorldError  string                `json:"world_error,omitempty"`
+	// Sidecar names the tModLoader sidecar downloaded with the file.
+	Sidecar      string            `json:"sidecar,omitempty"`
+	Mods         *terraria.ModData `json:"mods,omitempty"`
+	SidecarError string            `json:"sidecar_error,omitempty"`
+	// SHA256 and Size are what the file had when registered, to verify
+	// downloads against. A sidecar bundle's zip differs from them.
+	SHA256    string `json:"sha256,omitempty"`
+	Size      int64  `json:"size,omitempty"`
+	Integrity string `json:"integrity,omitempty"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Failed to write JSON response: %v", err)
+	}
+}
+
+func writeJSONError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+// APIFiles lists the files the user can see, with the header of each world.
+func (h *Handler) APIFiles(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	files, err := h.DB.GetFilesForUser(session.UserID)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to load files")
+		return
+	}
+
+	headers, problems := h.worldHeaders(files)
+	sidecars := h.sidecars(files)
+
+	list := make([]apiFile, 0, len(files))
+	for _, f := range files {
+		file := apiFile{
+			ID:          f.ID,
+			Name:        f.Name,
+			Description: f.Description,
+			Access:      f.Access,
+			World:       headers[f.ID],
+			WorldError:  problems[f.ID],
+			SHA256:      f.SHA256,
+			Size:        f.Size,
+			Integrity:   f.Integrity,
+		}
+		if sidecar := sidecars[f.ID]; sidecar != nil {
+			file.Sidecar = sidecar.Name
+			file.Mods = sidecar.Mods
+			file.SidecarError = sidecar.Problem
+		}
+		list = append(list, file)
+	}
+
+	writeJSON(w, http.StatusOK, list)
+}
+
+// APIWorld returns the header of one world file.
+func (h *Handler) APIWorld(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	file, status, msg := h.viewableWorld(session.UserID, r.URL.Query().Get("id"))
+	if file == nil {
+		writeJSONError(w, status, msg)
+		return
+	}
+
+	header, err := h.WorldHeaders.Get(file.FilePath)
+	if err != nil {
+		writeJSONError(w, http.StatusUnprocessableEntity, "World details unavailable: "+worldProblem(err))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, header)
+}
+
+// WorldMap serves a world's map as a PNG drawn on the server, or its
+// thumbnail with size=thumb. View access is enough, as for the map viewer.
+func (h *Handler) WorldMap(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	file, status, msg := h.viewableWorld(session.UserID, r.URL.Query().Get("id"))
+	if file == nil {
+		http.Error(w, msg, status)
+		return
+	}
+
+	render := h.Maps.Map
+	if r.URL.Query().Get("size") == "thumb" {
+		render = h.Maps.Thumbnail
+	}
+	path, sum, err := render(file.FilePath)
+	if err != nil {
+		http.Error(w, "Map unavailable: "+worldProblem(err), http.StatusUnprocessableEntity)
+		return
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		log.Printf("Failed to open map %s: %v", path, err)
+		http.Error(w, "Map unavailable", http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		http.Error(w, "Failed to read map info", http.StatusInternalServerError)
+		return
+	}
+
+	// The file record can be pointed at a new world at any time, so
+	// browsers revalidate against the content hash.
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.Header().Set("ETag", `"`+sum+`"`)
+	http.ServeContent(w, r, "", stat.ModTime(), f)
+}
+
+// viewableWorld loads the world file with the ID in raw if the user may view
+// it. On failure it returns the status and message to report.
+func (h *Handler) viewableWorld(userID int, raw string) (*database.File, int, string) {
+	return h.viewableFile(userID, raw, isWorldFile, "Not a world file")
+}
+
+// viewableFile is viewableWorld for any kind of file isKind accepts.
+func (h *Handler) viewableFile(userID int, raw string, isKind func(string) bool, notKind string) (*database.File, int, string) {
+	fileID, err := strconv.Atoi(raw)
+	if err != nil {
+		return nil, http.StatusBadRequest, "Invalid file ID"
+	}
+
+	file, err := h.DB.GetFileByID(fileID)
+	if err != nil {
+		return nil, http.StatusNotFound, "File not found"
+	}
+
+	level, err := h.DB.GetFileAccessLevel(userID, file.ID)
+	if err != nil || !database.GrantAllows(level, database.GrantView) {
+		return nil, http.StatusForbidden, "Access denied"
+	}
+
+	if !isKind(file.Name) {
+		return nil, http.StatusBadRequest, notKind
+	}
+	return file, 0, ""
+}
+
+// diffProblem is like worldProblem for comparisons.
+func diffProblem(err error) string {
+	if errors.Is(err, terraria.ErrSizeMismatch) {
+		return "the worlds are different sizes"
+	}
+	return worldProblem(err)
+}
+
+// WorldDiffPage compares two world files, a being the older. Without b it
+// lists the other worlds a can be compared with.
+func (h *Handler) WorldDiffPage(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	older, status, msg := h.viewableWorld(session.UserID, r.URL.Query().Get("a"))
+	if older == nil {
+		h.renderError(w, r, status, "Compare Worlds", msg)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Username": session.Username,
+		"Old":      older,
+	}
+
+	if r.URL.Query().Get("b") == "" {
+		files, err := h.DB.GetFilesForUser(session.UserID)
+		if err != nil {
+			h.renderError(w, r, http.StatusInternalServerError, "Compare Worlds", "Failed to load files")
+			return
+		}
+		var others []database.File
+		for _, f := range files {
+			if f.ID != older.ID && isWorldFile(f.Name) {
+				others = append(others, f)
+			}
+		}
+		data["Candidates"] = others
+		h.render(w, r, "worlds_diff.html", data)
+		return
+	}
+
+	newer, status, msg := h.viewableWorld(session.UserID, r.URL.Query().Get("b"))
+	if newer == nil {
+		h.renderError(w, r, status, "Compare Worlds", msg)
+		return
+	}
+	data["New"] = newer
+
+	_, diff, err := h.Maps.Diff(older.FilePath, newer.FilePath)
+	if err != nil {
+		data["Problem"] = diffProblem(err)
+	} else {
+		data["Diff"] = diff
+	}
+	h.render(w, r, "worlds_diff.html", data)
+}
+
+// WorldDiffImage serves the overlay PNG of a comparison: the newer world's
+// map dimmed, with added tiles green, removed red and changed yellow.
+func (h *Handler) WorldDiffImage(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	older, status, msg := h.viewableWorld(session.UserID, r.URL.Query().Get("a"))
+	if older == nil {
+		http.Error(w, msg, status)
+		return
+	}
+	newer, status, msg := h.viewableWorld(session.UserID, r.URL.Query().Get("b"))
+	if newer == nil {
+		http.Error(w, msg, status)
+		return
+	}
+
+	path, _, err := h.Maps.Diff(older.FilePath, newer.FilePath)
+	if err != nil {
+		http.Error(w, "Comparison unavailable: "+diffProblem(err), http.StatusUnprocessableEntity)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.Header().Set("ETag", `"`+strings.TrimSuffix(filepath.Base(path), ".png")+`"`)
+	http.ServeFile(w, r, path)
+}
+
+// APIWorldDiff returns the summary of a comparison as JSON.
+func (h *Handler) APIWorldDiff(w http.ResponseWriter, r *http.Request) {
+	session := r.Context().Value("session").(*auth.Session)
+
+	older, status, msg := h.viewableWorld(session.UserID, r.URL.Query().Get("a"))
+	if older == nil {
+		writeJSONError(w, status, msg)
+		return
+	}
+	newer, status, msg := h.viewableWorld(session.UserID, r.URL.Query().Get("b"))
+	if newer == nil {
+		writeJSONError(w, status, msg)
+		return
+	}
+
+	_, diff, err := h.Maps.Diff(older.FilePath, newer.FilePath)
+	if err != nil {
+		writeJSONError(w, http.StatusUnprocessableEntity, "Comparison unavailable: "+diffProblem(err))
+		return
+	}
+	writeJSON(w, http.StatusOK, diff)
+}
+
+// sendBundle sends a save and its tModLoader sidecar as one zip, named
+// after the save.
+func sendBundle(w http.ResponseWriter, file *database.File) {
+	sidecarPath, sidecarName := file.Sidecar()
+	entries := []struct{ path, name string }{
+		{file.FilePath, filepath.Base(file.Name)},
+		{sidecarPath, filepath.Base(sidecarName)},
+	}
+
+	// Open both first, so a missing file is still reported as an error
+	// rather than as a truncated zip.
+	files := make([]*os.File, len(entries))
+	for i, e := range entries {
+		f, err := os.Open(e.path)
+		if err != nil {
+			log.Printf("Failed to open file %s: %v", e.path, err)
+			http.Error(w, "File not accessible", http.StatusInternalServerError)
+			return
+		}
+		defer f.Close()
+		files[i] = f
+	}
+
+	name := strings.TrimSuffix(filepath.Base(file.Name), filepath.Ext(file.Name)) + ".zip"
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename*=UTF-8''%s", url.PathEscape(name)))
+	w.Header().Set("Content-Type", "application/zip")
+
+	zw := zip.NewWriter(w)
+	for i, e := range entries {
+		stat, err := files[i].Stat()
+		if err != nil {
+			log.Printf("Failed to read file info of %s: %v", e.path, err)
+			return
+		}
+		header, err := zip.FileInfoHeader(stat)
+		if err != nil {
+			log.Printf("Failed to bundle %s: %v", e.path, err)
+			return
+		}
+		header.Name = e.name
+		header.Method = zip.Deflate
+		entry, err := zw.CreateHeader(header)
+		if err != nil {
+			log.Printf("Failed to bundle %s: %v", e.path, err)
+			return
+		}
+		if _, err := io.Copy(entry, files[i]); err != nil {
+			log.Printf("Failed to bundle %s: %v", e.path, err)
+			return
+		}
+	}
+	if err := zw.Close(); err != nil {
+		log.Printf("Failed to finish bundle of %s: %v", file.Name, err)
+	}
+}
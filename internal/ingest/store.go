@@ -0,0 +1,189 @@
+package ingest
+
+import (
+	"backup_server/internal/database"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrChanged is returned when a file was written to while it was being
+// copied. Trying again once writes have stopped should succeed.
+var ErrChanged = errors.New("file changed while being copied")
+
+// Store copies files into managed storage, each copy a new version of the
+// file's record.
+type Store struct {
+	db  database.Repository
+	dir string
+
+	// mu guards paths, which holds a lock for each path being snapshotted,
+	// as the watcher and backup jobs may copy the same save at once.
+	mu    sync.Mutex
+	paths map[string]*pathLock
+}
+
+type pathLock struct {
+	sync.Mutex
+	users int
+}
+
+// NewStore keeps copies in the snapshots directory under storageDir.
+func NewStore(db database.Repository, storageDir string) *Store {
+	dir := filepath.Join(storageDir, "snapshots")
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	return &Store{db: db, dir: dir, paths: make(map[string]*pathLock)}
+}
+
+// Snapshot copies the file at path, with its tModLoader sidecar if it has
+// one, unless both are the same as in the newest snapshot of path. Paths
+// without a file record get one owned by groupID, and actor is audited as
+// having registered it. It returns the new version, or nil if nothing
+// changed.
+func (s *Store) Snapshot(path string, groupID int, actor string) (*database.FileVersion, error) {
+	defer s.lock(path)()
+
+	sum, err := hashFile(path)
+	if err != nil {
+		return nil, err
+	}
+	sidecar, _ := database.File{FilePath: path}.Sidecar()
+	sidecarSum := ""
+	if _, err := os.Stat(sidecar); err == nil {
+		if sidecarSum, err = hashFile(sidecar); err != nil {
+			return nil, err
+		}
+	} else {
+		sidecar = ""
+	}
+
+	latest, err := s.db.GetLatestFileVersion(path)
+	if err != nil {
+		return nil, err
+	}
+	if latest != nil && latest.SHA256 == sum && latest.SidecarSHA256 == sidecarSum {
+		return nil, nil
+	}
+
+	now := time.Now()
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp(s.dir, now.UTC().Format("20060102T150405Z")+"-"+sum[:12]+"-")
+	if err != nil {
+		return nil, err
+	}
+	blob := filepath.Join(dir, filepath.Base(path))
+	size, copied, err := copyFile(path, blob)
+	if err == nil && sidecar != "" {
+		var sidecarCopied string
+		_, sidecarCopied, err = copyFile(sidecar, filepath.Join(dir, filepath.Base(sidecar)))
+		if err == nil && sidecarCopied != sidecarSum {
+			copied = ""
+		}
+	}
+	if err == nil && copied != sum {
+		err = ErrChanged
+	}
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	v := database.FileVersion{
+		SourcePath:    path,
+		BlobPath:      blob,
+		Size:          size,
+		SHA256:        sum,
+		SidecarSHA256: sidecarSum,
+		CreatedAt:     now,
+	}
+	fileID, created, err := s.db.AddFileVersion(v, filepath.Base(path), groupID)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	v.FileID = fileID
+
+	if created {
+		detail := fmt.Sprintf("registered %s as file %d for group %d", path, fileID, groupID)
+		if err := s.db.AddAuditEntry(actor, "file.create", detail); err != nil {
+			log.Printf("Failed to write audit entry file.create for %s: %v", actor, err)
+		}
+	}
+	log.Printf("Snapshotted %s to %s", path, blob)
+	return &v, nil
+}
+
+// lock waits until no other snapshot of path is running, and returns the
+// function that lets the next one start.
+func (s *Store) lock(path string) func() {
+	s.mu.Lock()
+	l, ok := s.paths[path]
+	if !ok {
+		l = &pathLock{}
+		s.paths[path] = l
+	}
+	l.users++
+	s.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		s.mu.Lock()
+		if l.users--; l.users == 0 {
+			delete(s.paths, path)
+		}
+		s.mu.Unlock()
+	}
+}
+
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// copyFile copies src to dst and returns the size and SHA-256 of what it
+// copied.
+func copyFile(src, dst string) (int64, string, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return 0, "", err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return 0, "", err
+	}
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(out, h), in)
+	if err == nil {
+		err = out.Sync()
+	}
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return 0, "", err
+	}
+	return n, hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,333 @@
+// Package ingest copies Terraria saves out of watched directories into
+// managed storage, keeping every change as a new version of its file record.
+package ingest
+
+import (
+	"backup_server/internal/config"
+	"backup_server/internal/database"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watcher snapshots the saves in a set of directories once writes to them
+// have settled. Directories are watched for events where the system allows
+// it and scanned on an interval otherwise.
+type Watcher struct {
+	db       database.Repository
+	store    *Store
+	dirs     []string
+	group    string
+	settle   time.Duration
+	poll     time.Duration
+	ingested func()
+
+	// pending holds the saves waiting for writes to settle and seen the stat
+	// of each save when it was last snapshotted, to notice changes while
+	// polling. Only Run uses them.
+	pending map[string]*pendingSave
+	seen    map[string]signature
+
+	mu     sync.Mutex
+	status Status
+}
+
+// Status is what administrators are shown about the watcher.
+type Status struct {
+	Dirs  []Dir
+	Group string
+	// Snapshots counts the snapshots taken since the server started.
+	Snapshots        int
+	LastSnapshot     time.Time
+	LastSnapshotPath string
+	LastError        string
+	LastErrorAt      time.Time
+}
+
+// Dir is a watched directory and how it is watched: "events" or "polling".
+type Dir struct {
+	Path string
+	Mode string
+}
+
+// signature is what a stat says about a save and its sidecar. A save whose
+// signature stays the same for the settle time is taken to be written.
+type signature struct {
+	size, sidecarSize       int64
+	modTime, sidecarModTime time.Time
+}
+
+type pendingSave struct {
+	sig signature
+	due time.Time
+}
+
+// New returns a watcher that, once Run, watches the directories in
+// cfg.WatchDirs, snapshotting into store and calling ingested after each
+// snapshot. It returns nil when no directories are configured.
+func New(db database.Repository, store *Store, cfg *config.Config, ingested func()) *Watcher {
+	var dirs []string
+	for _, dir := range filepath.SplitList(cfg.WatchDirs) {
+		if dir == "" {
+			continue
+		}
+		if abs, err := filepath.Abs(dir); err == nil {
+			dir = abs
+		}
+		dirs = append(dirs, dir)
+	}
+	if len(dirs) == 0 {
+		return nil
+	}
+
+	w := &Watcher{
+		db:       db,
+		store:    store,
+		dirs:     dirs,
+		group:    cfg.WatchGroup,
+		settle:   cfg.WatchSettle,
+		poll:     cfg.WatchPollInterval,
+		ingested: ingested,
+		pending:  make(map[string]*pendingSave),
+		seen:     make(map[string]signature),
+		status:   Status{Group: cfg.WatchGroup},
+	}
+	return w
+}
+
+// Status reports how the directories are watched and the latest snapshot
+// and error.
+func (w *Watcher) Status() Status {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	s := w.status
+	s.Dirs = append([]Dir(nil), s.Dirs...)
+	return s
+}
+
+// Run watches the directories until ctx is cancelled.
+func (w *Watcher) Run(ctx context.Context) {
+	var events chan fsnotify.Event
+	var errs chan error
+	polled := w.dirs
+
+	notify, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Printf("Watching for file events is unavailable, polling instead: %v", err)
+	} else {
+		defer notify.Close()
+		events, errs = notify.Events, notify.Errors
+		polled = nil
+	}
+
+	var dirs []Dir
+	for _, dir := range w.dirs {
+		mode := "polling"
+		if notify != nil {
+			if err := notify.Add(dir); err != nil {
+				log.Printf("Cannot watch %s for events, polling instead: %v", dir, err)
+				polled = append(polled, dir)
+			} else {
+				mode = "events"
+			}
+		}
+		dirs = append(dirs, Dir{Path: dir, Mode: mode})
+	}
+	w.mu.Lock()
+	w.status.Dirs = dirs
+	w.mu.Unlock()
+
+	// Saves changed while the server was down are found by a first scan.
+	for _, dir := range w.dirs {
+		w.scan(dir)
+	}
+
+	poll := time.NewTicker(w.poll)
+	defer poll.Stop()
+	flush := time.NewTicker(max(w.settle/4, 100*time.Millisecond))
+	defer flush.Stop()
+
+	for {
+		select {
+		case ev := <-events:
+			if ev.Has(fsnotify.Create) || ev.Has(fsnotify.Write) {
+				w.schedule(ev.Name)
+			}
+		case err := <-errs:
+			w.fail(fmt.Errorf("watch: %w", err))
+		case <-poll.C:
+			for _, dir := range polled {
+				w.scan(dir)
+			}
+		case <-flush.C:
+			w.flush()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// savePath returns the save a changed file stands for: itself for worlds
+// and players, the save it belongs with for tModLoader sidecars, and an
+// empty string for anything else.
+func savePath(path string) string {
+	switch filepath.Ext(path) {
+	case ".wld", ".plr":
+		return path
+	}
+	return database.SidecarSave(path)
+}
+
+// scan schedules the saves in dir that changed since they were last seen.
+func (w *Watcher) scan(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		w.fail(err)
+		return
+	}
+	for _, entry := range entries {
+		path := savePath(filepath.Join(dir, entry.Name()))
+		if path == "" || !entry.Type().IsRegular() {
+			continue
+		}
+		sig, err := stat(path)
+		if err != nil {
+			continue
+		}
+		if seen, ok := w.seen[path]; !ok || seen != sig {
+			w.schedule(path)
+		}
+	}
+}
+
+// schedule puts off snapshotting a changed save until it has settled.
+func (w *Watcher) schedule(path string) {
+	path = savePath(path)
+	if path == "" {
+		return
+	}
+	sig, _ := stat(path)
+	w.pending[path] = &pendingSave{sig: sig, due: time.Now().Add(w.settle)}
+}
+
+// flush snapshots the pending saves that have not changed for the settle
+// time. Saves still being written wait another settle time.
+func (w *Watcher) flush() {
+	now := time.Now()
+	for path, p := range w.pending {
+		if now.Before(p.due) {
+			continue
+		}
+		sig, err := stat(path)
+		if err != nil {
+			// Gone, or only a sidecar was written; a later event brings
+			// the save back.
+			delete(w.pending, path)
+			continue
+		}
+		if sig != p.sig {
+			p.sig = sig
+			p.due = now.Add(w.settle)
+			continue
+		}
+
+		delete(w.pending, path)
+		err = w.ingest(path)
+		if errors.Is(err, ErrChanged) {
+			// Still being written after all; try again once it settles.
+			w.schedule(path)
+			continue
+		}
+		if err != nil {
+			// Tried again after the poll interval, even in directories
+			// watched for events.
+			w.fail(fmt.Errorf("snapshot %s: %w", path, err))
+			w.pending[path] = &pendingSave{sig: sig, due: now.Add(w.poll)}
+			continue
+		}
+		w.seen[path] = sig
+	}
+}
+
+func stat(path string) (signature, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return signature{}, err
+	}
+	sig := signature{size: info.Size(), modTime: info.ModTime()}
+	sidecar, _ := database.File{FilePath: path}.Sidecar()
+	if info, err := os.Stat(sidecar); err == nil {
+		sig.sidecarSize = info.Size()
+		sig.sidecarModTime = info.ModTime()
+	}
+	return sig, nil
+}
+
+// ingest snapshots a save that has settled.
+func (w *Watcher) ingest(path string) error {
+	groupID, err := w.groupID()
+	if err != nil {
+		return err
+	}
+
+	v, err := w.store.Snapshot(path, groupID, "watcher")
+	if err != nil || v == nil {
+		return err
+	}
+
+	w.mu.Lock()
+	w.status.Snapshots++
+	w.status.LastSnapshot = v.CreatedAt
+	w.status.LastSnapshotPath = path
+	w.mu.Unlock()
+
+	if w.ingested != nil {
+		w.ingested()
+	}
+	return nil
+}
+
+// CheckGroup reports an error if the group new saves are registered for is
+// not set or does not exist, so that the server refuses to start rather
+// than failing every snapshot.
+func (w *Watcher) CheckGroup() error {
+	if w.group == "" {
+		return errors.New("no group set for saves seen for the first time")
+	}
+	_, err := w.groupID()
+	return err
+}
+
+// groupID looks up the configured group each time, so it can be created
+// or renamed while the server runs.
+func (w *Watcher) groupID() (int, error) {
+	groups, err := w.db.GetAllGroups()
+	if err != nil {
+		return 0, err
+	}
+	for _, g := range groups {
+		if g.Name == w.group {
+			return g.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("group %q does not exist", w.group)
+}
+
+// fail records a problem. It is logged only when it differs from the last
+// one, as a missing directory fails every poll.
+func (w *Watcher) fail(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err.Error() != w.status.LastError {
+		log.Printf("Watcher: %v", err)
+	}
+	w.status.LastError = err.Error()
+	w.status.LastErrorAt = time.Now()
+}
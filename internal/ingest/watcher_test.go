@@ -0,0 +1,164 @@
+package ingest
+
+import (
+	"backup_server/internal/config"
+	"backup_server/internal/database"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func testDB(t *testing.T) *database.DB {
+	t.Helper()
+	db, err := database.InitDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("InitDB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func writeSave(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestWatcherRetriesFailedSnapshots(t *testing.T) {
+	db := testDB(t)
+	dir := t.TempDir()
+	path := writeSave(t, dir, "world.wld", "first")
+	cfg := &config.Config{WatchDirs: dir, WatchGroup: "watched", WatchPollInterval: time.Hour}
+	w := New(db, NewStore(db, t.TempDir()), cfg, nil)
+
+	// The group does not exist yet, so the snapshot fails.
+	w.scan(dir)
+	w.flush()
+	if _, ok := w.seen[path]; ok {
+		t.Fatal("save marked seen after a failed snapshot")
+	}
+	p, ok := w.pending[path]
+	if !ok || time.Until(p.due) < 59*time.Minute {
+		t.Fatalf("failed save not retried after the poll interval: %+v", p)
+	}
+	if s := w.Status(); s.Snapshots != 0 || s.LastError == "" {
+		t.Errorf("status after a failure = %+v", s)
+	}
+
+	if _, err := db.CreateGroup("watched"); err != nil {
+		t.Fatal(err)
+	}
+	p.due = time.Now()
+	w.flush()
+	if _, ok := w.seen[path]; !ok {
+		t.Error("save not marked seen after its snapshot")
+	}
+	if len(w.pending) != 0 {
+		t.Errorf("pending after the snapshot: %v", w.pending)
+	}
+	if s := w.Status(); s.Snapshots != 1 || s.LastSnapshotPath != path {
+		t.Errorf("status after the retry = %+v", s)
+	}
+
+	// Unchanged saves are not scheduled again.
+	w.scan(dir)
+	if len(w.pending) != 0 {
+		t.Errorf("unchanged save scheduled: %v", w.pending)
+	}
+}
+
+func TestSnapshotsOfOnePathDoNotOverlap(t *testing.T) {
+	db := testDB(t)
+	groupID, err := db.CreateGroup("jobs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := writeSave(t, t.TempDir(), "world.wld", "contents")
+	store := NewStore(db, t.TempDir())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := store.Snapshot(path, int(groupID), "test"); err != nil {
+				t.Errorf("Snapshot: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	summaries, err := db.GetFileVersionSummaries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summaries) != 1 {
+		t.Fatalf("snapshots went to %d files, want 1", len(summaries))
+	}
+	for _, s := range summaries {
+		if s.Count != 1 {
+			t.Errorf("%d snapshots of an unchanged save, want 1", s.Count)
+		}
+	}
+	if len(store.paths) != 0 {
+		t.Errorf("path locks left behind: %v", store.paths)
+	}
+}
+
+func TestWatcherWaitsForSavesChangedWhileCopied(t *testing.T) {
+	// Every read of this file gives a new UUID, like a save written to
+	// while it is being copied.
+	const changing = "/proc/sys/kernel/random/uuid"
+	if _, err := os.Stat(changing); err != nil {
+		t.Skipf("no file that changes on every read: %v", err)
+	}
+	db := testDB(t)
+	if _, err := db.CreateGroup("watched"); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "world.wld")
+	if err := os.Symlink(changing, path); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{WatchDirs: dir, WatchGroup: "watched", WatchSettle: time.Hour, WatchPollInterval: time.Minute}
+	w := New(db, NewStore(db, t.TempDir()), cfg, nil)
+
+	w.schedule(path)
+	w.pending[path].due = time.Now()
+	w.flush()
+	if _, ok := w.seen[path]; ok {
+		t.Error("save marked seen after it changed while being copied")
+	}
+	p, ok := w.pending[path]
+	if !ok || time.Until(p.due) < 59*time.Minute {
+		t.Errorf("changed save not tried again after the settle time: %+v", p)
+	}
+	if s := w.Status(); s.Snapshots != 0 || s.LastError != "" {
+		t.Errorf("status after a changed save = %+v", s)
+	}
+}
+
+func TestWatcherCheckGroup(t *testing.T) {
+	db := testDB(t)
+	dir := t.TempDir()
+	for _, group := range []string{"", "missing"} {
+		w := New(db, NewStore(db, t.TempDir()), &config.Config{WatchDirs: dir, WatchGroup: group}, nil)
+		if err := w.CheckGroup(); err == nil {
+			t.Errorf("group %q accepted", group)
+		}
+	}
+
+	if _, err := db.CreateGroup("watched"); err != nil {
+		t.Fatal(err)
+	}
+	w := New(db, NewStore(db, t.TempDir()), &config.Config{WatchDirs: dir, WatchGroup: "watched"}, nil)
+	if err := w.CheckGroup(); err != nil {
+		t.Errorf("CheckGroup: %v", err)
+	}
+}
@@ -0,0 +1,196 @@
+// Package integrity records checksums of stored files and checks them in
+// the background, to catch files that rot or are replaced on disk.
+package integrity
+
+import (
+	"backup_server/internal/database"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// Sum returns the SHA-256 and size of the file at path.
+func Sum(path string) (string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
+
+// problems describes the failed integrity statuses for logs.
+var problems = map[string]string{
+	database.IntegrityMismatch:   "no longer matches its checksum",
+	database.IntegrityMissing:    "is missing",
+	database.IntegrityUnreadable: "cannot be read",
+}
+
+// failure is the integrity status for a file Sum could not read.
+func failure(err error) string {
+	if errors.Is(err, fs.ErrNotExist) {
+		return database.IntegrityMissing
+	}
+	return database.IntegrityUnreadable
+}
+
+// Record stores the checksum a file is verified against from now on. A file
+// that cannot be read is marked missing or unreadable, and its checksum is
+// recorded the first time a scrub can read it.
+func Record(db database.Repository, fileID int, path string) error {
+	now := time.Now()
+	sum, size, err := Sum(path)
+	if err != nil {
+		return db.SetFileChecksum(fileID, "", 0, failure(err), now)
+	}
+	return db.SetFileChecksum(fileID, sum, size, database.IntegrityOK, now)
+}
+
+// Scrubber hashes every stored file again once its last check is interval
+// old, and records files that no longer match their checksum or are gone.
+// Because the time of each check is stored, restarting the server does not
+// put checks off.
+type Scrubber struct {
+	db       database.Repository
+	interval time.Duration
+	wake     chan struct{}
+
+	// mu guards the state shown to administrators.
+	mu        sync.Mutex
+	lastRun   time.Time
+	scrubbing bool
+}
+
+// New returns a scrubber that, once Run, checks files every interval, and
+// checks them all whenever Refresh is called.
+func New(db database.Repository, interval time.Duration) *Scrubber {
+	return &Scrubber{
+		db:       db,
+		interval: interval,
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Refresh asks for every file to be checked soon.
+func (s *Scrubber) Refresh() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Status reports when the last pass finished and whether one is running.
+func (s *Scrubber) Status() (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastRun, s.scrubbing
+}
+
+// Run checks files until ctx is cancelled.
+func (s *Scrubber) Run(ctx context.Context) {
+	// Files come due at different times, so look for them more often than
+	// the interval.
+	ticker := time.NewTicker(min(s.interval, time.Hour))
+	defer ticker.Stop()
+
+	all := false
+	for {
+		s.scrubAll(ctx, all)
+		select {
+		case <-ticker.C:
+			all = false
+		case <-s.wake:
+			all = true
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// scrubAll checks the files whose last check is older than the interval, or
+// every file if all is set, stopping early if ctx is cancelled.
+func (s *Scrubber) scrubAll(ctx context.Context, all bool) {
+	s.mu.Lock()
+	s.scrubbing = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.scrubbing = false
+		s.lastRun = time.Now()
+		s.mu.Unlock()
+	}()
+
+	files, err := s.db.GetAllFiles()
+	if err != nil {
+		log.Printf("Scrub: failed to list files: %v", err)
+		return
+	}
+
+	for _, f := range files {
+		if ctx.Err() != nil {
+			return
+		}
+		if !all && !f.CheckedAt.IsZero() && time.Since(f.CheckedAt) < s.interval {
+			continue
+		}
+		if err := s.scrub(f); err != nil {
+			log.Printf("Scrub: failed to record result for %s: %v", f.Name, err)
+		}
+	}
+}
+
+// scrub checks one file. Files without a checksum, such as ones registered
+// before checksums were recorded, have one recorded once they can be read.
+// That content is trusted as it is found, so the checksum is audited for
+// comparing with a copy known to be good.
+func (s *Scrubber) scrub(f database.File) error {
+	now := time.Now()
+	sum, size, err := Sum(f.FilePath)
+	if f.SHA256 == "" {
+		if err != nil {
+			return s.db.SetFileIntegrity(f.ID, f.FilePath, failure(err), now)
+		}
+		if err := s.db.SetFileChecksum(f.ID, sum, size, database.IntegrityOK, now); err != nil {
+			return err
+		}
+		detail := fmt.Sprintf("recorded first checksum %s for file %s (%d) at %s", sum, f.Name, f.ID, f.FilePath)
+		if err := s.db.AddAuditEntry("scrubber", "file.checksum", detail); err != nil {
+			log.Printf("Failed to write audit entry file.checksum for scrubber: %v", err)
+		}
+		return nil
+	}
+
+	status := database.IntegrityOK
+	switch {
+	case err != nil:
+		status = failure(err)
+	case sum != f.SHA256 || size != f.Size:
+		status = database.IntegrityMismatch
+	}
+
+	if status != database.IntegrityOK && status != f.Integrity {
+		detail := fmt.Sprintf("file %s (%d) at %s %s", f.Name, f.ID, f.FilePath, problems[status])
+		if err != nil {
+			detail += ": " + err.Error()
+		}
+		log.Printf("Scrub: %s", detail)
+		if err := s.db.AddAuditEntry("scrubber", "file.integrity", detail); err != nil {
+			log.Printf("Failed to write audit entry file.integrity for scrubber: %v", err)
+		}
+	}
+	return s.db.SetFileIntegrity(f.ID, f.FilePath, status, now)
+}
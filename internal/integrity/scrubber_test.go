@@ -0,0 +1,144 @@
+package integrity
+
+import (
+	"backup_server/internal/database"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testFile registers a file holding contents, without a checksum.
+func testFile(t *testing.T, contents string) (*database.DB, *Scrubber, string, int) {
+	t.Helper()
+	db, err := database.InitDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("InitDB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	groupID, err := db.CreateGroup("owners")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "world.wld")
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fileID, err := db.AddFile("world.wld", path, int(groupID), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, New(db, time.Hour), path, fileID
+}
+
+// scrub checks the file and returns its record and the audit entries
+// written by the check.
+func scrub(t *testing.T, db *database.DB, s *Scrubber, fileID int) (*database.File, []database.AuditEntry) {
+	t.Helper()
+	before, err := db.GetAuditEntries(100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := db.GetFileByID(fileID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.scrub(*f); err != nil {
+		t.Fatalf("scrub: %v", err)
+	}
+	after, err := db.GetAuditEntries(100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f, err = db.GetFileByID(fileID); err != nil {
+		t.Fatal(err)
+	}
+	return f, after[:len(after)-len(before)]
+}
+
+func TestScrub(t *testing.T) {
+	db, s, path, fileID := testFile(t, "contents")
+	if err := Record(db, fileID, path); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+
+	steps := []struct {
+		name    string
+		change  func() error
+		status  string
+		audited bool
+	}{
+		{"unchanged", func() error { return nil }, database.IntegrityOK, false},
+		{"rewritten", func() error { return os.WriteFile(path, []byte("CONTENTS"), 0o644) }, database.IntegrityMismatch, true},
+		// The same problem is audited once.
+		{"still rewritten", func() error { return nil }, database.IntegrityMismatch, false},
+		{"grown", func() error { return os.WriteFile(path, []byte("contents and more"), 0o644) }, database.IntegrityMismatch, false},
+		{"deleted", func() error { return os.Remove(path) }, database.IntegrityMissing, true},
+		{"replaced by a directory", func() error { return os.Mkdir(path, 0o755) }, database.IntegrityUnreadable, true},
+		{"restored", func() error {
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+			return os.WriteFile(path, []byte("contents"), 0o644)
+		}, database.IntegrityOK, false},
+	}
+	for _, step := range steps {
+		if err := step.change(); err != nil {
+			t.Fatal(err)
+		}
+		f, audit := scrub(t, db, s, fileID)
+		if f.Integrity != step.status {
+			t.Errorf("%s: status %q, want %q", step.name, f.Integrity, step.status)
+		}
+		if audited := len(audit) > 0; audited != step.audited {
+			t.Errorf("%s: audit entries %+v, want audited %v", step.name, audit, step.audited)
+		}
+		for _, entry := range audit {
+			if entry.Action != "file.integrity" {
+				t.Errorf("%s: audited as %s", step.name, entry.Action)
+			}
+		}
+		// The checksum to check against stays the recorded one.
+		if f.SHA256 != "d1b2a59fbea7e20077af9f91b27e95e865061b270be03ff539ab3b73587882e8" || f.Size != 8 {
+			t.Errorf("%s: checksum changed to %s (%d bytes)", step.name, f.SHA256, f.Size)
+		}
+	}
+}
+
+func TestScrubRecordsFirstChecksum(t *testing.T) {
+	db, s, path, fileID := testFile(t, "contents")
+
+	// A file unreadable when it was registered has no checksum yet.
+	if err := os.Rename(path, path+".away"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Record(db, fileID, path); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	f, audit := scrub(t, db, s, fileID)
+	if f.SHA256 != "" || f.Integrity != database.IntegrityMissing || len(audit) != 0 {
+		t.Fatalf("missing file without a checksum: %+v, audit %+v", f, audit)
+	}
+
+	// The first successful check records the content it finds, and audits
+	// the checksum it trusted.
+	if err := os.Rename(path+".away", path); err != nil {
+		t.Fatal(err)
+	}
+	f, audit = scrub(t, db, s, fileID)
+	if f.SHA256 != "d1b2a59fbea7e20077af9f91b27e95e865061b270be03ff539ab3b73587882e8" || f.Size != 8 || f.Integrity != database.IntegrityOK {
+		t.Errorf("after the first read: %+v", f)
+	}
+	if len(audit) != 1 || audit[0].Action != "file.checksum" {
+		t.Errorf("audit entries %+v, want one file.checksum", audit)
+	}
+
+	// From then on the file is checked against it.
+	if err := os.WriteFile(path, []byte("CONTENTS"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if f, _ := scrub(t, db, s, fileID); f.Integrity != database.IntegrityMismatch {
+		t.Errorf("changed after its first checksum: status %q", f.Integrity)
+	}
+}
@@ -0,0 +1,390 @@
+// Package jobs runs the backup jobs defined in the admin panel, which copy
+// files from the host into managed storage on a cron schedule.
+package jobs
+
+import (
+	"backup_server/internal/config"
+	"backup_server/internal/database"
+	"backup_server/internal/ingest"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// checkInterval is how often schedules are checked. Cron schedules have a
+// resolution of a minute.
+const checkInterval = 20 * time.Second
+
+// Scheduler starts jobs when their schedule comes round, retries failed
+// runs and alerts when a job has not succeeded within its window.
+type Scheduler struct {
+	db         database.Repository
+	store      *ingest.Store
+	retryDelay time.Duration
+	webhook    string
+	ingested   func()
+	wake       chan struct{}
+	// now is time.Now outside tests.
+	now func() time.Time
+	// jobs tracks the runs and alerts in progress, which Run waits for.
+	jobs sync.WaitGroup
+
+	// mu guards the jobs' in-memory state: which are running, which wait
+	// to be retried or were asked to run now, and which have been alerted
+	// about.
+	mu      sync.Mutex
+	running map[int]bool
+	retries map[int]retry
+	manual  map[int]bool
+	alerted map[int]bool
+}
+
+type retry struct {
+	attempt int
+	at      time.Time
+}
+
+// New returns a scheduler that, once Run, snapshots into store and calls
+// ingested after runs that copied something.
+func New(db database.Repository, store *ingest.Store, cfg *config.Config, ingested func()) *Scheduler {
+	return &Scheduler{
+		db:         db,
+		store:      store,
+		retryDelay: cfg.JobRetryDelay,
+		webhook:    cfg.AlertWebhook,
+		ingested:   ingested,
+		wake:       make(chan struct{}, 1),
+		now:        time.Now,
+		running:    make(map[int]bool),
+		retries:    make(map[int]retry),
+		manual:     make(map[int]bool),
+		alerted:    make(map[int]bool),
+	}
+}
+
+// ParseSchedule parses a cron schedule: five fields (minute, hour, day of
+// month, month, day of week) or a descriptor such as @daily or @every 6h.
+func ParseSchedule(spec string) (cron.Schedule, error) {
+	return cron.ParseStandard(spec)
+}
+
+// NextRun is when the job is next due after t, or zero if its schedule
+// does not parse.
+func NextRun(job database.BackupJob, t time.Time) time.Time {
+	sched, err := ParseSchedule(job.Schedule)
+	if err != nil {
+		return time.Time{}
+	}
+	return sched.Next(t)
+}
+
+// Window is how long the job may go without succeeding: its own window, or
+// twice the interval between its runs.
+func Window(job database.BackupJob) time.Duration {
+	if job.Window > 0 {
+		return job.Window
+	}
+	next := NextRun(job, time.Now())
+	if next.IsZero() {
+		return 0
+	}
+	return 2 * NextRun(job, next).Sub(next)
+}
+
+// Overdue reports whether an enabled job has gone longer than its window
+// without succeeding. Jobs that never succeeded count from their creation.
+func Overdue(job database.BackupJob, now time.Time) bool {
+	window := Window(job)
+	if !job.Enabled || window <= 0 {
+		return false
+	}
+	since := job.LastSuccess
+	if since.IsZero() {
+		since = job.CreatedAt
+	}
+	return now.Sub(since) > window
+}
+
+// RunNow starts the job as soon as it is not already running.
+func (s *Scheduler) RunNow(jobID int) {
+	s.mu.Lock()
+	s.manual[jobID] = true
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// State reports whether the job is running and, if a failed run waits to
+// be retried, when.
+func (s *Scheduler) State(jobID int) (running bool, retryAt time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running[jobID], s.retries[jobID].at
+}
+
+// Run starts jobs until ctx is cancelled, then waits for the runs and
+// alerts in progress to finish. Runs a previous server left unfinished are marked as
+// failed first.
+func (s *Scheduler) Run(ctx context.Context) {
+	if err := s.db.AbandonBackupJobRuns(); err != nil {
+		log.Printf("Failed to close interrupted backup job runs: %v", err)
+	}
+
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	defer s.jobs.Wait()
+
+	for {
+		s.check(s.now())
+		select {
+		case <-ticker.C:
+		case <-s.wake:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// check starts the jobs that are due and alerts about overdue ones.
+func (s *Scheduler) check(now time.Time) {
+	jobs, err := s.db.GetBackupJobs()
+	if err != nil {
+		log.Printf("Failed to load backup jobs: %v", err)
+		return
+	}
+
+	for _, job := range jobs {
+		if attempt := s.claim(job, now); attempt > 0 {
+			s.jobs.Add(1)
+			go func(job database.BackupJob) {
+				defer s.jobs.Done()
+				s.runJob(job, attempt)
+			}(job)
+		}
+		s.checkOverdue(job, now)
+	}
+}
+
+// claim decides whether the job should start now and, if so, marks it
+// running and returns the attempt number.
+func (s *Scheduler) claim(job database.BackupJob, now time.Time) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running[job.ID] {
+		return 0
+	}
+	attempt := 0
+	if s.manual[job.ID] {
+		delete(s.manual, job.ID)
+		delete(s.retries, job.ID)
+		attempt = 1
+	} else if r, ok := s.retries[job.ID]; ok {
+		if job.Enabled && !now.Before(r.at) {
+			delete(s.retries, job.ID)
+			attempt = r.attempt
+		}
+	} else if job.Enabled && due(job, now) {
+		attempt = 1
+	}
+	if attempt > 0 {
+		s.running[job.ID] = true
+	}
+	return attempt
+}
+
+// due reports whether the schedule has come round since the job last
+// started. A server that was down over several runs catches up with one.
+func due(job database.BackupJob, now time.Time) bool {
+	last := job.CreatedAt
+	if job.LastRun != nil && job.LastRun.StartedAt.After(last) {
+		last = job.LastRun.StartedAt
+	}
+	next := NextRun(job, last)
+	return !next.IsZero() && !next.After(now)
+}
+
+func (s *Scheduler) runJob(job database.BackupJob, attempt int) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.running, job.ID)
+		s.mu.Unlock()
+	}()
+
+	run := database.BackupJobRun{JobID: job.ID, Attempt: attempt, StartedAt: s.now()}
+	var err error
+	if run.ID, err = s.db.StartBackupJobRun(job.ID, attempt, run.StartedAt); err != nil {
+		log.Printf("Failed to record start of backup job %s: %v", job.Name, err)
+		return
+	}
+
+	run.Files, run.Bytes, err = s.copy(job)
+	run.FinishedAt = s.now()
+	run.Status = database.JobRunSucceeded
+	if err != nil {
+		run.Status = database.JobRunFailed
+		run.Error = err.Error()
+		log.Printf("Backup job %s failed (attempt %d): %v", job.Name, attempt, err)
+		if attempt <= job.Retries {
+			s.mu.Lock()
+			s.retries[job.ID] = retry{attempt: attempt + 1, at: run.FinishedAt.Add(s.retryDelay)}
+			s.mu.Unlock()
+		}
+	} else {
+		log.Printf("Backup job %s copied %d files (%d bytes) in %v", job.Name, run.Files, run.Bytes, run.Duration())
+	}
+
+	if err := s.db.FinishBackupJobRun(run); err != nil {
+		log.Printf("Failed to record end of backup job %s: %v", job.Name, err)
+	}
+	if run.Files > 0 && s.ingested != nil {
+		s.ingested()
+	}
+}
+
+// copy snapshots every file the job's source matches. Files that fail do
+// not stop the others, but fail the run.
+func (s *Scheduler) copy(job database.BackupJob) (int, int64, error) {
+	paths, err := sources(job.Source)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(paths) == 0 {
+		return 0, 0, fmt.Errorf("nothing matches %s", job.Source)
+	}
+
+	var files int
+	var size int64
+	var failures []string
+	for _, path := range paths {
+		v, err := s.store.Snapshot(path, job.GroupID, "job "+job.Name)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", path, err))
+			continue
+		}
+		if v != nil {
+			files++
+			size += v.Size
+		}
+	}
+
+	if len(failures) > 0 {
+		shown := failures[:min(len(failures), 3)]
+		return files, size, fmt.Errorf("%d of %d files failed: %s", len(failures), len(paths), strings.Join(shown, "; "))
+	}
+	return files, size, nil
+}
+
+// sources expands a job's source into the files to copy. The source is a
+// path or glob; directories it names stand for the files directly in them.
+// tModLoader sidecars are left out when their save is there, as they are
+// copied with it.
+func sources(pattern string) ([]string, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("bad source pattern %q: %w", pattern, err)
+	}
+
+	found := make(map[string]bool)
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil {
+			continue
+		}
+		if !info.IsDir() {
+			if info.Mode().IsRegular() {
+				found[match] = true
+			}
+			continue
+		}
+		entries, err := os.ReadDir(match)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if entry.Type().IsRegular() {
+				found[filepath.Join(match, entry.Name())] = true
+			}
+		}
+	}
+
+	var paths []string
+	for path := range found {
+		if save := database.SidecarSave(path); save != "" {
+			if _, err := os.Stat(save); err == nil {
+				continue
+			}
+		}
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// checkOverdue alerts once when a job becomes overdue. It alerts again
+// only after the job has succeeded in between.
+func (s *Scheduler) checkOverdue(job database.BackupJob, now time.Time) {
+	overdue := Overdue(job, now)
+
+	s.mu.Lock()
+	alerted := s.alerted[job.ID]
+	s.alerted[job.ID] = overdue
+	s.mu.Unlock()
+
+	if overdue && !alerted {
+		s.alert(job)
+	}
+}
+
+// alert reports an overdue job in the log and audit log, and to the alert
+// webhook when one is configured.
+func (s *Scheduler) alert(job database.BackupJob) {
+	last := "never"
+	if !job.LastSuccess.IsZero() {
+		last = job.LastSuccess.Local().Format("2006-01-02 15:04")
+	}
+	msg := fmt.Sprintf("Backup job %s has not succeeded within %v (last success: %s)", job.Name, Window(job), last)
+	log.Print(msg)
+	if err := s.db.AddAuditEntry("scheduler", "job.overdue", msg); err != nil {
+		log.Printf("Failed to write audit entry job.overdue for scheduler: %v", err)
+	}
+
+	if s.webhook == "" {
+		return
+	}
+	// Sent in the background so a slow webhook does not hold up the
+	// schedule, but tracked with the runs so shutdown waits for it.
+	s.jobs.Add(1)
+	go func() {
+		defer s.jobs.Done()
+		payload := map[string]interface{}{"text": msg, "job": job.Name}
+		if !job.LastSuccess.IsZero() {
+			payload["last_success"] = job.LastSuccess
+		}
+		body, _ := json.Marshal(payload)
+		client := &http.Client{Timeout: 10 * time.Second}
+		resp, err := client.Post(s.webhook, "application/json", bytes.NewReader(body))
+		if err != nil {
+			log.Printf("Failed to send alert for backup job %s: %v", job.Name, err)
+			return
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			log.Printf("Alert webhook answered %s for backup job %s", resp.Status, job.Name)
+		}
+	}()
+}
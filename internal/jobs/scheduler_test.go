@@ -0,0 +1,195 @@
+package jobs
+
+import (
+	"backup_server/internal/config"
+	"backup_server/internal/database"
+	"backup_server/internal/ingest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testScheduler returns a scheduler whose clock stands at now, with one
+// enabled job that backs up a directory with nothing in it.
+func testScheduler(t *testing.T, now time.Time) (*Scheduler, database.BackupJob) {
+	t.Helper()
+	db, err := database.InitDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("InitDB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	groupID, err := db.CreateGroup("backups")
+	if err != nil {
+		t.Fatal(err)
+	}
+	job := database.BackupJob{
+		Name:     "worlds",
+		Source:   filepath.Join(t.TempDir(), "*.wld"),
+		Schedule: "@hourly",
+		GroupID:  int(groupID),
+		Retries:  1,
+		Enabled:  true,
+	}
+	if err := db.CreateBackupJob(job); err != nil {
+		t.Fatal(err)
+	}
+	jobs, err := db.GetBackupJobs()
+	if err != nil || len(jobs) != 1 {
+		t.Fatalf("GetBackupJobs = %v, %v", jobs, err)
+	}
+
+	s := New(db, ingest.NewStore(db, t.TempDir()), &config.Config{JobRetryDelay: 5 * time.Minute}, nil)
+	s.now = func() time.Time { return now }
+	return s, jobs[0]
+}
+
+func TestClaim(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC)
+	s, job := testScheduler(t, now)
+	job.CreatedAt = now.Add(-10 * time.Minute)
+
+	if attempt := s.claim(job, now); attempt != 0 {
+		t.Fatalf("job not yet due claimed as attempt %d", attempt)
+	}
+
+	// A retry waits for its time.
+	s.retries[job.ID] = retry{attempt: 3, at: now.Add(time.Minute)}
+	if attempt := s.claim(job, now); attempt != 0 {
+		t.Fatalf("retry claimed early as attempt %d", attempt)
+	}
+	if attempt := s.claim(job, now.Add(time.Minute)); attempt != 3 {
+		t.Fatalf("due retry claimed as attempt %d, want 3", attempt)
+	}
+	if attempt := s.claim(job, now.Add(time.Minute)); attempt != 0 {
+		t.Fatalf("running job claimed again as attempt %d", attempt)
+	}
+	delete(s.running, job.ID)
+
+	// Run Now starts afresh and drops the pending retry, even for a
+	// disabled job.
+	s.retries[job.ID] = retry{attempt: 2, at: now.Add(time.Hour)}
+	s.RunNow(job.ID)
+	job.Enabled = false
+	if attempt := s.claim(job, now); attempt != 1 {
+		t.Fatalf("manual run claimed as attempt %d, want 1", attempt)
+	}
+	if _, ok := s.retries[job.ID]; ok {
+		t.Error("retry kept after a manual run")
+	}
+	delete(s.running, job.ID)
+
+	// Disabled jobs are not retried.
+	s.retries[job.ID] = retry{attempt: 2, at: now}
+	if attempt := s.claim(job, now); attempt != 0 {
+		t.Errorf("disabled job retried as attempt %d", attempt)
+	}
+}
+
+func TestRetriesStopAfterJobRetries(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s, job := testScheduler(t, now)
+
+	// Nothing matches the source, so every run fails.
+	s.runJob(job, 1)
+	if r, ok := s.retries[job.ID]; !ok || r.attempt != 2 || !r.at.Equal(now.Add(5*time.Minute)) {
+		t.Fatalf("retry after the first attempt = %+v, %v", r, ok)
+	}
+	delete(s.retries, job.ID)
+
+	s.runJob(job, 2)
+	if r, ok := s.retries[job.ID]; ok {
+		t.Errorf("retried again after %d retries: %+v", job.Retries, r)
+	}
+
+	runs, err := s.db.GetBackupJobRuns(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 2 {
+		t.Fatalf("%d runs recorded, want 2", len(runs))
+	}
+	for _, run := range runs {
+		if run.Status != database.JobRunFailed || !run.StartedAt.Equal(now) {
+			t.Errorf("run %+v, want failed at %v", run, now)
+		}
+	}
+}
+
+func TestDueCatchesUp(t *testing.T) {
+	created := time.Date(2026, 3, 1, 9, 10, 0, 0, time.UTC)
+	job := database.BackupJob{Schedule: "@hourly", CreatedAt: created}
+
+	if due(job, created.Add(49*time.Minute)) {
+		t.Error("due before the first hour")
+	}
+	if !due(job, created.Add(50*time.Minute)) {
+		t.Error("not due on the hour")
+	}
+
+	// After five missed hours the job is due once, and not again until
+	// the next hour after that run.
+	down := created.Add(5*time.Hour + 20*time.Minute)
+	job.LastRun = &database.BackupJobRun{StartedAt: created.Add(50 * time.Minute)}
+	if !due(job, down) {
+		t.Error("missed runs not caught up")
+	}
+	job.LastRun = &database.BackupJobRun{StartedAt: down}
+	if due(job, down.Add(29*time.Minute)) {
+		t.Error("due again before the next hour")
+	}
+	if !due(job, down.Add(30*time.Minute)) {
+		t.Error("not due at the next hour")
+	}
+
+	job.Schedule = "not a schedule"
+	if due(job, down.Add(24*time.Hour)) {
+		t.Error("due with a broken schedule")
+	}
+}
+
+func TestSources(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"world.wld", "world.twld", "lone.twld", "hero.plr", "hero.tplr", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "old"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "old", "world.wld"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		// Sidecars go with their saves; one without a save is copied on
+		// its own. Subdirectories are not descended into.
+		{dir, []string{"hero.plr", "lone.twld", "notes.txt", "world.wld"}},
+		{filepath.Join(dir, "*.*wld"), []string{"lone.twld", "world.wld"}},
+		{filepath.Join(dir, "missing"), nil},
+	}
+	for _, tt := range tests {
+		paths, err := sources(tt.pattern)
+		if err != nil {
+			t.Fatalf("sources(%q): %v", tt.pattern, err)
+		}
+		var names []string
+		for _, path := range paths {
+			rel, _ := filepath.Rel(dir, path)
+			names = append(names, rel)
+		}
+		if !reflect.DeepEqual(names, tt.want) {
+			t.Errorf("sources(%q) = %q, want %q", tt.pattern, names, tt.want)
+		}
+	}
+
+	if _, err := sources("["); err == nil {
+		t.Error("bad pattern accepted")
+	}
+}
@@ -0,0 +1,97 @@
+package login
+
+import (
+	"backup_server/internal/database"
+	"slices"
+	"strings"
+)
+
+// mapGroups returns the IDs of the local groups that the external group
+// names map to, or of defaultGroup if none do. groupMap holds comma-separated
+// "external=local" pairs, and only names listed there map to a local group:
+// matching names alone would let whoever runs the directory hand out any
+// local group, including ones carrying roles. Pairs naming no local group
+// are ignored.
+func mapGroups(db database.Repository, names []string, groupMap, defaultGroup string) ([]int, error) {
+	groupIDs, err := groupIDsByName(db)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping := parseGroupMap(groupMap)
+
+	var ids []int
+	seen := make(map[int]bool)
+	for _, name := range names {
+		localName, ok := mapping[strings.ToLower(name)]
+		if !ok {
+			continue
+		}
+		if id, ok := groupIDs[localName]; ok && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) == 0 && defaultGroup != "" {
+		if id, ok := groupIDs[defaultGroup]; ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// syncGroups returns the groups of a directory user after a sign-in that
+// mapped to the mapped groups: the groups groupMap and defaultGroup can give
+// are replaced by mapped, and the rest of current is kept, since those were
+// given here, for example by an admin or a group manager.
+func syncGroups(db database.Repository, current, mapped []int, groupMap, defaultGroup string) ([]int, error) {
+	groupIDs, err := groupIDsByName(db)
+	if err != nil {
+		return nil, err
+	}
+
+	managed := make(map[int]bool)
+	for _, localName := range parseGroupMap(groupMap) {
+		if id, ok := groupIDs[localName]; ok {
+			managed[id] = true
+		}
+	}
+	if id, ok := groupIDs[defaultGroup]; ok && defaultGroup != "" {
+		managed[id] = true
+	}
+
+	ids := append([]int(nil), mapped...)
+	for _, id := range current {
+		if !managed[id] && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func groupIDsByName(db database.Repository) (map[string]int, error) {
+	groups, err := db.GetAllGroups()
+	if err != nil {
+		return nil, err
+	}
+	groupIDs := make(map[string]int)
+	for _, g := range groups {
+		groupIDs[g.Name] = g.ID
+	}
+	return groupIDs, nil
+}
+
+// parseGroupMap reads "external=local" pairs separated by commas. External
+// names are compared case-insensitively, as directories do.
+func parseGroupMap(s string) map[string]string {
+	mapping := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		external, local, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		mapping[strings.ToLower(strings.TrimSpace(external))] = strings.TrimSpace(local)
+	}
+	return mapping
+}
@@ -0,0 +1,200 @@
+package login
+
+import (
+	"backup_server/internal/config"
+	"backup_server/internal/database"
+	"crypto/tls"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// Conn is the part of an LDAP connection the provider uses. A stand-in
+// directory can implement it in place of a real server.
+type Conn interface {
+	Bind(username, password string) error
+	Search(req *ldap.SearchRequest) (*ldap.SearchResult, error)
+	Close() error
+}
+
+// LDAP signs users in by binding to a directory as them. The first sign-in
+// creates a local account with AuthSource ldap, and every sign-in updates
+// the local groups its directory groups map to. Groups given here are kept.
+// Local accounts are never signed in through the directory, so a directory
+// entry cannot take over an existing local user.
+type LDAP struct {
+	DB     database.Repository
+	Config *config.Config
+	// Dial opens a connection to the directory.
+	Dial func() (Conn, error)
+}
+
+// NewLDAP returns a provider that dials cfg.LDAPURL.
+func NewLDAP(db database.Repository, cfg *config.Config) *LDAP {
+	return &LDAP{
+		DB:     db,
+		Config: cfg,
+		Dial: func() (Conn, error) {
+			conn, err := ldap.DialURL(cfg.LDAPURL)
+			if err != nil {
+				return nil, err
+			}
+			if cfg.LDAPStartTLS {
+				u, err := url.Parse(cfg.LDAPURL)
+				if err != nil {
+					conn.Close()
+					return nil, err
+				}
+				if err := conn.StartTLS(&tls.Config{ServerName: u.Hostname()}); err != nil {
+					conn.Close()
+					return nil, err
+				}
+			}
+			return conn, nil
+		},
+	}
+}
+
+func (p *LDAP) Name() string {
+	return database.AuthSourceLDAP
+}
+
+func (p *LDAP) Authenticate(username, password string) (*database.User, error) {
+	// Many servers treat a bind with an empty password as an anonymous
+	// bind and report success.
+	if username == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	conn, err := p.Dial()
+	if err != nil {
+		return nil, fmt.Errorf("connect to directory: %w", err)
+	}
+	defer conn.Close()
+
+	if err := p.bindService(conn); err != nil {
+		return nil, err
+	}
+
+	userDN, name, err := p.findUser(conn, username)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := p.DB.GetUserByUsername(name)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if existing != nil && existing.AuthSource != database.AuthSourceLDAP {
+		return nil, ErrInvalidCredentials
+	}
+
+	if err := conn.Bind(userDN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("bind as %s: %w", userDN, err)
+	}
+
+	// Group lookups run as the service account when there is one, since
+	// users often cannot read group entries themselves.
+	if err := p.bindService(conn); err != nil {
+		return nil, err
+	}
+
+	groupIDs, err := p.localGroups(conn, userDN, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing == nil {
+		return p.DB.CreateExternalUser(name, database.AuthSourceLDAP, groupIDs)
+	}
+
+	groupIDs, err = syncGroups(p.DB, existing.GroupIDs, groupIDs, p.Config.LDAPGroupMap, p.Config.LDAPDefaultGroup)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.DB.UpdateUser(existing.ID, existing.Username, groupIDs); err != nil {
+		log.Printf("Failed to update groups of %s from the directory: %v", existing.Username, err)
+		return existing, nil
+	}
+	return p.DB.GetUserByID(existing.ID)
+}
+
+func (p *LDAP) bindService(conn Conn) error {
+	if p.Config.LDAPBindDN == "" {
+		return nil
+	}
+	if err := conn.Bind(p.Config.LDAPBindDN, p.Config.LDAPBindPassword); err != nil {
+		return fmt.Errorf("bind as %s: %w", p.Config.LDAPBindDN, err)
+	}
+	return nil
+}
+
+// findUser returns the DN and directory username of the one entry matching
+// username.
+func (p *LDAP) findUser(conn Conn, username string) (dn, name string, err error) {
+	filter := strings.ReplaceAll(p.Config.LDAPUserFilter, "{username}", ldap.EscapeFilter(username))
+
+	result, err := conn.Search(ldap.NewSearchRequest(
+		p.Config.LDAPBaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, 0, false,
+		filter, []string{p.Config.LDAPUsernameAttribute}, nil,
+	))
+	if err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			return "", "", ErrInvalidCredentials
+		}
+		return "", "", fmt.Errorf("search for user: %w", err)
+	}
+	if len(result.Entries) != 1 {
+		return "", "", ErrInvalidCredentials
+	}
+
+	entry := result.Entries[0]
+	name = entry.GetAttributeValue(p.Config.LDAPUsernameAttribute)
+	if name == "" {
+		name = username
+	}
+	return entry.DN, name, nil
+}
+
+// localGroups returns the IDs of the local groups the user's directory
+// groups map to, or the default group if none do.
+func (p *LDAP) localGroups(conn Conn, userDN, name string) ([]int, error) {
+	baseDN := p.Config.LDAPGroupBaseDN
+	if baseDN == "" {
+		baseDN = p.Config.LDAPBaseDN
+	}
+	filter := strings.NewReplacer(
+		"{dn}", ldap.EscapeFilter(userDN),
+		"{username}", ldap.EscapeFilter(name),
+	).Replace(p.Config.LDAPGroupFilter)
+
+	result, err := conn.Search(ldap.NewSearchRequest(
+		baseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		filter, []string{p.Config.LDAPGroupAttribute}, nil,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("search for groups: %w", err)
+	}
+
+	var names []string
+	for _, entry := range result.Entries {
+		names = append(names, entry.GetAttributeValues(p.Config.LDAPGroupAttribute)...)
+	}
+
+	ids, err := mapGroups(p.DB, names, p.Config.LDAPGroupMap, p.Config.LDAPDefaultGroup)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%s is in no directory group that maps to a local group", name)
+	}
+	return ids, nil
+}
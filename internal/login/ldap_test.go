@@ -0,0 +1,275 @@
+package login
+
+import (
+	"backup_server/internal/auth"
+	"backup_server/internal/config"
+	"backup_server/internal/database"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// testDB opens an empty database with the groups "admins", holding the admin
+// role, and "players", and a local user alice in admins.
+func testDB(t *testing.T) (db *database.DB, admins, players int) {
+	t.Helper()
+
+	db, err := database.InitDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("InitDB: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	adminsID, err := db.CreateGroup("admins")
+	if err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+	playersID, err := db.CreateGroup("players")
+	if err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+	role, err := db.GetRoleByName(auth.AdminRoleName)
+	if err != nil {
+		t.Fatalf("GetRoleByName: %v", err)
+	}
+	if err := db.AssignRoleToGroup(role.ID, int(adminsID)); err != nil {
+		t.Fatalf("AssignRoleToGroup: %v", err)
+	}
+	if err := db.CreateUser("alice", "correct horse", []int{int(adminsID)}); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	return db, int(adminsID), int(playersID)
+}
+
+// directory is a stand-in LDAP server. It understands the single-clause
+// filters the tests configure, (uid=...) and (member=...), comparing the
+// escaped value with the escaped attribute like a server would.
+type directory struct {
+	// users maps DNs to passwords; uids maps DNs to usernames.
+	users  map[string]string
+	uids   map[string]string
+	groups map[string][]string // group name to member DNs
+
+	binds   []string
+	filters []string
+}
+
+const (
+	serviceDN       = "cn=service,dc=example,dc=org"
+	servicePassword = "service secret"
+)
+
+func newDirectory() *directory {
+	return &directory{
+		users: map[string]string{
+			serviceDN:                        servicePassword,
+			"uid=carol,ou=people,dc=example": "carol pw",
+			"uid=alice,ou=people,dc=example": "alice pw",
+		},
+		uids: map[string]string{
+			"uid=carol,ou=people,dc=example": "carol",
+			"uid=alice,ou=people,dc=example": "alice",
+		},
+		groups: map[string][]string{
+			"admins": {"uid=carol,ou=people,dc=example"},
+			"gamers": {"uid=carol,ou=people,dc=example"},
+		},
+	}
+}
+
+func (d *directory) Bind(dn, password string) error {
+	d.binds = append(d.binds, dn)
+	if pw, ok := d.users[dn]; !ok || pw != password {
+		return ldap.NewError(ldap.LDAPResultInvalidCredentials, errors.New("invalid credentials"))
+	}
+	return nil
+}
+
+func (d *directory) Search(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	d.filters = append(d.filters, req.Filter)
+	attr, value, ok := strings.Cut(strings.TrimSuffix(strings.TrimPrefix(req.Filter, "("), ")"), "=")
+	if !ok {
+		return nil, ldap.NewError(ldap.LDAPResultFilterError, errors.New("unsupported filter "+req.Filter))
+	}
+
+	result := &ldap.SearchResult{}
+	switch attr {
+	case "uid":
+		for dn, uid := range d.uids {
+			if ldap.EscapeFilter(uid) == value {
+				result.Entries = append(result.Entries, ldap.NewEntry(dn, map[string][]string{"uid": {uid}}))
+			}
+		}
+	case "member":
+		for name, members := range d.groups {
+			for _, dn := range members {
+				if ldap.EscapeFilter(dn) == value {
+					result.Entries = append(result.Entries, ldap.NewEntry("cn="+name+",ou=groups,dc=example", map[string][]string{"cn": {name}}))
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+func (d *directory) Close() error {
+	return nil
+}
+
+func newTestLDAP(db database.Repository, dir *directory) *LDAP {
+	cfg := &config.Config{
+		LDAPURL:               "ldap://directory.invalid",
+		LDAPBindDN:            serviceDN,
+		LDAPBindPassword:      servicePassword,
+		LDAPBaseDN:            "ou=people,dc=example",
+		LDAPUserFilter:        "(uid={username})",
+		LDAPUsernameAttribute: "uid",
+		LDAPGroupBaseDN:       "ou=groups,dc=example",
+		LDAPGroupFilter:       "(member={dn})",
+		LDAPGroupAttribute:    "cn",
+		LDAPGroupMap:          "gamers=players",
+	}
+	return &LDAP{DB: db, Config: cfg, Dial: func() (Conn, error) { return dir, nil }}
+}
+
+func TestLDAPSignIn(t *testing.T) {
+	db, admins, players := testDB(t)
+	dir := newDirectory()
+	p := newTestLDAP(db, dir)
+
+	user, err := p.Authenticate("carol", "carol pw")
+	if err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	if user.Username != "carol" || user.AuthSource != database.AuthSourceLDAP {
+		t.Errorf("user = %s from %s, want carol from ldap", user.Username, user.AuthSource)
+	}
+	// Only mapped directory groups count, so the directory's admins group
+	// does not give the local one of the same name.
+	if want := []int{players}; !reflect.DeepEqual(user.GroupIDs, want) {
+		t.Errorf("groups = %v, want %v", user.GroupIDs, want)
+	}
+	if ok, _ := db.UserHasPermission(user.ID, auth.PermManageUsers); ok {
+		t.Error("unmapped directory admins group gave manage_users")
+	}
+
+	// Once mapped, it gives the local group holding the admin role.
+	p.Config.LDAPGroupMap = "gamers=players,Admins=admins"
+	user, err = p.Authenticate("carol", "carol pw")
+	if err != nil {
+		t.Fatalf("Authenticate with admins mapped: %v", err)
+	}
+	groups := append([]int(nil), user.GroupIDs...)
+	sort.Ints(groups)
+	if want := []int{admins, players}; !reflect.DeepEqual(groups, want) {
+		t.Errorf("groups = %v, want %v", groups, want)
+	}
+	if ok, _ := db.UserHasPermission(user.ID, auth.PermManageUsers); !ok {
+		t.Error("member of the mapped directory admins group lacks manage_users")
+	}
+
+	// A later sign-in replaces the groups with the directory's current ones.
+	dir.groups["admins"] = nil
+	user, err = p.Authenticate("carol", "carol pw")
+	if err != nil {
+		t.Fatalf("second Authenticate: %v", err)
+	}
+	if want := []int{players}; !reflect.DeepEqual(user.GroupIDs, want) {
+		t.Errorf("groups after leaving admins = %v, want %v", user.GroupIDs, want)
+	}
+	if ok, _ := db.UserHasPermission(user.ID, auth.PermManageUsers); ok {
+		t.Error("manage_users kept after leaving the directory admins group")
+	}
+
+	// Groups given here, such as by a group manager, survive sign-ins.
+	staff, err := db.CreateGroup("staff")
+	if err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+	if err := db.AddGroupMember(int(staff), user.ID); err != nil {
+		t.Fatalf("AddGroupMember: %v", err)
+	}
+	user, err = p.Authenticate("carol", "carol pw")
+	if err != nil {
+		t.Fatalf("Authenticate after a local change: %v", err)
+	}
+	groups = append(groups[:0], user.GroupIDs...)
+	sort.Ints(groups)
+	if want := []int{players, int(staff)}; !reflect.DeepEqual(groups, want) {
+		t.Errorf("groups after a local change = %v, want %v", groups, want)
+	}
+	if err := db.RemoveGroupMember(int(staff), user.ID); err != nil {
+		t.Fatalf("RemoveGroupMember: %v", err)
+	}
+
+	// Without a group that maps to a local one the sign-in is refused.
+	dir.groups["gamers"] = nil
+	if _, err := p.Authenticate("carol", "carol pw"); err == nil {
+		t.Error("signed in a user in no mapped group")
+	}
+	p.Config.LDAPDefaultGroup = "players"
+	if user, err := p.Authenticate("carol", "carol pw"); err != nil || !reflect.DeepEqual(user.GroupIDs, []int{players}) {
+		t.Errorf("sign-in with a default group: user = %+v, err = %v", user, err)
+	}
+}
+
+func TestLDAPRejects(t *testing.T) {
+	db, _, _ := testDB(t)
+	dir := newDirectory()
+	p := newTestLDAP(db, dir)
+
+	tests := []struct {
+		name, username, password string
+	}{
+		{"wrong password", "carol", "not it"},
+		{"empty password", "carol", ""},
+		{"unknown user", "dave", "dave pw"},
+		// alice is a local account; the directory entry of the same name
+		// must not sign in as her, even with the right directory password.
+		{"local account", "alice", "alice pw"},
+		{"filter wildcard", "*", "carol pw"},
+		{"filter injection", "carol)(uid=*", "carol pw"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir.binds = nil
+			if _, err := p.Authenticate(tt.username, tt.password); !errors.Is(err, ErrInvalidCredentials) {
+				t.Errorf("err = %v, want ErrInvalidCredentials", err)
+			}
+			for _, dn := range dir.binds {
+				if dn == "uid=alice,ou=people,dc=example" {
+					t.Error("bound as the directory entry of a local user")
+				}
+			}
+		})
+	}
+
+	if _, err := db.GetUserByUsername("carol"); err == nil {
+		t.Error("rejected sign-ins created a local account")
+	}
+	if user, _ := db.GetUserByUsername("alice"); user == nil || user.AuthSource != database.AuthSourceLocal {
+		t.Errorf("local account changed: %+v", user)
+	}
+}
+
+func TestLDAPEscapesFilters(t *testing.T) {
+	db, _, _ := testDB(t)
+	dir := newDirectory()
+	dir.uids["uid=odd,ou=people,dc=example"] = "o*d)(x"
+	dir.users["uid=odd,ou=people,dc=example"] = "odd pw"
+	dir.groups["gamers"] = append(dir.groups["gamers"], "uid=odd,ou=people,dc=example")
+	p := newTestLDAP(db, dir)
+
+	if _, err := p.Authenticate("o*d)(x", "odd pw"); err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	want := []string{`(uid=o\2ad\29\28x)`, `(member=uid=odd,ou=people,dc=example)`}
+	if !reflect.DeepEqual(dir.filters, want) {
+		t.Errorf("filters = %q, want %q", dir.filters, want)
+	}
+}
@@ -0,0 +1,275 @@
+package login
+
+import (
+	"backup_server/internal/auth"
+	"backup_server/internal/config"
+	"backup_server/internal/database"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// OIDCRequestTTL is how long a user has to finish signing in at the provider.
+const OIDCRequestTTL = 10 * time.Minute
+
+var (
+	// ErrNoGroups is returned when none of the user's groups at the
+	// provider maps to a local group and there is no default group.
+	ErrNoGroups = errors.New("your account is not in any group that may use this server")
+	// ErrUsernameTaken is returned on first sign-in when a different local
+	// account already has the user's username. Its owner can link the
+	// identity from their account page instead.
+	ErrUsernameTaken = errors.New("an account with your username already exists; sign in with your password and link your identity from My Account")
+	// ErrUnknownState is returned when a callback does not belong to a
+	// sign-in this server started, or the sign-in took too long.
+	ErrUnknownState = errors.New("sign-in request not recognised or expired")
+)
+
+// OIDC signs users in through an OpenID Connect provider with the
+// authorization code flow and PKCE. Identities are linked to users by issuer
+// and subject; the first sign-in creates a user with AuthSource oidc.
+type OIDC struct {
+	DB     database.Repository
+	Config *config.Config
+
+	mu sync.Mutex
+	// provider is discovered on first use so the server starts even when
+	// the provider is unreachable.
+	provider *oidc.Provider
+	pending  map[string]oidcRequest
+}
+
+// oidcRequest is a sign-in waiting for the provider to call back.
+type oidcRequest struct {
+	verifier string
+	nonce    string
+	// linkUserID is set when a signed-in user is linking the identity to
+	// their account rather than signing in.
+	linkUserID int
+	expires    time.Time
+}
+
+// ExternalIdentity is what the provider vouched for in a verified ID token.
+type ExternalIdentity struct {
+	Issuer   string
+	Subject  string
+	Username string
+	Groups   []string
+}
+
+func NewOIDC(db database.Repository, cfg *config.Config) *OIDC {
+	return &OIDC{
+		DB:      db,
+		Config:  cfg,
+		pending: make(map[string]oidcRequest),
+	}
+}
+
+// Name is the label of the sign-in button.
+func (o *OIDC) Name() string {
+	return o.Config.OIDCName
+}
+
+func (o *OIDC) discover() (*oidc.Provider, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.provider != nil {
+		return o.provider, nil
+	}
+
+	// The context is kept by the provider for fetching signing keys later,
+	// so it must outlive any one request.
+	ctx := oidc.ClientContext(context.Background(), &http.Client{Timeout: 10 * time.Second})
+	provider, err := oidc.NewProvider(ctx, o.Config.OIDCIssuer)
+	if err != nil {
+		return nil, fmt.Errorf("discover %s: %w", o.Config.OIDCIssuer, err)
+	}
+	o.provider = provider
+	return provider, nil
+}
+
+func (o *OIDC) oauth2Config(provider *oidc.Provider) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     o.Config.OIDCClientID,
+		ClientSecret: o.Config.OIDCClientSecret,
+		RedirectURL:  o.Config.OIDCRedirectURL,
+		Endpoint:     provider.Endpoint(),
+		Scopes:       strings.Fields(o.Config.OIDCScopes),
+	}
+}
+
+// Start begins a sign-in and returns its state, which the caller must tie
+// to the browser, and the provider URL to send the browser to. A non-zero
+// linkUserID links the identity to that user instead of signing in.
+func (o *OIDC) Start(linkUserID int) (state, authURL string, err error) {
+	provider, err := o.discover()
+	if err != nil {
+		return "", "", err
+	}
+
+	state, err = auth.NewToken()
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := auth.NewToken()
+	if err != nil {
+		return "", "", err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	o.mu.Lock()
+	now := time.Now()
+	for s, req := range o.pending {
+		if now.After(req.expires) {
+			delete(o.pending, s)
+		}
+	}
+	o.pending[state] = oidcRequest{
+		verifier:   verifier,
+		nonce:      nonce,
+		linkUserID: linkUserID,
+		expires:    now.Add(OIDCRequestTTL),
+	}
+	o.mu.Unlock()
+
+	authURL = o.oauth2Config(provider).AuthCodeURL(state,
+		oauth2.S256ChallengeOption(verifier), oidc.Nonce(nonce))
+	return state, authURL, nil
+}
+
+// Finish redeems the code for the sign-in with the given state and verifies
+// the ID token against the provider's published keys. It returns the
+// identity and the user it is to be linked to, if any.
+func (o *OIDC) Finish(ctx context.Context, state, code string) (*ExternalIdentity, int, error) {
+	o.mu.Lock()
+	req, ok := o.pending[state]
+	delete(o.pending, state)
+	o.mu.Unlock()
+	if !ok || time.Now().After(req.expires) {
+		return nil, 0, ErrUnknownState
+	}
+
+	provider, err := o.discover()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	token, err := o.oauth2Config(provider).Exchange(ctx, code, oauth2.VerifierOption(req.verifier))
+	if err != nil {
+		return nil, 0, fmt.Errorf("exchange code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, 0, errors.New("token response has no id_token")
+	}
+
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: o.Config.OIDCClientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, 0, fmt.Errorf("verify id_token: %w", err)
+	}
+	if idToken.Nonce != req.nonce {
+		return nil, 0, errors.New("id_token nonce does not match")
+	}
+
+	var claims map[string]interface{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, 0, err
+	}
+
+	identity := &ExternalIdentity{
+		Issuer:  idToken.Issuer,
+		Subject: idToken.Subject,
+		Groups:  claimStrings(claims[o.Config.OIDCGroupsClaim]),
+	}
+	if username, ok := claims[o.Config.OIDCUsernameClaim].(string); ok {
+		identity.Username = strings.TrimSpace(username)
+	}
+	return identity, req.linkUserID, nil
+}
+
+// claimStrings reads a claim holding either one string or a list of them.
+func claimStrings(claim interface{}) []string {
+	switch v := claim.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var values []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// Resolve returns the local user for a verified identity. The first sign-in
+// creates a user named after the username claim; users created this way
+// have the groups the group map gives updated from the groups claim on
+// every sign-in, and keep the groups given here. Users
+// who linked the identity to an existing account keep the groups an admin
+// gave them.
+func (o *OIDC) Resolve(identity *ExternalIdentity) (*database.User, error) {
+	user, err := o.DB.GetUserByIdentity(identity.Issuer, identity.Subject)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if user != nil {
+		if user.AuthSource != database.AuthSourceOIDC {
+			return user, nil
+		}
+		groupIDs, err := mapGroups(o.DB, identity.Groups, o.Config.OIDCGroupMap, o.Config.OIDCDefaultGroup)
+		if err != nil {
+			return nil, err
+		}
+		if len(groupIDs) == 0 {
+			return nil, ErrNoGroups
+		}
+		groupIDs, err = syncGroups(o.DB, user.GroupIDs, groupIDs, o.Config.OIDCGroupMap, o.Config.OIDCDefaultGroup)
+		if err != nil {
+			return nil, err
+		}
+		if err := o.DB.UpdateUser(user.ID, user.Username, groupIDs); err != nil {
+			log.Printf("Failed to update groups of %s from %s: %v", user.Username, identity.Issuer, err)
+			return user, nil
+		}
+		return o.DB.GetUserByID(user.ID)
+	}
+
+	if identity.Username == "" {
+		return nil, fmt.Errorf("id_token has no %s claim", o.Config.OIDCUsernameClaim)
+	}
+	if _, err := o.DB.GetUserByUsername(identity.Username); err == nil {
+		return nil, ErrUsernameTaken
+	}
+
+	groupIDs, err := mapGroups(o.DB, identity.Groups, o.Config.OIDCGroupMap, o.Config.OIDCDefaultGroup)
+	if err != nil {
+		return nil, err
+	}
+	if len(groupIDs) == 0 {
+		return nil, ErrNoGroups
+	}
+
+	user, err = o.DB.CreateExternalUser(identity.Username, database.AuthSourceOIDC, groupIDs)
+	if err != nil {
+		return nil, err
+	}
+	if err := o.DB.LinkIdentity(user.ID, identity.Issuer, identity.Subject); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
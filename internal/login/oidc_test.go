@@ -0,0 +1,393 @@
+package login
+
+import (
+	"backup_server/internal/config"
+	"backup_server/internal/database"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testClientID = "backup-server"
+
+// issuer is a minimal OpenID provider: discovery, a key set and a token
+// endpoint. Tests play the browser's part by calling authorize, which
+// issues a code for the PKCE challenge and nonce in an authorization URL.
+type issuer struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]issuedCode
+	// verifiers are the PKCE verifiers the token endpoint accepted.
+	verifiers []string
+}
+
+type issuedCode struct {
+	challenge string
+	claims    map[string]interface{}
+}
+
+func newIssuer(t *testing.T) *issuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	iss := &issuer{key: key, codes: make(map[string]issuedCode)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                iss.URL,
+			"authorization_endpoint":                iss.URL + "/authorize",
+			"token_endpoint":                        iss.URL + "/token",
+			"jwks_uri":                              iss.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "test",
+				"use": "sig",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", iss.token)
+
+	iss.Server = httptest.NewServer(mux)
+	t.Cleanup(iss.Close)
+	return iss
+}
+
+func (iss *issuer) token(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	iss.mu.Lock()
+	code, ok := iss.codes[r.PostForm.Get("code")]
+	delete(iss.codes, r.PostForm.Get("code"))
+	iss.mu.Unlock()
+
+	verifier := r.PostForm.Get("code_verifier")
+	sum := sha256.Sum256([]byte(verifier))
+	if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != code.challenge {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	iss.mu.Lock()
+	iss.verifiers = append(iss.verifiers, verifier)
+	iss.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"access_token": "access",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     iss.sign(code.claims),
+	})
+}
+
+func (iss *issuer) sign(claims map[string]interface{}) string {
+	enc := func(v interface{}) string {
+		b, _ := json.Marshal(v)
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+	signed := enc(map[string]string{"alg": "RS256", "kid": "test", "typ": "JWT"}) + "." + enc(claims)
+	sum := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, iss.key, crypto.SHA256, sum[:])
+	if err != nil {
+		panic(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+// authorize signs subject in at the provider for the sign-in that authURL
+// starts and returns the code the browser brings back. extra claims are
+// added to the ID token, and may override the standard ones.
+func (iss *issuer) authorize(t *testing.T, authURL, subject string, extra map[string]interface{}) string {
+	t.Helper()
+
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("parse %s: %v", authURL, err)
+	}
+	q := u.Query()
+	if q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		t.Fatalf("authorization URL has no S256 PKCE challenge: %s", authURL)
+	}
+
+	claims := map[string]interface{}{
+		"iss":   iss.URL,
+		"aud":   testClientID,
+		"sub":   subject,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"nonce": q.Get("nonce"),
+	}
+	for k, v := range extra {
+		claims[k] = v
+	}
+
+	code := "code-" + q.Get("state")
+	iss.mu.Lock()
+	iss.codes[code] = issuedCode{challenge: q.Get("code_challenge"), claims: claims}
+	iss.mu.Unlock()
+	return code
+}
+
+func newTestOIDC(db database.Repository, iss *issuer) *OIDC {
+	return NewOIDC(db, &config.Config{
+		OIDCIssuer:        iss.URL,
+		OIDCClientID:      testClientID,
+		OIDCClientSecret:  "secret",
+		OIDCRedirectURL:   "http://localhost:8090/oidc/callback",
+		OIDCScopes:        "openid profile",
+		OIDCUsernameClaim: "preferred_username",
+		OIDCGroupsClaim:   "groups",
+		OIDCGroupMap:      "gamers=players",
+	})
+}
+
+// signIn runs a whole sign-in for subject with the given extra claims.
+func signIn(t *testing.T, o *OIDC, iss *issuer, subject string, extra map[string]interface{}) (*ExternalIdentity, error) {
+	t.Helper()
+
+	state, authURL, err := o.Start(0)
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	code := iss.authorize(t, authURL, subject, extra)
+	identity, _, err := o.Finish(context.Background(), state, code)
+	return identity, err
+}
+
+func TestOIDCSignIn(t *testing.T) {
+	db, admins, players := testDB(t)
+	iss := newIssuer(t)
+	o := newTestOIDC(db, iss)
+
+	identity, err := signIn(t, o, iss, "carol-1", map[string]interface{}{
+		"preferred_username": "carol",
+		"groups":             []string{"admins", "gamers", "strangers"},
+	})
+	if err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	if identity.Issuer != iss.URL || identity.Subject != "carol-1" || identity.Username != "carol" {
+		t.Errorf("identity = %+v", identity)
+	}
+	if len(iss.verifiers) != 1 {
+		t.Fatalf("token endpoint accepted %d verifiers, want 1", len(iss.verifiers))
+	}
+
+	user, err := o.Resolve(identity)
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if user.Username != "carol" || user.AuthSource != database.AuthSourceOIDC {
+		t.Errorf("created %s from %s, want carol from oidc", user.Username, user.AuthSource)
+	}
+	// admins is not in the group map, so it does not give the local group
+	// of the same name.
+	if want := []int{players}; !reflect.DeepEqual(user.GroupIDs, want) {
+		t.Errorf("groups = %v, want %v", user.GroupIDs, want)
+	}
+	if linked, _ := db.GetUserByIdentity(iss.URL, "carol-1"); linked == nil || linked.ID != user.ID {
+		t.Errorf("identity linked to %+v, want user %d", linked, user.ID)
+	}
+
+	// The next sign-in updates the mapped groups, even if the username
+	// claim changed in the meantime, and keeps groups given here.
+	if err := db.AddGroupMember(admins, user.ID); err != nil {
+		t.Fatalf("AddGroupMember: %v", err)
+	}
+	o.Config.OIDCGroupMap = "gamers=players,testers=testers"
+	testers, err := db.CreateGroup("testers")
+	if err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+	if err := db.AddGroupMember(int(testers), user.ID); err != nil {
+		t.Fatalf("AddGroupMember: %v", err)
+	}
+	identity, err = signIn(t, o, iss, "carol-1", map[string]interface{}{
+		"preferred_username": "carol.new",
+		"groups":             "gamers",
+	})
+	if err != nil {
+		t.Fatalf("second Finish: %v", err)
+	}
+	again, err := o.Resolve(identity)
+	if err != nil {
+		t.Fatalf("second Resolve: %v", err)
+	}
+	if again.ID != user.ID || again.Username != "carol" {
+		t.Errorf("second sign-in resolved to %+v, want user %d carol", again, user.ID)
+	}
+	groups := append([]int(nil), again.GroupIDs...)
+	sort.Ints(groups)
+	if want := []int{admins, players}; !reflect.DeepEqual(groups, want) {
+		t.Errorf("groups after the second sign-in = %v, want %v", groups, want)
+	}
+
+	identity.Groups = []string{"strangers"}
+	if _, err := o.Resolve(identity); !errors.Is(err, ErrNoGroups) {
+		t.Errorf("Resolve with no mapped group: err = %v, want ErrNoGroups", err)
+	}
+}
+
+func TestOIDCResolveExistingUsers(t *testing.T) {
+	db, admins, _ := testDB(t)
+	iss := newIssuer(t)
+	o := newTestOIDC(db, iss)
+
+	// alice is a local account, so a stranger whose username claim is
+	// alice must not get it.
+	identity := &ExternalIdentity{Issuer: iss.URL, Subject: "other-alice", Username: "alice", Groups: []string{"gamers"}}
+	if _, err := o.Resolve(identity); !errors.Is(err, ErrUsernameTaken) {
+		t.Errorf("Resolve with a taken username: err = %v, want ErrUsernameTaken", err)
+	}
+
+	// Once alice links her identity she signs in as herself and keeps the
+	// groups an admin gave her.
+	alice, err := db.GetUserByUsername("alice")
+	if err != nil {
+		t.Fatalf("GetUserByUsername: %v", err)
+	}
+	if err := db.LinkIdentity(alice.ID, iss.URL, "alice-1"); err != nil {
+		t.Fatalf("LinkIdentity: %v", err)
+	}
+	user, err := o.Resolve(&ExternalIdentity{Issuer: iss.URL, Subject: "alice-1", Username: "someone", Groups: []string{"gamers"}})
+	if err != nil {
+		t.Fatalf("Resolve linked identity: %v", err)
+	}
+	if user.ID != alice.ID || !reflect.DeepEqual(user.GroupIDs, []int{admins}) {
+		t.Errorf("linked identity resolved to %+v, want alice in admins", user)
+	}
+}
+
+func TestOIDCFinishRejects(t *testing.T) {
+	db, _, _ := testDB(t)
+	iss := newIssuer(t)
+	o := newTestOIDC(db, iss)
+	ctx := context.Background()
+
+	t.Run("unknown state", func(t *testing.T) {
+		_, authURL, err := o.Start(0)
+		if err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+		code := iss.authorize(t, authURL, "carol-1", nil)
+		if _, _, err := o.Finish(ctx, "forged", code); !errors.Is(err, ErrUnknownState) {
+			t.Errorf("err = %v, want ErrUnknownState", err)
+		}
+	})
+
+	t.Run("state used twice", func(t *testing.T) {
+		state, authURL, err := o.Start(0)
+		if err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+		if _, _, err := o.Finish(ctx, state, iss.authorize(t, authURL, "carol-1", nil)); err != nil {
+			t.Fatalf("first Finish: %v", err)
+		}
+		if _, _, err := o.Finish(ctx, state, iss.authorize(t, authURL, "carol-1", nil)); !errors.Is(err, ErrUnknownState) {
+			t.Errorf("err = %v, want ErrUnknownState", err)
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		state, authURL, err := o.Start(0)
+		if err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+		o.mu.Lock()
+		req := o.pending[state]
+		req.expires = time.Now().Add(-time.Second)
+		o.pending[state] = req
+		o.mu.Unlock()
+
+		if _, _, err := o.Finish(ctx, state, iss.authorize(t, authURL, "carol-1", nil)); !errors.Is(err, ErrUnknownState) {
+			t.Errorf("err = %v, want ErrUnknownState", err)
+		}
+	})
+
+	t.Run("nonce mismatch", func(t *testing.T) {
+		state, authURL, err := o.Start(0)
+		if err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+		code := iss.authorize(t, authURL, "carol-1", map[string]interface{}{"nonce": "replayed"})
+		if _, _, err := o.Finish(ctx, state, code); err == nil || !strings.Contains(err.Error(), "nonce") {
+			t.Errorf("err = %v, want a nonce mismatch", err)
+		}
+	})
+
+	t.Run("code for another sign-in", func(t *testing.T) {
+		// The code was issued for the first sign-in's PKCE challenge, so
+		// the second sign-in's verifier must not redeem it.
+		_, firstURL, err := o.Start(0)
+		if err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+		second, _, err := o.Start(0)
+		if err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+		code := iss.authorize(t, firstURL, "carol-1", nil)
+		if _, _, err := o.Finish(ctx, second, code); err == nil || !strings.Contains(err.Error(), "exchange code") {
+			t.Errorf("err = %v, want the code exchange to fail", err)
+		}
+	})
+
+	t.Run("token for another client", func(t *testing.T) {
+		state, authURL, err := o.Start(0)
+		if err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+		code := iss.authorize(t, authURL, "carol-1", map[string]interface{}{"aud": "someone-else"})
+		if _, _, err := o.Finish(ctx, state, code); err == nil || !strings.Contains(err.Error(), "verify id_token") {
+			t.Errorf("err = %v, want verification to fail", err)
+		}
+	})
+}
+
+func TestOIDCLinkStart(t *testing.T) {
+	db, _, _ := testDB(t)
+	iss := newIssuer(t)
+	o := newTestOIDC(db, iss)
+
+	state, authURL, err := o.Start(42)
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	_, linkUserID, err := o.Finish(context.Background(), state, iss.authorize(t, authURL, "carol-1", nil))
+	if err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	if linkUserID != 42 {
+		t.Errorf("linkUserID = %d, want 42", linkUserID)
+	}
+}
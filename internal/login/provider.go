@@ -0,0 +1,65 @@
+// Package login decides whether a username and password may sign in. Each
+// Provider checks credentials against one source, such as the local database
+// or an LDAP directory, and returns the matching local user.
+package login
+
+import (
+	"backup_server/internal/config"
+	"backup_server/internal/database"
+	"errors"
+	"log"
+)
+
+// ErrInvalidCredentials is returned when a provider does not accept the
+// username and password. Authenticate then moves on to the next provider.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+type Provider interface {
+	// Name identifies the provider in logs and matches the AuthSource of
+	// the users it signs in.
+	Name() string
+	Authenticate(username, password string) (*database.User, error)
+}
+
+// Providers returns the providers enabled by cfg, the local database first.
+func Providers(db database.Repository, cfg *config.Config) []Provider {
+	providers := []Provider{&Local{DB: db}}
+	if cfg.LDAPURL != "" {
+		providers = append(providers, NewLDAP(db, cfg))
+	}
+	return providers
+}
+
+// Authenticate asks each provider in turn and returns the first user one of
+// them accepts. Errors other than ErrInvalidCredentials, such as an
+// unreachable directory, are logged and treated as a rejection.
+func Authenticate(providers []Provider, username, password string) (*database.User, error) {
+	for _, p := range providers {
+		user, err := p.Authenticate(username, password)
+		if err == nil {
+			return user, nil
+		}
+		if !errors.Is(err, ErrInvalidCredentials) {
+			log.Printf("%s sign-in for %s failed: %v", p.Name(), username, err)
+		}
+	}
+	return nil, ErrInvalidCredentials
+}
+
+// Local checks the bcrypt password hashes stored in the database. It only
+// signs in users whose AuthSource is local.
+type Local struct {
+	DB database.Repository
+}
+
+func (l *Local) Name() string {
+	return database.AuthSourceLocal
+}
+
+func (l *Local) Authenticate(username, password string) (*database.User, error) {
+	user, err := l.DB.ValidateUser(username, password)
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+	return user, nil
+}
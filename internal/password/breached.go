@@ -0,0 +1,124 @@
+package password
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"io"
+	"os"
+	"strings"
+)
+
+// BreachedList looks passwords up in a local file of SHA-1 hashes, one per
+// line, optionally followed by ":count" as in the Have I Been Pwned
+// downloads. The file must be sorted by hash; it is searched in place so
+// lists far larger than memory can be used.
+type BreachedList struct {
+	f    *os.File
+	size int64
+}
+
+func OpenBreachedList(path string) (*BreachedList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	stat, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &BreachedList{f: f, size: stat.Size()}, nil
+}
+
+func (l *BreachedList) Close() error {
+	return l.f.Close()
+}
+
+// Contains reports whether the SHA-1 hash of password is in the list.
+func (l *BreachedList) Contains(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	target := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	// Binary search over byte offsets: each probe looks at the first line
+	// starting at or after mid.
+	lo, hi := int64(0), l.size
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		start, line, err := l.lineAt(mid)
+		if err != nil {
+			return false, err
+		}
+		if start >= hi || line == "" {
+			hi = mid
+			continue
+		}
+
+		hash := strings.ToUpper(line)
+		if i := strings.IndexByte(hash, ':'); i >= 0 {
+			hash = hash[:i]
+		}
+
+		switch {
+		case hash == target:
+			return true, nil
+		case hash < target:
+			lo = start + 1
+		default:
+			hi = mid
+		}
+	}
+	return false, nil
+}
+
+// lineAt returns the offset and contents of the first line that starts at or
+// after off. An empty line is returned at end of file.
+func (l *BreachedList) lineAt(off int64) (int64, string, error) {
+	start := off
+	if off > 0 {
+		// Find the newline ending the line that contains off-1.
+		nl, err := l.indexNewline(off - 1)
+		if err != nil {
+			return 0, "", err
+		}
+		if nl < 0 {
+			return l.size, "", nil
+		}
+		start = nl + 1
+	}
+
+	end, err := l.indexNewline(start)
+	if err != nil {
+		return 0, "", err
+	}
+	if end < 0 {
+		end = l.size
+	}
+
+	buf := make([]byte, end-start)
+	if _, err := l.f.ReadAt(buf, start); err != nil && err != io.EOF {
+		return 0, "", err
+	}
+	return start, strings.TrimSpace(string(buf)), nil
+}
+
+// indexNewline returns the offset of the first '\n' at or after off, or -1.
+func (l *BreachedList) indexNewline(off int64) (int64, error) {
+	buf := make([]byte, 256)
+	for off < l.size {
+		n, err := l.f.ReadAt(buf, off)
+		if i := bytes.IndexByte(buf[:n], '\n'); i >= 0 {
+			return off + int64(i), nil
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		off += int64(n)
+	}
+	return -1, nil
+}
@@ -0,0 +1,133 @@
+package password
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func sha1Hex(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+// writeList writes lines to a file and opens it as a breached list.
+func writeList(t *testing.T, lines []string) *BreachedList {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	l, err := OpenBreachedList(path)
+	if err != nil {
+		t.Fatalf("OpenBreachedList: %v", err)
+	}
+	t.Cleanup(func() { l.Close() })
+	return l
+}
+
+// openList returns a sorted list of the passwords' hashes with counts, as
+// in the Have I Been Pwned downloads.
+func openList(t *testing.T, passwords ...string) *BreachedList {
+	t.Helper()
+	var lines []string
+	for i, p := range passwords {
+		lines = append(lines, sha1Hex(p)+":"+strings.Repeat("7", i+1))
+	}
+	sort.Strings(lines)
+	return writeList(t, lines)
+}
+
+func TestBreachedListContains(t *testing.T) {
+	passwords := []string{"123456", "password", "qwerty", "letmein", "dragon", "monkey", "iloveyou"}
+	l := openList(t, passwords...)
+
+	// Sorted, the hashes of password and iloveyou come first and last.
+	hashes := make([]string, len(passwords))
+	for i, p := range passwords {
+		hashes[i] = sha1Hex(p)
+	}
+	sort.Strings(hashes)
+	if hashes[0] != sha1Hex("password") || hashes[len(hashes)-1] != sha1Hex("iloveyou") {
+		t.Fatalf("fixture order changed: %q", hashes)
+	}
+
+	for _, p := range passwords {
+		if found, err := l.Contains(p); err != nil || !found {
+			t.Errorf("Contains(%q) = %v, %v; want true", p, found, err)
+		}
+	}
+	// football and 111111 hash below the first entry, hunter2 above the
+	// last, and the others between entries.
+	for _, p := range []string{"football", "111111", "hunter2", "abc123", "correct horse battery staple", "", "shadow"} {
+		if found, err := l.Contains(p); err != nil || found {
+			t.Errorf("Contains(%q) = %v, %v; want false", p, found, err)
+		}
+	}
+}
+
+func TestBreachedListFormats(t *testing.T) {
+	// Lowercase hashes, no counts, CRLF endings and a final newline.
+	l := writeList(t, []string{
+		sha1Hex("password") + "\r",
+		strings.ToLower(sha1Hex("123456")) + "\r",
+		"",
+	})
+	for _, p := range []string{"123456", "password"} {
+		if found, err := l.Contains(p); err != nil || !found {
+			t.Errorf("Contains(%q) = %v, %v; want true", p, found, err)
+		}
+	}
+
+	empty := writeList(t, nil)
+	if found, err := empty.Contains("password"); err != nil || found {
+		t.Errorf("empty list: Contains = %v, %v", found, err)
+	}
+}
+
+func TestBreachedListDamaged(t *testing.T) {
+	passwords := []string{"123456", "password", "qwerty", "letmein", "dragon"}
+	var lines []string
+	for _, p := range passwords {
+		lines = append(lines, sha1Hex(p))
+	}
+	sort.Strings(lines)
+
+	// An unsorted or cut-off list cannot be searched reliably, but must
+	// not make Contains fail or hang.
+	unsorted := append([]string(nil), lines...)
+	unsorted[0], unsorted[len(unsorted)-1] = unsorted[len(unsorted)-1], unsorted[0]
+	truncated := append(append([]string(nil), lines[:3]...), lines[3][:17])
+	for name, l := range map[string]*BreachedList{"unsorted": writeList(t, unsorted), "truncated": writeList(t, truncated)} {
+		for _, p := range append(passwords, "not in the list") {
+			if _, err := l.Contains(p); err != nil {
+				t.Errorf("%s: Contains(%q): %v", name, p, err)
+			}
+		}
+	}
+
+	// Entries before a truncated last line are still found.
+	l := writeList(t, truncated)
+	for _, hash := range lines[:3] {
+		for _, p := range passwords {
+			if sha1Hex(p) != hash {
+				continue
+			}
+			if found, err := l.Contains(p); err != nil || !found {
+				t.Errorf("truncated: Contains(%q) = %v, %v; want true", p, found, err)
+			}
+		}
+	}
+	// The cut-off entry matches nothing.
+	for _, p := range passwords {
+		if sha1Hex(p) == lines[3] {
+			if found, _ := l.Contains(p); found {
+				t.Errorf("truncated: cut-off hash of %q matched", p)
+			}
+		}
+	}
+}
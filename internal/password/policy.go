@@ -0,0 +1,116 @@
+package password
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Policy describes the rules new passwords must satisfy. A nil *Policy
+// accepts any password.
+type Policy struct {
+	MinLength int
+	// MinClasses is how many of the four character classes (lowercase,
+	// uppercase, digits, symbols) a password must contain.
+	MinClasses       int
+	DisallowUsername bool
+	// HistorySize is how many previous passwords a user may not reuse.
+	HistorySize int
+	Breached    *BreachedList
+}
+
+// PolicyError lists every rule a rejected password broke.
+type PolicyError struct {
+	Problems []string
+}
+
+func (e *PolicyError) Error() string {
+	return "Password " + strings.Join(e.Problems, ", ")
+}
+
+// ErrReused is returned when a password matches one of the user's recent
+// passwords.
+var ErrReused = &PolicyError{Problems: []string{"was used recently"}}
+
+// Check validates password for username against everything except history,
+// which needs the stored hashes and is checked by the caller.
+func (p *Policy) Check(username, password string) error {
+	if p == nil {
+		return nil
+	}
+
+	var problems []string
+
+	if len([]rune(password)) < p.MinLength {
+		problems = append(problems, fmt.Sprintf("must be at least %d characters", p.MinLength))
+	}
+
+	if classes := countClasses(password); classes < p.MinClasses {
+		problems = append(problems, fmt.Sprintf("must mix at least %d of lowercase, uppercase, digits and symbols", p.MinClasses))
+	}
+
+	if p.DisallowUsername && username != "" && strings.Contains(strings.ToLower(password), strings.ToLower(username)) {
+		problems = append(problems, "must not contain the username")
+	}
+
+	if p.Breached != nil {
+		found, err := p.Breached.Contains(password)
+		if err != nil {
+			return fmt.Errorf("check breached passwords: %w", err)
+		}
+		if found {
+			problems = append(problems, "appears in a list of breached passwords")
+		}
+	}
+
+	if len(problems) > 0 {
+		return &PolicyError{Problems: problems}
+	}
+	return nil
+}
+
+// Describe summarizes the policy for display next to password fields.
+func (p *Policy) Describe() string {
+	if p == nil {
+		return ""
+	}
+
+	rules := []string{fmt.Sprintf("at least %d characters", p.MinLength)}
+	if p.MinClasses > 1 {
+		rules = append(rules, fmt.Sprintf("%d of lowercase, uppercase, digits and symbols", p.MinClasses))
+	}
+	if p.DisallowUsername {
+		rules = append(rules, "not containing the username")
+	}
+	if p.HistorySize > 0 {
+		rules = append(rules, fmt.Sprintf("different from the last %d passwords", p.HistorySize))
+	}
+	if p.Breached != nil {
+		rules = append(rules, "not a known breached password")
+	}
+	return "Passwords must be " + strings.Join(rules, ", ") + "."
+}
+
+func countClasses(password string) int {
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	count := 0
+	for _, present := range []bool{lower, upper, digit, symbol} {
+		if present {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,64 @@
+package password
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPolicyCheck(t *testing.T) {
+	p := &Policy{MinLength: 8, MinClasses: 3, DisallowUsername: true}
+	tests := []struct {
+		username, password string
+		problems           []string
+	}{
+		{"alice", "Tr0ub4dor", nil},
+		{"alice", "Tr0ub4", []string{"must be at least 8 characters"}},
+		// Length counts characters, not bytes.
+		{"alice", "Äöü1234", []string{"must be at least 8 characters"}},
+		{"alice", "Äöü12345", nil},
+		{"alice", "troubadour", []string{"must mix at least 3 of lowercase, uppercase, digits and symbols"}},
+		{"alice", "troub4dour", []string{"must mix at least 3 of lowercase, uppercase, digits and symbols"}},
+		{"alice", "troub4dour!", nil},
+		{"alice", "my-ALICE-1", []string{"must not contain the username"}},
+		{"", "my-ALICE-1", nil},
+		{"alice", "alice", []string{
+			"must be at least 8 characters",
+			"must mix at least 3 of lowercase, uppercase, digits and symbols",
+			"must not contain the username",
+		}},
+	}
+	for _, tt := range tests {
+		err := p.Check(tt.username, tt.password)
+		var problems []string
+		if err != nil {
+			var policyErr *PolicyError
+			if !errors.As(err, &policyErr) {
+				t.Fatalf("Check(%q, %q): %v is not a PolicyError", tt.username, tt.password, err)
+			}
+			problems = policyErr.Problems
+		}
+		if !reflect.DeepEqual(problems, tt.problems) {
+			t.Errorf("Check(%q, %q) problems = %q, want %q", tt.username, tt.password, problems, tt.problems)
+		}
+	}
+
+	p.DisallowUsername = false
+	if err := p.Check("alice", "my-ALICE-1"); err != nil {
+		t.Errorf("username allowed: %v", err)
+	}
+	var none *Policy
+	if err := none.Check("alice", ""); err != nil {
+		t.Errorf("nil policy: %v", err)
+	}
+}
+
+func TestPolicyCheckBreached(t *testing.T) {
+	p := &Policy{MinLength: 4, Breached: openList(t, "password", "letmein")}
+	if err := p.Check("alice", "letmein"); err == nil || err.Error() != "Password appears in a list of breached passwords" {
+		t.Errorf("breached password: err = %v", err)
+	}
+	if err := p.Check("alice", "letmeout"); err != nil {
+		t.Errorf("password not in the list: %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package terraria
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// HeaderCache remembers parsed world headers, and failures to parse them,
+// until the file's size or modification time changes.
+type HeaderCache struct {
+	files fileCache[*WorldHeader]
+}
+
+func NewHeaderCache() *HeaderCache {
+	return &HeaderCache{files: newFileCache(ReadWorldHeaderFile)}
+}
+
+// Get returns the header of the world file at path.
+func (c *HeaderCache) Get(path string) (*WorldHeader, error) {
+	return c.files.get(path)
+}
+
+// ModDataCache is HeaderCache for tModLoader sidecars.
+type ModDataCache struct {
+	files fileCache[*ModData]
+}
+
+func NewModDataCache() *ModDataCache {
+	return &ModDataCache{files: newFileCache(ReadModDataFile)}
+}
+
+// Get returns the mod data of the sidecar at path.
+func (c *ModDataCache) Get(path string) (*ModData, error) {
+	return c.files.get(path)
+}
+
+// fileCache remembers what read returned for each path, keyed by the file's
+// size and modification time.
+type fileCache[T any] struct {
+	read    func(path string) (T, error)
+	mu      sync.Mutex
+	entries map[string]cacheEntry[T]
+}
+
+type cacheEntry[T any] struct {
+	size    int64
+	modTime time.Time
+	value   T
+	err     error
+}
+
+func newFileCache[T any](read func(string) (T, error)) fileCache[T] {
+	return fileCache[T]{read: read, entries: make(map[string]cacheEntry[T])}
+}
+
+func (c *fileCache[T]) get(path string) (T, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[path]
+	c.mu.Unlock()
+	if ok && entry.size == info.Size() && entry.modTime.Equal(info.ModTime()) {
+		return entry.value, entry.err
+	}
+
+	value, err := c.read(path)
+
+	c.mu.Lock()
+	c.entries[path] = cacheEntry[T]{
+		size:    info.Size(),
+		modTime: info.ModTime(),
+		value:   value,
+		err:     err,
+	}
+	c.mu.Unlock()
+	return value, err
+}
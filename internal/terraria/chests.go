@@ -0,0 +1,187 @@
+package terraria
+
+//go:generate sh -c "node itemnames_gen.js && gofmt -w itemnames.go"
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Item is a stack of items in a chest slot.
+type Item struct {
+	ID     int32 `json:"id"`
+	Stack  int   `json:"stack"`
+	Prefix uint8 `json:"prefix"`
+}
+
+// ItemName returns the English name of an item ID.
+func ItemName(id int32) string {
+	if id > 0 && int(id) < len(itemNames) && itemNames[id] != "" {
+		return itemNames[id]
+	}
+	return fmt.Sprintf("Item #%d", id)
+}
+
+// Empty reports whether the slot holding the item is empty.
+func (i Item) Empty() bool {
+	return i.ID == 0 || i.Stack == 0
+}
+
+// Name is the item's English name, with its prefix if it has one.
+func (i Item) Name() string {
+	if int(i.Prefix) < len(prefixNames) && prefixNames[i.Prefix] != "" {
+		return prefixNames[i.Prefix] + " " + ItemName(i.ID)
+	}
+	return ItemName(i.ID)
+}
+
+// FindItems returns up to limit IDs of items whose English name contains
+// query, ignoring case, with exact matches first. A number finds the item
+// with that ID.
+func FindItems(query string, limit int) []int32 {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+	if id, err := strconv.Atoi(query); err == nil {
+		if id > 0 && id < len(itemNames) && itemNames[id] != "" {
+			return []int32{int32(id)}
+		}
+		return nil
+	}
+
+	var exact, partial []int32
+	for id, name := range itemNames {
+		lower := strings.ToLower(name)
+		switch {
+		case name == "":
+		case lower == query:
+			exact = append(exact, int32(id))
+		case strings.Contains(lower, query):
+			partial = append(partial, int32(id))
+		}
+	}
+	ids := append(exact, partial...)
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids
+}
+
+// Chest is a container placed in a world, located by its top-left tile.
+type Chest struct {
+	X     int32  `json:"x"`
+	Y     int32  `json:"y"`
+	Name  string `json:"name"`
+	Items []Item `json:"items"`
+}
+
+// Totals sums the chest's stacks by item ID.
+func (c *Chest) Totals() map[int32]int {
+	totals := make(map[int32]int)
+	for _, item := range c.Items {
+		totals[item.ID] += item.Stack
+	}
+	return totals
+}
+
+// maxChestSection bounds the chest data read into memory.
+const maxChestSection = 64 << 20
+
+// ReadChestsFile reads the header and chests of the world file at path.
+func ReadChestsFile(path string) (*WorldHeader, []Chest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	rd := newReader(f)
+	h, err := readWorldHeader(rd)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(h.sections) < 3 {
+		return nil, nil, fmt.Errorf("read chests: world has no chest section")
+	}
+	rd.seek(int64(h.sections[2]))
+	chests, err := readChests(rd, h)
+	if err != nil {
+		return nil, nil, err
+	}
+	return h, chests, nil
+}
+
+// readChests decodes the chest section, where rd must be positioned.
+func readChests(rd *reader, h *WorldHeader) ([]Chest, error) {
+	if len(h.sections) < 4 {
+		return nil, fmt.Errorf("read chests: world has no chest section")
+	}
+	size := int64(h.sections[3]) - int64(h.sections[2])
+	if size < 0 || size > maxChestSection {
+		return nil, fmt.Errorf("read chests: section of %d bytes", size)
+	}
+	data := rd.bytes(int(size))
+	if rd.err != nil {
+		return nil, fmt.Errorf("read chests: %w", rd.err)
+	}
+
+	// Terraria 1.4.4 stores one slot count for every chest; later releases
+	// store a count per chest. The section's recorded length tells which
+	// layout parses.
+	chests, err := parseChests(data, false)
+	if err != nil {
+		chests, err = parseChests(data, true)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read chests: %w", err)
+	}
+	return chests, nil
+}
+
+func parseChests(data []byte, slotsPerChest bool) ([]Chest, error) {
+	rd := newReader(bytes.NewReader(data))
+
+	count := int(rd.i16())
+	slots := 40
+	if !slotsPerChest {
+		slots = int(rd.i16())
+	}
+	if count < 0 || slots < 0 {
+		return nil, fmt.Errorf("%d chests of %d slots", count, slots)
+	}
+
+	chests := make([]Chest, 0, count)
+	for i := 0; i < count && rd.err == nil; i++ {
+		c := Chest{X: rd.i32(), Y: rd.i32(), Name: rd.str()}
+		if slotsPerChest {
+			slots = int(rd.i32())
+			if slots < 0 || slots > 1<<12 {
+				return nil, fmt.Errorf("chest of %d slots", slots)
+			}
+		}
+		for j := 0; j < slots && rd.err == nil; j++ {
+			stack := int(rd.i16())
+			if stack > 0 || (slotsPerChest && stack < 0) {
+				id := rd.i32()
+				prefix := rd.u8()
+				if stack < 0 {
+					stack = 1
+				}
+				c.Items = append(c.Items, Item{ID: id, Stack: stack, Prefix: prefix})
+			}
+		}
+		chests = append(chests, c)
+	}
+
+	if rd.err != nil {
+		return nil, rd.err
+	}
+	if rd.pos != int64(len(data)) {
+		return nil, fmt.Errorf("chest data ends at %d of %d bytes", rd.pos, len(data))
+	}
+	return chests, nil
+}
@@ -0,0 +1,110 @@
+package terraria
+
+import (
+	"encoding/binary"
+	"os"
+	"reflect"
+	"testing"
+)
+
+var testChests = []Chest{
+	{X: 10, Y: 20, Name: "", Items: []Item{{ID: 9, Stack: 99}, {ID: 4, Stack: 1, Prefix: 1}}},
+	{X: 300, Y: 40, Name: "Stash", Items: []Item{{ID: 73, Stack: 12}}},
+	{X: 7, Y: 8, Name: "Empty"},
+}
+
+func TestReadChestsFile(t *testing.T) {
+	for _, slotsPerChest := range []bool{false, true} {
+		path := writeTemp(t, "world.wld", testWorld{chests: testChests, slotsPerChest: slotsPerChest}.build())
+		h, chests, err := ReadChestsFile(path)
+		if err != nil {
+			t.Fatalf("slots per chest %v: ReadChestsFile: %v", slotsPerChest, err)
+		}
+		if h.Width != 8 {
+			t.Errorf("slots per chest %v: header width %d", slotsPerChest, h.Width)
+		}
+		if !reflect.DeepEqual(chests, testChests) {
+			t.Errorf("slots per chest %v: chests = %+v, want %+v", slotsPerChest, chests, testChests)
+		}
+	}
+}
+
+func TestReadChestsTruncated(t *testing.T) {
+	world := testWorld{chests: testChests}.build()
+	chestsAt := int(binary.LittleEndian.Uint32(world[26+8:]))
+	signsAt := int(binary.LittleEndian.Uint32(world[26+12:]))
+	path := writeTemp(t, "world.wld", nil)
+	for n := chestsAt; n < signsAt; n++ {
+		if err := os.WriteFile(path, world[:n], 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := ReadChestsFile(path); err == nil {
+			t.Fatalf("chests cut to %d of %d bytes were read", n-chestsAt, signsAt-chestsAt)
+		}
+	}
+
+	// A chest section longer than its chests is rejected too.
+	binary.LittleEndian.PutUint32(world[26+12:], uint32(signsAt+1))
+	if err := os.WriteFile(path, world, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := ReadChestsFile(path); err == nil {
+		t.Error("chest section with trailing bytes was read")
+	}
+}
+
+func TestItemNames(t *testing.T) {
+	if got := ItemName(2); got != "Dirt Block" {
+		t.Errorf("ItemName(2) = %q", got)
+	}
+	if got := ItemName(0); got != "Item #0" {
+		t.Errorf("ItemName(0) = %q", got)
+	}
+	if got := ItemName(1 << 20); got != "Item #1048576" {
+		t.Errorf("ItemName of an unknown ID = %q", got)
+	}
+	if got := (Item{ID: 4, Stack: 1, Prefix: 1}).Name(); got != "Large Iron Broadsword" {
+		t.Errorf("prefixed name = %q", got)
+	}
+	if got := (Item{ID: 4, Stack: 1}).Name(); got != "Iron Broadsword" {
+		t.Errorf("name = %q", got)
+	}
+}
+
+func TestFindItems(t *testing.T) {
+	tests := []struct {
+		query string
+		limit int
+		want  []int32
+	}{
+		{"", 10, nil},
+		{"2", 10, []int32{2}},
+		{"0", 10, nil},
+		{"99999", 10, nil},
+		{"  Dirt block ", 1, []int32{2}},
+		{"iron pickaxe", 10, []int32{1}},
+	}
+	for _, tt := range tests {
+		if got := FindItems(tt.query, tt.limit); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FindItems(%q, %d) = %v, want %v", tt.query, tt.limit, got, tt.want)
+		}
+	}
+
+	// Partial matches follow the exact one, up to the limit.
+	ids := FindItems("wood", 5)
+	if len(ids) != 5 || ids[0] != 9 {
+		t.Errorf("FindItems(wood) = %v, want 5 IDs starting with Wood", ids)
+	}
+	for _, id := range ids[1:] {
+		if id == 9 {
+			t.Errorf("Wood listed twice: %v", ids)
+		}
+	}
+}
+
+func TestChestTotals(t *testing.T) {
+	c := Chest{Items: []Item{{ID: 9, Stack: 40}, {ID: 8, Stack: 3}, {ID: 9, Stack: 2}}}
+	if got, want := c.Totals(), map[int32]int{9: 42, 8: 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Totals = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,307 @@
+package terraria
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"os"
+	"sort"
+)
+
+// ErrSizeMismatch is returned when two worlds of different sizes are
+// compared tile by tile.
+var ErrSizeMismatch = errors.New("worlds have different sizes")
+
+// diffBlock is the side, in tiles, of the squares changes are grouped into
+// before neighbouring squares are merged into regions.
+const diffBlock = 32
+
+// maxDiffRegions bounds how many regions a diff lists, largest first.
+const maxDiffRegions = 50
+
+// Overlay colours for each kind of change.
+var (
+	addedColor   = color.RGBA{0, 220, 0, 255}
+	removedColor = color.RGBA{230, 0, 0, 255}
+	changedColor = color.RGBA{255, 200, 0, 255}
+)
+
+// Region is a rectangle of a world, in tiles, in which tiles changed.
+type Region struct {
+	X       int `json:"x"`
+	Y       int `json:"y"`
+	Width   int `json:"width"`
+	Height  int `json:"height"`
+	Added   int `json:"added"`
+	Removed int `json:"removed"`
+	Changed int `json:"changed"`
+}
+
+// Total is the number of tiles that changed in the region.
+func (r Region) Total() int {
+	return r.Added + r.Removed + r.Changed
+}
+
+// ItemChange is the change in how many of an item a chest holds.
+type ItemChange struct {
+	ID     int32  `json:"id"`
+	Name   string `json:"name"`
+	Before int    `json:"before"`
+	After  int    `json:"after"`
+}
+
+// ChestChange describes a chest that was added, removed or whose contents
+// changed.
+type ChestChange struct {
+	X    int32  `json:"x"`
+	Y    int32  `json:"y"`
+	Name string `json:"name"`
+	// OldName is set when the chest was renamed.
+	OldName string       `json:"old_name,omitempty"`
+	Status  string       `json:"status"`
+	Items   []ItemChange `json:"items"`
+}
+
+// Diff summarises the changes from one world to another.
+type Diff struct {
+	Old *WorldHeader `json:"old"`
+	New *WorldHeader `json:"new"`
+	// Added counts tiles where a block or object appeared, Removed where
+	// one disappeared, and Changed where one was replaced or a wall
+	// changed. Liquids are ignored, as they move on their own.
+	Added   int `json:"added"`
+	Removed int `json:"removed"`
+	Changed int `json:"changed"`
+	// Regions lists the largest areas of change.
+	Regions      []Region      `json:"regions"`
+	MoreRegions  int           `json:"more_regions"`
+	ChestChanges []ChestChange `json:"chest_changes"`
+}
+
+// DiffFiles compares the world files at oldPath and newPath. It returns the
+// summary and an overlay image: the new world's map dimmed, with added,
+// removed and changed tiles in green, red and yellow. The image is scaled
+// down like RenderMap's for worlds larger than MaxMapPixels.
+func DiffFiles(oldPath, newPath string) (*Diff, *image.RGBA, error) {
+	oldFile, err := os.Open(oldPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer oldFile.Close()
+
+	newFile, err := os.Open(newPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer newFile.Close()
+
+	oldRd, newRd := newReader(oldFile), newReader(newFile)
+	oldH, err := readWorldHeader(oldRd)
+	if err != nil {
+		return nil, nil, err
+	}
+	newH, err := readWorldHeader(newRd)
+	if err != nil {
+		return nil, nil, err
+	}
+	if oldH.Width != newH.Width || oldH.Height != newH.Height {
+		return nil, nil, ErrSizeMismatch
+	}
+	if err := checkWorldSize(newH); err != nil {
+		return nil, nil, err
+	}
+
+	width, height := int(newH.Width), int(newH.Height)
+	d := &Diff{Old: oldH, New: newH}
+	canvas := newMapCanvas(width, height)
+
+	blocksX, blocksY := (width+diffBlock-1)/diffBlock, (height+diffBlock-1)/diffBlock
+	blocks := make([]Region, blocksX*blocksY)
+
+	oldCol, newCol := make([]Tile, height), make([]Tile, height)
+	for x := 0; x < width; x++ {
+		if err := readColumn(oldRd, oldH, oldCol); err != nil {
+			return nil, nil, err
+		}
+		if err := readColumn(newRd, newH, newCol); err != nil {
+			return nil, nil, err
+		}
+		for y := range newCol {
+			o, n := oldCol[y], newCol[y]
+			block := &blocks[(y/diffBlock)*blocksX+x/diffBlock]
+			switch {
+			case !o.Active && n.Active:
+				d.Added++
+				block.Added++
+				canvas.mark(x, y, addedColor)
+			case o.Active && !n.Active:
+				d.Removed++
+				block.Removed++
+				canvas.mark(x, y, removedColor)
+			case o.Active && n.Active && o.Type != n.Type, o.Wall != n.Wall:
+				d.Changed++
+				block.Changed++
+				canvas.mark(x, y, changedColor)
+			default:
+				canvas.set(x, y, dim(tileColor(newH, y, n)))
+			}
+		}
+		canvas.endColumn(x)
+	}
+	if err := checkSection(oldRd, oldH, 2, "tiles"); err != nil {
+		return nil, nil, err
+	}
+	if err := checkSection(newRd, newH, 2, "tiles"); err != nil {
+		return nil, nil, err
+	}
+
+	d.Regions = mergeBlocks(blocks, blocksX, blocksY, width, height)
+	if len(d.Regions) > maxDiffRegions {
+		d.MoreRegions = len(d.Regions) - maxDiffRegions
+		d.Regions = d.Regions[:maxDiffRegions]
+	}
+
+	oldChests, err := readChests(oldRd, oldH)
+	if err != nil {
+		return nil, nil, err
+	}
+	newChests, err := readChests(newRd, newH)
+	if err != nil {
+		return nil, nil, err
+	}
+	d.ChestChanges = diffChests(oldChests, newChests)
+
+	return d, canvas.img, nil
+}
+
+// dim darkens unchanged tiles so changes stand out.
+func dim(c color.RGBA) color.RGBA {
+	return color.RGBA{c.R / 3, c.G / 3, c.B / 3, 255}
+}
+
+// mergeBlocks joins neighbouring blocks with changes into regions, largest
+// first.
+func mergeBlocks(blocks []Region, blocksX, blocksY, width, height int) []Region {
+	seen := make([]bool, len(blocks))
+	var regions []Region
+	for start := range blocks {
+		if seen[start] || blocks[start].Total() == 0 {
+			continue
+		}
+
+		minX, minY, maxX, maxY := blocksX, blocksY, -1, -1
+		var r Region
+		stack := []int{start}
+		seen[start] = true
+		for len(stack) > 0 {
+			i := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			bx, by := i%blocksX, i/blocksX
+			r.Added += blocks[i].Added
+			r.Removed += blocks[i].Removed
+			r.Changed += blocks[i].Changed
+			minX, minY = min(minX, bx), min(minY, by)
+			maxX, maxY = max(maxX, bx), max(maxY, by)
+
+			for _, next := range [][2]int{{bx - 1, by}, {bx + 1, by}, {bx, by - 1}, {bx, by + 1}} {
+				nx, ny := next[0], next[1]
+				if nx < 0 || ny < 0 || nx >= blocksX || ny >= blocksY {
+					continue
+				}
+				j := ny*blocksX + nx
+				if !seen[j] && blocks[j].Total() > 0 {
+					seen[j] = true
+					stack = append(stack, j)
+				}
+			}
+		}
+
+		r.X, r.Y = minX*diffBlock, minY*diffBlock
+		r.Width = min((maxX+1)*diffBlock, width) - r.X
+		r.Height = min((maxY+1)*diffBlock, height) - r.Y
+		regions = append(regions, r)
+	}
+
+	sort.SliceStable(regions, func(i, j int) bool {
+		return regions[i].Total() > regions[j].Total()
+	})
+	return regions
+}
+
+// diffChests matches chests by position and reports those added, removed
+// or with different contents. Moving items between slots is not a change.
+func diffChests(oldChests, newChests []Chest) []ChestChange {
+	type key struct{ x, y int32 }
+	before := make(map[key]*Chest)
+	for i := range oldChests {
+		c := &oldChests[i]
+		before[key{c.X, c.Y}] = c
+	}
+
+	var changes []ChestChange
+	for i := range newChests {
+		n := &newChests[i]
+		k := key{n.X, n.Y}
+		o, ok := before[k]
+		delete(before, k)
+		if !ok {
+			changes = append(changes, chestChange(nil, n, "added"))
+			continue
+		}
+		if change := chestChange(o, n, "changed"); len(change.Items) > 0 || o.Name != n.Name {
+			changes = append(changes, change)
+		}
+	}
+	for i := range oldChests {
+		o := &oldChests[i]
+		if _, ok := before[key{o.X, o.Y}]; ok {
+			changes = append(changes, chestChange(o, nil, "removed"))
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].X != changes[j].X {
+			return changes[i].X < changes[j].X
+		}
+		return changes[i].Y < changes[j].Y
+	})
+	return changes
+}
+
+func chestChange(o, n *Chest, status string) ChestChange {
+	change := ChestChange{Status: status}
+	oldTotals, newTotals := map[int32]int{}, map[int32]int{}
+	if o != nil {
+		change.X, change.Y, change.Name = o.X, o.Y, o.Name
+		oldTotals = o.Totals()
+	}
+	if n != nil {
+		change.X, change.Y, change.Name = n.X, n.Y, n.Name
+		newTotals = n.Totals()
+	}
+	if o != nil && n != nil && o.Name != n.Name {
+		change.OldName = o.Name
+	}
+
+	ids := make(map[int32]bool)
+	for id := range oldTotals {
+		ids[id] = true
+	}
+	for id := range newTotals {
+		ids[id] = true
+	}
+	for id := range ids {
+		if oldTotals[id] != newTotals[id] {
+			change.Items = append(change.Items, ItemChange{
+				ID:     id,
+				Name:   ItemName(id),
+				Before: oldTotals[id],
+				After:  newTotals[id],
+			})
+		}
+	}
+	sort.Slice(change.Items, func(i, j int) bool {
+		return change.Items[i].Name < change.Items[j].Name
+	})
+	return change
+}
@@ -0,0 +1,120 @@
+package terraria
+
+import (
+	"errors"
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+func TestDiffFiles(t *testing.T) {
+	dirt := Tile{Active: true, Type: 0}
+	before := testWorld{
+		width:  64,
+		height: 40,
+		tile: func(x, y int) Tile {
+			switch [2]int{x, y} {
+			case [2]int{1, 1}, [2]int{2, 2}, [2]int{40, 35}:
+				return dirt
+			case [2]int{3, 3}:
+				return Tile{Wall: 1}
+			}
+			return Tile{}
+		},
+		chests: []Chest{
+			{X: 10, Y: 10, Items: []Item{{ID: 9, Stack: 10}}},
+			{X: 20, Y: 20, Name: "Stash", Items: []Item{{ID: 8, Stack: 5}}},
+			{X: 30, Y: 30, Items: []Item{{ID: 2, Stack: 1}}},
+		},
+	}
+	after := testWorld{
+		width:  64,
+		height: 40,
+		tile: func(x, y int) Tile {
+			switch [2]int{x, y} {
+			case [2]int{2, 2}:
+				return Tile{Active: true, Type: 1}
+			case [2]int{3, 3}:
+				return Tile{Wall: 2}
+			case [2]int{5, 5}, [2]int{40, 35}:
+				return dirt
+			case [2]int{6, 6}:
+				// Liquids move on their own and are not changes.
+				return Tile{Liquid: LiquidWater, LiquidAmount: 255}
+			}
+			return Tile{}
+		},
+		// The newer layout must compare with the older one.
+		slotsPerChest: true,
+		chests: []Chest{
+			{X: 5, Y: 5, Items: []Item{{ID: 8, Stack: 2}}},
+			// Splitting a stack between slots is not a change.
+			{X: 10, Y: 10, Items: []Item{{ID: 9, Stack: 4}, {ID: 73, Stack: 1}, {ID: 9, Stack: 6}}},
+			{X: 20, Y: 20, Name: "Loot", Items: []Item{{ID: 8, Stack: 5}}},
+		},
+	}
+
+	d, img, err := DiffFiles(writeTemp(t, "before.wld", before.build()), writeTemp(t, "after.wld", after.build()))
+	if err != nil {
+		t.Fatalf("DiffFiles: %v", err)
+	}
+	if d.Added != 1 || d.Removed != 1 || d.Changed != 2 {
+		t.Errorf("added %d, removed %d, changed %d; want 1, 1, 2", d.Added, d.Removed, d.Changed)
+	}
+	if want := []Region{{X: 0, Y: 0, Width: 32, Height: 32, Added: 1, Removed: 1, Changed: 2}}; !reflect.DeepEqual(d.Regions, want) {
+		t.Errorf("regions = %+v, want %+v", d.Regions, want)
+	}
+	if d.Old.Width != 64 || d.New.Height != 40 {
+		t.Errorf("headers %dx%d", d.Old.Width, d.New.Height)
+	}
+
+	pixels := map[[2]int]struct {
+		name string
+		want color.RGBA
+	}{
+		{5, 5}:   {"added", addedColor},
+		{1, 1}:   {"removed", removedColor},
+		{2, 2}:   {"changed", changedColor},
+		{3, 3}:   {"changed wall", changedColor},
+		{40, 35}: {"unchanged", dim(tileColors[0])},
+	}
+	for at, p := range pixels {
+		if got := img.RGBAAt(at[0], at[1]); got != p.want {
+			t.Errorf("%s tile at %v drawn %v, want %v", p.name, at, got, p.want)
+		}
+	}
+
+	want := []ChestChange{
+		{X: 5, Y: 5, Status: "added", Items: []ItemChange{{ID: 8, Name: "Torch", After: 2}}},
+		{X: 10, Y: 10, Status: "changed", Items: []ItemChange{{ID: 73, Name: "Gold Coin", After: 1}}},
+		{X: 20, Y: 20, Name: "Loot", OldName: "Stash", Status: "changed"},
+		{X: 30, Y: 30, Status: "removed", Items: []ItemChange{{ID: 2, Name: "Dirt Block", Before: 1}}},
+	}
+	if !reflect.DeepEqual(d.ChestChanges, want) {
+		t.Errorf("chest changes = %+v, want %+v", d.ChestChanges, want)
+	}
+}
+
+func TestDiffFilesSizeMismatch(t *testing.T) {
+	small := writeTemp(t, "small.wld", testWorld{}.build())
+	large := writeTemp(t, "large.wld", testWorld{width: 16, height: 6}.build())
+	if _, _, err := DiffFiles(small, large); !errors.Is(err, ErrSizeMismatch) {
+		t.Errorf("err = %v, want ErrSizeMismatch", err)
+	}
+}
+
+func TestMergeBlocks(t *testing.T) {
+	// Blocks 0 and 1 touch; block 5 stands alone and is larger.
+	blocks := make([]Region, 6)
+	blocks[0].Added = 1
+	blocks[1].Removed = 1
+	blocks[5].Changed = 3
+	got := mergeBlocks(blocks, 3, 2, 70, 40)
+	want := []Region{
+		{X: 64, Y: 32, Width: 6, Height: 8, Changed: 3},
+		{X: 0, Y: 0, Width: 64, Height: 32, Added: 1, Removed: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeBlocks = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,6229 @@
+// Code generated by itemnames_gen.js from static/terramap/resources/js/settings.js; DO NOT EDIT.
+
+package terraria
+
+// itemNames gives the English name of each item ID.
+var itemNames = [6145]string{
+	1:    "Iron Pickaxe",
+	2:    "Dirt Block",
+	3:    "Stone Block",
+	4:    "Iron Broadsword",
+	5:    "Mushroom",
+	6:    "Iron Shortsword",
+	7:    "Iron Hammer",
+	8:    "Torch",
+	9:    "Wood",
+	10:   "Iron Axe",
+	11:   "Iron Ore",
+	12:   "Copper Ore",
+	13:   "Gold Ore",
+	14:   "Silver Ore",
+	15:   "Copper Watch",
+	16:   "Silver Watch",
+	17:   "Gold Watch",
+	18:   "Depth Meter",
+	19:   "Gold Bar",
+	20:   "Copper Bar",
+	21:   "Silver Bar",
+	22:   "Iron Bar",
+	23:   "Gel",
+	24:   "Wooden Sword",
+	25:   "Wooden Door",
+	26:   "Stone Wall",
+	27:   "Acorn",
+	28:   "Lesser Healing Potion",
+	29:   "Life Crystal",
+	30:   "Dirt Wall",
+	31:   "Bottle",
+	32:   "Wooden Table",
+	33:   "Furnace",
+	34:   "Wooden Chair",
+	35:   "Iron Anvil",
+	36:   "Work Bench",
+	37:   "Goggles",
+	38:   "Lens",
+	39:   "Wooden Bow",
+	40:   "Wooden Arrow",
+	41:   "Flaming Arrow",
+	42:   "Shuriken",
+	43:   "Suspicious Looking Eye",
+	44:   "Demon Bow",
+	45:   "War Axe of the Night",
+	46:   "Light's Bane",
+	47:   "Unholy Arrow",
+	48:   "Chest",
+	49:   "Band of Regeneration",
+	50:   "Magic Mirror",
+	51:   "Jester's Arrow",
+	52:   "Angel Statue",
+	53:   "Cloud in a Bottle",
+	54:   "Hermes Boots",
+	55:   "Enchanted Boomerang",
+	56:   "Demonite Ore",
+	57:   "Demonite Bar",
+	58:   "Heart",
+	59:   "Corrupt Seeds",
+	60:   "Vile Mushroom",
+	61:   "Ebonstone Block",
+	62:   "Grass Seeds",
+	63:   "Sunflower",
+	64:   "Vilethorn",
+	65:   "Starfury",
+	66:   "Purification Powder",
+	67:   "Vile Powder",
+	68:   "Rotten Chunk",
+	69:   "Worm Tooth",
+	70:   "Worm Food",
+	71:   "Copper Coin",
+	72:   "Silver Coin",
+	73:   "Gold Coin",
+	74:   "Platinum Coin",
+	75:   "Fallen Star",
+	76:   "Copper Greaves",
+	77:   "Iron Greaves",
+	78:   "Silver Greaves",
+	79:   "Gold Greaves",
+	80:   "Copper Chainmail",
+	81:   "Iron Chainmail",
+	82:   "Silver Chainmail",
+	83:   "Gold Chainmail",
+	84:   "Grappling Hook",
+	85:   "Chain",
+	86:   "Shadow Scale",
+	87:   "Piggy Bank",
+	88:   "Mining Helmet",
+	89:   "Copper Helmet",
+	90:   "Iron Helmet",
+	91:   "Silver Helmet",
+	92:   "Gold Helmet",
+	93:   "Wood Wall",
+	94:   "Wood Platform",
+	95:   "Flintlock Pistol",
+	96:   "Musket",
+	97:   "Musket Ball",
+	98:   "Minishark",
+	99:   "Iron Bow",
+	100:  "Shadow Greaves",
+	101:  "Shadow Scalemail",
+	102:  "Shadow Helmet",
+	103:  "Nightmare Pickaxe",
+	104:  "The Breaker",
+	105:  "Candle",
+	106:  "Copper Chandelier",
+	107:  "Silver Chandelier",
+	108:  "Gold Chandelier",
+	109:  "Mana Crystal",
+	110:  "Lesser Mana Potion",
+	111:  "Band of Starpower",
+	112:  "Flower of Fire",
+	113:  "Magic Missile",
+	114:  "Dirt Rod",
+	115:  "Shadow Orb",
+	116:  "Meteorite",
+	117:  "Meteorite Bar",
+	118:  "Hook",
+	119:  "Flamarang",
+	120:  "Molten Fury",
+	121:  "Fiery Greatsword",
+	122:  "Molten Pickaxe",
+	123:  "Meteor Helmet",
+	124:  "Meteor Suit",
+	125:  "Meteor Leggings",
+	126:  "Bottled Water",
+	127:  "Space Gun",
+	128:  "Rocket Boots",
+	129:  "Gray Brick",
+	130:  "Gray Brick Wall",
+	131:  "Red Brick",
+	132:  "Red Brick Wall",
+	133:  "Clay Block",
+	134:  "Blue Brick",
+	135:  "Blue Brick Wall",
+	136:  "Chain Lantern",
+	137:  "Green Brick",
+	138:  "Green Brick Wall",
+	139:  "Pink Brick",
+	140:  "Pink Brick Wall",
+	141:  "Gold Brick",
+	142:  "Gold Brick Wall",
+	143:  "Silver Brick",
+	144:  "Silver Brick Wall",
+	145:  "Copper Brick",
+	146:  "Copper Brick Wall",
+	147:  "Spike",
+	148:  "Water Candle",
+	149:  "Book",
+	150:  "Cobweb",
+	151:  "Necro Helmet",
+	152:  "Necro Breastplate",
+	153:  "Necro Greaves",
+	154:  "Bone",
+	155:  "Muramasa",
+	156:  "Cobalt Shield",
+	157:  "Aqua Scepter",
+	158:  "Lucky Horseshoe",
+	159:  "Shiny Red Balloon",
+	160:  "Harpoon",
+	161:  "Spiky Ball",
+	162:  "Ball O' Hurt",
+	163:  "Blue Moon",
+	164:  "Handgun",
+	165:  "Water Bolt",
+	166:  "Bomb",
+	167:  "Dynamite",
+	168:  "Grenade",
+	169:  "Sand Block",
+	170:  "Glass",
+	171:  "Sign",
+	172:  "Ash Block",
+	173:  "Obsidian",
+	174:  "Hellstone",
+	175:  "Hellstone Bar",
+	176:  "Mud Block",
+	177:  "Sapphire",
+	178:  "Ruby",
+	179:  "Emerald",
+	180:  "Topaz",
+	181:  "Amethyst",
+	182:  "Diamond",
+	183:  "Glowing Mushroom",
+	184:  "Star",
+	185:  "Ivy Whip",
+	186:  "Breathing Reed",
+	187:  "Flipper",
+	188:  "Healing Potion",
+	189:  "Mana Potion",
+	190:  "Blade of Grass",
+	191:  "Thorn Chakram",
+	192:  "Obsidian Brick",
+	193:  "Obsidian Skull",
+	194:  "Mushroom Grass Seeds",
+	195:  "Jungle Grass Seeds",
+	196:  "Wooden Hammer",
+	197:  "Star Cannon",
+	198:  "Blue Phaseblade",
+	199:  "Red Phaseblade",
+	200:  "Green Phaseblade",
+	201:  "Purple Phaseblade",
+	202:  "White Phaseblade",
+	203:  "Yellow Phaseblade",
+	204:  "Meteor Hamaxe",
+	205:  "Empty Bucket",
+	206:  "Water Bucket",
+	207:  "Lava Bucket",
+	208:  "Jungle Rose",
+	209:  "Stinger",
+	210:  "Vine",
+	211:  "Feral Claws",
+	212:  "Anklet of the Wind",
+	213:  "Staff of Regrowth",
+	214:  "Hellstone Brick",
+	215:  "Whoopie Cushion",
+	216:  "Shackle",
+	217:  "Molten Hamaxe",
+	218:  "Flamelash",
+	219:  "Phoenix Blaster",
+	220:  "Sunfury",
+	221:  "Hellforge",
+	222:  "Clay Pot",
+	223:  "Nature's Gift",
+	224:  "Bed",
+	225:  "Silk",
+	226:  "Lesser Restoration Potion",
+	227:  "Restoration Potion",
+	228:  "Jungle Hat",
+	229:  "Jungle Shirt",
+	230:  "Jungle Pants",
+	231:  "Molten Helmet",
+	232:  "Molten Breastplate",
+	233:  "Molten Greaves",
+	234:  "Meteor Shot",
+	235:  "Sticky Bomb",
+	236:  "Black Lens",
+	237:  "Sunglasses",
+	238:  "Wizard Hat",
+	239:  "Top Hat",
+	240:  "Tuxedo Shirt",
+	241:  "Tuxedo Pants",
+	242:  "Summer Hat",
+	243:  "Bunny Hood",
+	244:  "Plumber's Hat",
+	245:  "Plumber's Shirt",
+	246:  "Plumber's Pants",
+	247:  "Hero's Hat",
+	248:  "Hero's Shirt",
+	249:  "Hero's Pants",
+	250:  "Fish Bowl",
+	251:  "Archaeologist's Hat",
+	252:  "Archaeologist's Jacket",
+	253:  "Archaeologist's Pants",
+	254:  "Black Thread",
+	255:  "Green Thread",
+	256:  "Ninja Hood",
+	257:  "Ninja Shirt",
+	258:  "Ninja Pants",
+	259:  "Leather",
+	260:  "Red Hat",
+	261:  "Goldfish",
+	262:  "Robe",
+	263:  "Robot Hat",
+	264:  "Gold Crown",
+	265:  "Hellfire Arrow",
+	266:  "Sandgun",
+	267:  "Guide Voodoo Doll",
+	268:  "Diving Helmet",
+	269:  "Familiar Shirt",
+	270:  "Familiar Pants",
+	271:  "Familiar Wig",
+	272:  "Demon Scythe",
+	273:  "Night's Edge",
+	274:  "Dark Lance",
+	275:  "Coral",
+	276:  "Cactus",
+	277:  "Trident",
+	278:  "Silver Bullet",
+	279:  "Throwing Knife",
+	280:  "Spear",
+	281:  "Blowpipe",
+	282:  "Glowstick",
+	283:  "Seed",
+	284:  "Wooden Boomerang",
+	285:  "Aglet",
+	286:  "Sticky Glowstick",
+	287:  "Poisoned Knife",
+	288:  "Obsidian Skin Potion",
+	289:  "Regeneration Potion",
+	290:  "Swiftness Potion",
+	291:  "Gills Potion",
+	292:  "Ironskin Potion",
+	293:  "Mana Regeneration Potion",
+	294:  "Magic Power Potion",
+	295:  "Featherfall Potion",
+	296:  "Spelunker Potion",
+	297:  "Invisibility Potion",
+	298:  "Shine Potion",
+	299:  "Night Owl Potion",
+	300:  "Battle Potion",
+	301:  "Thorns Potion",
+	302:  "Water Walking Potion",
+	303:  "Archery Potion",
+	304:  "Hunter Potion",
+	305:  "Gravitation Potion",
+	306:  "Gold Chest",
+	307:  "Daybloom Seeds",
+	308:  "Moonglow Seeds",
+	309:  "Blinkroot Seeds",
+	310:  "Deathweed Seeds",
+	311:  "Waterleaf Seeds",
+	312:  "Fireblossom Seeds",
+	313:  "Daybloom",
+	314:  "Moonglow",
+	315:  "Blinkroot",
+	316:  "Deathweed",
+	317:  "Waterleaf",
+	318:  "Fireblossom",
+	319:  "Shark Fin",
+	320:  "Feather",
+	321:  "Tombstone",
+	322:  "Mime Mask",
+	323:  "Antlion Mandible",
+	324:  "Illegal Gun Parts",
+	325:  "The Doctor's Shirt",
+	326:  "The Doctor's Pants",
+	327:  "Golden Key",
+	328:  "Shadow Chest",
+	329:  "Shadow Key",
+	330:  "Obsidian Brick Wall",
+	331:  "Jungle Spores",
+	332:  "Loom",
+	333:  "Piano",
+	334:  "Dresser",
+	335:  "Bench",
+	336:  "Bathtub",
+	337:  "Red Banner",
+	338:  "Green Banner",
+	339:  "Blue Banner",
+	340:  "Yellow Banner",
+	341:  "Lamp Post",
+	342:  "Tiki Torch",
+	343:  "Barrel",
+	344:  "Chinese Lantern",
+	345:  "Cooking Pot",
+	346:  "Safe",
+	347:  "Skull Lantern",
+	348:  "Trash Can",
+	349:  "Candelabra",
+	350:  "Pink Vase",
+	351:  "Mug",
+	352:  "Keg",
+	353:  "Ale",
+	354:  "Bookcase",
+	355:  "Throne",
+	356:  "Bowl",
+	357:  "Bowl of Soup",
+	358:  "Toilet",
+	359:  "Grandfather Clock",
+	360:  "Armor Statue",
+	361:  "Goblin Battle Standard",
+	362:  "Tattered Cloth",
+	363:  "Sawmill",
+	364:  "Cobalt Ore",
+	365:  "Mythril Ore",
+	366:  "Adamantite Ore",
+	367:  "Pwnhammer",
+	368:  "Excalibur",
+	369:  "Hallowed Seeds",
+	370:  "Ebonsand Block",
+	371:  "Cobalt Hat",
+	372:  "Cobalt Helmet",
+	373:  "Cobalt Mask",
+	374:  "Cobalt Breastplate",
+	375:  "Cobalt Leggings",
+	376:  "Mythril Hood",
+	377:  "Mythril Helmet",
+	378:  "Mythril Hat",
+	379:  "Mythril Chainmail",
+	380:  "Mythril Greaves",
+	381:  "Cobalt Bar",
+	382:  "Mythril Bar",
+	383:  "Cobalt Chainsaw",
+	384:  "Mythril Chainsaw",
+	385:  "Cobalt Drill",
+	386:  "Mythril Drill",
+	387:  "Adamantite Chainsaw",
+	388:  "Adamantite Drill",
+	389:  "Dao of Pow",
+	390:  "Mythril Halberd",
+	391:  "Adamantite Bar",
+	392:  "Glass Wall",
+	393:  "Compass",
+	394:  "Diving Gear",
+	395:  "GPS",
+	396:  "Obsidian Horseshoe",
+	397:  "Obsidian Shield",
+	398:  "Tinkerer's Workshop",
+	399:  "Cloud in a Balloon",
+	400:  "Adamantite Headgear",
+	401:  "Adamantite Helmet",
+	402:  "Adamantite Mask",
+	403:  "Adamantite Breastplate",
+	404:  "Adamantite Leggings",
+	405:  "Spectre Boots",
+	406:  "Adamantite Glaive",
+	407:  "Toolbelt",
+	408:  "Pearlsand Block",
+	409:  "Pearlstone Block",
+	410:  "Mining Shirt",
+	411:  "Mining Pants",
+	412:  "Pearlstone Brick",
+	413:  "Iridescent Brick",
+	414:  "Mudstone Block",
+	415:  "Cobalt Brick",
+	416:  "Mythril Brick",
+	417:  "Pearlstone Brick Wall",
+	418:  "Iridescent Brick Wall",
+	419:  "Mudstone Brick Wall",
+	420:  "Cobalt Brick Wall",
+	421:  "Mythril Brick Wall",
+	422:  "Holy Water",
+	423:  "Unholy Water",
+	424:  "Silt Block",
+	425:  "Fairy Bell",
+	426:  "Breaker Blade",
+	427:  "Blue Torch",
+	428:  "Red Torch",
+	429:  "Green Torch",
+	430:  "Purple Torch",
+	431:  "White Torch",
+	432:  "Yellow Torch",
+	433:  "Demon Torch",
+	434:  "Clockwork Assault Rifle",
+	435:  "Cobalt Repeater",
+	436:  "Mythril Repeater",
+	437:  "Dual Hook",
+	438:  "Star Statue",
+	439:  "Sword Statue",
+	440:  "Slime Statue",
+	441:  "Goblin Statue",
+	442:  "Shield Statue",
+	443:  "Bat Statue",
+	444:  "Fish Statue",
+	445:  "Bunny Statue",
+	446:  "Skeleton Statue",
+	447:  "Reaper Statue",
+	448:  "Woman Statue",
+	449:  "Imp Statue",
+	450:  "Gargoyle Statue",
+	451:  "Gloom Statue",
+	452:  "Hornet Statue",
+	453:  "Bomb Statue",
+	454:  "Crab Statue",
+	455:  "Hammer Statue",
+	456:  "Potion Statue",
+	457:  "Spear Statue",
+	458:  "Cross Statue",
+	459:  "Jellyfish Statue",
+	460:  "Bow Statue",
+	461:  "Boomerang Statue",
+	462:  "Boot Statue",
+	463:  "Chest Statue",
+	464:  "Bird Statue",
+	465:  "Axe Statue",
+	466:  "Corrupt Statue",
+	467:  "Tree Statue",
+	468:  "Anvil Statue",
+	469:  "Pickaxe Statue",
+	470:  "Mushroom Statue",
+	471:  "Eyeball Statue",
+	472:  "Pillar Statue",
+	473:  "Heart Statue",
+	474:  "Pot Statue",
+	475:  "Sunflower Statue",
+	476:  "King Statue",
+	477:  "Queen Statue",
+	478:  "Piranha Statue",
+	479:  "Planked Wall",
+	480:  "Wooden Beam",
+	481:  "Adamantite Repeater",
+	482:  "Adamantite Sword",
+	483:  "Cobalt Sword",
+	484:  "Mythril Sword",
+	485:  "Moon Charm",
+	486:  "Ruler",
+	487:  "Crystal Ball",
+	488:  "Disco Ball",
+	489:  "Sorcerer Emblem",
+	490:  "Warrior Emblem",
+	491:  "Ranger Emblem",
+	492:  "Demon Wings",
+	493:  "Angel Wings",
+	494:  "Magical Harp",
+	495:  "Rainbow Rod",
+	496:  "Ice Rod",
+	497:  "Neptune's Shell",
+	498:  "Mannequin",
+	499:  "Greater Healing Potion",
+	500:  "Greater Mana Potion",
+	501:  "Pixie Dust",
+	502:  "Crystal Shard",
+	503:  "Clown Hat",
+	504:  "Clown Shirt",
+	505:  "Clown Pants",
+	506:  "Flamethrower",
+	507:  "Bell",
+	508:  "Harp",
+	509:  "Wrench",
+	510:  "Wire Cutter",
+	511:  "Active Stone Block",
+	512:  "Inactive Stone Block",
+	513:  "Lever",
+	514:  "Laser Rifle",
+	515:  "Crystal Bullet",
+	516:  "Holy Arrow",
+	517:  "Magic Dagger",
+	518:  "Crystal Storm",
+	519:  "Cursed Flames",
+	520:  "Soul of Light",
+	521:  "Soul of Night",
+	522:  "Cursed Flame",
+	523:  "Cursed Torch",
+	524:  "Adamantite Forge",
+	525:  "Mythril Anvil",
+	526:  "Unicorn Horn",
+	527:  "Dark Shard",
+	528:  "Light Shard",
+	529:  "Red Pressure Plate",
+	530:  "Wire",
+	531:  "Spell Tome",
+	532:  "Star Cloak",
+	533:  "Megashark",
+	534:  "Shotgun",
+	535:  "Philosopher's Stone",
+	536:  "Titan Glove",
+	537:  "Cobalt Naginata",
+	538:  "Switch",
+	539:  "Dart Trap",
+	540:  "Boulder",
+	541:  "Green Pressure Plate",
+	542:  "Gray Pressure Plate",
+	543:  "Brown Pressure Plate",
+	544:  "Mechanical Eye",
+	545:  "Cursed Arrow",
+	546:  "Cursed Bullet",
+	547:  "Soul of Fright",
+	548:  "Soul of Might",
+	549:  "Soul of Sight",
+	550:  "Gungnir",
+	551:  "Hallowed Plate Mail",
+	552:  "Hallowed Greaves",
+	553:  "Hallowed Helmet",
+	554:  "Cross Necklace",
+	555:  "Mana Flower",
+	556:  "Mechanical Worm",
+	557:  "Mechanical Skull",
+	558:  "Hallowed Headgear",
+	559:  "Hallowed Mask",
+	560:  "Slime Crown",
+	561:  "Light Disc",
+	562:  "Music Box (Overworld Day)",
+	563:  "Music Box (Eerie)",
+	564:  "Music Box (Night)",
+	565:  "Music Box (Title)",
+	566:  "Music Box (Underground)",
+	567:  "Music Box (Boss 1)",
+	568:  "Music Box (Jungle)",
+	569:  "Music Box (Corruption)",
+	570:  "Music Box (Underground Corruption)",
+	571:  "Music Box (The Hallow)",
+	572:  "Music Box (Boss 2)",
+	573:  "Music Box (Underground Hallow)",
+	574:  "Music Box (Boss 3)",
+	575:  "Soul of Flight",
+	576:  "Music Box",
+	577:  "Demonite Brick",
+	578:  "Hallowed Repeater",
+	579:  "Drax",
+	580:  "Explosives",
+	581:  "Inlet Pump",
+	582:  "Outlet Pump",
+	583:  "1 Second Timer",
+	584:  "3 Second Timer",
+	585:  "5 Second Timer",
+	586:  "Candy Cane Block",
+	587:  "Candy Cane Wall",
+	588:  "Santa Hat",
+	589:  "Santa Shirt",
+	590:  "Santa Pants",
+	591:  "Green Candy Cane Block",
+	592:  "Green Candy Cane Wall",
+	593:  "Snow Block",
+	594:  "Snow Brick",
+	595:  "Snow Brick Wall",
+	596:  "Blue Light",
+	597:  "Red Light",
+	598:  "Green Light",
+	599:  "Blue Present",
+	600:  "Green Present",
+	601:  "Yellow Present",
+	602:  "Snow Globe",
+	603:  "Carrot",
+	604:  "Adamantite Beam",
+	605:  "Adamantite Beam Wall",
+	606:  "Demonite Brick Wall",
+	607:  "Sandstone Brick",
+	608:  "Sandstone Brick Wall",
+	609:  "Ebonstone Brick",
+	610:  "Ebonstone Brick Wall",
+	611:  "Red Stucco",
+	612:  "Yellow Stucco",
+	613:  "Green Stucco",
+	614:  "Gray Stucco",
+	615:  "Red Stucco Wall",
+	616:  "Yellow Stucco Wall",
+	617:  "Green Stucco Wall",
+	618:  "Gray Stucco Wall",
+	619:  "Ebonwood",
+	620:  "Rich Mahogany",
+	621:  "Pearlwood",
+	622:  "Ebonwood Wall",
+	623:  "Rich Mahogany Wall",
+	624:  "Pearlwood Wall",
+	625:  "Ebonwood Chest",
+	626:  "Rich Mahogany Chest",
+	627:  "Pearlwood Chest",
+	628:  "Ebonwood Chair",
+	629:  "Rich Mahogany Chair",
+	630:  "Pearlwood Chair",
+	631:  "Ebonwood Platform",
+	632:  "Rich Mahogany Platform",
+	633:  "Pearlwood Platform",
+	634:  "Bone Platform",
+	635:  "Ebonwood Work Bench",
+	636:  "Rich Mahogany Work Bench",
+	637:  "Pearlwood Work Bench",
+	638:  "Ebonwood Table",
+	639:  "Rich Mahogany Table",
+	640:  "Pearlwood Table",
+	641:  "Ebonwood Piano",
+	642:  "Rich Mahogany Piano",
+	643:  "Pearlwood Piano",
+	644:  "Ebonwood Bed",
+	645:  "Rich Mahogany Bed",
+	646:  "Pearlwood Bed",
+	647:  "Ebonwood Dresser",
+	648:  "Rich Mahogany Dresser",
+	649:  "Pearlwood Dresser",
+	650:  "Ebonwood Door",
+	651:  "Rich Mahogany Door",
+	652:  "Pearlwood Door",
+	653:  "Ebonwood Sword",
+	654:  "Ebonwood Hammer",
+	655:  "Ebonwood Bow",
+	656:  "Rich Mahogany Sword",
+	657:  "Rich Mahogany Hammer",
+	658:  "Rich Mahogany Bow",
+	659:  "Pearlwood Sword",
+	660:  "Pearlwood Hammer",
+	661:  "Pearlwood Bow",
+	662:  "Rainbow Brick",
+	663:  "Rainbow Brick Wall",
+	664:  "Ice Block",
+	665:  "Red's Wings",
+	666:  "Red's Helmet",
+	667:  "Red's Breastplate",
+	668:  "Red's Leggings",
+	669:  "Fish",
+	670:  "Ice Boomerang",
+	671:  "Keybrand",
+	672:  "Cutlass",
+	673:  "Icemourne",
+	674:  "True Excalibur",
+	675:  "True Night's Edge",
+	676:  "Frostbrand",
+	677:  "Scythe",
+	678:  "Red Potion",
+	679:  "Tactical Shotgun",
+	680:  "Ivy Chest",
+	681:  "Ice Chest",
+	682:  "Marrow",
+	683:  "Unholy Trident",
+	684:  "Frost Helmet",
+	685:  "Frost Breastplate",
+	686:  "Frost Leggings",
+	687:  "Tin Helmet",
+	688:  "Tin Chainmail",
+	689:  "Tin Greaves",
+	690:  "Lead Helmet",
+	691:  "Lead Chainmail",
+	692:  "Lead Greaves",
+	693:  "Tungsten Helmet",
+	694:  "Tungsten Chainmail",
+	695:  "Tungsten Greaves",
+	696:  "Platinum Helmet",
+	697:  "Platinum Chainmail",
+	698:  "Platinum Greaves",
+	699:  "Tin Ore",
+	700:  "Lead Ore",
+	701:  "Tungsten Ore",
+	702:  "Platinum Ore",
+	703:  "Tin Bar",
+	704:  "Lead Bar",
+	705:  "Tungsten Bar",
+	706:  "Platinum Bar",
+	707:  "Tin Watch",
+	708:  "Tungsten Watch",
+	709:  "Platinum Watch",
+	710:  "Tin Chandelier",
+	711:  "Tungsten Chandelier",
+	712:  "Platinum Chandelier",
+	713:  "Platinum Candle",
+	714:  "Platinum Candelabra",
+	715:  "Platinum Crown",
+	716:  "Lead Anvil",
+	717:  "Tin Brick",
+	718:  "Tungsten Brick",
+	719:  "Platinum Brick",
+	720:  "Tin Brick Wall",
+	721:  "Tungsten Brick Wall",
+	722:  "Platinum Brick Wall",
+	723:  "Beam Sword",
+	724:  "Ice Blade",
+	725:  "Ice Bow",
+	726:  "Frost Staff",
+	727:  "Wood Helmet",
+	728:  "Wood Breastplate",
+	729:  "Wood Greaves",
+	730:  "Ebonwood Helmet",
+	731:  "Ebonwood Breastplate",
+	732:  "Ebonwood Greaves",
+	733:  "Rich Mahogany Helmet",
+	734:  "Rich Mahogany Breastplate",
+	735:  "Rich Mahogany Greaves",
+	736:  "Pearlwood Helmet",
+	737:  "Pearlwood Breastplate",
+	738:  "Pearlwood Greaves",
+	739:  "Amethyst Staff",
+	740:  "Topaz Staff",
+	741:  "Sapphire Staff",
+	742:  "Emerald Staff",
+	743:  "Ruby Staff",
+	744:  "Diamond Staff",
+	745:  "Grass Wall",
+	746:  "Jungle Wall",
+	747:  "Flower Wall",
+	748:  "Jetpack",
+	749:  "Butterfly Wings",
+	750:  "Cactus Wall",
+	751:  "Cloud",
+	752:  "Cloud Wall",
+	753:  "Seaweed",
+	754:  "Rune Hat",
+	755:  "Rune Robe",
+	756:  "Mushroom Spear",
+	757:  "Terra Blade",
+	758:  "Grenade Launcher",
+	759:  "Rocket Launcher",
+	760:  "Proximity Mine Launcher",
+	761:  "Fairy Wings",
+	762:  "Slime Block",
+	763:  "Flesh Block",
+	764:  "Mushroom Wall",
+	765:  "Rain Cloud",
+	766:  "Bone Block",
+	767:  "Frozen Slime Block",
+	768:  "Bone Block Wall",
+	769:  "Slime Block Wall",
+	770:  "Flesh Block Wall",
+	771:  "Rocket I",
+	772:  "Rocket II",
+	773:  "Rocket III",
+	774:  "Rocket IV",
+	775:  "Asphalt Block",
+	776:  "Cobalt Pickaxe",
+	777:  "Mythril Pickaxe",
+	778:  "Adamantite Pickaxe",
+	779:  "Clentaminator",
+	780:  "Green Solution",
+	781:  "Blue Solution",
+	782:  "Purple Solution",
+	783:  "Dark Blue Solution",
+	784:  "Red Solution",
+	785:  "Harpy Wings",
+	786:  "Bone Wings",
+	787:  "Hammush",
+	788:  "Nettle Burst",
+	789:  "Ankh Banner",
+	790:  "Snake Banner",
+	791:  "Omega Banner",
+	792:  "Crimson Helmet",
+	793:  "Crimson Scalemail",
+	794:  "Crimson Greaves",
+	795:  "Blood Butcherer",
+	796:  "Tendon Bow",
+	797:  "Flesh Grinder",
+	798:  "Deathbringer Pickaxe",
+	799:  "Blood Lust Cluster",
+	800:  "The Undertaker",
+	801:  "The Meatball",
+	802:  "The Rotted Fork",
+	803:  "Eskimo Hood",
+	804:  "Eskimo Coat",
+	805:  "Eskimo Pants",
+	806:  "Living Wood Chair",
+	807:  "Cactus Chair",
+	808:  "Bone Chair",
+	809:  "Flesh Chair",
+	810:  "Mushroom Chair",
+	811:  "Bone Work Bench",
+	812:  "Cactus Work Bench",
+	813:  "Flesh Work Bench",
+	814:  "Mushroom Work Bench",
+	815:  "Slime Work Bench",
+	816:  "Cactus Door",
+	817:  "Flesh Door",
+	818:  "Mushroom Door",
+	819:  "Living Wood Door",
+	820:  "Bone Door",
+	821:  "Flame Wings",
+	822:  "Frozen Wings",
+	823:  "Ghost Wings",
+	824:  "Sunplate Block",
+	825:  "Disc Wall",
+	826:  "Skyware Chair",
+	827:  "Bone Table",
+	828:  "Flesh Table",
+	829:  "Living Wood Table",
+	830:  "Skyware Table",
+	831:  "Living Wood Chest",
+	832:  "Living Wood Wand",
+	833:  "Purple Ice Block",
+	834:  "Pink Ice Block",
+	835:  "Red Ice Block",
+	836:  "Crimstone Block",
+	837:  "Skyware Door",
+	838:  "Skyware Chest",
+	839:  "Steampunk Hat",
+	840:  "Steampunk Shirt",
+	841:  "Steampunk Pants",
+	842:  "Bee Hat",
+	843:  "Bee Shirt",
+	844:  "Bee Pants",
+	845:  "World Banner",
+	846:  "Sun Banner",
+	847:  "Gravity Banner",
+	848:  "Pharaoh's Mask",
+	849:  "Actuator",
+	850:  "Blue Wrench",
+	851:  "Green Wrench",
+	852:  "Blue Pressure Plate",
+	853:  "Yellow Pressure Plate",
+	854:  "Discount Card",
+	855:  "Lucky Coin",
+	856:  "Unicorn on a Stick",
+	857:  "Sandstorm in a Bottle",
+	858:  "Boreal Wood Sofa",
+	859:  "Beach Ball",
+	860:  "Charm of Myths",
+	861:  "Moon Shell",
+	862:  "Star Veil",
+	863:  "Water Walking Boots",
+	864:  "Tiara",
+	865:  "Princess Dress",
+	866:  "Pharaoh's Robe",
+	867:  "Green Cap",
+	868:  "Mushroom Cap",
+	869:  "Tam O' Shanter",
+	870:  "Mummy Mask",
+	871:  "Mummy Shirt",
+	872:  "Mummy Pants",
+	873:  "Cowboy Hat",
+	874:  "Cowboy Jacket",
+	875:  "Cowboy Pants",
+	876:  "Pirate Hat",
+	877:  "Pirate Shirt",
+	878:  "Pirate Pants",
+	879:  "Viking Helmet",
+	880:  "Crimtane Ore",
+	881:  "Cactus Sword",
+	882:  "Cactus Pickaxe",
+	883:  "Ice Brick",
+	884:  "Ice Brick Wall",
+	885:  "Adhesive Bandage",
+	886:  "Armor Polish",
+	887:  "Bezoar",
+	888:  "Blindfold",
+	889:  "Fast Clock",
+	890:  "Megaphone",
+	891:  "Nazar",
+	892:  "Vitamins",
+	893:  "Trifold Map",
+	894:  "Cactus Helmet",
+	895:  "Cactus Breastplate",
+	896:  "Cactus Leggings",
+	897:  "Power Glove",
+	898:  "Lightning Boots",
+	899:  "Sun Stone",
+	900:  "Moon Stone",
+	901:  "Armor Bracing",
+	902:  "Medicated Bandage",
+	903:  "The Plan",
+	904:  "Countercurse Mantra",
+	905:  "Coin Gun",
+	906:  "Lava Charm",
+	907:  "Obsidian Water Walking Boots",
+	908:  "Lava Waders",
+	909:  "Pure Water Fountain",
+	910:  "Desert Water Fountain",
+	911:  "Shadewood",
+	912:  "Shadewood Door",
+	913:  "Shadewood Platform",
+	914:  "Shadewood Chest",
+	915:  "Shadewood Chair",
+	916:  "Shadewood Work Bench",
+	917:  "Shadewood Table",
+	918:  "Shadewood Dresser",
+	919:  "Shadewood Piano",
+	920:  "Shadewood Bed",
+	921:  "Shadewood Sword",
+	922:  "Shadewood Hammer",
+	923:  "Shadewood Bow",
+	924:  "Shadewood Helmet",
+	925:  "Shadewood Breastplate",
+	926:  "Shadewood Greaves",
+	927:  "Shadewood Wall",
+	928:  "Cannon",
+	929:  "Cannonball",
+	930:  "Flare Gun",
+	931:  "Flare",
+	932:  "Bone Wand",
+	933:  "Leaf Wand",
+	934:  "Flying Carpet",
+	935:  "Avenger Emblem",
+	936:  "Mechanical Glove",
+	937:  "Land Mine",
+	938:  "Paladin's Shield",
+	939:  "Web Slinger",
+	940:  "Jungle Water Fountain",
+	941:  "Icy Water Fountain",
+	942:  "Corrupt Water Fountain",
+	943:  "Crimson Water Fountain",
+	944:  "Hallowed Water Fountain",
+	945:  "Blood Water Fountain",
+	946:  "Umbrella",
+	947:  "Chlorophyte Ore",
+	948:  "Steampunk Wings",
+	949:  "Snowball",
+	950:  "Ice Skates",
+	951:  "Snowball Launcher",
+	952:  "Web Covered Chest",
+	953:  "Climbing Claws",
+	954:  "Ancient Iron Helmet",
+	955:  "Ancient Gold Helmet",
+	956:  "Ancient Shadow Helmet",
+	957:  "Ancient Shadow Scalemail",
+	958:  "Ancient Shadow Greaves",
+	959:  "Ancient Necro Helmet",
+	960:  "Ancient Cobalt Helmet",
+	961:  "Ancient Cobalt Breastplate",
+	962:  "Ancient Cobalt Leggings",
+	963:  "Black Belt",
+	964:  "Boomstick",
+	965:  "Rope",
+	966:  "Campfire",
+	967:  "Marshmallow",
+	968:  "Marshmallow on a Stick",
+	969:  "Cooked Marshmallow",
+	970:  "Red Rocket",
+	971:  "Green Rocket",
+	972:  "Blue Rocket",
+	973:  "Yellow Rocket",
+	974:  "Ice Torch",
+	975:  "Shoe Spikes",
+	976:  "Tiger Climbing Gear",
+	977:  "Tabi",
+	978:  "Pink Eskimo Hood",
+	979:  "Pink Eskimo Coat",
+	980:  "Pink Eskimo Pants",
+	981:  "Pink Thread",
+	982:  "Mana Regeneration Band",
+	983:  "Sandstorm in a Balloon",
+	984:  "Master Ninja Gear",
+	985:  "Rope Coil",
+	986:  "Blowgun",
+	987:  "Blizzard in a Bottle",
+	988:  "Frostburn Arrow",
+	989:  "Enchanted Sword",
+	990:  "Pickaxe Axe",
+	991:  "Cobalt Waraxe",
+	992:  "Mythril Waraxe",
+	993:  "Adamantite Waraxe",
+	994:  "Eater's Bone",
+	995:  "Blend-O-Matic",
+	996:  "Meat Grinder",
+	997:  "Extractinator",
+	998:  "Solidifier",
+	999:  "Amber",
+	1000: "Confetti Gun",
+	1001: "Chlorophyte Mask",
+	1002: "Chlorophyte Helmet",
+	1003: "Chlorophyte Headgear",
+	1004: "Chlorophyte Plate Mail",
+	1005: "Chlorophyte Greaves",
+	1006: "Chlorophyte Bar",
+	1007: "Red Dye",
+	1008: "Orange Dye",
+	1009: "Yellow Dye",
+	1010: "Lime Dye",
+	1011: "Green Dye",
+	1012: "Teal Dye",
+	1013: "Cyan Dye",
+	1014: "Sky Blue Dye",
+	1015: "Blue Dye",
+	1016: "Purple Dye",
+	1017: "Violet Dye",
+	1018: "Pink Dye",
+	1019: "Red and Black Dye",
+	1020: "Orange and Black Dye",
+	1021: "Yellow and Black Dye",
+	1022: "Lime and Black Dye",
+	1023: "Green and Black Dye",
+	1024: "Teal and Black Dye",
+	1025: "Cyan and Black Dye",
+	1026: "Sky Blue and Black Dye",
+	1027: "Blue and Black Dye",
+	1028: "Purple and Black Dye",
+	1029: "Violet and Black Dye",
+	1030: "Pink and Black Dye",
+	1031: "Flame Dye",
+	1032: "Flame and Black Dye",
+	1033: "Green Flame Dye",
+	1034: "Green Flame and Black Dye",
+	1035: "Blue Flame Dye",
+	1036: "Blue Flame and Black Dye",
+	1037: "Silver Dye",
+	1038: "Bright Red Dye",
+	1039: "Bright Orange Dye",
+	1040: "Bright Yellow Dye",
+	1041: "Bright Lime Dye",
+	1042: "Bright Green Dye",
+	1043: "Bright Teal Dye",
+	1044: "Bright Cyan Dye",
+	1045: "Bright Sky Blue Dye",
+	1046: "Bright Blue Dye",
+	1047: "Bright Purple Dye",
+	1048: "Bright Violet Dye",
+	1049: "Bright Pink Dye",
+	1050: "Black Dye",
+	1051: "Red and Silver Dye",
+	1052: "Orange and Silver Dye",
+	1053: "Yellow and Silver Dye",
+	1054: "Lime and Silver Dye",
+	1055: "Green and Silver Dye",
+	1056: "Teal and Silver Dye",
+	1057: "Cyan and Silver Dye",
+	1058: "Sky Blue and Silver Dye",
+	1059: "Blue and Silver Dye",
+	1060: "Purple and Silver Dye",
+	1061: "Violet and Silver Dye",
+	1062: "Pink and Silver Dye",
+	1063: "Intense Flame Dye",
+	1064: "Intense Green Flame Dye",
+	1065: "Intense Blue Flame Dye",
+	1066: "Rainbow Dye",
+	1067: "Intense Rainbow Dye",
+	1068: "Yellow Gradient Dye",
+	1069: "Cyan Gradient Dye",
+	1070: "Violet Gradient Dye",
+	1071: "Paintbrush",
+	1072: "Paint Roller",
+	1073: "Red Paint",
+	1074: "Orange Paint",
+	1075: "Yellow Paint",
+	1076: "Lime Paint",
+	1077: "Green Paint",
+	1078: "Teal Paint",
+	1079: "Cyan Paint",
+	1080: "Sky Blue Paint",
+	1081: "Blue Paint",
+	1082: "Purple Paint",
+	1083: "Violet Paint",
+	1084: "Pink Paint",
+	1085: "Deep Red Paint",
+	1086: "Deep Orange Paint",
+	1087: "Deep Yellow Paint",
+	1088: "Deep Lime Paint",
+	1089: "Deep Green Paint",
+	1090: "Deep Teal Paint",
+	1091: "Deep Cyan Paint",
+	1092: "Deep Sky Blue Paint",
+	1093: "Deep Blue Paint",
+	1094: "Deep Purple Paint",
+	1095: "Deep Violet Paint",
+	1096: "Deep Pink Paint",
+	1097: "Black Paint",
+	1098: "White Paint",
+	1099: "Gray Paint",
+	1100: "Paint Scraper",
+	1101: "Lihzahrd Brick",
+	1102: "Lihzahrd Brick Wall",
+	1103: "Slush Block",
+	1104: "Palladium Ore",
+	1105: "Orichalcum Ore",
+	1106: "Titanium Ore",
+	1107: "Teal Mushroom",
+	1108: "Green Mushroom",
+	1109: "Sky Blue Flower",
+	1110: "Yellow Marigold",
+	1111: "Blue Berries",
+	1112: "Lime Kelp",
+	1113: "Pink Prickly Pear",
+	1114: "Orange Bloodroot",
+	1115: "Red Husk",
+	1116: "Cyan Husk",
+	1117: "Violet Husk",
+	1118: "Purple Mucos",
+	1119: "Black Ink",
+	1120: "Dye Vat",
+	1121: "Bee Gun",
+	1122: "Possessed Hatchet",
+	1123: "Bee Keeper",
+	1124: "Hive",
+	1125: "Honey Block",
+	1126: "Hive Wall",
+	1127: "Crispy Honey Block",
+	1128: "Honey Bucket",
+	1129: "Hive Wand",
+	1130: "Beenade",
+	1131: "Gravity Globe",
+	1132: "Honey Comb",
+	1133: "Abeemination",
+	1134: "Bottled Honey",
+	1135: "Rain Hat",
+	1136: "Rain Coat",
+	1137: "Lihzahrd Door",
+	1138: "Dungeon Door",
+	1139: "Lead Door",
+	1140: "Iron Door",
+	1141: "Temple Key",
+	1142: "Lihzahrd Chest",
+	1143: "Lihzahrd Chair",
+	1144: "Lihzahrd Table",
+	1145: "Lihzahrd Work Bench",
+	1146: "Super Dart Trap",
+	1147: "Flame Trap",
+	1148: "Spiky Ball Trap",
+	1149: "Spear Trap",
+	1150: "Wooden Spike",
+	1151: "Lihzahrd Pressure Plate",
+	1152: "Lihzahrd Statue",
+	1153: "Lihzahrd Watcher Statue",
+	1154: "Lihzahrd Guardian Statue",
+	1155: "Wasp Gun",
+	1156: "Piranha Gun",
+	1157: "Pygmy Staff",
+	1158: "Pygmy Necklace",
+	1159: "Tiki Mask",
+	1160: "Tiki Shirt",
+	1161: "Tiki Pants",
+	1162: "Leaf Wings",
+	1163: "Blizzard in a Balloon",
+	1164: "Bundle of Balloons",
+	1165: "Bat Wings",
+	1166: "Bone Sword",
+	1167: "Hercules Beetle",
+	1168: "Smoke Bomb",
+	1169: "Bone Key",
+	1170: "Nectar",
+	1171: "Tiki Totem",
+	1172: "Lizard Egg",
+	1173: "Grave Marker",
+	1174: "Cross Grave Marker",
+	1175: "Headstone",
+	1176: "Gravestone",
+	1177: "Obelisk",
+	1178: "Leaf Blower",
+	1179: "Chlorophyte Bullet",
+	1180: "Parrot Cracker",
+	1181: "Strange Glowing Mushroom",
+	1182: "Seedling",
+	1183: "Wisp in a Bottle",
+	1184: "Palladium Bar",
+	1185: "Palladium Sword",
+	1186: "Palladium Pike",
+	1187: "Palladium Repeater",
+	1188: "Palladium Pickaxe",
+	1189: "Palladium Drill",
+	1190: "Palladium Chainsaw",
+	1191: "Orichalcum Bar",
+	1192: "Orichalcum Sword",
+	1193: "Orichalcum Halberd",
+	1194: "Orichalcum Repeater",
+	1195: "Orichalcum Pickaxe",
+	1196: "Orichalcum Drill",
+	1197: "Orichalcum Chainsaw",
+	1198: "Titanium Bar",
+	1199: "Titanium Sword",
+	1200: "Titanium Trident",
+	1201: "Titanium Repeater",
+	1202: "Titanium Pickaxe",
+	1203: "Titanium Drill",
+	1204: "Titanium Chainsaw",
+	1205: "Palladium Mask",
+	1206: "Palladium Helmet",
+	1207: "Palladium Headgear",
+	1208: "Palladium Breastplate",
+	1209: "Palladium Leggings",
+	1210: "Orichalcum Mask",
+	1211: "Orichalcum Helmet",
+	1212: "Orichalcum Headgear",
+	1213: "Orichalcum Breastplate",
+	1214: "Orichalcum Leggings",
+	1215: "Titanium Mask",
+	1216: "Titanium Helmet",
+	1217: "Titanium Headgear",
+	1218: "Titanium Breastplate",
+	1219: "Titanium Leggings",
+	1220: "Orichalcum Anvil",
+	1221: "Titanium Forge",
+	1222: "Palladium Waraxe",
+	1223: "Orichalcum Waraxe",
+	1224: "Titanium Waraxe",
+	1225: "Hallowed Bar",
+	1226: "Chlorophyte Claymore",
+	1227: "Chlorophyte Saber",
+	1228: "Chlorophyte Partisan",
+	1229: "Chlorophyte Shotbow",
+	1230: "Chlorophyte Pickaxe",
+	1231: "Chlorophyte Drill",
+	1232: "Chlorophyte Chainsaw",
+	1233: "Chlorophyte Greataxe",
+	1234: "Chlorophyte Warhammer",
+	1235: "Chlorophyte Arrow",
+	1236: "Amethyst Hook",
+	1237: "Topaz Hook",
+	1238: "Sapphire Hook",
+	1239: "Emerald Hook",
+	1240: "Ruby Hook",
+	1241: "Diamond Hook",
+	1242: "Amber Mosquito",
+	1243: "Umbrella Hat",
+	1244: "Nimbus Rod",
+	1245: "Orange Torch",
+	1246: "Crimsand Block",
+	1247: "Bee Cloak",
+	1248: "Eye of the Golem",
+	1249: "Honey Balloon",
+	1250: "Blue Horseshoe Balloon",
+	1251: "White Horseshoe Balloon",
+	1252: "Yellow Horseshoe Balloon",
+	1253: "Frozen Turtle Shell",
+	1254: "Sniper Rifle",
+	1255: "Venus Magnum",
+	1256: "Crimson Rod",
+	1257: "Crimtane Bar",
+	1258: "Stynger",
+	1259: "Flower Pow",
+	1260: "Rainbow Gun",
+	1261: "Stynger Bolt",
+	1262: "Chlorophyte Jackhammer",
+	1263: "Teleporter",
+	1264: "Flower of Frost",
+	1265: "Uzi",
+	1266: "Magnet Sphere",
+	1267: "Purple Stained Glass",
+	1268: "Yellow Stained Glass",
+	1269: "Blue Stained Glass",
+	1270: "Green Stained Glass",
+	1271: "Red Stained Glass",
+	1272: "Multicolored Stained Glass",
+	1273: "Skeletron Hand",
+	1274: "Skull",
+	1275: "Balla Hat",
+	1276: "Gangsta Hat",
+	1277: "Sailor Hat",
+	1278: "Eye Patch",
+	1279: "Sailor Shirt",
+	1280: "Sailor Pants",
+	1281: "Skeletron Mask",
+	1282: "Amethyst Robe",
+	1283: "Topaz Robe",
+	1284: "Sapphire Robe",
+	1285: "Emerald Robe",
+	1286: "Ruby Robe",
+	1287: "Diamond Robe",
+	1288: "White Tuxedo Shirt",
+	1289: "White Tuxedo Pants",
+	1290: "Panic Necklace",
+	1291: "Life Fruit",
+	1292: "Lihzahrd Altar",
+	1293: "Lihzahrd Power Cell",
+	1294: "Picksaw",
+	1295: "Heat Ray",
+	1296: "Staff of Earth",
+	1297: "Golem Fist",
+	1298: "Water Chest",
+	1299: "Binoculars",
+	1300: "Rifle Scope",
+	1301: "Destroyer Emblem",
+	1302: "High Velocity Bullet",
+	1303: "Jellyfish Necklace",
+	1304: "Zombie Arm",
+	1305: "The Axe",
+	1306: "Ice Sickle",
+	1307: "Clothier Voodoo Doll",
+	1308: "Poison Staff",
+	1309: "Slime Staff",
+	1310: "Poison Dart",
+	1311: "Eye Spring",
+	1312: "Toy Sled",
+	1313: "Book of Skulls",
+	1314: "KO Cannon",
+	1315: "Pirate Map",
+	1316: "Turtle Helmet",
+	1317: "Turtle Scale Mail",
+	1318: "Turtle Leggings",
+	1319: "Snowball Cannon",
+	1320: "Bone Pickaxe",
+	1321: "Magic Quiver",
+	1322: "Magma Stone",
+	1323: "Obsidian Rose",
+	1324: "Bananarang",
+	1325: "Chain Knife",
+	1326: "Rod of Discord",
+	1327: "Death Sickle",
+	1328: "Turtle Shell",
+	1329: "Tissue Sample",
+	1330: "Vertebrae",
+	1331: "Bloody Spine",
+	1332: "Ichor",
+	1333: "Ichor Torch",
+	1334: "Ichor Arrow",
+	1335: "Ichor Bullet",
+	1336: "Golden Shower",
+	1337: "Bunny Cannon",
+	1338: "Explosive Bunny",
+	1339: "Vial of Venom",
+	1340: "Flask of Venom",
+	1341: "Venom Arrow",
+	1342: "Venom Bullet",
+	1343: "Fire Gauntlet",
+	1344: "Cog",
+	1345: "Confetti",
+	1346: "Nanites",
+	1347: "Explosive Powder",
+	1348: "Gold Dust",
+	1349: "Party Bullet",
+	1350: "Nano Bullet",
+	1351: "Exploding Bullet",
+	1352: "Golden Bullet",
+	1353: "Flask of Cursed Flames",
+	1354: "Flask of Fire",
+	1355: "Flask of Gold",
+	1356: "Flask of Ichor",
+	1357: "Flask of Nanites",
+	1358: "Flask of Party",
+	1359: "Flask of Poison",
+	1360: "Eye of Cthulhu Trophy",
+	1361: "Eater of Worlds Trophy",
+	1362: "Brain of Cthulhu Trophy",
+	1363: "Skeletron Trophy",
+	1364: "Queen Bee Trophy",
+	1365: "Wall of Flesh Trophy",
+	1366: "Destroyer Trophy",
+	1367: "Skeletron Prime Trophy",
+	1368: "Retinazer Trophy",
+	1369: "Spazmatism Trophy",
+	1370: "Plantera Trophy",
+	1371: "Golem Trophy",
+	1372: "Blood Moon Rising",
+	1373: "The Hanged Man",
+	1374: "Glory of the Fire",
+	1375: "Bone Warp",
+	1376: "Wall Skeleton",
+	1377: "Hanging Skeleton",
+	1378: "Blue Slab Wall",
+	1379: "Blue Tiled Wall",
+	1380: "Pink Slab Wall",
+	1381: "Pink Tiled Wall",
+	1382: "Green Slab Wall",
+	1383: "Green Tiled Wall",
+	1384: "Blue Brick Platform",
+	1385: "Pink Brick Platform",
+	1386: "Green Brick Platform",
+	1387: "Metal Shelf",
+	1388: "Brass Shelf",
+	1389: "Wood Shelf",
+	1390: "Brass Lantern",
+	1391: "Caged Lantern",
+	1392: "Carriage Lantern",
+	1393: "Alchemy Lantern",
+	1394: "Diablost Lamp",
+	1395: "Oil Rag Sconse",
+	1396: "Blue Dungeon Chair",
+	1397: "Blue Dungeon Table",
+	1398: "Blue Dungeon Work Bench",
+	1399: "Green Dungeon Chair",
+	1400: "Green Dungeon Table",
+	1401: "Green Dungeon Work Bench",
+	1402: "Pink Dungeon Chair",
+	1403: "Pink Dungeon Table",
+	1404: "Pink Dungeon Work Bench",
+	1405: "Blue Dungeon Candle",
+	1406: "Green Dungeon Candle",
+	1407: "Pink Dungeon Candle",
+	1408: "Blue Dungeon Vase",
+	1409: "Green Dungeon Vase",
+	1410: "Pink Dungeon Vase",
+	1411: "Blue Dungeon Door",
+	1412: "Green Dungeon Door",
+	1413: "Pink Dungeon Door",
+	1414: "Blue Dungeon Bookcase",
+	1415: "Green Dungeon Bookcase",
+	1416: "Pink Dungeon Bookcase",
+	1417: "Catacomb",
+	1418: "Dungeon Shelf",
+	1419: "Skellington J Skellingsworth",
+	1420: "The Cursed Man",
+	1421: "The Eye Sees the End",
+	1422: "Something Evil is Watching You",
+	1423: "The Twins Have Awoken",
+	1424: "The Screamer",
+	1425: "Goblins Playing Poker",
+	1426: "Dryadisque",
+	1427: "Sunflowers",
+	1428: "Terrarian Gothic",
+	1429: "Beanie",
+	1430: "Imbuing Station",
+	1431: "Star in a Bottle",
+	1432: "Empty Bullet",
+	1433: "Impact",
+	1434: "Powered by Birds",
+	1435: "The Destroyer",
+	1436: "The Persistency of Eyes",
+	1437: "Unicorn Crossing the Hallows",
+	1438: "Great Wave",
+	1439: "Starry Night",
+	1440: "Guide Picasso",
+	1441: "The Guardian's Gaze",
+	1442: "Father of Someone",
+	1443: "Nurse Lisa",
+	1444: "Shadowbeam Staff",
+	1445: "Inferno Fork",
+	1446: "Spectre Staff",
+	1447: "Wooden Fence",
+	1448: "Metal Fence",
+	1449: "Bubble Machine",
+	1450: "Bubble Wand",
+	1451: "Marching Bones Banner",
+	1452: "Necromantic Sign",
+	1453: "Rusted Company Standard",
+	1454: "Ragged Brotherhood Sigil",
+	1455: "Molten Legion Flag",
+	1456: "Diabolic Sigil",
+	1457: "Obsidian Platform",
+	1458: "Obsidian Door",
+	1459: "Obsidian Chair",
+	1460: "Obsidian Table",
+	1461: "Obsidian Work Bench",
+	1462: "Obsidian Vase",
+	1463: "Obsidian Bookcase",
+	1464: "Hellbound Banner",
+	1465: "Hell Hammer Banner",
+	1466: "Helltower Banner",
+	1467: "Lost Hopes of Man Banner",
+	1468: "Obsidian Watcher Banner",
+	1469: "Lava Erupts Banner",
+	1470: "Blue Dungeon Bed",
+	1471: "Green Dungeon Bed",
+	1472: "Red Dungeon Bed",
+	1473: "Obsidian Bed",
+	1474: "Waldo",
+	1475: "Darkness",
+	1476: "Dark Soul Reaper",
+	1477: "Land",
+	1478: "Trapped Ghost",
+	1479: "Demon's Eye",
+	1480: "Finding Gold",
+	1481: "First Encounter",
+	1482: "Good Morning",
+	1483: "Underground Reward",
+	1484: "Through the Window",
+	1485: "Place Above the Clouds",
+	1486: "Do Not Step on the Grass",
+	1487: "Cold Waters in the White Land",
+	1488: "Lightless Chasms",
+	1489: "The Land of Deceiving Looks",
+	1490: "Daylight",
+	1491: "Secret of the Sands",
+	1492: "Deadland Comes Alive",
+	1493: "Evil Presence",
+	1494: "Sky Guardian",
+	1495: "American Explosive",
+	1496: "Discover",
+	1497: "Hand Earth",
+	1498: "Old Miner",
+	1499: "Skelehead",
+	1500: "Facing the Cerebral Mastermind",
+	1501: "Lake of Fire",
+	1502: "Trio Super Heroes",
+	1503: "Spectre Hood",
+	1504: "Spectre Robe",
+	1505: "Spectre Pants",
+	1506: "Spectre Pickaxe",
+	1507: "Spectre Hamaxe",
+	1508: "Ectoplasm",
+	1509: "Gothic Chair",
+	1510: "Gothic Table",
+	1511: "Gothic Work Bench",
+	1512: "Gothic Bookcase",
+	1513: "Paladin's Hammer",
+	1514: "SWAT Helmet",
+	1515: "Bee Wings",
+	1516: "Giant Harpy Feather",
+	1517: "Bone Feather",
+	1518: "Fire Feather",
+	1519: "Ice Feather",
+	1520: "Broken Bat Wing",
+	1521: "Tattered Bee Wing",
+	1522: "Large Amethyst",
+	1523: "Large Topaz",
+	1524: "Large Sapphire",
+	1525: "Large Emerald",
+	1526: "Large Ruby",
+	1527: "Large Diamond",
+	1528: "Jungle Chest",
+	1529: "Corruption Chest",
+	1530: "Crimson Chest",
+	1531: "Hallowed Chest",
+	1532: "Frozen Chest",
+	1533: "Jungle Key",
+	1534: "Corruption Key",
+	1535: "Crimson Key",
+	1536: "Hallowed Key",
+	1537: "Frozen Key",
+	1538: "Imp Face",
+	1539: "Ominous Presence",
+	1540: "Shining Moon",
+	1541: "Living Gore",
+	1542: "Flowing Magma",
+	1543: "Spectre Paintbrush",
+	1544: "Spectre Paint Roller",
+	1545: "Spectre Paint Scraper",
+	1546: "Shroomite Headgear",
+	1547: "Shroomite Mask",
+	1548: "Shroomite Helmet",
+	1549: "Shroomite Breastplate",
+	1550: "Shroomite Leggings",
+	1551: "Autohammer",
+	1552: "Shroomite Bar",
+	1553: "S.D.M.G.",
+	1554: "Cenx's Tiara",
+	1555: "Cenx's Breastplate",
+	1556: "Cenx's Leggings",
+	1557: "Crowno's Mask",
+	1558: "Crowno's Breastplate",
+	1559: "Crowno's Leggings",
+	1560: "Will's Helmet",
+	1561: "Will's Breastplate",
+	1562: "Will's Leggings",
+	1563: "Jim's Helmet",
+	1564: "Jim's Breastplate",
+	1565: "Jim's Leggings",
+	1566: "Aaron's Helmet",
+	1567: "Aaron's Breastplate",
+	1568: "Aaron's Leggings",
+	1569: "Vampire Knives",
+	1570: "Broken Hero Sword",
+	1571: "Scourge of the Corruptor",
+	1572: "Staff of the Frost Hydra",
+	1573: "The Creation of the Guide",
+	1574: "The Merchant",
+	1575: "Crowno Devours His Lunch",
+	1576: "Rare Enchantment",
+	1577: "Glorious Night",
+	1578: "Sweetheart Necklace",
+	1579: "Flurry Boots",
+	1580: "D-Town's Helmet",
+	1581: "D-Town's Breastplate",
+	1582: "D-Town's Leggings",
+	1583: "D-Town's Wings",
+	1584: "Will's Wings",
+	1585: "Crowno's Wings",
+	1586: "Cenx's Wings",
+	1587: "Cenx's Dress",
+	1588: "Cenx's Dress Pants",
+	1589: "Palladium Column",
+	1590: "Palladium Column Wall",
+	1591: "Bubblegum Block",
+	1592: "Bubblegum Block Wall",
+	1593: "Titanstone Block",
+	1594: "Titanstone Block Wall",
+	1595: "Magic Cuffs",
+	1596: "Music Box (Snow)",
+	1597: "Music Box (Space)",
+	1598: "Music Box (Crimson)",
+	1599: "Music Box (Boss 4)",
+	1600: "Music Box (Alt Overworld Day)",
+	1601: "Music Box (Rain)",
+	1602: "Music Box (Ice)",
+	1603: "Music Box (Desert)",
+	1604: "Music Box (Ocean)",
+	1605: "Music Box (Dungeon)",
+	1606: "Music Box (Plantera)",
+	1607: "Music Box (Boss 5)",
+	1608: "Music Box (Temple)",
+	1609: "Music Box (Eclipse)",
+	1610: "Music Box (Mushrooms)",
+	1611: "Butterfly Dust",
+	1612: "Ankh Charm",
+	1613: "Ankh Shield",
+	1614: "Blue Flare",
+	1615: "Angler Fish Banner",
+	1616: "Angry Nimbus Banner",
+	1617: "Anomura Fungus Banner",
+	1618: "Antlion Banner",
+	1619: "Arapaima Banner",
+	1620: "Armored Skeleton Banner",
+	1621: "Cave Bat Banner",
+	1622: "Bird Banner",
+	1623: "Black Recluse Banner",
+	1624: "Blood Feeder Banner",
+	1625: "Blood Jelly Banner",
+	1626: "Blood Crawler Banner",
+	1627: "Bone Serpent Banner",
+	1628: "Bunny Banner",
+	1629: "Chaos Elemental Banner",
+	1630: "Mimic Banner",
+	1631: "Clown Banner",
+	1632: "Corrupt Bunny Banner",
+	1633: "Corrupt Goldfish Banner",
+	1634: "Crab Banner",
+	1635: "Crimera Banner",
+	1636: "Crimson Axe Banner",
+	1637: "Cursed Hammer Banner",
+	1638: "Demon Banner",
+	1639: "Demon Eye Banner",
+	1640: "Derpling Banner",
+	1641: "Eater of Souls Banner",
+	1642: "Enchanted Sword Banner",
+	1643: "Zombie Eskimo Banner",
+	1644: "Face Monster Banner",
+	1645: "Floaty Gross Banner",
+	1646: "Flying Fish Banner",
+	1647: "Flying Snake Banner",
+	1648: "Frankenstein Banner",
+	1649: "Fungi Bulb Banner",
+	1650: "Fungo Fish Banner",
+	1651: "Gastropod Banner",
+	1652: "Goblin Archer Banner",
+	1653: "Goblin Sorcerer Banner",
+	1654: "Goblin Scout Banner",
+	1655: "Goblin Thief Banner",
+	1656: "Goblin Warrior Banner",
+	1657: "Goldfish Banner",
+	1658: "Harpy Banner",
+	1659: "Hellbat Banner",
+	1660: "Herpling Banner",
+	1661: "Hornet Banner",
+	1662: "Ice Elemental Banner",
+	1663: "Icy Merman Banner",
+	1664: "Fire Imp Banner",
+	1665: "Blue Jellyfish Banner",
+	1666: "Jungle Creeper Banner",
+	1667: "Lihzahrd Banner",
+	1668: "Man Eater Banner",
+	1669: "Meteor Head Banner",
+	1670: "Moth Banner",
+	1671: "Mummy Banner",
+	1672: "Mushi Ladybug Banner",
+	1673: "Parrot Banner",
+	1674: "Pigron Banner",
+	1675: "Piranha Banner",
+	1676: "Pirate Deckhand Banner",
+	1677: "Pixie Banner",
+	1678: "Raincoat Zombie Banner",
+	1679: "Reaper Banner",
+	1680: "Shark Banner",
+	1681: "Skeleton Banner",
+	1682: "Dark Caster Banner",
+	1683: "Blue Slime Banner",
+	1684: "Snow Flinx Banner",
+	1685: "Wall Creeper Banner",
+	1686: "Spore Zombie Banner",
+	1687: "Swamp Thing Banner",
+	1688: "Giant Tortoise Banner",
+	1689: "Toxic Sludge Banner",
+	1690: "Umbrella Slime Banner",
+	1691: "Unicorn Banner",
+	1692: "Vampire Banner",
+	1693: "Vulture Banner",
+	1694: "Nymph Banner",
+	1695: "Werewolf Banner",
+	1696: "Wolf Banner",
+	1697: "World Feeder Banner",
+	1698: "Worm Banner",
+	1699: "Wraith Banner",
+	1700: "Wyvern Banner",
+	1701: "Zombie Banner",
+	1702: "Glass Platform",
+	1703: "Glass Chair",
+	1704: "Golden Chair",
+	1705: "Golden Toilet",
+	1706: "Bar Stool",
+	1707: "Honey Chair",
+	1708: "Steam Punk Chair",
+	1709: "Glass Door",
+	1710: "Golden Door",
+	1711: "Honey Door",
+	1712: "Steam Punk Door",
+	1713: "Glass Table",
+	1714: "Banquet Table",
+	1715: "Bar",
+	1716: "Golden Table",
+	1717: "Honey Table",
+	1718: "Steam Punk Table",
+	1719: "Glass Bed",
+	1720: "Golden Bed",
+	1721: "Honey Bed",
+	1722: "Steam Punk Bed",
+	1723: "Living Wood Wall",
+	1724: "Fart in a Jar",
+	1725: "Pumpkin",
+	1726: "Pumpkin Wall",
+	1727: "Hay",
+	1728: "Hay Wall",
+	1729: "Spooky Wood",
+	1730: "Spooky Wood Wall",
+	1731: "Pumpkin Helmet",
+	1732: "Pumpkin Breastplate",
+	1733: "Pumpkin Leggings",
+	1734: "Candy Apple",
+	1735: "Soul Cake",
+	1736: "Nurse Hat",
+	1737: "Nurse Shirt",
+	1738: "Nurse Pants",
+	1739: "Wizard's Hat",
+	1740: "Guy Fawkes Mask",
+	1741: "Dye Trader Robe",
+	1742: "Steam Punk Goggles",
+	1743: "Cyborg Helmet",
+	1744: "Cyborg Shirt",
+	1745: "Cyborg Pants",
+	1746: "Creeper Mask",
+	1747: "Creeper Shirt",
+	1748: "Creeper Pants",
+	1749: "Cat Mask",
+	1750: "Cat Shirt",
+	1751: "Cat Pants",
+	1752: "Ghost Mask",
+	1753: "Ghost Shirt",
+	1754: "Pumpkin Mask",
+	1755: "Pumpkin Shirt",
+	1756: "Pumpkin Pants",
+	1757: "Robot Mask",
+	1758: "Robot Shirt",
+	1759: "Robot Pants",
+	1760: "Unicorn Mask",
+	1761: "Unicorn Shirt",
+	1762: "Unicorn Pants",
+	1763: "Vampire Mask",
+	1764: "Vampire Shirt",
+	1765: "Vampire Pants",
+	1766: "Witch Hat",
+	1767: "Leprechaun Hat",
+	1768: "Leprechaun Shirt",
+	1769: "Leprechaun Pants",
+	1770: "Pixie Shirt",
+	1771: "Pixie Pants",
+	1772: "Princess Hat",
+	1773: "Princess Dress",
+	1774: "Goodie Bag",
+	1775: "Witch Dress",
+	1776: "Witch Boots",
+	1777: "Bride of Frankenstein Mask",
+	1778: "Bride of Frankenstein Dress",
+	1779: "Karate Tortoise Mask",
+	1780: "Karate Tortoise Shirt",
+	1781: "Karate Tortoise Pants",
+	1782: "Candy Corn Rifle",
+	1783: "Candy Corn",
+	1784: "Jack 'O Lantern Launcher",
+	1785: "Explosive Jack 'O Lantern",
+	1786: "Sickle",
+	1787: "Pumpkin Pie",
+	1788: "Scarecrow Hat",
+	1789: "Scarecrow Shirt",
+	1790: "Scarecrow Pants",
+	1791: "Cauldron",
+	1792: "Pumpkin Chair",
+	1793: "Pumpkin Door",
+	1794: "Pumpkin Table",
+	1795: "Pumpkin Work Bench",
+	1796: "Pumpkin Platform",
+	1797: "Tattered Fairy Wings",
+	1798: "Spider Egg",
+	1799: "Magical Pumpkin Seed",
+	1800: "Bat Hook",
+	1801: "Bat Scepter",
+	1802: "Raven Staff",
+	1803: "Jungle Key Mold",
+	1804: "Corruption Key Mold",
+	1805: "Crimson Key Mold",
+	1806: "Hallowed Key Mold",
+	1807: "Frozen Key Mold",
+	1808: "Hanging Jack 'O Lantern",
+	1809: "Rotten Egg",
+	1810: "Unlucky Yarn",
+	1811: "Black Fairy Dust",
+	1812: "Jackelier",
+	1813: "Jack 'O Lantern",
+	1814: "Spooky Chair",
+	1815: "Spooky Door",
+	1816: "Spooky Table",
+	1817: "Spooky Work Bench",
+	1818: "Spooky Platform",
+	1819: "Reaper Hood",
+	1820: "Reaper Robe",
+	1821: "Fox Mask",
+	1822: "Fox Shirt",
+	1823: "Fox Pants",
+	1824: "Cat Ears",
+	1825: "Bloody Machete",
+	1826: "The Horseman's Blade",
+	1827: "Bladed Glove",
+	1828: "Pumpkin Seed",
+	1829: "Spooky Hook",
+	1830: "Spooky Wings",
+	1831: "Spooky Twig",
+	1832: "Spooky Helmet",
+	1833: "Spooky Breastplate",
+	1834: "Spooky Leggings",
+	1835: "Stake Launcher",
+	1836: "Stake",
+	1837: "Cursed Sapling",
+	1838: "Space Creature Mask",
+	1839: "Space Creature Shirt",
+	1840: "Space Creature Pants",
+	1841: "Wolf Mask",
+	1842: "Wolf Shirt",
+	1843: "Wolf Pants",
+	1844: "Pumpkin Moon Medallion",
+	1845: "Necromantic Scroll",
+	1846: "Jacking Skeletron",
+	1847: "Bitter Harvest",
+	1848: "Blood Moon Countess",
+	1849: "Hallow's Eve",
+	1850: "Morbid Curiosity",
+	1851: "Treasure Hunter Shirt",
+	1852: "Treasure Hunter Pants",
+	1853: "Dryad Coverings",
+	1854: "Dryad Loincloth",
+	1855: "Mourning Wood Trophy",
+	1856: "Pumpking Trophy",
+	1857: "Jack 'O Lantern Mask",
+	1858: "Sniper Scope",
+	1859: "Heart Lantern",
+	1860: "Jellyfish Diving Gear",
+	1861: "Arctic Diving Gear",
+	1862: "Frostspark Boots",
+	1863: "Fart in a Balloon",
+	1864: "Papyrus Scarab",
+	1865: "Celestial Stone",
+	1866: "Hoverboard",
+	1867: "Candy Cane",
+	1868: "Sugar Plum",
+	1869: "Present",
+	1870: "Red Ryder",
+	1871: "Festive Wings",
+	1872: "Pine Tree Block",
+	1873: "Christmas Tree",
+	1874: "Star Topper 1",
+	1875: "Star Topper 2",
+	1876: "Star Topper 3",
+	1877: "Bow Topper",
+	1878: "White Garland",
+	1879: "White and Red Garland",
+	1880: "Red Garland",
+	1881: "Red and Green Garland",
+	1882: "Green Garland",
+	1883: "Green and White Garland",
+	1884: "Multicolored Bulb",
+	1885: "Red Bulb",
+	1886: "Yellow Bulb",
+	1887: "Green Bulb",
+	1888: "Red and Green Bulb",
+	1889: "Yellow and Green Bulb",
+	1890: "Red and Yellow Bulb",
+	1891: "White Bulb",
+	1892: "White and Red Bulb",
+	1893: "White and Yellow Bulb",
+	1894: "White and Green Bulb",
+	1895: "Multicolored Lights",
+	1896: "Red Lights",
+	1897: "Green Lights",
+	1898: "Blue Lights",
+	1899: "Yellow Lights",
+	1900: "Red and Yellow Lights",
+	1901: "Red and Green Lights",
+	1902: "Yellow and Green Lights",
+	1903: "Blue and Green Lights",
+	1904: "Red and Blue Lights",
+	1905: "Blue and Yellow Lights",
+	1906: "Giant Bow",
+	1907: "Reindeer Antlers",
+	1908: "Holly",
+	1909: "Candy Cane Sword",
+	1910: "Elf Melter",
+	1911: "Christmas Pudding",
+	1912: "Eggnog",
+	1913: "Star Anise",
+	1914: "Reindeer Bells",
+	1915: "Candy Cane Hook",
+	1916: "Christmas Hook",
+	1917: "Candy Cane Pickaxe",
+	1918: "Fruitcake Chakram",
+	1919: "Sugar Cookie",
+	1920: "Gingerbread Cookie",
+	1921: "Hand Warmer",
+	1922: "Coal",
+	1923: "Toolbox",
+	1924: "Pine Door",
+	1925: "Pine Chair",
+	1926: "Pine Table",
+	1927: "Dog Whistle",
+	1928: "Christmas Tree Sword",
+	1929: "Chain Gun",
+	1930: "Razorpine",
+	1931: "Blizzard Staff",
+	1932: "Mrs. Claus Hat",
+	1933: "Mrs. Claus Shirt",
+	1934: "Mrs. Claus Heels",
+	1935: "Parka Hood",
+	1936: "Parka Coat",
+	1937: "Parka Pants",
+	1938: "Snow Hat",
+	1939: "Ugly Sweater",
+	1940: "Tree Mask",
+	1941: "Tree Shirt",
+	1942: "Tree Trunks",
+	1943: "Elf Hat",
+	1944: "Elf Shirt",
+	1945: "Elf Pants",
+	1946: "Snowman Cannon",
+	1947: "North Pole",
+	1948: "Christmas Tree Wallpaper",
+	1949: "Ornament Wallpaper",
+	1950: "Candy Cane Wallpaper",
+	1951: "Festive Wallpaper",
+	1952: "Stars Wallpaper",
+	1953: "Squiggles Wallpaper",
+	1954: "Snowflake Wallpaper",
+	1955: "Krampus Horn Wallpaper",
+	1956: "Bluegreen Wallpaper",
+	1957: "Grinch Finger Wallpaper",
+	1958: "Naughty Present",
+	1959: "Baby Grinch's Mischief Whistle",
+	1960: "Ice Queen Trophy",
+	1961: "Santa-NK1 Trophy",
+	1962: "Everscream Trophy",
+	1963: "Music Box (Pumpkin Moon)",
+	1964: "Music Box (Alt Underground)",
+	1965: "Music Box (Frost Moon)",
+	1966: "Brown Paint",
+	1967: "Shadow Paint",
+	1968: "Negative Paint",
+	1969: "Team Dye",
+	1970: "Amethyst Gemspark Block",
+	1971: "Topaz Gemspark Block",
+	1972: "Sapphire Gemspark Block",
+	1973: "Emerald Gemspark Block",
+	1974: "Ruby Gemspark Block",
+	1975: "Diamond Gemspark Block",
+	1976: "Amber Gemspark Block",
+	1977: "Life Hair Dye",
+	1978: "Mana Hair Dye",
+	1979: "Depth Hair Dye",
+	1980: "Money Hair Dye",
+	1981: "Time Hair Dye",
+	1982: "Team Hair Dye",
+	1983: "Biome Hair Dye",
+	1984: "Party Hair Dye",
+	1985: "Rainbow Hair Dye",
+	1986: "Speed Hair Dye",
+	1987: "Angel Halo",
+	1988: "Fez",
+	1989: "Womannequin",
+	1990: "Hair Dye Remover",
+	1991: "Bug Net",
+	1992: "Firefly",
+	1993: "Firefly in a Bottle",
+	1994: "Monarch Butterfly",
+	1995: "Purple Emperor Butterfly",
+	1996: "Red Admiral Butterfly",
+	1997: "Ulysses Butterfly",
+	1998: "Sulphur Butterfly",
+	1999: "Tree Nymph Butterfly",
+	2000: "Zebra Swallowtail Butterfly",
+	2001: "Julia Butterfly",
+	2002: "Worm",
+	2003: "Mouse",
+	2004: "Lightning Bug",
+	2005: "Lightning Bug in a Bottle",
+	2006: "Snail",
+	2007: "Glowing Snail",
+	2008: "Fancy Grey Wallpaper",
+	2009: "Ice Floe Wallpaper",
+	2010: "Music Wallpaper",
+	2011: "Purple Rain Wallpaper",
+	2012: "Rainbow Wallpaper",
+	2013: "Sparkle Stone Wallpaper",
+	2014: "Starlit Heaven Wallpaper",
+	2015: "Bird",
+	2016: "Blue Jay",
+	2017: "Cardinal",
+	2018: "Squirrel",
+	2019: "Bunny",
+	2020: "Cactus Bookcase",
+	2021: "Ebonwood Bookcase",
+	2022: "Flesh Bookcase",
+	2023: "Honey Bookcase",
+	2024: "Steampunk Bookcase",
+	2025: "Glass Bookcase",
+	2026: "Rich Mahogany Bookcase",
+	2027: "Pearlwood Bookcase",
+	2028: "Spooky Bookcase",
+	2029: "Skyware Bookcase",
+	2030: "Lihzahrd Bookcase",
+	2031: "Frozen Bookcase",
+	2032: "Cactus Lantern",
+	2033: "Ebonwood Lantern",
+	2034: "Flesh Lantern",
+	2035: "Honey Lantern",
+	2036: "Steampunk Lantern",
+	2037: "Glass Lantern",
+	2038: "Rich Mahogany Lantern",
+	2039: "Pearlwood Lantern",
+	2040: "Frozen Lantern",
+	2041: "Lihzahrd Lantern",
+	2042: "Skyware Lantern",
+	2043: "Spooky Lantern",
+	2044: "Frozen Door",
+	2045: "Cactus Candle",
+	2046: "Ebonwood Candle",
+	2047: "Flesh Candle",
+	2048: "Glass Candle",
+	2049: "Frozen Candle",
+	2050: "Rich Mahogany Candle",
+	2051: "Pearlwood Candle",
+	2052: "Lihzahrd Candle",
+	2053: "Skyware Candle",
+	2054: "Pumpkin Candle",
+	2055: "Cactus Chandelier",
+	2056: "Ebonwood Chandelier",
+	2057: "Flesh Chandelier",
+	2058: "Honey Chandelier",
+	2059: "Frozen Chandelier",
+	2060: "Rich Mahogany Chandelier",
+	2061: "Pearlwood Chandelier",
+	2062: "Lihzahrd Chandelier",
+	2063: "Skyware Chandelier",
+	2064: "Spooky Chandelier",
+	2065: "Glass Chandelier",
+	2066: "Cactus Bed",
+	2067: "Flesh Bed",
+	2068: "Frozen Bed",
+	2069: "Lihzahrd Bed",
+	2070: "Skyware Bed",
+	2071: "Spooky Bed",
+	2072: "Cactus Bathtub",
+	2073: "Ebonwood Bathtub",
+	2074: "Flesh Bathtub",
+	2075: "Glass Bathtub",
+	2076: "Frozen Bathtub",
+	2077: "Rich Mahogany Bathtub",
+	2078: "Pearlwood Bathtub",
+	2079: "Lihzahrd Bathtub",
+	2080: "Skyware Bathtub",
+	2081: "Spooky Bathtub",
+	2082: "Cactus Lamp",
+	2083: "Ebonwood Lamp",
+	2084: "Flesh Lamp",
+	2085: "Glass Lamp",
+	2086: "Frozen Lamp",
+	2087: "Rich Mahogany Lamp",
+	2088: "Pearlwood Lamp",
+	2089: "Lihzahrd Lamp",
+	2090: "Skyware Lamp",
+	2091: "Spooky Lamp",
+	2092: "Cactus Candelabra",
+	2093: "Ebonwood Candelabra",
+	2094: "Flesh Candelabra",
+	2095: "Honey Candelabra",
+	2096: "Steampunk Candelabra",
+	2097: "Glass Candelabra",
+	2098: "Rich Mahogany Candelabra",
+	2099: "Pearlwood Candelabra",
+	2100: "Frozen Candelabra",
+	2101: "Lihzahrd Candelabra",
+	2102: "Skyware Candelabra",
+	2103: "Spooky Candelabra",
+	2104: "Brain of Cthulhu Mask",
+	2105: "Wall of Flesh Mask",
+	2106: "Twin Mask",
+	2107: "Skeletron Prime Mask",
+	2108: "Queen Bee Mask",
+	2109: "Plantera Mask",
+	2110: "Golem Mask",
+	2111: "Eater of Worlds Mask",
+	2112: "Eye of Cthulhu Mask",
+	2113: "Destroyer Mask",
+	2114: "Blacksmith Rack",
+	2115: "Carpentry Rack",
+	2116: "Helmet Rack",
+	2117: "Spear Rack",
+	2118: "Sword Rack",
+	2119: "Stone Slab",
+	2120: "Sandstone Slab",
+	2121: "Frog",
+	2122: "Mallard Duck",
+	2123: "Duck",
+	2124: "Honey Bathtub",
+	2125: "Steampunk Bathtub",
+	2126: "Living Wood Bathtub",
+	2127: "Shadewood Bathtub",
+	2128: "Bone Bathtub",
+	2129: "Honey Lamp",
+	2130: "Steampunk Lamp",
+	2131: "Living Wood Lamp",
+	2132: "Shadewood Lamp",
+	2133: "Golden Lamp",
+	2134: "Bone Lamp",
+	2135: "Living Wood Bookcase",
+	2136: "Shadewood Bookcase",
+	2137: "Golden Bookcase",
+	2138: "Bone Bookcase",
+	2139: "Living Wood Bed",
+	2140: "Bone Bed",
+	2141: "Living Wood Chandelier",
+	2142: "Shadewood Chandelier",
+	2143: "Golden Chandelier",
+	2144: "Bone Chandelier",
+	2145: "Living Wood Lantern",
+	2146: "Shadewood Lantern",
+	2147: "Golden Lantern",
+	2148: "Bone Lantern",
+	2149: "Living Wood Candelabra",
+	2150: "Shadewood Candelabra",
+	2151: "Golden Candelabra",
+	2152: "Bone Candelabra",
+	2153: "Living Wood Candle",
+	2154: "Shadewood Candle",
+	2155: "Golden Candle",
+	2156: "Black Scorpion",
+	2157: "Scorpion",
+	2158: "Bubble Wallpaper",
+	2159: "Copper Pipe Wallpaper",
+	2160: "Ducky Wallpaper",
+	2161: "Frost Core",
+	2162: "Bunny Cage",
+	2163: "Squirrel Cage",
+	2164: "Mallard Duck Cage",
+	2165: "Duck Cage",
+	2166: "Bird Cage",
+	2167: "Blue Jay Cage",
+	2168: "Cardinal Cage",
+	2169: "Waterfall Wall",
+	2170: "Lavafall Wall",
+	2171: "Crimson Seeds",
+	2172: "Heavy Work Bench",
+	2173: "Copper Plating",
+	2174: "Snail Cage",
+	2175: "Glowing Snail Cage",
+	2176: "Shroomite Digging Claw",
+	2177: "Ammo Box",
+	2178: "Monarch Butterfly Jar",
+	2179: "Purple Emperor Butterfly Jar",
+	2180: "Red Admiral Butterfly Jar",
+	2181: "Ulysses Butterfly Jar",
+	2182: "Sulphur Butterfly Jar",
+	2183: "Tree Nymph Butterfly Jar",
+	2184: "Zebra Swallowtail Butterfly Jar",
+	2185: "Julia Butterfly Jar",
+	2186: "Scorpion Cage",
+	2187: "Black Scorpion Cage",
+	2188: "Venom Staff",
+	2189: "Spectre Mask",
+	2190: "Frog Cage",
+	2191: "Mouse Cage",
+	2192: "Bone Welder",
+	2193: "Flesh Cloning Vat",
+	2194: "Glass Kiln",
+	2195: "Lihzahrd Furnace",
+	2196: "Living Loom",
+	2197: "Sky Mill",
+	2198: "Ice Machine",
+	2199: "Beetle Helmet",
+	2200: "Beetle Scale Mail",
+	2201: "Beetle Shell",
+	2202: "Beetle Leggings",
+	2203: "Steampunk Boiler",
+	2204: "Honey Dispenser",
+	2205: "Penguin",
+	2206: "Penguin Cage",
+	2207: "Worm Cage",
+	2208: "Terrarium",
+	2209: "Super Mana Potion",
+	2210: "Ebonwood Fence",
+	2211: "Rich Mahogany Fence",
+	2212: "Pearlwood Fence",
+	2213: "Shadewood Fence",
+	2214: "Brick Layer",
+	2215: "Extendo Grip",
+	2216: "Paint Sprayer",
+	2217: "Portable Cement Mixer",
+	2218: "Beetle Husk",
+	2219: "Celestial Magnet",
+	2220: "Celestial Emblem",
+	2221: "Celestial Cuffs",
+	2222: "Peddler's Hat",
+	2223: "Pulse Bow",
+	2224: "Large Dynasty Lantern",
+	2225: "Dynasty Lamp",
+	2226: "Dynasty Lantern",
+	2227: "Large Dynasty Candle",
+	2228: "Dynasty Chair",
+	2229: "Dynasty Work Bench",
+	2230: "Dynasty Chest",
+	2231: "Dynasty Bed",
+	2232: "Dynasty Bathtub",
+	2233: "Dynasty Bookcase",
+	2234: "Dynasty Cup",
+	2235: "Dynasty Bowl",
+	2236: "Dynasty Candle",
+	2237: "Dynasty Grandfather Clock",
+	2238: "Golden Grandfather Clock",
+	2239: "Glass Grandfather Clock",
+	2240: "Honey Grandfather Clock",
+	2241: "Steampunk Grandfather Clock",
+	2242: "Fancy Dishes",
+	2243: "Glass Bowl",
+	2244: "Wine Glass",
+	2245: "Living Wood Piano",
+	2246: "Flesh Piano",
+	2247: "Frozen Piano",
+	2248: "Frozen Table",
+	2249: "Honey Chest",
+	2250: "Steampunk Chest",
+	2251: "Honey Work Bench",
+	2252: "Frozen Work Bench",
+	2253: "Steampunk Work Bench",
+	2254: "Glass Piano",
+	2255: "Honey Piano",
+	2256: "Steampunk Piano",
+	2257: "Honey Cup",
+	2258: "Chalice",
+	2259: "Dynasty Table",
+	2260: "Dynasty Wood",
+	2261: "Red Dynasty Shingles",
+	2262: "Blue Dynasty Shingles",
+	2263: "White Dynasty Wall",
+	2264: "Blue Dynasty Wall",
+	2265: "Dynasty Door",
+	2266: "Sake",
+	2267: "Pad Thai",
+	2268: "Pho",
+	2269: "Revolver",
+	2270: "Gatligator",
+	2271: "Arcane Rune Wall",
+	2272: "Water Gun",
+	2273: "Katana",
+	2274: "Ultrabright Torch",
+	2275: "Magic Hat",
+	2276: "Diamond Ring",
+	2277: "Gi",
+	2278: "Kimono",
+	2279: "Gypsy Robe",
+	2280: "Beetle Wings",
+	2281: "Tiger Skin",
+	2282: "Leopard Skin",
+	2283: "Zebra Skin",
+	2284: "Crimson Cloak",
+	2285: "Mysterious Cape",
+	2286: "Red Cape",
+	2287: "Winter Cape",
+	2288: "Frozen Chair",
+	2289: "Wood Fishing Pole",
+	2290: "Bass",
+	2291: "Reinforced Fishing Pole",
+	2292: "Fiberglass Fishing Pole",
+	2293: "Fisher of Souls",
+	2294: "Golden Fishing Rod",
+	2295: "Mechanic's Rod",
+	2296: "Sitting Duck's Fishing Pole",
+	2297: "Trout",
+	2298: "Salmon",
+	2299: "Atlantic Cod",
+	2300: "Tuna",
+	2301: "Red Snapper",
+	2302: "Neon Tetra",
+	2303: "Armored Cavefish",
+	2304: "Damselfish",
+	2305: "Crimson Tigerfish",
+	2306: "Frost Minnow",
+	2307: "Princess Fish",
+	2308: "Golden Carp",
+	2309: "Specular Fish",
+	2310: "Prismite",
+	2311: "Variegated Lardfish",
+	2312: "Flarefin Koi",
+	2313: "Double Cod",
+	2314: "Honeyfin",
+	2315: "Obsidifish",
+	2316: "Shrimp",
+	2317: "Chaos Fish",
+	2318: "Ebonkoi",
+	2319: "Hemopiranha",
+	2320: "Rockfish",
+	2321: "Stinkfish",
+	2322: "Mining Potion",
+	2323: "Heartreach Potion",
+	2324: "Calming Potion",
+	2325: "Builder Potion",
+	2326: "Titan Potion",
+	2327: "Flipper Potion",
+	2328: "Summoning Potion",
+	2329: "Dangersense Potion",
+	2330: "Purple Clubberfish",
+	2331: "Obsidian Swordfish",
+	2332: "Swordfish",
+	2333: "Iron Fence",
+	2334: "Wooden Crate",
+	2335: "Iron Crate",
+	2336: "Golden Crate",
+	2337: "Old Shoe",
+	2338: "Seaweed",
+	2339: "Tin Can",
+	2340: "Minecart Track",
+	2341: "Reaver Shark",
+	2342: "Sawtooth Shark",
+	2343: "Minecart",
+	2344: "Ammo Reservation Potion",
+	2345: "Lifeforce Potion",
+	2346: "Endurance Potion",
+	2347: "Rage Potion",
+	2348: "Inferno Potion",
+	2349: "Wrath Potion",
+	2350: "Recall Potion",
+	2351: "Teleportation Potion",
+	2352: "Love Potion",
+	2353: "Stink Potion",
+	2354: "Fishing Potion",
+	2355: "Sonar Potion",
+	2356: "Crate Potion",
+	2357: "Shiverthorn Seeds",
+	2358: "Shiverthorn",
+	2359: "Warmth Potion",
+	2360: "Fish Hook",
+	2361: "Bee Headgear",
+	2362: "Bee Breastplate",
+	2363: "Bee Greaves",
+	2364: "Hornet Staff",
+	2365: "Imp Staff",
+	2366: "Queen Spider Staff",
+	2367: "Angler Hat",
+	2368: "Angler Vest",
+	2369: "Angler Pants",
+	2370: "Spider Mask",
+	2371: "Spider Breastplate",
+	2372: "Spider Greaves",
+	2373: "High Test Fishing Line",
+	2374: "Angler Earring",
+	2375: "Tackle Box",
+	2376: "Blue Dungeon Piano",
+	2377: "Green Dungeon Piano",
+	2378: "Pink Dungeon Piano",
+	2379: "Golden Piano",
+	2380: "Obsidian Piano",
+	2381: "Bone Piano",
+	2382: "Cactus Piano",
+	2383: "Spooky Piano",
+	2384: "Skyware Piano",
+	2385: "Lihzahrd Piano",
+	2386: "Blue Dungeon Dresser",
+	2387: "Green Dungeon Dresser",
+	2388: "Pink Dungeon Dresser",
+	2389: "Golden Dresser",
+	2390: "Obsidian Dresser",
+	2391: "Bone Dresser",
+	2392: "Cactus Dresser",
+	2393: "Spooky Dresser",
+	2394: "Skyware Dresser",
+	2395: "Honey Dresser",
+	2396: "Lihzahrd Dresser",
+	2397: "Sofa",
+	2398: "Ebonwood Sofa",
+	2399: "Rich Mahogany Sofa",
+	2400: "Pearlwood Sofa",
+	2401: "Shadewood Sofa",
+	2402: "Blue Dungeon Sofa",
+	2403: "Green Dungeon Sofa",
+	2404: "Pink Dungeon Sofa",
+	2405: "Golden Sofa",
+	2406: "Obsidian Sofa",
+	2407: "Bone Sofa",
+	2408: "Cactus Sofa",
+	2409: "Spooky Sofa",
+	2410: "Skyware Sofa",
+	2411: "Honey Sofa",
+	2412: "Steampunk Sofa",
+	2413: "Mushroom Sofa",
+	2414: "Glass Sofa",
+	2415: "Pumpkin Sofa",
+	2416: "Lihzahrd Sofa",
+	2417: "Seashell Hairpin",
+	2418: "Mermaid Adornment",
+	2419: "Mermaid Tail",
+	2420: "Zephyr Fish",
+	2421: "Fleshcatcher",
+	2422: "Hotline Fishing Hook",
+	2423: "Frog Leg",
+	2424: "Anchor",
+	2425: "Cooked Fish",
+	2426: "Cooked Shrimp",
+	2427: "Sashimi",
+	2428: "Fuzzy Carrot",
+	2429: "Scaly Truffle",
+	2430: "Slimy Saddle",
+	2431: "Bee Wax",
+	2432: "Copper Plating Wall",
+	2433: "Stone Slab Wall",
+	2434: "Sail",
+	2435: "Coralstone Block",
+	2436: "Blue Jellyfish",
+	2437: "Green Jellyfish",
+	2438: "Pink Jellyfish",
+	2439: "Blue Jellyfish Jar",
+	2440: "Green Jellyfish Jar",
+	2441: "Pink Jellyfish Jar",
+	2442: "Life Preserver",
+	2443: "Ship's Wheel",
+	2444: "Compass Rose",
+	2445: "Wall Anchor",
+	2446: "Goldfish Trophy",
+	2447: "Bunnyfish Trophy",
+	2448: "Swordfish Trophy",
+	2449: "Sharkteeth Trophy",
+	2450: "Batfish",
+	2451: "Bumblebee Tuna",
+	2452: "Catfish",
+	2453: "Cloudfish",
+	2454: "Cursedfish",
+	2455: "Dirtfish",
+	2456: "Dynamite Fish",
+	2457: "Eater of Plankton",
+	2458: "Fallen Starfish",
+	2459: "The Fish of Cthulhu",
+	2460: "Fishotron",
+	2461: "Harpyfish",
+	2462: "Hungerfish",
+	2463: "Ichorfish",
+	2464: "Jewelfish",
+	2465: "Mirage Fish",
+	2466: "Mutant Flinxfin",
+	2467: "Pengfish",
+	2468: "Pixiefish",
+	2469: "Spiderfish",
+	2470: "Tundra Trout",
+	2471: "Unicorn Fish",
+	2472: "Guide Voodoo Fish",
+	2473: "Wyverntail",
+	2474: "Zombie Fish",
+	2475: "Amanitia Fungifin",
+	2476: "Angelfish",
+	2477: "Bloody Manowar",
+	2478: "Bonefish",
+	2479: "Bunnyfish",
+	2480: "Cap'n Tunabeard",
+	2481: "Clownfish",
+	2482: "Demonic Hellfish",
+	2483: "Derpfish",
+	2484: "Fishron",
+	2485: "Infected Scabbardfish",
+	2486: "Mudfish",
+	2487: "Slimefish",
+	2488: "Tropical Barracuda",
+	2489: "King Slime Trophy",
+	2490: "Ship in a Bottle",
+	2491: "Hardy Saddle",
+	2492: "Pressure Plate Track",
+	2493: "King Slime Mask",
+	2494: "Fin Wings",
+	2495: "Treasure Map",
+	2496: "Seaweed Planter",
+	2497: "Pillagin Me Pixels",
+	2498: "Fish Costume Mask",
+	2499: "Fish Costume Shirt",
+	2500: "Fish Costume Finskirt",
+	2501: "Ginger Beard",
+	2502: "Honeyed Goggles",
+	2503: "Boreal Wood",
+	2504: "Palm Wood",
+	2505: "Boreal Wood Wall",
+	2506: "Palm Wood Wall",
+	2507: "Boreal Wood Fence",
+	2508: "Palm Wood Fence",
+	2509: "Boreal Wood Helmet",
+	2510: "Boreal Wood Breastplate",
+	2511: "Boreal Wood Greaves",
+	2512: "Palm Wood Helmet",
+	2513: "Palm Wood Breastplate",
+	2514: "Palm Wood Greaves",
+	2515: "Palm Wood Sword",
+	2516: "Palm Wood Hammer",
+	2517: "Palm Wood Bow",
+	2518: "Palm Wood Platform",
+	2519: "Palm Wood Bathtub",
+	2520: "Palm Wood Bed",
+	2521: "Palm Wood Bench",
+	2522: "Palm Wood Candelabra",
+	2523: "Palm Wood Candle",
+	2524: "Palm Wood Chair",
+	2525: "Palm Wood Chandelier",
+	2526: "Palm Wood Chest",
+	2527: "Palm Wood Sofa",
+	2528: "Palm Wood Door",
+	2529: "Palm Wood Dresser",
+	2530: "Palm Wood Lantern",
+	2531: "Palm Wood Piano",
+	2532: "Palm Wood Table",
+	2533: "Palm Wood Lamp",
+	2534: "Palm Wood Work Bench",
+	2535: "Optic Staff",
+	2536: "Palm Wood Bookcase",
+	2537: "Mushroom Bathtub",
+	2538: "Mushroom Bed",
+	2539: "Mushroom Bench",
+	2540: "Mushroom Bookcase",
+	2541: "Mushroom Candelabra",
+	2542: "Mushroom Candle",
+	2543: "Mushroom Chandelier",
+	2544: "Mushroom Chest",
+	2545: "Mushroom Dresser",
+	2546: "Mushroom Lantern",
+	2547: "Mushroom Lamp",
+	2548: "Mushroom Piano",
+	2549: "Mushroom Platform",
+	2550: "Mushroom Table",
+	2551: "Spider Staff",
+	2552: "Boreal Wood Bathtub",
+	2553: "Boreal Wood Bed",
+	2554: "Boreal Wood Bookcase",
+	2555: "Boreal Wood Candelabra",
+	2556: "Boreal Wood Candle",
+	2557: "Boreal Wood Chair",
+	2558: "Boreal Wood Chandelier",
+	2559: "Boreal Wood Chest",
+	2560: "Boreal Wood Clock",
+	2561: "Boreal Wood Door",
+	2562: "Boreal Wood Dresser",
+	2563: "Boreal Wood Lamp",
+	2564: "Boreal Wood Lantern",
+	2565: "Boreal Wood Piano",
+	2566: "Boreal Wood Platform",
+	2567: "Slime Bathtub",
+	2568: "Slime Bed",
+	2569: "Slime Bookcase",
+	2570: "Slime Candelabra",
+	2571: "Slime Candle",
+	2572: "Slime Chair",
+	2573: "Slime Chandelier",
+	2574: "Slime Chest",
+	2575: "Slime Clock",
+	2576: "Slime Door",
+	2577: "Slime Dresser",
+	2578: "Slime Lamp",
+	2579: "Slime Lantern",
+	2580: "Slime Piano",
+	2581: "Slime Platform",
+	2582: "Slime Sofa",
+	2583: "Slime Table",
+	2584: "Pirate Staff",
+	2585: "Slime Hook",
+	2586: "Sticky Grenade",
+	2587: "Tartar Sauce",
+	2588: "Duke Fishron Mask",
+	2589: "Duke Fishron Trophy",
+	2590: "Molotov Cocktail",
+	2591: "Bone Clock",
+	2592: "Cactus Clock",
+	2593: "Ebonwood Clock",
+	2594: "Frozen Clock",
+	2595: "Lihzahrd Clock",
+	2596: "Living Wood Clock",
+	2597: "Rich Mahogany Clock",
+	2598: "Flesh Clock",
+	2599: "Mushroom Clock",
+	2600: "Obsidian Clock",
+	2601: "Palm Wood Clock",
+	2602: "Pearlwood Clock",
+	2603: "Pumpkin Clock",
+	2604: "Shadewood Clock",
+	2605: "Spooky Clock",
+	2606: "Sunplate Clock",
+	2607: "Spider Fang",
+	2608: "Falcon Blade",
+	2609: "Fishron Wings",
+	2610: "Slime Gun",
+	2611: "Flairon",
+	2612: "Green Dungeon Chest",
+	2613: "Pink Dungeon Chest",
+	2614: "Blue Dungeon Chest",
+	2615: "Bone Chest",
+	2616: "Cactus Chest",
+	2617: "Flesh Chest",
+	2618: "Obsidian Chest",
+	2619: "Pumpkin Chest",
+	2620: "Spooky Chest",
+	2621: "Tempest Staff",
+	2622: "Razorblade Typhoon",
+	2623: "Bubble Gun",
+	2624: "Tsunami",
+	2625: "Seashell",
+	2626: "Starfish",
+	2627: "Steampunk Platform",
+	2628: "Skyware Platform",
+	2629: "Living Wood Platform",
+	2630: "Honey Platform",
+	2631: "Skyware Work Bench",
+	2632: "Glass Work Bench",
+	2633: "Living Wood Work Bench",
+	2634: "Flesh Sofa",
+	2635: "Frozen Sofa",
+	2636: "Living Wood Sofa",
+	2637: "Pumpkin Dresser",
+	2638: "Steampunk Dresser",
+	2639: "Glass Dresser",
+	2640: "Flesh Dresser",
+	2641: "Pumpkin Lantern",
+	2642: "Obsidian Lantern",
+	2643: "Pumpkin Lamp",
+	2644: "Obsidian Lamp",
+	2645: "Blue Dungeon Lamp",
+	2646: "Green Dungeon Lamp",
+	2647: "Pink Dungeon Lamp",
+	2648: "Honey Candle",
+	2649: "Steampunk Candle",
+	2650: "Spooky Candle",
+	2651: "Obsidian Candle",
+	2652: "Blue Dungeon Chandelier",
+	2653: "Green Dungeon Chandelier",
+	2654: "Pink Dungeon Chandelier",
+	2655: "Steampunk Chandelier",
+	2656: "Pumpkin Chandelier",
+	2657: "Obsidian Chandelier",
+	2658: "Blue Dungeon Bathtub",
+	2659: "Green Dungeon Bathtub",
+	2660: "Pink Dungeon Bathtub",
+	2661: "Pumpkin Bathtub",
+	2662: "Obsidian Bathtub",
+	2663: "Golden Bathtub",
+	2664: "Blue Dungeon Candelabra",
+	2665: "Green Dungeon Candelabra",
+	2666: "Pink Dungeon Candelabra",
+	2667: "Obsidian Candelabra",
+	2668: "Pumpkin Candelabra",
+	2669: "Pumpkin Bed",
+	2670: "Pumpkin Bookcase",
+	2671: "Pumpkin Piano",
+	2672: "Shark Statue",
+	2673: "Truffle Worm",
+	2674: "Apprentice Bait",
+	2675: "Journeyman Bait",
+	2676: "Master Bait",
+	2677: "Amber Gemspark Wall",
+	2678: "Offline Amber Gemspark Wall",
+	2679: "Amethyst Gemspark Wall",
+	2680: "Offline Amethyst Gemspark Wall",
+	2681: "Diamond Gemspark Wall",
+	2682: "Offline Diamond Gemspark Wall",
+	2683: "Emerald Gemspark Wall",
+	2684: "Offline Emerald Gemspark Wall",
+	2685: "Ruby Gemspark Wall",
+	2686: "Offline Ruby Gemspark Wall",
+	2687: "Sapphire Gemspark Wall",
+	2688: "Offline Sapphire Gemspark Wall",
+	2689: "Topaz Gemspark Wall",
+	2690: "Offline Topaz Gemspark Wall",
+	2691: "Tin Plating Wall",
+	2692: "Tin Plating",
+	2693: "Waterfall Block",
+	2694: "Lavafall Block",
+	2695: "Confetti Block",
+	2696: "Confetti Wall",
+	2697: "Midnight Confetti Block",
+	2698: "Midnight Confetti Wall",
+	2699: "Weapon Rack",
+	2700: "Fireworks Box",
+	2701: "Living Fire Block",
+	2702: "0 Statue",
+	2703: "1 Statue",
+	2704: "2 Statue",
+	2705: "3 Statue",
+	2706: "4 Statue",
+	2707: "5 Statue",
+	2708: "6 Statue",
+	2709: "7 Statue",
+	2710: "8 Statue",
+	2711: "9 Statue",
+	2712: "A Statue",
+	2713: "B Statue",
+	2714: "C Statue",
+	2715: "D Statue",
+	2716: "E Statue",
+	2717: "F Statue",
+	2718: "G Statue",
+	2719: "H Statue",
+	2720: "I Statue",
+	2721: "J Statue",
+	2722: "K Statue",
+	2723: "L Statue",
+	2724: "M Statue",
+	2725: "N Statue",
+	2726: "O Statue",
+	2727: "P Statue",
+	2728: "Q Statue",
+	2729: "R Statue",
+	2730: "S Statue",
+	2731: "T Statue",
+	2732: "U Statue",
+	2733: "V Statue",
+	2734: "W Statue",
+	2735: "X Statue",
+	2736: "Y Statue",
+	2737: "Z Statue",
+	2738: "Firework Fountain",
+	2739: "Booster Track",
+	2740: "Grasshopper",
+	2741: "Grasshopper Cage",
+	2742: "Music Box (Underground Crimson)",
+	2743: "Cactus Table",
+	2744: "Cactus Platform",
+	2745: "Boreal Wood Sword",
+	2746: "Boreal Wood Hammer",
+	2747: "Boreal Wood Bow",
+	2748: "Glass Chest",
+	2749: "Xeno Staff",
+	2750: "Meteor Staff",
+	2751: "Living Cursed Fire Block",
+	2752: "Living Demon Fire Block",
+	2753: "Living Frost Fire Block",
+	2754: "Living Ichor Block",
+	2755: "Living Ultrabright Fire Block",
+	2756: "Gender Change Potion",
+	2757: "Vortex Helmet",
+	2758: "Vortex Breastplate",
+	2759: "Vortex Leggings",
+	2760: "Nebula Helmet",
+	2761: "Nebula Breastplate",
+	2762: "Nebula Leggings",
+	2763: "Solar Flare Helmet",
+	2764: "Solar Flare Breastplate",
+	2765: "Solar Flare Leggings",
+	2766: "Solar Tablet Fragment",
+	2767: "Solar Tablet",
+	2768: "Drill Containment Unit",
+	2769: "Cosmic Car Key",
+	2770: "Mothron Wings",
+	2771: "Brain Scrambler",
+	2772: "Vortex Axe",
+	2773: "Vortex Chainsaw",
+	2774: "Vortex Drill",
+	2775: "Vortex Hammer",
+	2776: "Vortex Pickaxe",
+	2777: "Nebula Axe",
+	2778: "Nebula Chainsaw",
+	2779: "Nebula Drill",
+	2780: "Nebula Hammer",
+	2781: "Nebula Pickaxe",
+	2782: "Solar Flare Axe",
+	2783: "Solar Flare Chainsaw",
+	2784: "Solar Flare Drill",
+	2785: "Solar Flare Hammer",
+	2786: "Solar Flare Pickaxe",
+	2787: "Honeyfall Block",
+	2788: "Honeyfall Wall",
+	2789: "Chlorophyte Brick Wall",
+	2790: "Crimtane Brick Wall",
+	2791: "Shroomite Plating Wall",
+	2792: "Chlorophyte Brick",
+	2793: "Crimtane Brick",
+	2794: "Shroomite Plating",
+	2795: "Laser Machinegun",
+	2796: "Electrosphere Launcher",
+	2797: "Xenopopper",
+	2798: "Laser Drill",
+	2799: "Mechanical Ruler",
+	2800: "Anti-Gravity Hook",
+	2801: "Moon Mask",
+	2802: "Sun Mask",
+	2803: "Martian Costume Mask",
+	2804: "Martian Costume Shirt",
+	2805: "Martian Costume Pants",
+	2806: "Martian Uniform Helmet",
+	2807: "Martian Uniform Torso",
+	2808: "Martian Uniform Pants",
+	2809: "Martian Astro Clock",
+	2810: "Martian Bathtub",
+	2811: "Martian Bed",
+	2812: "Martian Hover Chair",
+	2813: "Martian Chandelier",
+	2814: "Martian Chest",
+	2815: "Martian Door",
+	2816: "Martian Dresser",
+	2817: "Martian Holobookcase",
+	2818: "Martian Hover Candle",
+	2819: "Martian Lamppost",
+	2820: "Martian Lantern",
+	2821: "Martian Piano",
+	2822: "Martian Platform",
+	2823: "Martian Sofa",
+	2824: "Martian Table",
+	2825: "Martian Table Lamp",
+	2826: "Martian Work Bench",
+	2827: "Wooden Sink",
+	2828: "Ebonwood Sink",
+	2829: "Rich Mahogany Sink",
+	2830: "Pearlwood Sink",
+	2831: "Bone Sink",
+	2832: "Flesh Sink",
+	2833: "Living Wood Sink",
+	2834: "Skyware Sink",
+	2835: "Shadewood Sink",
+	2836: "Lihzahrd Sink",
+	2837: "Blue Dungeon Sink",
+	2838: "Green Dungeon Sink",
+	2839: "Pink Dungeon Sink",
+	2840: "Obsidian Sink",
+	2841: "Metal Sink",
+	2842: "Glass Sink",
+	2843: "Golden Sink",
+	2844: "Honey Sink",
+	2845: "Steampunk Sink",
+	2846: "Pumpkin Sink",
+	2847: "Spooky Sink",
+	2848: "Frozen Sink",
+	2849: "Dynasty Sink",
+	2850: "Palm Wood Sink",
+	2851: "Mushroom Sink",
+	2852: "Boreal Wood Sink",
+	2853: "Slime Sink",
+	2854: "Cactus Sink",
+	2855: "Martian Sink",
+	2856: "Solar Cultist Hood",
+	2857: "Lunar Cultist Hood",
+	2858: "Solar Cultist Robe",
+	2859: "Lunar Cultist Robe",
+	2860: "Martian Conduit Plating",
+	2861: "Martian Conduit Wall",
+	2862: "HiTek Sunglasses",
+	2863: "Martian Hair Dye",
+	2864: "Martian Dye",
+	2865: "Castle Marsberg",
+	2866: "Martia Lisa",
+	2867: "The Truth Is Up There",
+	2868: "Smoke Block",
+	2869: "Living Flame Dye",
+	2870: "Living Rainbow Dye",
+	2871: "Shadow Dye",
+	2872: "Negative Dye",
+	2873: "Living Ocean Dye",
+	2874: "Brown Dye",
+	2875: "Brown and Black Dye",
+	2876: "Bright Brown Dye",
+	2877: "Brown and Silver Dye",
+	2878: "Wisp Dye",
+	2879: "Pixie Dye",
+	2880: "Influx Waver",
+	2881: "Phasic Warp Ejector",
+	2882: "Charged Blaster Cannon",
+	2883: "Chlorophyte Dye",
+	2884: "Unicorn Wisp Dye",
+	2885: "Infernal Wisp Dye",
+	2886: "Vicious Powder",
+	2887: "Vicious Mushroom",
+	2888: "The Bee's Knees",
+	2889: "Gold Bird",
+	2890: "Gold Bunny",
+	2891: "Gold Butterfly",
+	2892: "Gold Frog",
+	2893: "Gold Grasshopper",
+	2894: "Gold Mouse",
+	2895: "Gold Worm",
+	2896: "Sticky Dynamite",
+	2897: "Angry Trapper Banner",
+	2898: "Armored Viking Banner",
+	2899: "Black Slime Banner",
+	2900: "Blue Armored Bones Banner",
+	2901: "Blue Cultist Archer Banner",
+	2902: "Blue Cultist Caster Banner",
+	2903: "Blue Cultist Fighter Banner",
+	2904: "Bone Lee Banner",
+	2905: "Clinger Banner",
+	2906: "Cochineal Beetle Banner",
+	2907: "Corrupt Penguin Banner",
+	2908: "Corrupt Slime Banner",
+	2909: "Corruptor Banner",
+	2910: "Crimslime Banner",
+	2911: "Cursed Skull Banner",
+	2912: "Cyan Beetle Banner",
+	2913: "Devourer Banner",
+	2914: "Diabolist Banner",
+	2915: "Doctor Bones Banner",
+	2916: "Dungeon Slime Banner",
+	2917: "Dungeon Spirit Banner",
+	2918: "Elf Archer Banner",
+	2919: "Elf Copter Banner",
+	2920: "Eyezor Banner",
+	2921: "Flocko Banner",
+	2922: "Ghost Banner",
+	2923: "Giant Bat Banner",
+	2924: "Giant Cursed Skull Banner",
+	2925: "Giant Flying Fox Banner",
+	2926: "Gingerbread Man Banner",
+	2927: "Goblin Archer Banner",
+	2928: "Green Slime Banner",
+	2929: "Headless Horseman Banner",
+	2930: "Hell Armored Bones Banner",
+	2931: "Hellhound Banner",
+	2932: "Hoppin' Jack Banner",
+	2933: "Ice Bat Banner",
+	2934: "Ice Golem Banner",
+	2935: "Ice Slime Banner",
+	2936: "Ichor Sticker Banner",
+	2937: "Illuminant Bat Banner",
+	2938: "Illuminant Slime Banner",
+	2939: "Jungle Bat Banner",
+	2940: "Jungle Slime Banner",
+	2941: "Krampus Banner",
+	2942: "Lac Beetle Banner",
+	2943: "Lava Bat Banner",
+	2944: "Lava Slime Banner",
+	2945: "Martian Brainscrambler Banner",
+	2946: "Martian Drone Banner",
+	2947: "Martian Engineer Banner",
+	2948: "Martian Gigazapper Banner",
+	2949: "Martian Gray Grunt Banner",
+	2950: "Martian Officer Banner",
+	2951: "Martian Raygunner Banner",
+	2952: "Martian Scutlix Gunner Banner",
+	2953: "Martian Tesla Turret Banner",
+	2954: "Mister Stabby Banner",
+	2955: "Mother Slime Banner",
+	2956: "Necromancer Banner",
+	2957: "Nutcracker Banner",
+	2958: "Paladin Banner",
+	2959: "Penguin Banner",
+	2960: "Pinky Banner",
+	2961: "Poltergeist Banner",
+	2962: "Possessed Armor Banner",
+	2963: "Present Mimic Banner",
+	2964: "Purple Slime Banner",
+	2965: "Ragged Caster Banner",
+	2966: "Rainbow Slime Banner",
+	2967: "Raven Banner",
+	2968: "Red Slime Banner",
+	2969: "Rune Wizard Banner",
+	2970: "Rusty Armored Bones Banner",
+	2971: "Scarecrow Banner",
+	2972: "Scutlix Banner",
+	2973: "Skeleton Archer Banner",
+	2974: "Skeleton Commando Banner",
+	2975: "Skeleton Sniper Banner",
+	2976: "Slimer Banner",
+	2977: "Snatcher Banner",
+	2978: "Snow Balla Banner",
+	2979: "Snowman Gangsta Banner",
+	2980: "Spiked Ice Slime Banner",
+	2981: "Spiked Jungle Slime Banner",
+	2982: "Splinterling Banner",
+	2983: "Squid Banner",
+	2984: "Tactical Skeleton Banner",
+	2985: "The Groom Banner",
+	2986: "Tim Banner",
+	2987: "Undead Miner Banner",
+	2988: "Undead Viking Banner",
+	2989: "White Cultist Archer Banner",
+	2990: "White Cultist Caster Banner",
+	2991: "White Cultist Fighter Banner",
+	2992: "Yellow Slime Banner",
+	2993: "Yeti Banner",
+	2994: "Zombie Elf Banner",
+	2995: "Sparky",
+	2996: "Vine Rope",
+	2997: "Wormhole Potion",
+	2998: "Summoner Emblem",
+	2999: "Bewitching Table",
+	3000: "Alchemy Table",
+	3001: "Strange Brew",
+	3002: "Spelunker Glowstick",
+	3003: "Bone Arrow",
+	3004: "Bone Torch",
+	3005: "Vine Rope Coil",
+	3006: "Life Drain",
+	3007: "Dart Pistol",
+	3008: "Dart Rifle",
+	3009: "Crystal Dart",
+	3010: "Cursed Dart",
+	3011: "Ichor Dart",
+	3012: "Chain Guillotines",
+	3013: "Fetid Baghnakhs",
+	3014: "Clinger Staff",
+	3015: "Putrid Scent",
+	3016: "Flesh Knuckles",
+	3017: "Flower Boots",
+	3018: "Seedler",
+	3019: "Hellwing Bow",
+	3020: "Tendon Hook",
+	3021: "Thorn Hook",
+	3022: "Illuminant Hook",
+	3023: "Worm Hook",
+	3024: "Skiphs's Blood",
+	3025: "Purple Ooze Dye",
+	3026: "Reflective Silver Dye",
+	3027: "Reflective Gold Dye",
+	3028: "Blue Acid Dye",
+	3029: "Daedalus Stormbow",
+	3030: "Flying Knife",
+	3031: "Bottomless Water Bucket",
+	3032: "Super Absorbant Sponge",
+	3033: "Gold Ring",
+	3034: "Coin Ring",
+	3035: "Greedy Ring",
+	3036: "Fish Finder",
+	3037: "Weather Radio",
+	3038: "Hades Dye",
+	3039: "Twilight Dye",
+	3040: "Acid Dye",
+	3041: "Glowing Mushroom Dye",
+	3042: "Phase Dye",
+	3043: "Magic Lantern",
+	3044: "Music Box (Lunar Boss)",
+	3045: "Rainbow Torch",
+	3046: "Cursed Campfire",
+	3047: "Demon Campfire",
+	3048: "Frozen Campfire",
+	3049: "Ichor Campfire",
+	3050: "Rainbow Campfire",
+	3051: "Crystal Vile Shard",
+	3052: "Shadowflame Bow",
+	3053: "Shadowflame Hex Doll",
+	3054: "Shadowflame Knife",
+	3055: "Acorns",
+	3056: "Cold Snap",
+	3057: "Cursed Saint",
+	3058: "Snowfellas",
+	3059: "The Season",
+	3060: "Bone Rattle",
+	3061: "Architect Gizmo Pack",
+	3062: "Crimson Heart",
+	3063: "Meowmere",
+	3064: "Enchanted Sundial",
+	3065: "Star Wrath",
+	3066: "Smooth Marble Block",
+	3067: "Hellstone Brick Wall",
+	3068: "Guide to Plant Fiber Cordage",
+	3069: "Wand of Sparking",
+	3070: "Gold Bird Cage",
+	3071: "Gold Bunny Cage",
+	3072: "Gold Butterfly Jar",
+	3073: "Gold Frog Cage",
+	3074: "Gold Grasshopper Cage",
+	3075: "Gold Mouse Cage",
+	3076: "Gold Worm Cage",
+	3077: "Silk Rope",
+	3078: "Web Rope",
+	3079: "Silk Rope Coil",
+	3080: "Web Rope Coil",
+	3081: "Marble Block",
+	3082: "Marble Wall",
+	3083: "Smooth Marble Wall",
+	3084: "Radar",
+	3085: "Golden Lock Box",
+	3086: "Granite Block",
+	3087: "Smooth Granite Block",
+	3088: "Granite Wall",
+	3089: "Smooth Granite Wall",
+	3090: "Royal Gel",
+	3091: "Key of Night",
+	3092: "Key of Light",
+	3093: "Herb Bag",
+	3094: "Javelin",
+	3095: "Tally Counter",
+	3096: "Sextant",
+	3097: "Shield of Cthulhu",
+	3098: "Butcher's Chainsaw",
+	3099: "Stopwatch",
+	3100: "Meteorite Brick",
+	3101: "Meteorite Brick Wall",
+	3102: "Metal Detector",
+	3103: "Endless Quiver",
+	3104: "Endless Musket Pouch",
+	3105: "Toxic Flask",
+	3106: "Psycho Knife",
+	3107: "Nail Gun",
+	3108: "Nail",
+	3109: "Night Vision Helmet",
+	3110: "Celestial Shell",
+	3111: "Pink Gel",
+	3112: "Bouncy Glowstick",
+	3113: "Pink Slime Block",
+	3114: "Pink Torch",
+	3115: "Bouncy Bomb",
+	3116: "Bouncy Grenade",
+	3117: "Peace Candle",
+	3118: "Lifeform Analyzer",
+	3119: "DPS Meter",
+	3120: "Fisherman's Pocket Guide",
+	3121: "Goblin Tech",
+	3122: "R.E.K. 3000",
+	3123: "PDA",
+	3124: "Cell Phone",
+	3125: "Granite Chest",
+	3126: "Meteorite Clock",
+	3127: "Marble Clock",
+	3128: "Granite Clock",
+	3129: "Meteorite Door",
+	3130: "Marble Door",
+	3131: "Granite Door",
+	3132: "Meteorite Dresser",
+	3133: "Marble Dresser",
+	3134: "Granite Dresser",
+	3135: "Meteorite Lamp",
+	3136: "Marble Lamp",
+	3137: "Granite Lamp",
+	3138: "Meteorite Lantern",
+	3139: "Marble Lantern",
+	3140: "Granite Lantern",
+	3141: "Meteorite Piano",
+	3142: "Marble Piano",
+	3143: "Granite Piano",
+	3144: "Meteorite Platform",
+	3145: "Marble Platform",
+	3146: "Granite Platform",
+	3147: "Meteorite Sink",
+	3148: "Marble Sink",
+	3149: "Granite Sink",
+	3150: "Meteorite Sofa",
+	3151: "Marble Sofa",
+	3152: "Granite Sofa",
+	3153: "Meteorite Table",
+	3154: "Marble Table",
+	3155: "Granite Table",
+	3156: "Meteorite Work Bench",
+	3157: "Marble Work Bench",
+	3158: "Granite Work Bench",
+	3159: "Meteorite Bathtub",
+	3160: "Marble Bathtub",
+	3161: "Granite Bathtub",
+	3162: "Meteorite Bed",
+	3163: "Marble Bed",
+	3164: "Granite Bed",
+	3165: "Meteorite Bookcase",
+	3166: "Marble Bookcase",
+	3167: "Granite Bookcase",
+	3168: "Meteorite Candelabra",
+	3169: "Marble Candelabra",
+	3170: "Granite Candelabra",
+	3171: "Meteorite Candle",
+	3172: "Marble Candle",
+	3173: "Granite Candle",
+	3174: "Meteorite Chair",
+	3175: "Marble Chair",
+	3176: "Granite Chair",
+	3177: "Meteorite Chandelier",
+	3178: "Marble Chandelier",
+	3179: "Granite Chandelier",
+	3180: "Meteorite Chest",
+	3181: "Marble Chest",
+	3182: "Magic Water Dropper",
+	3183: "Golden Bug Net",
+	3184: "Magic Lava Dropper",
+	3185: "Magic Honey Dropper",
+	3186: "Empty Dropper",
+	3187: "Gladiator Helmet",
+	3188: "Gladiator Breastplate",
+	3189: "Gladiator Leggings",
+	3190: "Reflective Dye",
+	3191: "Enchanted Nightcrawler",
+	3192: "Grubby",
+	3193: "Sluggy",
+	3194: "Buggy",
+	3195: "Grub Soup",
+	3196: "Bomb Fish",
+	3197: "Frost Daggerfish",
+	3198: "Sharpening Station",
+	3199: "Ice Mirror",
+	3200: "Sailfish Boots",
+	3201: "Tsunami in a Bottle",
+	3202: "Target Dummy",
+	3203: "Corrupt Crate",
+	3204: "Crimson Crate",
+	3205: "Dungeon Crate",
+	3206: "Sky Crate",
+	3207: "Hallowed Crate",
+	3208: "Jungle Crate",
+	3209: "Crystal Serpent",
+	3210: "Toxikarp",
+	3211: "Bladetongue",
+	3212: "Shark Tooth Necklace",
+	3213: "Money Trough",
+	3214: "Bubble",
+	3215: "Daybloom Planter Box",
+	3216: "Moonglow Planter Box",
+	3217: "Deathweed Planter Box",
+	3218: "Deathweed Planter Box",
+	3219: "Blinkroot Planter Box",
+	3220: "Waterleaf Planter Box",
+	3221: "Shiverthorn Planter Box",
+	3222: "Fireblossom Planter Box",
+	3223: "Brain of Confusion",
+	3224: "Worm Scarf",
+	3225: "Balloon Pufferfish",
+	3226: "Lazure's Valkyrie Circlet",
+	3227: "Lazure's Valkyrie Cloak",
+	3228: "Lazure's Barrier Platform",
+	3229: "Golden Cross Grave Marker",
+	3230: "Golden Tombstone",
+	3231: "Golden Grave Marker",
+	3232: "Golden Gravestone",
+	3233: "Golden Headstone",
+	3234: "Crystal Block",
+	3235: "Music Box (Martian Madness)",
+	3236: "Music Box (Pirate Invasion)",
+	3237: "Music Box (Hell)",
+	3238: "Crystal Block Wall",
+	3239: "Trap Door",
+	3240: "Tall Gate",
+	3241: "Sharkron Balloon",
+	3242: "Tax Collector's Hat",
+	3243: "Tax Collector's Suit",
+	3244: "Tax Collector's Pants",
+	3245: "Bone Glove",
+	3246: "Clothier's Jacket",
+	3247: "Clothier's Pants",
+	3248: "Dye Trader's Turban",
+	3249: "Deadly Sphere Staff",
+	3250: "Green Horseshoe Balloon",
+	3251: "Amber Horseshoe Balloon",
+	3252: "Pink Horseshoe Balloon",
+	3253: "Lava Lamp",
+	3254: "Enchanted Nightcrawler Cage",
+	3255: "Buggy Cage",
+	3256: "Grubby Cage",
+	3257: "Sluggy Cage",
+	3258: "Slap Hand",
+	3259: "Twilight Hair Dye",
+	3260: "Blessed Apple",
+	3261: "Spectre Bar",
+	3262: "Code 1",
+	3263: "Buccaneer Bandana",
+	3264: "Buccaneer Tunic",
+	3265: "Buccaneer Pantaloons",
+	3266: "Obsidian Outlaw Hat",
+	3267: "Obsidian Longcoat",
+	3268: "Obsidian Pants",
+	3269: "Medusa Head",
+	3270: "Item Frame",
+	3271: "Sandstone Block",
+	3272: "Hardened Sand Block",
+	3273: "Sandstone Wall",
+	3274: "Hardened Ebonsand Block",
+	3275: "Hardened Crimsand Block",
+	3276: "Ebonsandstone Block",
+	3277: "Crimsandstone Block",
+	3278: "Wooden Yoyo",
+	3279: "Malaise",
+	3280: "Artery",
+	3281: "Amazon",
+	3282: "Cascade",
+	3283: "Chik",
+	3284: "Code 2",
+	3285: "Rally",
+	3286: "Yelets",
+	3287: "Red's Throw",
+	3288: "Valkyrie Yoyo",
+	3289: "Amarok",
+	3290: "Hel-Fire",
+	3291: "Kraken",
+	3292: "The Eye of Cthulhu",
+	3293: "Red String",
+	3294: "Orange String",
+	3295: "Yellow String",
+	3296: "Lime String",
+	3297: "Green String",
+	3298: "Teal String",
+	3299: "Cyan String",
+	3300: "Sky Blue String",
+	3301: "Blue String",
+	3302: "Purple String",
+	3303: "Violet String",
+	3304: "Pink String",
+	3305: "Brown String",
+	3306: "White String",
+	3307: "Rainbow String",
+	3308: "Black String",
+	3309: "Black Counterweight",
+	3310: "Blue Counterweight",
+	3311: "Green Counterweight",
+	3312: "Purple Counterweight",
+	3313: "Red Counterweight",
+	3314: "Yellow Counterweight",
+	3315: "Format:C",
+	3316: "Gradient",
+	3317: "Valor",
+	3318: "Treasure Bag",
+	3319: "Treasure Bag",
+	3320: "Treasure Bag",
+	3321: "Treasure Bag",
+	3322: "Treasure Bag",
+	3323: "Treasure Bag",
+	3324: "Treasure Bag",
+	3325: "Treasure Bag",
+	3326: "Treasure Bag",
+	3327: "Treasure Bag",
+	3328: "Treasure Bag",
+	3329: "Treasure Bag",
+	3330: "Treasure Bag",
+	3331: "Treasure Bag",
+	3332: "Treasure Bag",
+	3333: "Hive Pack",
+	3334: "Yoyo Glove",
+	3335: "Demon Heart",
+	3336: "Spore Sac",
+	3337: "Shiny Stone",
+	3338: "Hardened Pearlsand Block",
+	3339: "Pearlsandstone Block",
+	3340: "Hardened Sand Wall",
+	3341: "Hardened Ebonsand Wall",
+	3342: "Hardened Crimsand Wall",
+	3343: "Hardened Pearlsand Wall",
+	3344: "Ebonsandstone Wall",
+	3345: "Crimsandstone Wall",
+	3346: "Pearlsandstone Wall",
+	3347: "Desert Fossil",
+	3348: "Desert Fossil Wall",
+	3349: "Exotic Scimitar",
+	3350: "Paintball Gun",
+	3351: "Classy Cane",
+	3352: "Stylish Scissors",
+	3353: "Mechanical Cart",
+	3354: "Mechanical Wheel Piece",
+	3355: "Mechanical Wagon Piece",
+	3356: "Mechanical Battery Piece",
+	3357: "Ancient Cultist Trophy",
+	3358: "Martian Saucer Trophy",
+	3359: "Flying Dutchman Trophy",
+	3360: "Living Mahogany Wand",
+	3361: "Rich Mahogany Leaf Wand",
+	3362: "Fallen Tuxedo Shirt",
+	3363: "Fallen Tuxedo Pants",
+	3364: "Fireplace",
+	3365: "Chimney",
+	3366: "Yoyo Bag",
+	3367: "Shrimpy Truffle",
+	3368: "Arkhalis",
+	3369: "Confetti Cannon",
+	3370: "Music Box (The Towers)",
+	3371: "Music Box (Goblin Invasion)",
+	3372: "Ancient Cultist Mask",
+	3373: "Moon Lord Mask",
+	3374: "Fossil Helmet",
+	3375: "Fossil Plate",
+	3376: "Fossil Greaves",
+	3377: "Amber Staff",
+	3378: "Bone Javelin",
+	3379: "Bone Throwing Knife",
+	3380: "Sturdy Fossil",
+	3381: "Stardust Helmet",
+	3382: "Stardust Plate",
+	3383: "Stardust Leggings",
+	3384: "Portal Gun",
+	3385: "Strange Plant",
+	3386: "Strange Plant",
+	3387: "Strange Plant",
+	3388: "Strange Plant",
+	3389: "Terrarian",
+	3390: "Goblin Summoner Banner",
+	3391: "Salamander Banner",
+	3392: "Giant Shelly Banner",
+	3393: "Crawdad Banner",
+	3394: "Fritz Banner",
+	3395: "Creature From The Deep Banner",
+	3396: "Dr. Man Fly Banner",
+	3397: "Mothron Banner",
+	3398: "Severed Hand Banner",
+	3399: "The Possessed Banner",
+	3400: "Butcher Banner",
+	3401: "Psycho Banner",
+	3402: "Deadly Sphere Banner",
+	3403: "Nailhead Banner",
+	3404: "Poisonous Spore Banner",
+	3405: "Medusa Banner",
+	3406: "Hoplite Banner",
+	3407: "Granite Elemental Banner",
+	3408: "Grolem Banner",
+	3409: "Blood Zombie Banner",
+	3410: "Drippler Banner",
+	3411: "Tomb Crawler Banner",
+	3412: "Dune Splicer Banner",
+	3413: "Antlion Swarmer Banner",
+	3414: "Antlion Charger Banner",
+	3415: "Ghoul Banner",
+	3416: "Lamia Banner",
+	3417: "Desert Spirit Banner",
+	3418: "Basilisk Banner",
+	3419: "Ravager Scorpion Banner",
+	3420: "Stargazer Banner",
+	3421: "Milkyway Weaver Banner",
+	3422: "Flow Invader Banner",
+	3423: "Twinkle Popper Banner",
+	3424: "Small Star Cell Banner",
+	3425: "Star Cell Banner",
+	3426: "Corite Banner",
+	3427: "Sroller Banner",
+	3428: "Crawltipede Banner",
+	3429: "Drakomire Rider Banner",
+	3430: "Drakomire Banner",
+	3431: "Selenian Banner",
+	3432: "Predictor Banner",
+	3433: "Brain Suckler Banner",
+	3434: "Nebula Floater Banner",
+	3435: "Evolution Beast Banner",
+	3436: "Alien Larva Banner",
+	3437: "Alien Queen Banner",
+	3438: "Alien Hornet Banner",
+	3439: "Vortexian Banner",
+	3440: "Storm Diver Banner",
+	3441: "Pirate Captain Banner",
+	3442: "Pirate Deadeye Banner",
+	3443: "Pirate Corsair Banner",
+	3444: "Pirate Crossbower Banner",
+	3445: "Martian Walker Banner",
+	3446: "Red Devil Banner",
+	3447: "Pink Jellyfish Banner",
+	3448: "Green Jellyfish Banner",
+	3449: "Dark Mummy Banner",
+	3450: "Light Mummy Banner",
+	3451: "Angry Bones Banner",
+	3452: "Ice Tortoise Banner",
+	3453: "Damage Booster",
+	3454: "Life Booster",
+	3455: "Mana Booster",
+	3456: "Vortex Fragment",
+	3457: "Nebula Fragment",
+	3458: "Solar Fragment",
+	3459: "Stardust Fragment",
+	3460: "Luminite",
+	3461: "Luminite Brick",
+	3462: "Stardust Axe",
+	3463: "Stardust Chainsaw",
+	3464: "Stardust Drill",
+	3465: "Stardust Hammer",
+	3466: "Stardust Pickaxe",
+	3467: "Luminite Bar",
+	3468: "Solar Wings",
+	3469: "Vortex Booster",
+	3470: "Nebula Mantle",
+	3471: "Stardust Wings",
+	3472: "Luminite Brick Wall",
+	3473: "Solar Eruption",
+	3474: "Stardust Cell Staff",
+	3475: "Vortex Beater",
+	3476: "Nebula Arcanum",
+	3477: "Blood Water",
+	3478: "Wedding Veil",
+	3479: "Wedding Dress",
+	3480: "Platinum Bow",
+	3481: "Platinum Hammer",
+	3482: "Platinum Axe",
+	3483: "Platinum Shortsword",
+	3484: "Platinum Broadsword",
+	3485: "Platinum Pickaxe",
+	3486: "Tungsten Bow",
+	3487: "Tungsten Hammer",
+	3488: "Tungsten Axe",
+	3489: "Tungsten Shortsword",
+	3490: "Tungsten Broadsword",
+	3491: "Tungsten Pickaxe",
+	3492: "Lead Bow",
+	3493: "Lead Hammer",
+	3494: "Lead Axe",
+	3495: "Lead Shortsword",
+	3496: "Lead Broadsword",
+	3497: "Lead Pickaxe",
+	3498: "Tin Bow",
+	3499: "Tin Hammer",
+	3500: "Tin Axe",
+	3501: "Tin Shortsword",
+	3502: "Tin Broadsword",
+	3503: "Tin Pickaxe",
+	3504: "Copper Bow",
+	3505: "Copper Hammer",
+	3506: "Copper Axe",
+	3507: "Copper Shortsword",
+	3508: "Copper Broadsword",
+	3509: "Copper Pickaxe",
+	3510: "Silver Bow",
+	3511: "Silver Hammer",
+	3512: "Silver Axe",
+	3513: "Silver Shortsword",
+	3514: "Silver Broadsword",
+	3515: "Silver Pickaxe",
+	3516: "Gold Bow",
+	3517: "Gold Hammer",
+	3518: "Gold Axe",
+	3519: "Gold Shortsword",
+	3520: "Gold Broadsword",
+	3521: "Gold Pickaxe",
+	3522: "Solar Flare Hamaxe",
+	3523: "Vortex Hamaxe",
+	3524: "Nebula Hamaxe",
+	3525: "Stardust Hamaxe",
+	3526: "Solar Dye",
+	3527: "Nebula Dye",
+	3528: "Vortex Dye",
+	3529: "Stardust Dye",
+	3530: "Void Dye",
+	3531: "Stardust Dragon Staff",
+	3532: "Bacon",
+	3533: "Shifting Sands Dye",
+	3534: "Mirage Dye",
+	3535: "Shifting Pearlsands Dye",
+	3536: "Vortex Monolith",
+	3537: "Nebula Monolith",
+	3538: "Stardust Monolith",
+	3539: "Solar Monolith",
+	3540: "Phantasm",
+	3541: "Last Prism",
+	3542: "Nebula Blaze",
+	3543: "Daybreak",
+	3544: "Super Healing Potion",
+	3545: "Detonator",
+	3546: "Celebration",
+	3547: "Bouncy Dynamite",
+	3548: "Happy Grenade",
+	3549: "Ancient Manipulator",
+	3550: "Flame and Silver Dye",
+	3551: "Green Flame and Silver Dye",
+	3552: "Blue Flame and Silver Dye",
+	3553: "Reflective Copper Dye",
+	3554: "Reflective Obsidian Dye",
+	3555: "Reflective Metal Dye",
+	3556: "Midnight Rainbow Dye",
+	3557: "Black and White Dye",
+	3558: "Bright Silver Dye",
+	3559: "Silver and Black Dye",
+	3560: "Red Acid Dye",
+	3561: "Gel Dye",
+	3562: "Pink Gel Dye",
+	3563: "Red Squirrel",
+	3564: "Gold Squirrel",
+	3565: "Red Squirrel Cage",
+	3566: "Gold Squirrel Cage",
+	3567: "Luminite Bullet",
+	3568: "Luminite Arrow",
+	3569: "Lunar Portal Staff",
+	3570: "Lunar Flare",
+	3571: "Rainbow Crystal Staff",
+	3572: "Lunar Hook",
+	3573: "Solar Fragment Block",
+	3574: "Vortex Fragment Block",
+	3575: "Nebula Fragment Block",
+	3576: "Stardust Fragment Block",
+	3577: "Suspicious Looking Tentacle",
+	3578: "Yoraiz0r's Uniform",
+	3579: "Yoraiz0r's Skirt",
+	3580: "Yoraiz0r's Spell",
+	3581: "Yoraiz0r's Scowl",
+	3582: "Jim's Wings",
+	3583: "Yoraiz0r's Recolored Goggles",
+	3584: "Living Leaf Wall",
+	3585: "Skiphs's Mask",
+	3586: "Skiphs's Skin",
+	3587: "Skiphs's Bear Butt",
+	3588: "Skiphs's Paws",
+	3589: "Loki's Helmet",
+	3590: "Loki's Breastplate",
+	3591: "Loki's Greaves",
+	3592: "Loki's Wings",
+	3593: "Sand Slime Banner",
+	3594: "Sea Snail Banner",
+	3595: "Moon Lord Trophy",
+	3596: "Not a Kid, nor a Squid",
+	3597: "Burning Hades Dye",
+	3598: "Grim Dye",
+	3599: "Loki's Dye",
+	3600: "Shadowflame Hades Dye",
+	3601: "Celestial Sigil",
+	3602: "Logic Gate Lamp (Off)",
+	3603: "Logic Gate (AND)",
+	3604: "Logic Gate (OR)",
+	3605: "Logic Gate (NAND)",
+	3606: "Logic Gate (NOR)",
+	3607: "Logic Gate (XOR)",
+	3608: "Logic Gate (XNOR)",
+	3609: "Conveyor Belt (Clockwise)",
+	3610: "Conveyor Belt (Counter Clockwise)",
+	3611: "The Grand Design",
+	3612: "Yellow Wrench",
+	3613: "Logic Sensor (Day)",
+	3614: "Logic Sensor (Night)",
+	3615: "Logic Sensor (Player Above)",
+	3616: "Junction Box",
+	3617: "Announcement Box",
+	3618: "Logic Gate Lamp (On)",
+	3619: "Mechanical Lens",
+	3620: "Actuation Rod",
+	3621: "Red Team Block",
+	3622: "Red Team Platform",
+	3623: "Static Hook",
+	3624: "Presserator",
+	3625: "Multicolor Wrench",
+	3626: "Weighted Pressure Plate Pink",
+	3627: "Engineering Helmet",
+	3628: "Companion Cube",
+	3629: "Wire Bulb",
+	3630: "Weighted Pressure Plate Orange",
+	3631: "Weighted Pressure Plate Purple",
+	3632: "Weighted Pressure Plate Cyan",
+	3633: "Team Block Green",
+	3634: "Team Block Blue",
+	3635: "Team Block Yellow",
+	3636: "Team Block Pink",
+	3637: "Team Block White",
+	3638: "Team Block Green Platform",
+	3639: "Team Block BlueP latform",
+	3640: "Team Block Yellow Platform",
+	3641: "Team Block Pink Platform",
+	3642: "Team Block White Platform",
+	3643: "Large Amber",
+	3644: "Gem Lock Ruby",
+	3645: "Gem Lock Sapphire",
+	3646: "Gem Lock Emerald",
+	3647: "Gem Lock Topaz",
+	3648: "Gem Lock Amethyst",
+	3649: "Gem Lock Diamond",
+	3650: "Gem Lock Amber",
+	3651: "Squirrel Statue",
+	3652: "Butterfly Statue",
+	3653: "Worm Statue",
+	3654: "Firefly Statue",
+	3655: "Scorpion Statue",
+	3656: "Snail Statue",
+	3657: "Grasshopper Statue",
+	3658: "Mouse Statue",
+	3659: "Duck Statue",
+	3660: "Penguin Statue",
+	3661: "Frog Statue",
+	3662: "Buggy Statue",
+	3663: "Logic Gate Lamp (Faulty)",
+	3664: "Portal Gun Station",
+	3665: "Trapped Chest",
+	3666: "Trapped Gold Chest",
+	3667: "Trapped Shadow Chest",
+	3668: "Trapped Ebonwood Chest",
+	3669: "Trapped RichMahogany Chest",
+	3670: "Trapped Pearlwood Chest",
+	3671: "Trapped Ivy Chest",
+	3672: "Trapped Ice Chest",
+	3673: "Trapped Living Wood Chest",
+	3674: "Trapped Skyware Chest",
+	3675: "Trapped Shadewood Chest",
+	3676: "Trapped Web Covered Chest",
+	3677: "Trapped Lihzahrd Chest",
+	3678: "Trapped Water Chest",
+	3679: "Trapped JungleChest",
+	3680: "Trapped CorruptionChest",
+	3681: "Trapped CrimsonChest",
+	3682: "Trapped HallowedChest",
+	3683: "Trapped FrozenChest",
+	3684: "Trapped DynastyChest",
+	3685: "Trapped HoneyChest",
+	3686: "Trapped SteampunkChest",
+	3687: "Trapped PalmWoodChest",
+	3688: "Trapped MushroomChest",
+	3689: "Trapped BorealWoodChest",
+	3690: "Trapped SlimeChest",
+	3691: "Trapped GreenDungeonChest",
+	3692: "Trapped PinkDungeonChest",
+	3693: "Trapped BlueDungeonChest",
+	3694: "Trapped BoneChest",
+	3695: "Trapped CactusChest",
+	3696: "Trapped FleshChest",
+	3697: "Trapped ObsidianChest",
+	3698: "Trapped PumpkinChest",
+	3699: "Trapped SpookyChest",
+	3700: "Trapped GlassChest",
+	3701: "Trapped MartianChest",
+	3702: "Trapped MeteoriteChest",
+	3703: "Trapped GraniteChest",
+	3704: "Trapped MarbleChest",
+	3705: "Trapped newchest1",
+	3706: "Trapped newchest2",
+	3707: "Projectile Pressure Pad",
+	3708: "Wall Creeper Statue",
+	3709: "Unicorn Statue",
+	3710: "Drippler Statue",
+	3711: "Wraith Statue",
+	3712: "Bone Skeleton Statue",
+	3713: "Undead Viking Statue",
+	3714: "Medusa Statue",
+	3715: "Harpy Statue",
+	3716: "Pigron Statue",
+	3717: "Hoplite Statue",
+	3718: "Granite Golem Statue",
+	3719: "Armed Zombie Statue",
+	3720: "Blood Zombie Statue",
+	3721: "Angler Tackle Bag",
+	3722: "Geyser",
+	3723: "Ultra Bright Campfire",
+	3724: "Bone Campfire",
+	3725: "Pixel Box",
+	3726: "Liquid Sensor (Water)",
+	3727: "Liquid Sensor (Lava)",
+	3728: "Liquid Sensor (Honey)",
+	3729: "Liquid Sensor (Any)",
+	3730: "Bundled Party Balloons",
+	3731: "Balloon Animal",
+	3732: "Party Hat",
+	3733: "Silly Sunflower Petals",
+	3734: "Silly Sunflower Tops",
+	3735: "Silly Sunflower Bottoms",
+	3736: "Silly Balloon Pink",
+	3737: "Silly Balloon Purple",
+	3738: "Silly Balloon Green",
+	3739: "Silly Streamer Blue",
+	3740: "Silly Streamer Green",
+	3741: "Silly Streamer Pink",
+	3742: "Silly Balloon Machine",
+	3743: "Silly Balloon Tied Pink",
+	3744: "Silly Balloon Tied Purple",
+	3745: "Silly Balloon Tied Green",
+	3746: "Pigronata",
+	3747: "Party Center",
+	3748: "Silly Tied Bundle of Balloons",
+	3749: "Party Present",
+	3750: "Slice of Cake",
+	3751: "Cog Wall",
+	3752: "Sandfall Wall",
+	3753: "Snowfall Wall",
+	3754: "Sandfall Block",
+	3755: "Snowfall Block",
+	3756: "Snow Cloud",
+	3757: "Pedguin's Hood",
+	3758: "Pedguin's Jacket",
+	3759: "Pedguin's Trousers",
+	3760: "Silly Balloon Pink Wall",
+	3761: "Silly Balloon Purple Wall",
+	3762: "Silly Balloon Green Wall",
+	3763: "0x33's Sunglasses",
+	3764: "Blue Phasesaber",
+	3765: "Red Phasesaber",
+	3766: "Green Phasesaber",
+	3767: "Purple Phasesaber",
+	3768: "White Phasesaber",
+	3769: "Yellow Phasesaber",
+	3770: "Djinn's Curse",
+	3771: "Ancient Horn",
+	3772: "Mandible Blade",
+	3773: "Ancient Headdress",
+	3774: "Ancient Garments",
+	3775: "Ancient Slacks",
+	3776: "Forbidden Mask",
+	3777: "Forbidden Robes",
+	3778: "Forbidden Treads",
+	3779: "Spirit Flame",
+	3780: "Sand Elemental Banner",
+	3781: "Pocket Mirror",
+	3782: "Magic Sand Dropper",
+	3783: "Forbidden Fragment",
+	3784: "Lamia Tail",
+	3785: "Lamia Wraps",
+	3786: "Lamia Mask",
+	3787: "Sky Fracture",
+	3788: "Onyx Blaster",
+	3789: "Sand Shark Banner",
+	3790: "Bone Biter Banner",
+	3791: "Flesh Reaver Banner",
+	3792: "Crystal Thresher Banner",
+	3793: "Angry Tumbler Banner",
+	3794: "Ancient Cloth",
+	3795: "Desert Spirit Lamp",
+	3796: "Music Box (Sandstorm)",
+	3797: "Apprentice's Hat",
+	3798: "Apprentice's Robe",
+	3799: "Apprentice's Trousers",
+	3800: "Squire's Great Helm",
+	3801: "Squire's Plating",
+	3802: "Squire's Greaves",
+	3803: "Huntress's Wig",
+	3804: "Huntress's Jerkin",
+	3805: "Huntress's Pants",
+	3806: "Monk's Bushy Brow Bald Cap",
+	3807: "Monk's Shirt",
+	3808: "Monk's Pants",
+	3809: "Apprentice's Scarf",
+	3810: "Squire's Shield",
+	3811: "Huntress's Buckler",
+	3812: "Monk's Belt",
+	3813: "Defender's Forge",
+	3814: "War Table",
+	3815: "War Table Banner",
+	3816: "Elder Crystal Stand",
+	3817: "Defender Medal",
+	3818: "Flameburst Rod",
+	3819: "Flameburst Cane",
+	3820: "Flameburst Staff",
+	3821: "Ale Tosser",
+	3822: "Etherian Mana",
+	3823: "Brand of the Inferno",
+	3824: "Ballista Rod",
+	3825: "Ballista Cane",
+	3826: "Ballista Staff",
+	3827: "Flying Dragon",
+	3828: "Elder Crystal",
+	3829: "Lightning Aura Rod",
+	3830: "Lightning Aura Cane",
+	3831: "Lightning Aura Staff",
+	3832: "Explosive Trap Rod",
+	3833: "Explosive Trap Cane",
+	3834: "Explosive Trap Staff",
+	3835: "Sleepy Octopod",
+	3836: "Ghastly Glaive",
+	3837: "Etherian Goblin Bomber Banner",
+	3838: "Etherian Goblin Banner",
+	3839: "Old One's Skeleton Banner",
+	3840: "Drakin Banner",
+	3841: "Kobold Glider Banner",
+	3842: "Kobold Banner",
+	3843: "Wither Beast Banner",
+	3844: "Etherian Wyvern Banner",
+	3845: "Etherian Javelin Thrower Banner",
+	3846: "Etherian Lightning Bug Banner",
+	3852: "Tome of Infinite Wisdom",
+	3854: "Phantom Phoenix",
+	3855: "Gato Egg",
+	3856: "Creeper Egg",
+	3857: "Dragon Egg",
+	3858: "Sky Dragon's Fury",
+	3859: "Aerial Bane",
+	3863: "Betsy Mask",
+	3864: "Dark Mage Mask",
+	3865: "Ogre Mask",
+	3866: "Betsy Trophy",
+	3867: "Dark Mage Trophy",
+	3868: "Ogre Trophy",
+	3869: "Music Box (Old One's Army)",
+	3870: "Betsy's Wrath",
+	3871: "Valhalla Knight's Helm",
+	3872: "Valhalla Knight's Breastplate",
+	3873: "Valhalla Knight's Greaves",
+	3874: "Dark Artist's Hat",
+	3875: "Dark Artist's Robes",
+	3876: "Dark Artist's Leggings",
+	3877: "Red Riding Hood",
+	3878: "Red Riding Dress",
+	3879: "Red Riding Leggings",
+	3880: "Shinobi Infiltrator's Helmet",
+	3881: "Shinobi Infiltrator's Torso",
+	3882: "Shinobi Infiltrator's Pants",
+	3883: "Betsy's Wings",
+	3884: "Crystal Chest",
+	3885: "Golden Chest",
+	3886: "Trapped Crystal Chest",
+	3887: "Trapped Golden Chest",
+	3888: "Crystal Door",
+	3889: "Crystal Chair",
+	3890: "Crystal Candle",
+	3891: "Crystal Lantern",
+	3892: "Crystal Lamp",
+	3893: "Crystal Candelabra",
+	3894: "Crystal Chandelier",
+	3895: "Crystal Bathtub",
+	3896: "Crystal Sink",
+	3897: "Crystal Bed",
+	3898: "Crystal Clock",
+	3899: "Sunplate Clock",
+	3900: "Blue Dungeon Clock",
+	3901: "Green Dungeon Clock",
+	3902: "Pink Dungeon Clock",
+	3903: "Crystal Platform",
+	3904: "Golden Platform",
+	3905: "Dynasty Platform",
+	3906: "Lihzahrd Platform",
+	3907: "Flesh Platform",
+	3908: "Frozen Platform",
+	3909: "Crystal Work Bench",
+	3910: "Golden Work Bench",
+	3911: "Crystal Dresser",
+	3912: "Dynasty Dresser",
+	3913: "Frozen Dresser",
+	3914: "Living Wood Dresser",
+	3915: "Crystal Piano",
+	3916: "Dynasty Piano",
+	3917: "Crystal Bookcase",
+	3918: "Crystal Sofa",
+	3919: "Dynasty Sofa",
+	3920: "Crystal Table",
+	3921: "Arkhalis's Hood",
+	3922: "Arkhalis's Bodice",
+	3923: "Arkhalis's Tights",
+	3924: "Arkhalis's Lightwings",
+	3925: "Leinfors' Hair Protector",
+	3926: "Leinfors' Excessive Style",
+	3927: "Leinfors' Fancypants",
+	3928: "Leinfors' Prehensile Cloak",
+	3929: "Leinfors' Luxury Shampoo",
+	3930: "Celeb2",
+	3931: "SpiderBathtub",
+	3932: "SpiderBed",
+	3933: "SpiderBookcase",
+	3934: "SpiderDresser",
+	3935: "SpiderCandelabra",
+	3936: "SpiderCandle",
+	3937: "SpiderChair",
+	3938: "SpiderChandelier",
+	3939: "SpiderChest",
+	3940: "SpiderClock",
+	3941: "SpiderDoor",
+	3942: "SpiderLamp",
+	3943: "SpiderLantern",
+	3944: "SpiderPiano",
+	3945: "SpiderPlatform",
+	3946: "SpiderSinkSpiderSinkDoesWhateverASpiderSinkDoes",
+	3947: "SpiderSofa",
+	3948: "SpiderTable",
+	3949: "SpiderWorkbench",
+	3950: "Fake_SpiderChest",
+	3951: "IronBrick",
+	3952: "IronBrickWall",
+	3953: "LeadBrick",
+	3954: "LeadBrickWall",
+	3955: "LesionBlock",
+	3956: "LesionBlockWall",
+	3957: "LesionPlatform",
+	3958: "LesionBathtub",
+	3959: "LesionBed",
+	3960: "LesionBookcase",
+	3961: "LesionCandelabra",
+	3962: "LesionCandle",
+	3963: "LesionChair",
+	3964: "LesionChandelier",
+	3965: "LesionChest",
+	3966: "LesionClock",
+	3967: "LesionDoor",
+	3968: "LesionDresser",
+	3969: "LesionLamp",
+	3970: "LesionLantern",
+	3971: "LesionPiano",
+	3972: "LesionSink",
+	3973: "LesionSofa",
+	3974: "LesionTable",
+	3975: "LesionWorkbench",
+	3976: "Fake_LesionChest",
+	3977: "HatRack",
+	3978: "ColorOnlyDye",
+	3979: "WoodenCrateHard",
+	3980: "IronCrateHard",
+	3981: "GoldenCrateHard",
+	3982: "CorruptFishingCrateHard",
+	3983: "CrimsonFishingCrateHard",
+	3984: "DungeonFishingCrateHard",
+	3985: "FloatingIslandFishingCrateHard",
+	3986: "HallowedFishingCrateHard",
+	3987: "JungleFishingCrateHard",
+	3988: "DeadMansChest",
+	3989: "GolfBall",
+	3990: "AmphibianBoots",
+	3991: "ArcaneFlower",
+	3992: "BerserkerGlove",
+	3993: "FairyBoots",
+	3994: "FrogFlipper",
+	3995: "FrogGear",
+	3996: "FrogWebbing",
+	3997: "FrozenShield",
+	3998: "HeroShield",
+	3999: "LavaSkull",
+	4000: "MagnetFlower",
+	4001: "ManaCloak",
+	4002: "MoltenQuiver",
+	4003: "MoltenSkullRose",
+	4004: "ObsidianSkullRose",
+	4005: "ReconScope",
+	4006: "StalkersQuiver",
+	4007: "StingerNecklace",
+	4008: "UltrabrightHelmet",
+	4009: "Apple",
+	4010: "ApplePieSlice",
+	4011: "ApplePie",
+	4012: "BananaSplit",
+	4013: "BBQRibs",
+	4014: "BunnyStew",
+	4015: "Burger",
+	4016: "ChickenNugget",
+	4017: "ChocolateChipCookie",
+	4018: "CreamSoda",
+	4019: "Escargot",
+	4020: "FriedEgg",
+	4021: "Fries",
+	4022: "GoldenDelight",
+	4023: "Grapes",
+	4024: "GrilledSquirrel",
+	4025: "Hotdog",
+	4026: "IceCream",
+	4027: "Milkshake",
+	4028: "Nachos",
+	4029: "Pizza",
+	4030: "PotatoChips",
+	4031: "RoastedBird",
+	4032: "RoastedDuck",
+	4033: "SauteedFrogLegs",
+	4034: "SeafoodDinner",
+	4035: "ShrimpPoBoy",
+	4036: "Spaghetti",
+	4037: "Steak",
+	4038: "MoltenCharm",
+	4039: "GolfClubIron",
+	4040: "GolfCup",
+	4041: "FlowerPacketBlue",
+	4042: "FlowerPacketMagenta",
+	4043: "FlowerPacketPink",
+	4044: "FlowerPacketRed",
+	4045: "FlowerPacketYellow",
+	4046: "FlowerPacketViolet",
+	4047: "FlowerPacketWhite",
+	4048: "FlowerPacketTallGrass",
+	4049: "LawnMower",
+	4050: "CrimstoneBrick",
+	4051: "SmoothSandstone",
+	4052: "CrimstoneBrickWall",
+	4053: "SmoothSandstoneWall",
+	4054: "BloodMoonMonolith",
+	4055: "SandBoots",
+	4056: "AncientChisel",
+	4057: "CarbonGuitar",
+	4058: "SkeletonBow",
+	4059: "FossilPickaxe",
+	4060: "SuperStarCannon",
+	4061: "ThunderSpear",
+	4062: "ThunderStaff",
+	4063: "DrumSet",
+	4064: "PicnicTable",
+	4065: "PicnicTableWithCloth",
+	4066: "DesertMinecart",
+	4067: "FishMinecart",
+	4068: "FairyCritterPink",
+	4069: "FairyCritterGreen",
+	4070: "FairyCritterBlue",
+	4071: "JunoniaShell",
+	4072: "LightningWhelkShell",
+	4073: "TulipShell",
+	4074: "PinWheel",
+	4075: "WeatherVane",
+	4076: "VoidVault",
+	4077: "MusicBoxOceanAlt",
+	4078: "MusicBoxSlimeRain",
+	4079: "MusicBoxSpaceAlt",
+	4080: "MusicBoxTownDay",
+	4081: "MusicBoxTownNight",
+	4082: "MusicBoxWindyDay",
+	4083: "GolfCupFlagWhite",
+	4084: "GolfCupFlagRed",
+	4085: "GolfCupFlagGreen",
+	4086: "GolfCupFlagBlue",
+	4087: "GolfCupFlagYellow",
+	4088: "GolfCupFlagPurple",
+	4089: "GolfTee",
+	4090: "ShellPileBlock",
+	4091: "AntiPortalBlock",
+	4092: "GolfClubPutter",
+	4093: "GolfClubWedge",
+	4094: "GolfClubDriver",
+	4095: "GolfWhistle",
+	4096: "ToiletEbonyWood",
+	4097: "ToiletRichMahogany",
+	4098: "ToiletPearlwood",
+	4099: "ToiletLivingWood",
+	4100: "ToiletCactus",
+	4101: "ToiletBone",
+	4102: "ToiletFlesh",
+	4103: "ToiletMushroom",
+	4104: "ToiletSunplate",
+	4105: "ToiletShadewood",
+	4106: "ToiletLihzhard",
+	4107: "ToiletDungeonBlue",
+	4108: "ToiletDungeonGreen",
+	4109: "ToiletDungeonPink",
+	4110: "ToiletObsidian",
+	4111: "ToiletFrozen",
+	4112: "ToiletGlass",
+	4113: "ToiletHoney",
+	4114: "ToiletSteampunk",
+	4115: "ToiletPumpkin",
+	4116: "ToiletSpooky",
+	4117: "ToiletDynasty",
+	4118: "ToiletPalm",
+	4119: "ToiletBoreal",
+	4120: "ToiletSlime",
+	4121: "ToiletMartian",
+	4122: "ToiletGranite",
+	4123: "ToiletMarble",
+	4124: "ToiletCrystal",
+	4125: "ToiletSpider",
+	4126: "ToiletLesion",
+	4127: "ToiletDiamond",
+	4128: "MaidHead",
+	4129: "MaidShirt",
+	4130: "MaidPants",
+	4131: "VoidLens",
+	4132: "MaidHead2",
+	4133: "MaidShirt2",
+	4134: "MaidPants2",
+	4135: "GolfHat",
+	4136: "GolfShirt",
+	4137: "GolfPants",
+	4138: "GolfVisor",
+	4139: "SpiderBlock",
+	4140: "SpiderWall",
+	4141: "ToiletMeteor",
+	4142: "LesionStation",
+	4143: "ManaCloakStar",
+	4144: "Terragrim",
+	4145: "SolarBathtub",
+	4146: "SolarBed",
+	4147: "SolarBookcase",
+	4148: "SolarDresser",
+	4149: "SolarCandelabra",
+	4150: "SolarCandle",
+	4151: "SolarChair",
+	4152: "SolarChandelier",
+	4153: "SolarChest",
+	4154: "SolarClock",
+	4155: "SolarDoor",
+	4156: "SolarLamp",
+	4157: "SolarLantern",
+	4158: "SolarPiano",
+	4159: "SolarPlatform",
+	4160: "SolarSink",
+	4161: "SolarSofa",
+	4162: "SolarTable",
+	4163: "SolarWorkbench",
+	4164: "Fake_SolarChest",
+	4165: "SolarToilet",
+	4166: "VortexBathtub",
+	4167: "VortexBed",
+	4168: "VortexBookcase",
+	4169: "VortexDresser",
+	4170: "VortexCandelabra",
+	4171: "VortexCandle",
+	4172: "VortexChair",
+	4173: "VortexChandelier",
+	4174: "VortexChest",
+	4175: "VortexClock",
+	4176: "VortexDoor",
+	4177: "VortexLamp",
+	4178: "VortexLantern",
+	4179: "VortexPiano",
+	4180: "VortexPlatform",
+	4181: "VortexSink",
+	4182: "VortexSofa",
+	4183: "VortexTable",
+	4184: "VortexWorkbench",
+	4185: "Fake_VortexChest",
+	4186: "VortexToilet",
+	4187: "NebulaBathtub",
+	4188: "NebulaBed",
+	4189: "NebulaBookcase",
+	4190: "NebulaDresser",
+	4191: "NebulaCandelabra",
+	4192: "NebulaCandle",
+	4193: "NebulaChair",
+	4194: "NebulaChandelier",
+	4195: "NebulaChest",
+	4196: "NebulaClock",
+	4197: "NebulaDoor",
+	4198: "NebulaLamp",
+	4199: "NebulaLantern",
+	4200: "NebulaPiano",
+	4201: "NebulaPlatform",
+	4202: "NebulaSink",
+	4203: "NebulaSofa",
+	4204: "NebulaTable",
+	4205: "NebulaWorkbench",
+	4206: "Fake_NebulaChest",
+	4207: "NebulaToilet",
+	4208: "StardustBathtub",
+	4209: "StardustBed",
+	4210: "StardustBookcase",
+	4211: "StardustDresser",
+	4212: "StardustCandelabra",
+	4213: "StardustCandle",
+	4214: "StardustChair",
+	4215: "StardustChandelier",
+	4216: "StardustChest",
+	4217: "StardustClock",
+	4218: "StardustDoor",
+	4219: "StardustLamp",
+	4220: "StardustLantern",
+	4221: "StardustPiano",
+	4222: "StardustPlatform",
+	4223: "StardustSink",
+	4224: "StardustSofa",
+	4225: "StardustTable",
+	4226: "StardustWorkbench",
+	4227: "Fake_StardustChest",
+	4228: "StardustToilet",
+	4229: "SolarBrick",
+	4230: "VortexBrick",
+	4231: "NebulaBrick",
+	4232: "StardustBrick",
+	4233: "SolarBrickWall",
+	4234: "VortexBrickWall",
+	4235: "NebulaBrickWall",
+	4236: "StardustBrickWall",
+	4237: "MusicBoxDayRemix",
+	4238: "CrackedBlueBrick",
+	4239: "CrackedGreenBrick",
+	4240: "CrackedPinkBrick",
+	4241: "FlowerPacketWild",
+	4242: "GolfBallDyedBlack",
+	4243: "GolfBallDyedBlue",
+	4244: "GolfBallDyedBrown",
+	4245: "GolfBallDyedCyan",
+	4246: "GolfBallDyedGreen",
+	4247: "GolfBallDyedLimeGreen",
+	4248: "GolfBallDyedOrange",
+	4249: "GolfBallDyedPink",
+	4250: "GolfBallDyedPurple",
+	4251: "GolfBallDyedRed",
+	4252: "GolfBallDyedSkyBlue",
+	4253: "GolfBallDyedTeal",
+	4254: "GolfBallDyedViolet",
+	4255: "GolfBallDyedYellow",
+	4256: "AmberRobe",
+	4257: "AmberHook",
+	4258: "OrangePhaseblade",
+	4259: "OrangePhasesaber",
+	4260: "OrangeStainedGlass",
+	4261: "OrangePressurePlate",
+	4262: "MysticCoilSnake",
+	4263: "MagicConch",
+	4264: "GolfCart",
+	4265: "GolfChest",
+	4266: "Fake_GolfChest",
+	4267: "DesertChest",
+	4268: "Fake_DesertChest",
+	4269: "SanguineStaff",
+	4270: "SharpTears",
+	4271: "BloodMoonStarter",
+	4272: "DripplerFlail",
+	4273: "VampireFrogStaff",
+	4274: "GoldGoldfish",
+	4275: "GoldGoldfishBowl",
+	4276: "CatBast",
+	4277: "GoldStarryGlassBlock",
+	4278: "BlueStarryGlassBlock",
+	4279: "GoldStarryGlassWall",
+	4280: "BlueStarryGlassWall",
+	4281: "BabyBirdStaff",
+	4282: "Apricot",
+	4283: "Banana",
+	4284: "BlackCurrant",
+	4285: "BloodOrange",
+	4286: "Cherry",
+	4287: "Coconut",
+	4288: "Dragonfruit",
+	4289: "Elderberry",
+	4290: "Grapefruit",
+	4291: "Lemon",
+	4292: "Mango",
+	4293: "Peach",
+	4294: "Pineapple",
+	4295: "Plum",
+	4296: "Rambutan",
+	4297: "Starfruit",
+	4298: "SandstoneBathtub",
+	4299: "SandstoneBed",
+	4300: "SandstoneBookcase",
+	4301: "SandstoneDresser",
+	4302: "SandstoneCandelabra",
+	4303: "SandstoneCandle",
+	4304: "SandstoneChair",
+	4305: "SandstoneChandelier",
+	4306: "SandstoneClock",
+	4307: "SandstoneDoor",
+	4308: "SandstoneLamp",
+	4309: "SandstoneLantern",
+	4310: "SandstonePiano",
+	4311: "SandstonePlatform",
+	4312: "SandstoneSink",
+	4313: "SandstoneSofa",
+	4314: "SandstoneTable",
+	4315: "SandstoneWorkbench",
+	4316: "SandstoneToilet",
+	4317: "BloodHamaxe",
+	4318: "VoidMonolith",
+	4319: "ArrowSign",
+	4320: "PaintedArrowSign",
+	4321: "GameMasterShirt",
+	4322: "GameMasterPants",
+	4323: "StarPrincessCrown",
+	4324: "StarPrincessDress",
+	4325: "Chum Caster",
+	4326: "FoodPlatter",
+	4327: "BlackDragonflyJar",
+	4328: "BlueDragonflyJar",
+	4329: "GreenDragonflyJar",
+	4330: "OrangeDragonflyJar",
+	4331: "RedDragonflyJar",
+	4332: "YellowDragonflyJar",
+	4333: "GoldDragonflyJar",
+	4334: "BlackDragonfly",
+	4335: "BlueDragonfly",
+	4336: "GreenDragonfly",
+	4337: "OrangeDragonfly",
+	4338: "RedDragonfly",
+	4339: "YellowDragonfly",
+	4340: "GoldDragonfly",
+	4341: "PortableStool",
+	4342: "DragonflyStatue",
+	4343: "PaperAirplaneA",
+	4344: "PaperAirplaneB",
+	4345: "CanOfWorms",
+	4346: "EncumberingStone",
+	4347: "ZapinatorGray",
+	4348: "ZapinatorOrange",
+	4349: "GreenMoss",
+	4350: "BrownMoss",
+	4351: "RedMoss",
+	4352: "BlueMoss",
+	4353: "PurpleMoss",
+	4354: "LavaMoss",
+	4355: "BoulderStatue",
+	4356: "MusicBoxTitleAlt",
+	4357: "MusicBoxStorm",
+	4358: "MusicBoxGraveyard",
+	4359: "Seagull",
+	4360: "SeagullStatue",
+	4361: "LadyBug",
+	4362: "GoldLadyBug",
+	4363: "Maggot",
+	4364: "MaggotCage",
+	4365: "CelestialWand",
+	4366: "EucaluptusSap",
+	4367: "KiteBlue",
+	4368: "KiteBlueAndYellow",
+	4369: "KiteRed",
+	4370: "KiteRedAndYellow",
+	4371: "KiteYellow",
+	4372: "IvyGuitar",
+	4373: "Pupfish",
+	4374: "Grebe",
+	4375: "Rat",
+	4376: "RatCage",
+	4377: "KryptonMoss",
+	4378: "XenonMoss",
+	4379: "KiteWyvern",
+	4380: "LadybugCage",
+	4381: "BloodRainBow",
+	4382: "CombatBook",
+	4383: "DesertTorch",
+	4384: "CoralTorch",
+	4385: "CorruptTorch",
+	4386: "CrimsonTorch",
+	4387: "HallowedTorch",
+	4388: "JungleTorch",
+	4389: "ArgonMoss",
+	4390: "RollingCactus",
+	4391: "ThinIce",
+	4392: "EchoBlock",
+	4393: "ScarabFish",
+	4394: "ScorpioFish",
+	4395: "Owl",
+	4396: "OwlCage",
+	4397: "OwlStatue",
+	4398: "PupfishBowl",
+	4399: "GoldLadybugCage",
+	4400: "Geode",
+	4401: "Flounder",
+	4402: "RockLobster",
+	4403: "LobsterTail",
+	4404: "FloatingTube",
+	4405: "FrozenCrate",
+	4406: "FrozenCrateHard",
+	4407: "OasisCrate",
+	4408: "OasisCrateHard",
+	4409: "SpectreGoggles",
+	4410: "Oyster",
+	4411: "ShuckedOyster",
+	4412: "WhitePearl",
+	4413: "BlackPearl",
+	4414: "PinkPearl",
+	4415: "StoneDoor",
+	4416: "StonePlatform",
+	4417: "OasisFountain",
+	4418: "WaterStrider",
+	4419: "GoldWaterStrider",
+	4420: "LawnFlamingo",
+	4421: "MusicBoxUndergroundJungle",
+	4422: "Grate",
+	4423: "ScarabBomb",
+	4424: "WroughtIronFence",
+	4425: "SharkBait",
+	4426: "BeeMinecart",
+	4427: "LadybugMinecart",
+	4428: "PigronMinecart",
+	4429: "SunflowerMinecart",
+	4430: "PottedForestCedar",
+	4431: "PottedJungleCedar",
+	4432: "PottedHallowCedar",
+	4433: "PottedForestTree",
+	4434: "PottedJungleTree",
+	4435: "PottedHallowTree",
+	4436: "PottedForestPalm",
+	4437: "PottedJunglePalm",
+	4438: "PottedHallowPalm",
+	4439: "PottedForestBamboo",
+	4440: "PottedJungleBamboo",
+	4441: "PottedHallowBamboo",
+	4442: "ScarabFishingRod",
+	4443: "HellMinecart",
+	4444: "WitchBroom",
+	4445: "ClusterRocketI",
+	4446: "ClusterRocketII",
+	4447: "WetRocket",
+	4448: "LavaRocket",
+	4449: "HoneyRocket",
+	4450: "ShroomMinecart",
+	4451: "AmethystMinecart",
+	4452: "TopazMinecart",
+	4453: "SapphireMinecart",
+	4454: "EmeraldMinecart",
+	4455: "RubyMinecart",
+	4456: "DiamondMinecart",
+	4457: "MiniNukeI",
+	4458: "MiniNukeII",
+	4459: "DryRocket",
+	4460: "SandcastleBucket",
+	4461: "TurtleCage",
+	4462: "TurtleJungleCage",
+	4463: "Gladius",
+	4464: "Turtle",
+	4465: "TurtleJungle",
+	4466: "TurtleStatue",
+	4467: "AmberMinecart",
+	4468: "BeetleMinecart",
+	4469: "MeowmereMinecart",
+	4470: "PartyMinecart",
+	4471: "PirateMinecart",
+	4472: "SteampunkMinecart",
+	4473: "GrebeCage",
+	4474: "SeagullCage",
+	4475: "WaterStriderCage",
+	4476: "GoldWaterStriderCage",
+	4477: "LuckPotionLesser",
+	4478: "LuckPotion",
+	4479: "LuckPotionGreater",
+	4480: "Seahorse",
+	4481: "SeahorseCage",
+	4482: "GoldSeahorse",
+	4483: "GoldSeahorseCage",
+	4484: "TimerOneHalfSecond",
+	4485: "TimerOneFourthSecond",
+	4486: "EbonstoneEcho",
+	4487: "MudWallEcho",
+	4488: "PearlstoneEcho",
+	4489: "SnowWallEcho",
+	4490: "AmethystEcho",
+	4491: "TopazEcho",
+	4492: "SapphireEcho",
+	4493: "EmeraldEcho",
+	4494: "RubyEcho",
+	4495: "DiamondEcho",
+	4496: "Cave1Echo",
+	4497: "Cave2Echo",
+	4498: "Cave3Echo",
+	4499: "Cave4Echo",
+	4500: "Cave5Echo",
+	4501: "Cave6Echo",
+	4502: "Cave7Echo",
+	4503: "SpiderEcho",
+	4504: "CorruptGrassEcho",
+	4505: "HallowedGrassEcho",
+	4506: "IceEcho",
+	4507: "ObsidianBackEcho",
+	4508: "CrimsonGrassEcho",
+	4509: "CrimstoneEcho",
+	4510: "CaveWall1Echo",
+	4511: "CaveWall2Echo",
+	4512: "Cave8Echo",
+	4513: "Corruption1Echo",
+	4514: "Corruption2Echo",
+	4515: "Corruption3Echo",
+	4516: "Corruption4Echo",
+	4517: "Crimson1Echo",
+	4518: "Crimson2Echo",
+	4519: "Crimson3Echo",
+	4520: "Crimson4Echo",
+	4521: "Dirt1Echo",
+	4522: "Dirt2Echo",
+	4523: "Dirt3Echo",
+	4524: "Dirt4Echo",
+	4525: "Hallow1Echo",
+	4526: "Hallow2Echo",
+	4527: "Hallow3Echo",
+	4528: "Hallow4Echo",
+	4529: "Jungle1Echo",
+	4530: "Jungle2Echo",
+	4531: "Jungle3Echo",
+	4532: "Jungle4Echo",
+	4533: "Lava1Echo",
+	4534: "Lava2Echo",
+	4535: "Lava3Echo",
+	4536: "Lava4Echo",
+	4537: "Rocks1Echo",
+	4538: "Rocks2Echo",
+	4539: "Rocks3Echo",
+	4540: "Rocks4Echo",
+	4541: "TheBrideBanner",
+	4542: "ZombieMermanBanner",
+	4543: "EyeballFlyingFishBanner",
+	4544: "BloodSquidBanner",
+	4545: "BloodEelBanner",
+	4546: "GoblinSharkBanner",
+	4547: "LargeBambooBlock",
+	4548: "LargeBambooBlockWall",
+	4549: "DemonHorns",
+	4550: "BambooLeaf",
+	4551: "HellCake",
+	4552: "FogMachine",
+	4553: "PlasmaLamp",
+	4554: "MarbleColumn",
+	4555: "ChefHat",
+	4556: "ChefShirt",
+	4557: "ChefPants",
+	4558: "StarHairpin",
+	4559: "HeartHairpin",
+	4560: "BunnyEars",
+	4561: "DevilHorns",
+	4562: "Fedora",
+	4563: "UnicornHornHat",
+	4564: "BambooBlock",
+	4565: "BambooBlockWall",
+	4566: "BambooBathtub",
+	4567: "BambooBed",
+	4568: "BambooBookcase",
+	4569: "BambooDresser",
+	4570: "BambooCandelabra",
+	4571: "BambooCandle",
+	4572: "BambooChair",
+	4573: "BambooChandelier",
+	4574: "BambooChest",
+	4575: "BambooClock",
+	4576: "BambooDoor",
+	4577: "BambooLamp",
+	4578: "BambooLantern",
+	4579: "BambooPiano",
+	4580: "BambooPlatform",
+	4581: "BambooSink",
+	4582: "BambooSofa",
+	4583: "BambooTable",
+	4584: "BambooWorkbench",
+	4585: "Fake_BambooChest",
+	4586: "BambooToilet",
+	4587: "GolfClubStoneIron",
+	4588: "GolfClubRustyPutter",
+	4589: "GolfClubBronzeWedge",
+	4590: "GolfClubWoodDriver",
+	4591: "GolfClubMythrilIron",
+	4592: "GolfClubLeadPutter",
+	4593: "GolfClubGoldWedge",
+	4594: "GolfClubPearlwoodDriver",
+	4595: "GolfClubTitaniumIron",
+	4596: "GolfClubShroomitePutter",
+	4597: "GolfClubDiamondWedge",
+	4598: "GolfClubChlorophyteDriver",
+	4599: "GolfTrophyBronze",
+	4600: "GolfTrophySilver",
+	4601: "GolfTrophyGold",
+	4602: "BloodNautilusBanner",
+	4603: "BirdieRattle",
+	4604: "ExoticEasternChewToy",
+	4605: "BedazzledNectar",
+	4606: "MusicBoxJungleNight",
+	4607: "Desert Tiger Staff",
+	4608: "ChumBucket",
+	4609: "GardenGnome",
+	4610: "KiteBoneSerpent",
+	4611: "KiteWorldFeeder",
+	4612: "KiteBunny",
+	4613: "KitePigron",
+	4614: "AppleJuice",
+	4615: "GrapeJuice",
+	4616: "Lemonade",
+	4617: "BananaDaiquiri",
+	4618: "PeachSangria",
+	4619: "PinaColada",
+	4620: "TropicalSmoothie",
+	4621: "BloodyMoscato",
+	4622: "SmoothieofDarkness",
+	4623: "PrismaticPunch",
+	4624: "FruitJuice",
+	4625: "FruitSalad",
+	4626: "AndrewSphinx",
+	4627: "WatchfulAntlion",
+	4628: "BurningSpirit",
+	4629: "JawsOfDeath",
+	4630: "TheSandsOfSlime",
+	4631: "SnakesIHateSnakes",
+	4632: "LifeAboveTheSand",
+	4633: "Oasis",
+	4634: "PrehistoryPreserved",
+	4635: "AncientTablet",
+	4636: "Uluru",
+	4637: "VisitingThePyramids",
+	4638: "BandageBoy",
+	4639: "DivineEye",
+	4640: "AmethystStoneBlock",
+	4641: "TopazStoneBlock",
+	4642: "SapphireStoneBlock",
+	4643: "EmeraldStoneBlock",
+	4644: "RubyStoneBlock",
+	4645: "DiamondStoneBlock",
+	4646: "AmberStoneBlock",
+	4647: "AmberStoneWallEcho",
+	4648: "KiteManEater",
+	4649: "KiteJellyfishBlue",
+	4650: "KiteJellyfishPink",
+	4651: "KiteShark",
+	4652: "SuperHeroMask",
+	4653: "SuperHeroCostume",
+	4654: "SuperHeroTights",
+	4655: "PinkFairyJar",
+	4656: "GreenFairyJar",
+	4657: "BlueFairyJar",
+	4658: "GolfPainting1",
+	4659: "GolfPainting2",
+	4660: "GolfPainting3",
+	4661: "GolfPainting4",
+	4662: "FogboundDye",
+	4663: "BloodbathDye",
+	4664: "PrettyPinkDressSkirt",
+	4665: "PrettyPinkDressPants",
+	4666: "PrettyPinkRibbon",
+	4667: "BambooFence",
+	4668: "GlowPaint",
+	4669: "KiteSandShark",
+	4670: "KiteBunnyCorrupt",
+	4671: "KiteBunnyCrimson",
+	4672: "BlandWhip",
+	4673: "DrumStick",
+	4674: "KiteGoldfish",
+	4675: "KiteAngryTrapper",
+	4676: "KiteKoi",
+	4677: "KiteCrawltipede",
+	4678: "SwordWhip",
+	4679: "MaceWhip",
+	4680: "ScytheWhip",
+	4681: "KiteSpectrum",
+	4682: "ReleaseDoves",
+	4683: "KiteWanderingEye",
+	4684: "KiteUnicorn",
+	4685: "UndertakerHat",
+	4686: "UndertakerCoat",
+	4687: "DandelionBanner",
+	4688: "GnomeBanner",
+	4689: "DesertCampfire",
+	4690: "CoralCampfire",
+	4691: "CorruptCampfire",
+	4692: "CrimsonCampfire",
+	4693: "HallowedCampfire",
+	4694: "JungleCampfire",
+	4695: "SoulBottleLight",
+	4696: "SoulBottleNight",
+	4697: "SoulBottleFlight",
+	4698: "SoulBottleSight",
+	4699: "SoulBottleMight",
+	4700: "SoulBottleFright",
+	4701: "MudBud",
+	4702: "ReleaseLantern",
+	4703: "QuadBarrelShotgun",
+	4704: "FuneralHat",
+	4705: "FuneralCoat",
+	4706: "FuneralPants",
+	4707: "TragicUmbrella",
+	4708: "VictorianGothHat",
+	4709: "VictorianGothDress",
+	4710: "TatteredWoodSign",
+	4711: "GravediggerShovel",
+	4712: "DungeonDesertChest",
+	4713: "Fake_DungeonDesertChest",
+	4714: "DungeonDesertKey",
+	4715: "SparkleGuitar",
+	4716: "MolluskWhistle",
+	4717: "BorealBeam",
+	4718: "RichMahoganyBeam",
+	4719: "GraniteColumn",
+	4720: "SandstoneColumn",
+	4721: "MushroomBeam",
+	4722: "FirstFractal",
+	4723: "Nevermore",
+	4724: "Reborn",
+	4725: "Graveyard",
+	4726: "GhostManifestation",
+	4727: "WickedUndead",
+	4728: "BloodyGoblet",
+	4729: "StillLife",
+	4730: "GhostarsWings",
+	4731: "TerraToilet",
+	4732: "GhostarSkullPin",
+	4733: "GhostarShirt",
+	4734: "GhostarPants",
+	4735: "BallOfFuseWire",
+	4736: "FullMoonSqueakyToy",
+	4737: "OrnateShadowKey",
+	4738: "DrManFlyMask",
+	4739: "DrManFlyLabCoat",
+	4740: "ButcherMask",
+	4741: "ButcherApron",
+	4742: "ButcherPants",
+	4743: "Football",
+	4744: "HunterCloak",
+	4745: "CoffinMinecart",
+	4746: "SafemanWings",
+	4747: "SafemanSunHair",
+	4748: "SafemanSunDress",
+	4749: "SafemanDressLeggings",
+	4750: "FoodBarbarianWings",
+	4751: "FoodBarbarianHelm",
+	4752: "FoodBarbarianArmor",
+	4753: "FoodBarbarianGreaves",
+	4754: "GroxTheGreatWings",
+	4755: "GroxTheGreatHelm",
+	4756: "GroxTheGreatArmor",
+	4757: "GroxTheGreatGreaves",
+	4758: "Smolstar",
+	4759: "SquirrelHook",
+	4760: "BouncingShield",
+	4761: "RockGolemHead",
+	4762: "CritterShampoo",
+	4763: "DiggingMoleMinecart",
+	4764: "Shroomerang",
+	4765: "TreeGlobe",
+	4766: "WorldGlobe",
+	4767: "DontHurtCrittersBook",
+	4768: "DogEars",
+	4769: "DogTail",
+	4770: "FoxEars",
+	4771: "FoxTail",
+	4772: "LizardEars",
+	4773: "LizardTail",
+	4774: "PandaEars",
+	4775: "BunnyTail",
+	4776: "FairyGlowstick",
+	4777: "LightningCarrot",
+	4778: "HallowBossDye",
+	4779: "MushroomHat",
+	4780: "MushroomVest",
+	4781: "MushroomPants",
+	4782: "FairyQueenBossBag",
+	4783: "FairyQueenTrophy",
+	4784: "FairyQueenMask",
+	4785: "PaintedHorseSaddle",
+	4786: "MajesticHorseSaddle",
+	4787: "DarkHorseSaddle",
+	4788: "JoustingLance",
+	4789: "ShadowJoustingLance",
+	4790: "HallowJoustingLance",
+	4791: "PogoStick",
+	4792: "PirateShipMountItem",
+	4793: "SpookyWoodMountItem",
+	4794: "SantankMountItem",
+	4795: "WallOfFleshGoatMountItem",
+	4796: "DarkMageBookMountItem",
+	4797: "KingSlimePetItem",
+	4798: "EyeOfCthulhuPetItem",
+	4799: "EaterOfWorldsPetItem",
+	4800: "BrainOfCthulhuPetItem",
+	4801: "SkeletronPetItem",
+	4802: "QueenBeePetItem",
+	4803: "DestroyerPetItem",
+	4804: "TwinsPetItem",
+	4805: "SkeletronPrimePetItem",
+	4806: "PlanteraPetItem",
+	4807: "GolemPetItem",
+	4808: "DukeFishronPetItem",
+	4809: "LunaticCultistPetItem",
+	4810: "MoonLordPetItem",
+	4811: "FairyQueenPetItem",
+	4812: "PumpkingPetItem",
+	4813: "EverscreamPetItem",
+	4814: "IceQueenPetItem",
+	4815: "MartianPetItem",
+	4816: "DD2OgrePetItem",
+	4817: "DD2BetsyPetItem",
+	4818: "CombatWrench",
+	4819: "DemonConch",
+	4820: "BottomlessLavaBucket",
+	4821: "FireproofBugNet",
+	4822: "FlameWakerBoots",
+	4823: "RainbowWings",
+	4824: "WetBomb",
+	4825: "LavaBomb",
+	4826: "HoneyBomb",
+	4827: "DryBomb",
+	4828: "SuperheatedBlood",
+	4829: "LicenseCat",
+	4830: "LicenseDog",
+	4831: "GemSquirrelAmethyst",
+	4832: "GemSquirrelTopaz",
+	4833: "GemSquirrelSapphire",
+	4834: "GemSquirrelEmerald",
+	4835: "GemSquirrelRuby",
+	4836: "GemSquirrelDiamond",
+	4837: "GemSquirrelAmber",
+	4838: "GemBunnyAmethyst",
+	4839: "GemBunnyTopaz",
+	4840: "GemBunnySapphire",
+	4841: "GemBunnyEmerald",
+	4842: "GemBunnyRuby",
+	4843: "GemBunnyDiamond",
+	4844: "GemBunnyAmber",
+	4845: "HellButterfly",
+	4846: "HellButterflyJar",
+	4847: "Lavafly",
+	4848: "LavaflyinaBottle",
+	4849: "MagmaSnail",
+	4850: "MagmaSnailCage",
+	4851: "GemTreeTopazSeed",
+	4852: "GemTreeAmethystSeed",
+	4853: "GemTreeSapphireSeed",
+	4854: "GemTreeEmeraldSeed",
+	4855: "GemTreeRubySeed",
+	4856: "GemTreeDiamondSeed",
+	4857: "GemTreeAmberSeed",
+	4858: "PotSuspended",
+	4859: "PotSuspendedDaybloom",
+	4860: "PotSuspendedMoonglow",
+	4861: "PotSuspendedWaterleaf",
+	4862: "PotSuspendedShiverthorn",
+	4863: "PotSuspendedBlinkroot",
+	4864: "PotSuspendedDeathweedCorrupt",
+	4865: "PotSuspendedDeathweedCrimson",
+	4866: "PotSuspendedFireblossom",
+	4867: "BrazierSuspended",
+	4868: "VolcanoSmall",
+	4869: "VolcanoLarge",
+	4870: "PotionOfReturn",
+	4871: "VanityTreeSakuraSeed",
+	4872: "LavaAbsorbantSponge",
+	4873: "HallowedHood",
+	4874: "HellfireTreads",
+	4875: "TeleportationPylonJungle",
+	4876: "TeleportationPylonPurity",
+	4877: "LavaCrate",
+	4878: "LavaCrateHard",
+	4879: "ObsidianLockbox",
+	4880: "LavaFishbowl",
+	4881: "LavaFishingHook",
+	4882: "AmethystBunnyCage",
+	4883: "TopazBunnyCage",
+	4884: "SapphireBunnyCage",
+	4885: "EmeraldBunnyCage",
+	4886: "RubyBunnyCage",
+	4887: "DiamondBunnyCage",
+	4888: "AmberBunnyCage",
+	4889: "AmethystSquirrelCage",
+	4890: "TopazSquirrelCage",
+	4891: "SapphireSquirrelCage",
+	4892: "EmeraldSquirrelCage",
+	4893: "RubySquirrelCage",
+	4894: "DiamondSquirrelCage",
+	4895: "AmberSquirrelCage",
+	4896: "AncientHallowedMask",
+	4897: "AncientHallowedHelmet",
+	4898: "AncientHallowedHeadgear",
+	4899: "AncientHallowedHood",
+	4900: "AncientHallowedPlateMail",
+	4901: "AncientHallowedGreaves",
+	4902: "PottedLavaPlantPalm",
+	4903: "PottedLavaPlantBush",
+	4904: "PottedLavaPlantBramble",
+	4905: "PottedLavaPlantBulb",
+	4906: "PottedLavaPlantTendrils",
+	4907: "VanityTreeYellowWillowSeed",
+	4908: "DirtBomb",
+	4909: "DirtStickyBomb",
+	4910: "LicenseBunny",
+	4911: "CoolWhip",
+	4912: "FireWhip",
+	4913: "ThornWhip",
+	4914: "RainbowWhip",
+	4915: "TungstenBullet",
+	4916: "TeleportationPylonHallow",
+	4917: "TeleportationPylonUnderground",
+	4918: "TeleportationPylonOcean",
+	4919: "TeleportationPylonDesert",
+	4920: "TeleportationPylonSnow",
+	4921: "TeleportationPylonMushroom",
+	4922: "CavernFountain",
+	4923: "PiercingStarlight",
+	4924: "EyeofCthulhuMasterTrophy",
+	4925: "EaterofWorldsMasterTrophy",
+	4926: "BrainofCthulhuMasterTrophy",
+	4927: "SkeletronMasterTrophy",
+	4928: "QueenBeeMasterTrophy",
+	4929: "KingSlimeMasterTrophy",
+	4930: "WallofFleshMasterTrophy",
+	4931: "TwinsMasterTrophy",
+	4932: "DestroyerMasterTrophy",
+	4933: "SkeletronPrimeMasterTrophy",
+	4934: "PlanteraMasterTrophy",
+	4935: "GolemMasterTrophy",
+	4936: "DukeFishronMasterTrophy",
+	4937: "LunaticCultistMasterTrophy",
+	4938: "MoonLordMasterTrophy",
+	4939: "UFOMasterTrophy",
+	4940: "FlyingDutchmanMasterTrophy",
+	4941: "MourningWoodMasterTrophy",
+	4942: "PumpkingMasterTrophy",
+	4943: "IceQueenMasterTrophy",
+	4944: "EverscreamMasterTrophy",
+	4945: "SantankMasterTrophy",
+	4946: "DarkMageMasterTrophy",
+	4947: "OgreMasterTrophy",
+	4948: "BetsyMasterTrophy",
+	4949: "FairyQueenMasterTrophy",
+	4950: "QueenSlimeMasterTrophy",
+	4951: "TeleportationPylonVictory",
+	4952: "FairyQueenMagicItem",
+	4953: "FairyQueenRangedItem",
+	4954: "LongRainbowTrailWings",
+	4955: "RabbitOrder",
+	4956: "Zenith",
+	4957: "QueenSlimeBossBag",
+	4958: "QueenSlimeTrophy",
+	4959: "QueenSlimeMask",
+	4960: "QueenSlimePetItem",
+	4961: "EmpressButterfly",
+	4962: "AccentSlab",
+	4963: "TruffleWormCage",
+	4964: "EmpressButterflyJar",
+	4965: "RockGolemBanner",
+	4966: "BloodMummyBanner",
+	4967: "SporeSkeletonBanner",
+	4968: "SporeBatBanner",
+	4969: "LarvaeAntlionBanner",
+	4970: "CrimsonBunnyBanner",
+	4971: "CrimsonGoldfishBanner",
+	4972: "CrimsonPenguinBanner",
+	4973: "BigMimicCorruptionBanner",
+	4974: "BigMimicCrimsonBanner",
+	4975: "BigMimicHallowBanner",
+	4976: "MossHornetBanner",
+	4977: "WanderingEyeBanner",
+	4978: "CreativeWings",
+	4979: "MusicBoxQueenSlime",
+	4980: "QueenSlimeHook",
+	4981: "QueenSlimeMountSaddle",
+	4982: "CrystalNinjaHelmet",
+	4983: "CrystalNinjaChestplate",
+	4984: "CrystalNinjaLeggings",
+	4985: "MusicBoxEmpressOfLight",
+	4986: "GelBalloon",
+	4987: "VolatileGelatin",
+	4988: "QueenSlimeCrystal",
+	4989: "EmpressFlightBooster",
+	4990: "MusicBoxDukeFishron",
+	4991: "MusicBoxMorningRain",
+	4992: "MusicBoxConsoleTitle",
+	4993: "ChippysCouch",
+	4994: "GraduationCapBlue",
+	4995: "GraduationCapMaroon",
+	4996: "GraduationCapBlack",
+	4997: "GraduationGownBlue",
+	4998: "GraduationGownMaroon",
+	4999: "GraduationGownBlack",
+	5000: "TerrasparkBoots",
+	5001: "MoonLordLegs",
+	5002: "OceanCrate",
+	5003: "OceanCrateHard",
+	5004: "BadgersHat",
+	5005: "EmpressBlade",
+	5006: "MusicBoxUndergroundDesert",
+	5007: "DeadMansSweater",
+	5008: "TeaKettle",
+	5009: "Teacup",
+	5010: "TreasureMagnet",
+	5011: "Mace",
+	5012: "FlamingMace",
+	5013: "SleepingIcon",
+	5014: "MusicBoxOWRain",
+	5015: "MusicBoxOWDay",
+	5016: "MusicBoxOWNight",
+	5017: "MusicBoxOWUnderground",
+	5018: "MusicBoxOWDesert",
+	5019: "MusicBoxOWOcean",
+	5020: "MusicBoxOWMushroom",
+	5021: "MusicBoxOWDungeon",
+	5022: "MusicBoxOWSpace",
+	5023: "MusicBoxOWUnderworld",
+	5024: "MusicBoxOWSnow",
+	5025: "MusicBoxOWCorruption",
+	5026: "MusicBoxOWUndergroundCorruption",
+	5027: "MusicBoxOWCrimson",
+	5028: "MusicBoxOWUndergroundCrimson",
+	5029: "MusicBoxOWUndergroundSnow",
+	5030: "MusicBoxOWUndergroundHallow",
+	5031: "MusicBoxOWBloodMoon",
+	5032: "MusicBoxOWBoss2",
+	5033: "MusicBoxOWBoss1",
+	5034: "MusicBoxOWInvasion",
+	5035: "MusicBoxOWTowers",
+	5036: "MusicBoxOWMoonLord",
+	5037: "MusicBoxOWPlantera",
+	5038: "MusicBoxOWJungle",
+	5039: "MusicBoxOWWallOfFlesh",
+	5040: "MusicBoxOWHallow",
+	5041: "MilkCarton",
+	5042: "CoffeeCup",
+	5043: "Torch God's Favor",
+	5044: "Music Box (Journey's End)",
+	5045: "Plaguebringer's Skull",
+	5046: "Plaguebringer's Cloak",
+	5047: "Plaguebringer's Treads",
+	5048: "Wandering Jingasa",
+	5049: "Wandering Yukata",
+	5050: "Wandering Geta",
+	5051: "Timeless Traveler's Hood",
+	5052: "Timeless Traveler's Cloak",
+	5053: "Timeless Traveler's Footwear",
+	5054: "Floret Protector Helmet",
+	5055: "Floret Protector Shirt",
+	5056: "Floret Protector Pants",
+	5057: "Capricorn Helmet",
+	5058: "Capricorn Chestplate",
+	5059: "Capricorn Hooves",
+	5060: "Capricorn Tail",
+	5061: "Video Visage",
+	5062: "Lazer Blazer",
+	5063: "Pinstripe Pants",
+	5064: "Lavaproof Tackle Bag",
+	5065: "Resonance Scepter",
+	5066: "Bee Hive",
+	5067: "Antlion Eggs",
+	5068: "Flinx Fur Coat",
+	5069: "Flinx Staff",
+	5070: "Flinx Fur",
+	5071: "Royal Tiara",
+	5072: "Royal Blouse",
+	5073: "Royal Dress",
+	5074: "Spinal Tap",
+	5075: "Rainbow Cursor",
+	5076: "Royal Scepter",
+	5077: "Glass Slipper",
+	5078: "Prince Uniform",
+	5079: "Prince Pants",
+	5080: "Prince Cape",
+	5081: "Potted Crystal Fern",
+	5082: "Potted Crystal Spiral",
+	5083: "Potted Crystal Teardrop",
+	5084: "Potted Crystal Tree",
+	5085: "Princess 64",
+	5086: "Painting of a Lass",
+	5087: "Dark Side of the Hallow",
+	5088: "Bernie's Button",
+	5089: "Glommer's Flower",
+	5090: "Deerclops Eyeball",
+	5091: "Monster Meat",
+	5092: "Monster Lasagna",
+	5093: "Froggle Bunwich",
+	5094: "Tentacle Spike",
+	5095: "Lucy the Axe",
+	5096: "Ham Bat",
+	5097: "Bat Bat",
+	5098: "Eye Bone",
+	5099: "Garland",
+	5100: "Bone Helm",
+	5101: "Eyebrella",
+	5102: "Gentleman's Vest",
+	5103: "Gentleman's Trousers",
+	5104: "Gentleman's Beard",
+	5105: "Gentleman's Long Beard",
+	5106: "Gentleman's Magnificent Beard",
+	5107: "Magiluminescence",
+	5108: "Deerclops Trophy",
+	5109: "Deerclops Mask",
+	5110: "Deerclops Relic",
+	5111: "Treasure Bag (Deerclops)",
+	5112: "Music Box (Deerclops)",
+	5113: "Radio Thing",
+	5114: "Abigail's Flower",
+	5115: "Firestarter's Sweater",
+	5116: "Firestarter's Skirt",
+	5117: "Pew-matic Horn",
+	5118: "Weather Pain",
+	5119: "Houndius Shootius",
+	5120: "Deer Thing",
+	5121: "The Gentleman Scientist",
+	5122: "The Firestarter",
+	5123: "The Bereaved",
+	5124: "The Strongman",
+	5125: "FartMinecart",
+	5126: "HandOfCreation",
+	5127: "VioletMoss",
+	5128: "RainbowMoss",
+	5129: "Flymeal",
+	5130: "WolfMountItem",
+	5131: "ResplendentDessert",
+	5132: "Stinkbug",
+	5133: "StinkbugCage",
+	5134: "Clentaminator2",
+	5135: "VenomDartTrap",
+	5136: "VulkelfEar",
+	5137: "StinkbugHousingBlocker",
+	5138: "StinkbugHousingBlockerEcho",
+	5139: "FishingBobber",
+	5140: "FishingBobberGlowingStar",
+	5141: "FishingBobberGlowingLava",
+	5142: "FishingBobberGlowingKrypton",
+	5143: "FishingBobberGlowingXenon",
+	5144: "FishingBobberGlowingArgon",
+	5145: "FishingBobberGlowingViolet",
+	5146: "FishingBobberGlowingRainbow",
+	5147: "WandofFrosting",
+	5148: "CoralBathtub",
+	5149: "CoralBed",
+	5150: "CoralBookcase",
+	5151: "CoralDresser",
+	5152: "CoralCandelabra",
+	5153: "CoralCandle",
+	5154: "CoralChair",
+	5155: "CoralChandelier",
+	5156: "CoralChest",
+	5157: "CoralClock",
+	5158: "CoralDoor",
+	5159: "CoralLamp",
+	5160: "CoralLantern",
+	5161: "CoralPiano",
+	5162: "CoralPlatform",
+	5163: "CoralSink",
+	5164: "CoralSofa",
+	5165: "CoralTable",
+	5166: "CoralWorkbench",
+	5167: "Fake_CoralChest",
+	5168: "CoralToilet",
+	5169: "BalloonBathtub",
+	5170: "BalloonBed",
+	5171: "BalloonBookcase",
+	5172: "BalloonDresser",
+	5173: "BalloonCandelabra",
+	5174: "BalloonCandle",
+	5175: "BalloonChair",
+	5176: "BalloonChandelier",
+	5177: "BalloonChest",
+	5178: "BalloonClock",
+	5179: "BalloonDoor",
+	5180: "BalloonLamp",
+	5181: "BalloonLantern",
+	5182: "BalloonPiano",
+	5183: "BalloonPlatform",
+	5184: "BalloonSink",
+	5185: "BalloonSofa",
+	5186: "BalloonTable",
+	5187: "BalloonWorkbench",
+	5188: "Fake_BalloonChest",
+	5189: "BalloonToilet",
+	5190: "AshWoodBathtub",
+	5191: "AshWoodBed",
+	5192: "AshWoodBookcase",
+	5193: "AshWoodDresser",
+	5194: "AshWoodCandelabra",
+	5195: "AshWoodCandle",
+	5196: "AshWoodChair",
+	5197: "AshWoodChandelier",
+	5198: "AshWoodChest",
+	5199: "AshWoodClock",
+	5200: "AshWoodDoor",
+	5201: "AshWoodLamp",
+	5202: "AshWoodLantern",
+	5203: "AshWoodPiano",
+	5204: "AshWoodPlatform",
+	5205: "AshWoodSink",
+	5206: "AshWoodSofa",
+	5207: "AshWoodTable",
+	5208: "AshWoodWorkbench",
+	5209: "Fake_AshWoodChest",
+	5210: "AshWoodToilet",
+	5211: "BiomeSightPotion",
+	5212: "ScarletMacaw",
+	5213: "ScarletMacawCage",
+	5214: "AshGrassSeeds",
+	5215: "AshWood",
+	5216: "AshWoodWall",
+	5217: "AshWoodFence",
+	5218: "Outcast",
+	5219: "FairyGuides",
+	5220: "AHorribleNightforAlchemy",
+	5221: "MorningHunt",
+	5222: "SuspiciouslySparkly",
+	5223: "Requiem",
+	5224: "CatSword",
+	5225: "KargohsSummon",
+	5226: "HighPitch",
+	5227: "AMachineforTerrarians",
+	5228: "TerraBladeChronicles",
+	5229: "BennyWarhol",
+	5230: "LizardKing",
+	5231: "MySon",
+	5232: "Duality",
+	5233: "ParsecPals",
+	5234: "RemnantsofDevotion",
+	5235: "NotSoLostInParadise",
+	5236: "OcularResonance",
+	5237: "WingsofEvil",
+	5238: "Constellation",
+	5239: "Eyezorhead",
+	5240: "DreadoftheRedSea",
+	5241: "DoNotEattheVileMushroom",
+	5242: "YuumaTheBlueTiger",
+	5243: "MoonmanandCompany",
+	5244: "SunshineofIsrapony",
+	5245: "Purity",
+	5246: "SufficientlyAdvanced",
+	5247: "StrangeGrowth",
+	5248: "HappyLittleTree",
+	5249: "StrangeDeadFellows",
+	5250: "Secrets",
+	5251: "Thunderbolt",
+	5252: "Crustography",
+	5253: "TheWerewolf",
+	5254: "BlessingfromTheHeavens",
+	5255: "LoveisintheTrashSlot",
+	5256: "Fangs",
+	5257: "HailtotheKing",
+	5258: "SeeTheWorldForWhatItIs",
+	5259: "WhatLurksBelow",
+	5260: "ThisIsGettingOutOfHand",
+	5261: "Buddies",
+	5262: "MidnightSun",
+	5263: "CouchGag",
+	5264: "SilentFish",
+	5265: "TheDuke",
+	5266: "RoyalRomance",
+	5267: "Bioluminescence",
+	5268: "Wildflowers",
+	5269: "VikingVoyage",
+	5270: "Bifrost",
+	5271: "Heartlands",
+	5272: "ForestTroll",
+	5273: "AuroraBorealis",
+	5274: "LadyOfTheLake",
+	5275: "JojaCola",
+	5276: "JunimoPetItem",
+	5277: "SpicyPepper",
+	5278: "Pomegranate",
+	5279: "AshWoodHelmet",
+	5280: "AshWoodBreastplate",
+	5281: "AshWoodGreaves",
+	5282: "AshWoodBow",
+	5283: "AshWoodHammer",
+	5284: "AshWoodSword",
+	5285: "MoonGlobe",
+	5286: "RepairedLifeCrystal",
+	5287: "RepairedManaCrystal",
+	5288: "TerraFartMinecart",
+	5289: "MinecartPowerup",
+	5290: "JimsCap",
+	5291: "EchoWall",
+	5292: "EchoPlatform",
+	5293: "MushroomTorch",
+	5294: "HiveFive",
+	5295: "AcornAxe",
+	5296: "ChlorophyteExtractinator",
+	5297: "BlueEgg",
+	5298: "Trimarang",
+	5299: "MushroomCampfire",
+	5300: "BlueMacaw",
+	5301: "BlueMacawCage",
+	5302: "BottomlessHoneyBucket",
+	5303: "HoneyAbsorbantSponge",
+	5304: "UltraAbsorbantSponge",
+	5305: "GoblorcEar",
+	5306: "ReefBlock",
+	5307: "ReefWall",
+	5308: "PlacePainting",
+	5309: "DontHurtNatureBook",
+	5310: "PrincessStyle",
+	5311: "Toucan",
+	5312: "YellowCockatiel",
+	5313: "GrayCockatiel",
+	5314: "ToucanCage",
+	5315: "YellowCockatielCage",
+	5316: "GrayCockatielCage",
+	5317: "MacawStatue",
+	5318: "ToucanStatue",
+	5319: "CockatielStatue",
+	5320: "PlaceableHealingPotion",
+	5321: "PlaceableManaPotion",
+	5322: "ShadowCandle",
+	5323: "DontHurtComboBook",
+	5324: "RubblemakerSmall",
+	5325: "ClosedVoidBag",
+	5326: "ArtisanLoaf",
+	5327: "TNTBarrel",
+	5328: "ChestLock",
+	5329: "RubblemakerMedium",
+	5330: "RubblemakerLarge",
+	5331: "HorseshoeBundle",
+	5332: "SpiffoPlush",
+	5333: "GlowTulip",
+	5334: "MechdusaSummon",
+	5335: "RodOfHarmony",
+	5336: "CombatBookVolumeTwo",
+	5337: "AegisCrystal",
+	5338: "AegisFruit",
+	5339: "ArcaneCrystal",
+	5340: "GalaxyPearl",
+	5341: "GummyWorm",
+	5342: "Ambrosia",
+	5343: "PeddlersSatchel",
+	5344: "EchoCoating",
+	5345: "EchoMonolith",
+	5346: "GasTrap",
+	5347: "ShimmerMonolith",
+	5348: "ShimmerArrow",
+	5349: "ShimmerBlock",
+	5350: "Shimmerfly",
+	5351: "ShimmerflyinaBottle",
+	5352: "ShimmerSlimeBanner",
+	5353: "ShimmerTorch",
+	5354: "ReflectiveShades",
+	5355: "ShimmerCloak",
+	5356: "UsedGasTrap",
+	5357: "ShimmerCampfire",
+	5358: "Shellphone",
+	5359: "ShellphoneSpawn",
+	5360: "ShellphoneOcean",
+	5361: "ShellphoneHell",
+	5362: "MusicBoxShimmer",
+	5363: "SpiderWallUnsafe",
+	5364: "BottomlessShimmerBucket",
+	5365: "BlueBrickWallUnsafe",
+	5366: "BlueSlabWallUnsafe",
+	5367: "BlueTiledWallUnsafe",
+	5368: "PinkBrickWallUnsafe",
+	5369: "PinkSlabWallUnsafe",
+	5370: "PinkTiledWallUnsafe",
+	5371: "GreenBrickWallUnsafe",
+	5372: "GreenSlabWallUnsafe",
+	5373: "GreenTiledWallUnsafe",
+	5374: "SandstoneWallUnsafe",
+	5375: "HardenedSandWallUnsafe",
+	5376: "LihzahrdWallUnsafe",
+	5377: "SpelunkerFlare",
+	5378: "CursedFlare",
+	5379: "RainbowFlare",
+	5380: "ShimmerFlare",
+	5381: "Moondial",
+	5382: "WaffleIron",
+	5383: "BouncyBoulder",
+	5384: "LifeCrystalBoulder",
+	5385: "DizzyHat",
+	5386: "LincolnsHoodie",
+	5387: "LincolnsPants",
+	5388: "SunOrnament",
+	5389: "HoplitePizza",
+	5390: "LincolnsHood",
+	5391: "UncumberingStone",
+	5392: "SandSolution",
+	5393: "SnowSolution",
+	5394: "DirtSolution",
+	5395: "PoopBlock",
+	5396: "PoopWall",
+	5397: "ShimmerWall",
+	5398: "ShimmerBrick",
+	5399: "ShimmerBrickWall",
+	5400: "DirtiestBlock",
+	5401: "LunarRustBrick",
+	5402: "DarkCelestialBrick",
+	5403: "AstraBrick",
+	5404: "CosmicEmberBrick",
+	5405: "CryocoreBrick",
+	5406: "MercuryBrick",
+	5407: "StarRoyaleBrick",
+	5408: "HeavenforgeBrick",
+	5409: "LunarRustBrickWall",
+	5410: "DarkCelestialBrickWall",
+	5411: "AstraBrickWall",
+	5412: "CosmicEmberBrickWall",
+	5413: "CryocoreBrickWall",
+	5414: "MercuryBrickWall",
+	5415: "StarRoyaleBrickWall",
+	5416: "HeavenforgeBrickWall",
+	5417: "AncientBlueDungeonBrick",
+	5418: "AncientBlueDungeonBrickWall",
+	5419: "AncientGreenDungeonBrick",
+	5420: "AncientGreenDungeonBrickWall",
+	5421: "AncientPinkDungeonBrick",
+	5422: "AncientPinkDungeonBrickWall",
+	5423: "AncientGoldBrick",
+	5424: "AncientGoldBrickWall",
+	5425: "AncientSilverBrick",
+	5426: "AncientSilverBrickWall",
+	5427: "AncientCopperBrick",
+	5428: "AncientCopperBrickWall",
+	5429: "AncientCobaltBrick",
+	5430: "AncientCobaltBrickWall",
+	5431: "AncientMythrilBrick",
+	5432: "AncientMythrilBrickWall",
+	5433: "AncientObsidianBrick",
+	5434: "AncientObsidianBrickWall",
+	5435: "AncientHellstoneBrick",
+	5436: "AncientHellstoneBrickWall",
+	5437: "ShellphoneDummy",
+	5438: "Fertilizer",
+	5439: "LavaMossBlock",
+	5440: "ArgonMossBlock",
+	5441: "KryptonMossBlock",
+	5442: "XenonMossBlock",
+	5443: "VioletMossBlock",
+	5444: "RainbowMossBlock",
+	5445: "LavaMossBlockWall",
+	5446: "ArgonMossBlockWall",
+	5447: "KryptonMossBlockWall",
+	5448: "XenonMossBlockWall",
+	5449: "VioletMossBlockWall",
+	5450: "RainbowMossBlockWall",
+	5451: "JimsDrone",
+	5452: "JimsDroneVisor",
+	5453: "DontHurtCrittersBookInactive",
+	5454: "DontHurtNatureBookInactive",
+	5455: "DontHurtComboBookInactive",
+	5456: "DeadCellsMushroomBoiSummonItem",
+	5457: "DeadCellsBeheadedHead",
+	5458: "DeadCellsBeheadedBody",
+	5459: "DeadCellsBeheadedLegs",
+	5460: "DeadCellsBarrelLauncher",
+	5461: "DeadCellsKillingDeck",
+	5462: "DeadCellsFlint",
+	5463: "DeadCellsBarnacleSummonItem",
+	5464: "MiteyTitey",
+	5465: "DeadCellsRamRune",
+	5466: "DeadCellsSwarmGrenade",
+	5467: "DemonAltarReplica",
+	5468: "CrimsonAltarReplica",
+	5469: "ShadowOrbReplica",
+	5470: "CrimsonHeartReplica",
+	5471: "CobwebReplica",
+	5472: "DeadCellsDisplayJar",
+	5473: "CobWhip",
+	5474: "CorruptWhip",
+	5475: "CrimsonWhip",
+	5476: "MeteorWhip",
+	5477: "FlowerWhip",
+	5478: "EelWhip",
+	5479: "ConstellationWhip",
+	5480: "MoonLordWhip",
+	5481: "PortableKiln",
+	5482: "DeadCellsPotionStation",
+	5483: "QueenOfBees",
+	5484: "PlayerVoiceCowbellItem",
+	5485: "PlayerVoiceChickenFeetItem",
+	5486: "TheSeaOfSilence",
+	5487: "HeroesFromAnotherWorld",
+	5488: "Crystallize",
+	5489: "EaterOfLife",
+	5490: "ThisIsCanonNow",
+	5491: "WinterAtVaringskollen",
+	5492: "MagicShimmerDropper",
+	5493: "ShimmerFallBlock",
+	5494: "ShimmerFallWall",
+	5495: "ShimmerGun",
+	5496: "LifeFruitHealingPotion",
+	5497: "PinkBanner",
+	5498: "WhiteBanner",
+	5499: "PlayerVoiceFrogItem",
+	5500: "PlayerVoiceGoatItem",
+	5501: "PlayerVoiceRetroItem",
+	5502: "PlayerVoiceCatItem",
+	5503: "PlayerVoiceDogItem",
+	5504: "PlayerVoiceTurkeyItem",
+	5505: "PlayerVoiceGoblinItem",
+	5506: "PlayerVoiceCrowItem",
+	5507: "PlayerVoiceBalloonItem",
+	5508: "PlayerVoiceUndeadItem",
+	5509: "PlayerVoiceVampireItem",
+	5510: "VelociraptorMountItem",
+	5511: "Pufferfish",
+	5512: "PufferfishCage",
+	5513: "PufferfishPet",
+	5514: "RainbowBoulder",
+	5515: "MoonLordBody",
+	5516: "Poulder",
+	5517: "AxeFairyPetItem",
+	5518: "SuperFertilizer",
+	5519: "Axearang",
+	5520: "LavaBoulder",
+	5521: "SpiderBoulder",
+	5522: "Ghoulder",
+	5523: "BoulderPet",
+	5524: "ChlorophyteVisor",
+	5525: "RatMountItem",
+	5526: "FlaironFlail",
+	5527: "OfSeaAndDreams",
+	5528: "TheRunicPixie",
+	5529: "BannerOfTheBeast",
+	5530: "StickmanVsTerrTerr",
+	5531: "CozyWindow",
+	5532: "DemonAltar",
+	5533: "CrimsonAltar",
+	5534: "PlayerVoiceFairyItem",
+	5535: "PinkPhaseblade",
+	5536: "PinkPhasesaber",
+	5537: "BlackenedFish",
+	5538: "MusicBoxQueenBee",
+	5539: "MusicBoxTwins",
+	5540: "MagicString",
+	5541: "MagicYoyoBag",
+	5542: "FreezeBomb",
+	5543: "StressBall",
+	5544: "CloudPlatform",
+	5545: "LivingWoodWallUnsafe",
+	5546: "DirtWallUnsafe",
+	5547: "StrungCounterweight",
+	5548: "AetheriumBathtub",
+	5549: "AetheriumBed",
+	5550: "AetheriumBookcase",
+	5551: "AetheriumDresser",
+	5552: "AetheriumCandelabra",
+	5553: "AetheriumCandle",
+	5554: "AetheriumChair",
+	5555: "AetheriumChandelier",
+	5556: "AetheriumChest",
+	5557: "AetheriumClock",
+	5558: "AetheriumDoor",
+	5559: "AetheriumLamp",
+	5560: "AetheriumLantern",
+	5561: "AetheriumPiano",
+	5562: "AetheriumPlatform",
+	5563: "AetheriumSink",
+	5564: "AetheriumSofa",
+	5565: "AetheriumTable",
+	5566: "AetheriumWorkbench",
+	5567: "Fake_AetheriumChest",
+	5568: "AetheriumToilet",
+	5569: "LavaCloud",
+	5570: "StarCloud",
+	5571: "RainbowCloud",
+	5572: "MudBallPlayer",
+	5573: "TorchGodPotion",
+	5574: "LuckyClover",
+	5575: "WiltedClover",
+	5576: "RavenFeather",
+	5577: "PrettyMirror",
+	5578: "MusicBoxKingSlime",
+	5579: "MusicBoxQueenBeeAlt",
+	5580: "MusicBoxLunaticCultist",
+	5581: "MusicBoxSkeletronPrime",
+	5582: "MusicBoxDestroyer",
+	5583: "ChickenBonesHead",
+	5584: "ChickenBonesBody",
+	5585: "ChickenBonesLegs",
+	5586: "ChickenBonesWings",
+	5587: "ChickenBonesRobe",
+	5588: "UpgradedMiningHead",
+	5589: "UpgradedMiningBody",
+	5590: "UpgradedMiningLegs",
+	5591: "UpgradedFishingHead",
+	5592: "UpgradedFishingBody",
+	5593: "UpgradedFishingLegs",
+	5594: "SuperBomb",
+	5595: "SuperStickyBomb",
+	5596: "WeldingMask",
+	5597: "BatMountItem",
+	5598: "CRTMonolith",
+	5599: "RetroMonolith",
+	5600: "RollerSkatesBlueMountItem",
+	5601: "FallenStarBathtub",
+	5602: "FallenStarBed",
+	5603: "FallenStarBookcase",
+	5604: "FallenStarDresser",
+	5605: "FallenStarCandelabra",
+	5606: "FallenStarCandle",
+	5607: "FallenStarChair",
+	5608: "FallenStarChandelier",
+	5609: "FallenStarChest",
+	5610: "FallenStarClock",
+	5611: "FallenStarDoor",
+	5612: "FallenStarLamp",
+	5613: "FallenStarLantern",
+	5614: "FallenStarPiano",
+	5615: "FallenStarPlatform",
+	5616: "FallenStarSink",
+	5617: "FallenStarSofa",
+	5618: "FallenStarTable",
+	5619: "FallenStarWorkbench",
+	5620: "Fake_FallenStarChest",
+	5621: "FallenStarToilet",
+	5622: "FallenStarBlock",
+	5623: "FallenStarWall",
+	5624: "ChippysHead",
+	5625: "ChippysBody",
+	5626: "ChippysLegs",
+	5627: "ChippysWings",
+	5628: "ChippysHeadband",
+	5629: "AcornSlingshot",
+	5630: "PaintingRPlace2023",
+	5631: "PaintingBouldChoices",
+	5632: "PaintingDarkForebodings",
+	5633: "PaintingGermanZenith",
+	5634: "PaintingItsScragglinTime",
+	5635: "PaintingKaguya",
+	5636: "PaintingGermanBeer",
+	5637: "MusicBoxEaterOfWorlds",
+	5638: "MusicBoxTorchGod",
+	5639: "MusicBoxTorchGodAlt",
+	5640: "RollerSkatesGreenMountItem",
+	5641: "RollerSkatesClassicMountItem",
+	5642: "RollerSkatesPartyMountItem",
+	5643: "RainbowGlowstick",
+	5644: "ScryingOrb",
+	5645: "RockCandy",
+	5646: "BlueBikiniBody",
+	5647: "BlueBikiniLegs",
+	5648: "RedSwimsuit",
+	5649: "GreenSwimshorts",
+	5650: "GraySwimshorts",
+	5651: "OrcaBanner",
+	5652: "TeleportationPylonUnderworld",
+	5653: "TeleportationPylonShimmer",
+	5654: "RainbowBoulderPet",
+	5655: "NoirMonolith",
+	5656: "HeroicisHead",
+	5657: "HeroicisBody",
+	5658: "HeroicisLegs",
+	5659: "HeroicisWings",
+	5660: "HallowedCrown",
+	5661: "HeroicisWingsInactive",
+	5662: "EnchantedPixieDust",
+	5663: "PalworldMinionCattiva",
+	5664: "PalworldMinionFoxsparks",
+	5665: "PalworldPetChillet",
+	5666: "PalworldPetChilletIgnis",
+	5667: "PalworldDigtoise",
+	5668: "SoundGun",
+	5669: "TrueCopperShortsword",
+	5670: "RainbowPhaseblade",
+	5671: "RainbowPhasesaber",
+	5672: "LibrarianSkeletonBanner",
+	5673: "WaterBoltMimicBanner",
+	5674: "TeamBlockRedVariant",
+	5675: "TeamBlockGreenVariant",
+	5676: "TeamBlockBlueVariant",
+	5677: "TeamBlockYellowVariant",
+	5678: "TeamBlockPinkVariant",
+	5679: "TeamBlockWhiteVariant",
+	5680: "LilacDuskHead",
+	5681: "LilacDuskBody",
+	5682: "LilacDuskLegs",
+	5683: "KazzymodusHood",
+	5684: "KazzymodusChestpiece",
+	5685: "KazzymodusLeggings",
+	5686: "KazzymodusWings",
+	5687: "SlimeSpear",
+	5688: "SlimeWhip",
+	5689: "FeywoodBathtub",
+	5690: "FeywoodBed",
+	5691: "FeywoodBookcase",
+	5692: "FeywoodDresser",
+	5693: "FeywoodCandelabra",
+	5694: "FeywoodCandle",
+	5695: "FeywoodChair",
+	5696: "FeywoodChandelier",
+	5697: "FeywoodChest",
+	5698: "FeywoodClock",
+	5699: "FeywoodDoor",
+	5700: "FeywoodLamp",
+	5701: "FeywoodLantern",
+	5702: "FeywoodPiano",
+	5703: "FeywoodPlatform",
+	5704: "FeywoodSink",
+	5705: "FeywoodSofa",
+	5706: "FeywoodTable",
+	5707: "FeywoodWorkbench",
+	5708: "Fake_FeywoodChest",
+	5709: "FeywoodToilet",
+	5710: "Feywood",
+	5711: "FeywoodWall",
+	5712: "HallowedBathtub",
+	5713: "HallowedBed",
+	5714: "HallowedBookcase",
+	5715: "HallowedDresser",
+	5716: "HallowedCandelabra",
+	5717: "HallowedCandle",
+	5718: "HallowedChair",
+	5719: "HallowedChandelier",
+	5720: "HallowedFurnitureChest",
+	5721: "HallowedClock",
+	5722: "HallowedDoor",
+	5723: "HallowedLamp",
+	5724: "HallowedLantern",
+	5725: "HallowedPiano",
+	5726: "HallowedPlatform",
+	5727: "HallowedSink",
+	5728: "HallowedSofa",
+	5729: "HallowedTable",
+	5730: "HallowedWorkbench",
+	5731: "Fake_HallowedFurnitureChest",
+	5732: "HallowedToilet",
+	5733: "HallowedBrick",
+	5734: "HallowedBrickWall",
+	5735: "PalworldPalMetalArmorBody",
+	5736: "PalworldPalMetalArmorLegs",
+	5737: "ChippysWingsInactive",
+	5738: "RemoteControlCar",
+	5739: "GothicBathtub",
+	5740: "GothicBed",
+	5741: "GothicDresser",
+	5742: "GothicCandelabra",
+	5743: "GothicCandle",
+	5744: "GothicChandelier",
+	5745: "GothicChest",
+	5746: "GothicClock",
+	5747: "GothicDoor",
+	5748: "GothicLamp",
+	5749: "GothicLantern",
+	5750: "GothicPiano",
+	5751: "GothicPlatform",
+	5752: "GothicSink",
+	5753: "GothicSofa",
+	5754: "Fake_GothicChest",
+	5755: "GothicToilet",
+	5756: "DemoniteBathtub",
+	5757: "DemoniteBed",
+	5758: "DemoniteBookcase",
+	5759: "DemoniteCandelabra",
+	5760: "DemoniteCandle",
+	5761: "DemoniteChair",
+	5762: "DemoniteChandelier",
+	5763: "DemoniteChest",
+	5764: "DemoniteClock",
+	5765: "DemoniteDoor",
+	5766: "DemoniteDresser",
+	5767: "DemoniteLamp",
+	5768: "DemoniteLantern",
+	5769: "DemonitePiano",
+	5770: "DemonitePlatform",
+	5771: "DemoniteSink",
+	5772: "DemoniteSofa",
+	5773: "DemoniteTable",
+	5774: "DemoniteToilet",
+	5775: "DemoniteWorkbench",
+	5776: "Fake_DemoniteChest",
+	5777: "CrimtaneBathtub",
+	5778: "CrimtaneBed",
+	5779: "CrimtaneBookcase",
+	5780: "CrimtaneCandelabra",
+	5781: "CrimtaneCandle",
+	5782: "CrimtaneChair",
+	5783: "CrimtaneChandelier",
+	5784: "CrimtaneChest",
+	5785: "CrimtaneClock",
+	5786: "CrimtaneDoor",
+	5787: "CrimtaneDresser",
+	5788: "CrimtaneLamp",
+	5789: "CrimtaneLantern",
+	5790: "CrimtanePiano",
+	5791: "CrimtanePlatform",
+	5792: "CrimtaneSink",
+	5793: "CrimtaneSofa",
+	5794: "CrimtaneTable",
+	5795: "CrimtaneToilet",
+	5796: "CrimtaneWorkbench",
+	5797: "Fake_CrimtaneChest",
+	5798: "SnowBathtub",
+	5799: "SnowBed",
+	5800: "SnowBookcase",
+	5801: "SnowCandelabra",
+	5802: "SnowCandle",
+	5803: "SnowChair",
+	5804: "SnowChandelier",
+	5805: "SnowChest",
+	5806: "SnowClock",
+	5807: "SnowDoor",
+	5808: "SnowDresser",
+	5809: "SnowLamp",
+	5810: "SnowLantern",
+	5811: "SnowPiano",
+	5812: "SnowPlatform",
+	5813: "SnowSink",
+	5814: "SnowSofa",
+	5815: "SnowTable",
+	5816: "SnowToilet",
+	5817: "SnowWorkbench",
+	5818: "Fake_SnowChest",
+	5819: "FlinxFurBathtub",
+	5820: "FlinxFurBed",
+	5821: "FlinxFurBookcase",
+	5822: "FlinxFurCandelabra",
+	5823: "FlinxFurCandle",
+	5824: "FlinxFurChair",
+	5825: "FlinxFurChandelier",
+	5826: "FlinxFurChest",
+	5827: "FlinxFurClock",
+	5828: "FlinxFurDoor",
+	5829: "FlinxFurDresser",
+	5830: "FlinxFurLamp",
+	5831: "FlinxFurLantern",
+	5832: "FlinxFurPiano",
+	5833: "FlinxFurPlatform",
+	5834: "FlinxFurSink",
+	5835: "FlinxFurSofa",
+	5836: "FlinxFurTable",
+	5837: "FlinxFurToilet",
+	5838: "FlinxFurWorkbench",
+	5839: "Fake_FlinxFurChest",
+	5840: "PineBathtub",
+	5841: "PineBed",
+	5842: "PineBookcase",
+	5843: "PineCandelabra",
+	5844: "PineCandle",
+	5845: "PineChandelier",
+	5846: "PineChest",
+	5847: "PineClock",
+	5848: "PineDresser",
+	5849: "PineLamp",
+	5850: "PineLantern",
+	5851: "PinePiano",
+	5852: "PinePlatform",
+	5853: "PineSink",
+	5854: "PineSofa",
+	5855: "PineToilet",
+	5856: "PineWorkbench",
+	5857: "Fake_PineChest",
+	5858: "EasterBathtub",
+	5859: "EasterBed",
+	5860: "EasterBookcase",
+	5861: "EasterCandelabra",
+	5862: "EasterCandle",
+	5863: "EasterChair",
+	5864: "EasterChandelier",
+	5865: "EasterChest",
+	5866: "EasterClock",
+	5867: "EasterDoor",
+	5868: "EasterDresser",
+	5869: "EasterLamp",
+	5870: "EasterLantern",
+	5871: "EasterPiano",
+	5872: "EasterPlatform",
+	5873: "EasterSink",
+	5874: "EasterSofa",
+	5875: "EasterTable",
+	5876: "EasterToilet",
+	5877: "EasterWorkbench",
+	5878: "Fake_EasterChest",
+	5879: "StoneBathtub",
+	5880: "StoneBed",
+	5881: "StoneBookcase",
+	5882: "StoneCandelabra",
+	5883: "StoneCandle",
+	5884: "StoneChair",
+	5885: "StoneChandelier",
+	5886: "StoneChest",
+	5887: "StoneClock",
+	5888: "StoneDresser",
+	5889: "StoneLamp",
+	5890: "StoneLantern",
+	5891: "StonePiano",
+	5892: "StoneSink",
+	5893: "StoneSofa",
+	5894: "StoneTable",
+	5895: "StoneToilet",
+	5896: "StoneWorkbench",
+	5897: "Fake_StoneChest",
+	5898: "JellyfishBathtub",
+	5899: "JellyfishBed",
+	5900: "JellyfishBookcase",
+	5901: "JellyfishCandelabra",
+	5902: "JellyfishCandle",
+	5903: "JellyfishChair",
+	5904: "JellyfishChandelier",
+	5905: "JellyfishChest",
+	5906: "JellyfishClock",
+	5907: "JellyfishDoor",
+	5908: "JellyfishDresser",
+	5909: "JellyfishLamp",
+	5910: "JellyfishLantern",
+	5911: "JellyfishPiano",
+	5912: "JellyfishPlatform",
+	5913: "JellyfishSink",
+	5914: "JellyfishSofa",
+	5915: "JellyfishTable",
+	5916: "JellyfishToilet",
+	5917: "JellyfishWorkbench",
+	5918: "Fake_JellyfishChest",
+	5919: "PineTreeBlockWall",
+	5920: "EasterBlock",
+	5921: "EasterBlockWall",
+	5922: "GothicBrick",
+	5923: "GothicBrickWall",
+	5924: "FlinxFurBlock",
+	5925: "FlinxFurBlockWall",
+	5926: "JellyfishBlock",
+	5927: "JellyfishBlockWall",
+	5928: "ToyBreakerBlock",
+	5929: "PaintingRemix",
+	5930: "PineWoodBlock",
+	5931: "PineWoodBlockWall",
+	5932: "HarpyBathtub",
+	5933: "HarpyBed",
+	5934: "HarpyBookcase",
+	5935: "HarpyCandelabra",
+	5936: "HarpyCandle",
+	5937: "HarpyChair",
+	5938: "HarpyChandelier",
+	5939: "HarpyChest",
+	5940: "HarpyClock",
+	5941: "HarpyDoor",
+	5942: "HarpyDresser",
+	5943: "HarpyLamp",
+	5944: "HarpyLantern",
+	5945: "HarpyPiano",
+	5946: "HarpyPlatform",
+	5947: "HarpySink",
+	5948: "HarpySofa",
+	5949: "HarpyTable",
+	5950: "HarpyToilet",
+	5951: "HarpyWorkbench",
+	5952: "Fake_HarpyChest",
+	5953: "HarpyBlock",
+	5954: "HarpyBlockWall",
+	5955: "CloudBathtub",
+	5956: "CloudBed",
+	5957: "CloudBookcase",
+	5958: "CloudCandelabra",
+	5959: "CloudCandle",
+	5960: "CloudChair",
+	5961: "CloudChandelier",
+	5962: "CloudChest",
+	5963: "CloudClock",
+	5964: "CloudDoor",
+	5965: "CloudDresser",
+	5966: "CloudLamp",
+	5967: "CloudLantern",
+	5968: "CloudPiano",
+	5969: "CloudSink",
+	5970: "CloudSofa",
+	5971: "CloudTable",
+	5972: "CloudToilet",
+	5973: "CloudWorkbench",
+	5974: "Fake_CloudChest",
+	5975: "MoonplateBathtub",
+	5976: "MoonplateBed",
+	5977: "MoonplateBookcase",
+	5978: "MoonplateCandelabra",
+	5979: "MoonplateCandle",
+	5980: "MoonplateChair",
+	5981: "MoonplateChandelier",
+	5982: "MoonplateChest",
+	5983: "MoonplateClock",
+	5984: "MoonplateDoor",
+	5985: "MoonplateDresser",
+	5986: "MoonplateLamp",
+	5987: "MoonplateLantern",
+	5988: "MoonplatePiano",
+	5989: "MoonplatePlatform",
+	5990: "MoonplateSink",
+	5991: "MoonplateSofa",
+	5992: "MoonplateTable",
+	5993: "MoonplateToilet",
+	5994: "MoonplateWorkbench",
+	5995: "Fake_MoonplateChest",
+	5996: "MoonplateBlock",
+	5997: "MoonplateBlockWall",
+	5998: "LibrarianBathtub",
+	5999: "LibrarianBed",
+	6000: "LibrarianBookcase",
+	6001: "LibrarianCandelabra",
+	6002: "LibrarianCandle",
+	6003: "LibrarianChair",
+	6004: "LibrarianChandelier",
+	6005: "LibrarianChest",
+	6006: "LibrarianClock",
+	6007: "LibrarianDoor",
+	6008: "LibrarianDresser",
+	6009: "LibrarianLamp",
+	6010: "LibrarianLantern",
+	6011: "LibrarianPiano",
+	6012: "LibrarianPlatform",
+	6013: "LibrarianSink",
+	6014: "LibrarianSofa",
+	6015: "LibrarianTable",
+	6016: "LibrarianToilet",
+	6017: "LibrarianWorkbench",
+	6018: "Fake_LibrarianChest",
+	6019: "LibrarianBlock",
+	6020: "LibrarianBlockWall",
+	6021: "SpikeBathtub",
+	6022: "SpikeBed",
+	6023: "SpikeBookcase",
+	6024: "SpikeCandelabra",
+	6025: "SpikeCandle",
+	6026: "SpikeChair",
+	6027: "SpikeChandelier",
+	6028: "SpikeChest",
+	6029: "SpikeClock",
+	6030: "SpikeDoor",
+	6031: "SpikeDresser",
+	6032: "SpikeLamp",
+	6033: "SpikeLantern",
+	6034: "SpikePiano",
+	6035: "SpikePlatform",
+	6036: "SpikeSink",
+	6037: "SpikeSofa",
+	6038: "SpikeTable",
+	6039: "SpikeToilet",
+	6040: "SpikeWorkbench",
+	6041: "Fake_SpikeChest",
+	6042: "SpikeBlock",
+	6043: "SpikeBlockWall",
+	6044: "OfficeBathtub",
+	6045: "OfficeBed",
+	6046: "OfficeBookcase",
+	6047: "OfficeCandelabra",
+	6048: "OfficeCandle",
+	6049: "OfficeChair",
+	6050: "OfficeChandelier",
+	6051: "OfficeChest",
+	6052: "OfficeClock",
+	6053: "OfficeDoor",
+	6054: "OfficeDresser",
+	6055: "OfficeLamp",
+	6056: "OfficeLantern",
+	6057: "OfficePiano",
+	6058: "OfficePlatform",
+	6059: "OfficeSink",
+	6060: "OfficeSofa",
+	6061: "OfficeTable",
+	6062: "OfficeToilet",
+	6063: "OfficeWorkbench",
+	6064: "Fake_OfficeChest",
+	6065: "OfficeBlock",
+	6066: "OfficeBlockWall",
+	6067: "ForbiddenBathtub",
+	6068: "ForbiddenBed",
+	6069: "ForbiddenBookcase",
+	6070: "ForbiddenCandelabra",
+	6071: "ForbiddenCandle",
+	6072: "ForbiddenChair",
+	6073: "ForbiddenChandelier",
+	6074: "ForbiddenChest",
+	6075: "ForbiddenClock",
+	6076: "ForbiddenDoor",
+	6077: "ForbiddenDresser",
+	6078: "ForbiddenLamp",
+	6079: "ForbiddenLantern",
+	6080: "ForbiddenPiano",
+	6081: "ForbiddenPlatform",
+	6082: "ForbiddenSink",
+	6083: "ForbiddenSofa",
+	6084: "ForbiddenTable",
+	6085: "ForbiddenToilet",
+	6086: "ForbiddenWorkbench",
+	6087: "Fake_ForbiddenChest",
+	6088: "ForbiddenBlock",
+	6089: "ForbiddenBlockWall",
+	6090: "WaterBathtub",
+	6091: "WaterBed",
+	6092: "WaterBookcase",
+	6093: "WaterCandelabra",
+	6094: "WaterFurnitureCandle",
+	6095: "WaterChair",
+	6096: "WaterChandelier",
+	6097: "WaterClock",
+	6098: "WaterDoor",
+	6099: "WaterDresser",
+	6100: "WaterLamp",
+	6101: "WaterLantern",
+	6102: "WaterPiano",
+	6103: "WaterPlatform",
+	6104: "WaterSink",
+	6105: "WaterSofa",
+	6106: "WaterTable",
+	6107: "WaterToilet",
+	6108: "WaterWorkbench",
+	6109: "WaterBlock",
+	6110: "WaterBlockWall",
+	6111: "BoulderBathtub",
+	6112: "BoulderBed",
+	6113: "BoulderBookcase",
+	6114: "BoulderCandelabra",
+	6115: "BoulderCandle",
+	6116: "BoulderChair",
+	6117: "BoulderChandelier",
+	6118: "BoulderChest",
+	6119: "BoulderClock",
+	6120: "BoulderDoor",
+	6121: "BoulderDresser",
+	6122: "BoulderLamp",
+	6123: "BoulderLantern",
+	6124: "BoulderPiano",
+	6125: "BoulderPlatform",
+	6126: "BoulderSink",
+	6127: "BoulderSofa",
+	6128: "BoulderTable",
+	6129: "BoulderToilet",
+	6130: "BoulderWorkbench",
+	6131: "Fake_BoulderChest",
+	6132: "BoulderBlock",
+	6133: "BoulderBlockWall",
+	6134: "DamagingSpikeBlock",
+	6135: "DemonAltarIcon",
+	6136: "CrimsonAltarIcon",
+	6137: "LunasHead",
+	6138: "LunasBody",
+	6139: "LunasLegs",
+	6140: "LunasWings",
+	6141: "LunasCloak",
+	6142: "PalworldChilletEgg",
+	6143: "FoxparksTagEffect",
+	6144: "MusicBoxSkeletron",
+}
+
+// prefixNames gives the English name of each item prefix.
+var prefixNames = [84]string{
+	1:  "Large",
+	2:  "Massive",
+	3:  "Dangerous",
+	4:  "Savage",
+	5:  "Sharp",
+	6:  "Pointy",
+	7:  "Tiny",
+	8:  "Terrible",
+	9:  "Small",
+	10: "Dull",
+	11: "Unhappy",
+	12: "Bulky",
+	13: "Shameful",
+	14: "Heavy",
+	15: "Light",
+	16: "Sighted",
+	17: "Rapid",
+	18: "Hasty (R)",
+	19: "Intimidating",
+	20: "Deadly (R)",
+	21: "Staunch",
+	22: "Awful",
+	23: "Lethargic",
+	24: "Awkward",
+	25: "Powerful",
+	26: "Mystic",
+	27: "Adept",
+	28: "Masterful",
+	29: "Inept",
+	30: "Ignorant",
+	31: "Deranged",
+	32: "Intense",
+	33: "Taboo",
+	34: "Celestial",
+	35: "Furious",
+	36: "Keen",
+	37: "Superior",
+	38: "Forceful",
+	39: "Broken",
+	40: "Damaged",
+	41: "Shoddy",
+	42: "Quick (C)",
+	43: "Deadly (C)",
+	44: "Agile",
+	45: "Nimble",
+	46: "Murderous",
+	47: "Slow",
+	48: "Sluggish",
+	49: "Lazy",
+	50: "Annoying",
+	51: "Nasty",
+	52: "Manic",
+	53: "Hurtful",
+	54: "Strong",
+	55: "Unpleasant",
+	56: "Weak",
+	57: "Ruthless",
+	58: "Frenzying",
+	59: "Godly",
+	60: "Demonic",
+	61: "Zealous",
+	62: "Hard",
+	63: "Guarding",
+	64: "Armored",
+	65: "Warding",
+	66: "Arcane",
+	67: "Precise",
+	68: "Lucky",
+	69: "Jagged",
+	70: "Spiked",
+	71: "Angry",
+	72: "Menacing",
+	73: "Brisk",
+	74: "Fleeting",
+	75: "Hasty (A)",
+	76: "Quick (A)",
+	77: "Wild",
+	78: "Rash",
+	79: "Intrepid",
+	80: "Violent",
+	81: "Legendary",
+	82: "Unreal",
+	83: "Mythical",
+}
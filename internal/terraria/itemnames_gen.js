@@ -0,0 +1,42 @@
+// Writes itemnames.go from the item and prefix lists of the TerraMap
+// viewer. Run with go generate.
+const fs = require("fs");
+const vm = require("vm");
+
+const source = "../../static/terramap/resources/js/settings.js";
+const ctx = {};
+vm.createContext(ctx);
+vm.runInContext(fs.readFileSync(source, "utf8").replace(/^var settings/m, "this.settings"), ctx);
+
+const names = [];
+for (const item of ctx.settings.Items) {
+  const id = Number(item.Id);
+  if (id > 0 && names[id] === undefined) {
+    names[id] = item.Name;
+  }
+}
+
+let out = "// Code generated by itemnames_gen.js from " + source.replace("../../", "") + "; DO NOT EDIT.\n\n";
+out += "package terraria\n\n";
+out += "// itemNames gives the English name of each item ID.\n";
+out += `var itemNames = [${names.length}]string{\n`;
+names.forEach((name, id) => {
+  if (name !== undefined) {
+    out += `\t${id}: ${JSON.stringify(name)},\n`;
+  }
+});
+out += "}\n\n";
+
+const prefixes = [];
+for (const prefix of ctx.settings.ItemPrefix) {
+  prefixes[Number(prefix.Id)] = prefix.Name;
+}
+out += "// prefixNames gives the English name of each item prefix.\n";
+out += `var prefixNames = [${prefixes.length}]string{\n`;
+prefixes.forEach((name, id) => {
+  if (name) {
+    out += `\t${id}: ${JSON.stringify(name)},\n`;
+  }
+});
+out += "}\n";
+fs.writeFileSync("itemnames.go", out);
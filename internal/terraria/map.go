@@ -0,0 +1,233 @@
+package terraria
+
+//go:generate sh -c "node mapcolors_gen.js && gofmt -w mapcolors.go"
+
+import (
+	"image"
+	"image/color"
+	"io"
+	"os"
+)
+
+// Background colours of the map layers, as the TerraMap viewer draws them.
+var (
+	skyColor    = color.RGBA{155, 209, 255, 255}
+	earthColor  = color.RGBA{84, 57, 42, 255}
+	rockColor   = color.RGBA{72, 64, 57, 255}
+	hellColor   = color.RGBA{51, 0, 0, 255}
+	unknownTile = color.RGBA{255, 0, 255, 255}
+)
+
+// MaxMapPixels bounds the size of a rendered map. Worlds up to the largest
+// vanilla size, 8400x2400, are drawn with one pixel per tile and larger ones
+// with one pixel per square of tiles, so a huge modded world cannot make
+// the server allocate gigabytes for its image.
+const MaxMapPixels = 8400 * 2400
+
+// liquidAlpha is how opaque each liquid is drawn over what is behind it,
+// indexed like liquidColors.
+var liquidAlpha = [4]uint8{128, 240, 240, 200}
+
+// RenderMapFile draws the world file at path.
+func RenderMapFile(path string) (*image.RGBA, *WorldHeader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	return RenderMap(f)
+}
+
+// RenderMap draws a world in the colours of the in-game map, with one pixel
+// per tile unless that would exceed MaxMapPixels.
+func RenderMap(r io.Reader) (*image.RGBA, *WorldHeader, error) {
+	rd := newReader(r)
+	h, err := readWorldHeader(rd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := checkWorldSize(h); err != nil {
+		return nil, nil, err
+	}
+
+	width, height := int(h.Width), int(h.Height)
+	canvas := newMapCanvas(width, height)
+	col := make([]Tile, height)
+	for x := 0; x < width; x++ {
+		if err := readColumn(rd, h, col); err != nil {
+			return nil, nil, err
+		}
+		for y, t := range col {
+			canvas.set(x, y, tileColor(h, y, t))
+		}
+		canvas.endColumn(x)
+	}
+	if err := checkSection(rd, h, 2, "tiles"); err != nil {
+		return nil, nil, err
+	}
+	return canvas.img, h, nil
+}
+
+// mapScale returns how many tiles wide and high each pixel of the map of a
+// world of the given size covers.
+func mapScale(width, height int) int {
+	s := 1
+	for ((width+s-1)/s)*((height+s-1)/s) > MaxMapPixels {
+		s++
+	}
+	return s
+}
+
+// mapCanvas draws a map scale tiles to a pixel in each direction, averaging
+// the colours of the tiles a pixel covers. Tiles are added a column at a
+// time, left to right, so only one column of pixels is pending at once.
+type mapCanvas struct {
+	img   *image.RGBA
+	width int
+	scale int
+	// sums adds up the red, green and blue of the tiles under each pixel
+	// of the pending column, with the number of tiles last.
+	sums [][4]int
+	// marks holds colours set with mark, which win over the average.
+	marks []color.RGBA
+}
+
+func newMapCanvas(width, height int) *mapCanvas {
+	s := mapScale(width, height)
+	w, h := (width+s-1)/s, (height+s-1)/s
+	return &mapCanvas{
+		img:   image.NewRGBA(image.Rect(0, 0, w, h)),
+		width: width,
+		scale: s,
+		sums:  make([][4]int, h),
+		marks: make([]color.RGBA, h),
+	}
+}
+
+// set adds the colour of the tile at x, y.
+func (m *mapCanvas) set(x, y int, c color.RGBA) {
+	if m.scale == 1 {
+		m.img.SetRGBA(x, y, c)
+		return
+	}
+	sum := &m.sums[y/m.scale]
+	sum[0] += int(c.R)
+	sum[1] += int(c.G)
+	sum[2] += int(c.B)
+	sum[3]++
+}
+
+// mark draws the pixel covering x, y in c whatever the other tiles under it
+// are, so single tiles stay visible on a scaled-down map.
+func (m *mapCanvas) mark(x, y int, c color.RGBA) {
+	if m.scale == 1 {
+		m.img.SetRGBA(x, y, c)
+		return
+	}
+	m.marks[y/m.scale] = c
+}
+
+// endColumn is called after the last tile of column x, and draws the
+// pending column of pixels once all the tiles they cover are in.
+func (m *mapCanvas) endColumn(x int) {
+	if m.scale == 1 || ((x+1)%m.scale != 0 && x+1 != m.width) {
+		return
+	}
+	px := x / m.scale
+	for py := range m.sums {
+		sum := &m.sums[py]
+		switch {
+		case m.marks[py].A != 0:
+			m.img.SetRGBA(px, py, m.marks[py])
+		case sum[3] > 0:
+			n := sum[3]
+			m.img.SetRGBA(px, py, color.RGBA{uint8(sum[0] / n), uint8(sum[1] / n), uint8(sum[2] / n), 255})
+		}
+		*sum = [4]int{}
+		m.marks[py] = color.RGBA{}
+	}
+}
+
+func tileColor(h *WorldHeader, y int, t Tile) color.RGBA {
+	if t.Active {
+		if int(t.Type) >= len(tileColors) {
+			return unknownTile
+		}
+		if c := tileColors[t.Type]; c.A != 0 {
+			return c
+		}
+	}
+
+	c := backgroundColor(h, y)
+	if int(t.Wall) < len(wallColors) && wallColors[t.Wall].A != 0 {
+		c = wallColors[t.Wall]
+	}
+	if t.Liquid != LiquidNone && t.LiquidAmount > 0 {
+		i := t.Liquid - 1
+		c = blend(c, liquidColors[i], liquidAlpha[i])
+	}
+	return c
+}
+
+func backgroundColor(h *WorldHeader, y int) color.RGBA {
+	switch {
+	case float64(y) < h.SurfaceLevel:
+		return skyColor
+	case float64(y) < h.CavernLevel:
+		return earthColor
+	case y < int(h.Height)-200:
+		return rockColor
+	}
+	return hellColor
+}
+
+func blend(under, over color.RGBA, alpha uint8) color.RGBA {
+	mix := func(a, b uint8) uint8 {
+		return uint8((int(a)*(255-int(alpha)) + int(b)*int(alpha)) / 255)
+	}
+	return color.RGBA{mix(under.R, over.R), mix(under.G, over.G), mix(under.B, over.B), 255}
+}
+
+// Thumbnail shrinks img to fit within maxWidth by maxHeight, averaging the
+// pixels each thumbnail pixel covers.
+func Thumbnail(img *image.RGBA, maxWidth, maxHeight int) *image.RGBA {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	scale := 1.0
+	if w > maxWidth {
+		scale = float64(maxWidth) / float64(w)
+	}
+	if float64(h)*scale > float64(maxHeight) {
+		scale = float64(maxHeight) / float64(h)
+	}
+	tw, th := int(float64(w)*scale), int(float64(h)*scale)
+	if tw < 1 {
+		tw = 1
+	}
+	if th < 1 {
+		th = 1
+	}
+
+	thumb := image.NewRGBA(image.Rect(0, 0, tw, th))
+	for ty := 0; ty < th; ty++ {
+		y0, y1 := ty*h/th, (ty+1)*h/th
+		for tx := 0; tx < tw; tx++ {
+			x0, x1 := tx*w/tw, (tx+1)*w/tw
+			var r, g, bl, n int
+			for y := y0; y < y1; y++ {
+				for x := x0; x < x1; x++ {
+					c := img.RGBAAt(b.Min.X+x, b.Min.Y+y)
+					r += int(c.R)
+					g += int(c.G)
+					bl += int(c.B)
+					n++
+				}
+			}
+			if n > 0 {
+				thumb.SetRGBA(tx, ty, color.RGBA{uint8(r / n), uint8(g / n), uint8(bl / n), 255})
+			}
+		}
+	}
+	return thumb
+}
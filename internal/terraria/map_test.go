@@ -0,0 +1,292 @@
+package terraria
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMapScale(t *testing.T) {
+	tests := []struct {
+		width, height, want int
+	}{
+		{8, 6, 1},
+		{8400, 2400, 1},
+		{8401, 2400, 2},
+		{16384, 1300, 2},
+		{16384, 16384, 4},
+	}
+	for _, tt := range tests {
+		if got := mapScale(tt.width, tt.height); got != tt.want {
+			t.Errorf("mapScale(%d, %d) = %d, want %d", tt.width, tt.height, got, tt.want)
+		}
+	}
+}
+
+func TestReadColumn(t *testing.T) {
+	// Type 21 (chests) is framed; 700 needs two bytes, as does wall 400.
+	importance := make([]bool, 22)
+	importance[21] = true
+	tiles := map[[2]int]Tile{
+		{0, 0}: {Active: true, Type: 21, U: 18, V: 36},
+		{0, 1}: {Active: true, Type: 700, Inactive: true},
+		{0, 2}: {Wall: 400},
+		{1, 0}: {Liquid: LiquidShimmer, LiquidAmount: 255},
+		{1, 1}: {Liquid: LiquidHoney, LiquidAmount: 12, Wall: 4},
+	}
+	tw := testWorld{
+		height:     300,
+		importance: importance,
+		tile: func(x, y int) Tile {
+			if t, ok := tiles[[2]int{x, y}]; ok {
+				return t
+			}
+			// A long run in column 2 takes a two-byte count.
+			if x == 2 {
+				return Tile{Active: true, Type: 1}
+			}
+			return Tile{}
+		},
+	}
+	data := tw.build()
+
+	rd := newReader(bytes.NewReader(data))
+	h, err := readWorldHeader(rd)
+	if err != nil {
+		t.Fatalf("readWorldHeader: %v", err)
+	}
+	col := make([]Tile, h.Height)
+	for x := 0; x < int(h.Width); x++ {
+		if err := readColumn(rd, h, col); err != nil {
+			t.Fatalf("column %d: %v", x, err)
+		}
+		for y, got := range col {
+			if want := tw.at(x, y); got != want {
+				t.Fatalf("tile %d,%d = %+v, want %+v", x, y, got, want)
+			}
+		}
+	}
+	if err := checkSection(rd, h, 2, "tiles"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRenderMap(t *testing.T) {
+	water := Tile{Liquid: LiquidWater, LiquidAmount: 255}
+	tw := testWorld{
+		tile: func(x, y int) Tile {
+			switch x {
+			case 1:
+				return Tile{Active: true, Type: 0}
+			case 2:
+				return Tile{Active: true, Type: uint16(len(tileColors))}
+			case 3:
+				return water
+			}
+			return Tile{}
+		},
+	}
+	img, h, err := RenderMap(bytes.NewReader(tw.build()))
+	if err != nil {
+		t.Fatalf("RenderMap: %v", err)
+	}
+	if got := img.Bounds(); got != image.Rect(0, 0, 8, 6) {
+		t.Fatalf("bounds = %v, want 8x6", got)
+	}
+
+	// The empty column shows the sky above the surface at 2, the earth
+	// above the caverns at 4, and the underworld below, as the world is
+	// less than 200 tiles high.
+	for y, want := range []color.RGBA{skyColor, skyColor, earthColor, earthColor, hellColor, hellColor} {
+		if got := img.RGBAAt(0, y); got != want {
+			t.Errorf("background at row %d = %v, want %v", y, got, want)
+		}
+	}
+	if got := img.RGBAAt(1, 4); got != tileColors[0] {
+		t.Errorf("dirt = %v, want %v", got, tileColors[0])
+	}
+	if got := img.RGBAAt(2, 0); got != unknownTile {
+		t.Errorf("unknown tile = %v, want %v", got, unknownTile)
+	}
+	if got, want := img.RGBAAt(3, 0), blend(skyColor, liquidColors[0], liquidAlpha[0]); got != want {
+		t.Errorf("water = %v, want %v", got, want)
+	}
+	if h.Width != 8 || h.Height != 6 {
+		t.Errorf("header size %dx%d", h.Width, h.Height)
+	}
+}
+
+func TestRenderMapScalesLargeWorlds(t *testing.T) {
+	// Dirt in the top two rows of every even column, over the sky.
+	tw := testWorld{
+		width:   16383,
+		height:  1300,
+		surface: 1000,
+		cavern:  1100,
+		tile: func(x, y int) Tile {
+			return Tile{Active: x%2 == 0 && y < 2}
+		},
+	}
+	img, _, err := RenderMap(bytes.NewReader(tw.build()))
+	if err != nil {
+		t.Fatalf("RenderMap: %v", err)
+	}
+	if got := img.Bounds(); got != image.Rect(0, 0, 8192, 650) {
+		t.Fatalf("bounds = %v, want 8192x650", got)
+	}
+
+	dirt := tileColors[0]
+	mixed := color.RGBA{
+		uint8((int(dirt.R) + int(skyColor.R)) / 2),
+		uint8((int(dirt.G) + int(skyColor.G)) / 2),
+		uint8((int(dirt.B) + int(skyColor.B)) / 2),
+		255,
+	}
+	if got := img.RGBAAt(0, 0); got != mixed {
+		t.Errorf("pixel over dirt and sky = %v, want their average %v", got, mixed)
+	}
+	if got := img.RGBAAt(0, 1); got != skyColor {
+		t.Errorf("pixel over sky = %v, want %v", got, skyColor)
+	}
+	// The last pixel covers only the last column, an even one of dirt.
+	if got := img.RGBAAt(8191, 0); got != dirt {
+		t.Errorf("last pixel = %v, want %v", got, dirt)
+	}
+}
+
+func TestMapCanvasMarks(t *testing.T) {
+	m := &mapCanvas{
+		img:   image.NewRGBA(image.Rect(0, 0, 2, 1)),
+		width: 3,
+		scale: 2,
+		sums:  make([][4]int, 1),
+		marks: make([]color.RGBA, 1),
+	}
+	for x := 0; x < 3; x++ {
+		for y := 0; y < 2; y++ {
+			m.set(x, y, skyColor)
+		}
+		if x == 1 {
+			m.mark(x, 1, addedColor)
+		}
+		m.endColumn(x)
+	}
+	if got := m.img.RGBAAt(0, 0); got != addedColor {
+		t.Errorf("marked pixel = %v, want %v", got, addedColor)
+	}
+	if got := m.img.RGBAAt(1, 0); got != skyColor {
+		t.Errorf("pixel after a marked one = %v, want %v", got, skyColor)
+	}
+}
+
+func TestRenderMapTruncated(t *testing.T) {
+	world := testWorld{
+		tile: func(x, y int) Tile { return Tile{Active: (x+y)%2 == 0, Wall: uint16(x)} },
+	}.build()
+	tilesAt := int(binary.LittleEndian.Uint32(world[26+4:]))
+	chestsAt := int(binary.LittleEndian.Uint32(world[26+8:]))
+	for n := tilesAt; n < chestsAt; n++ {
+		if _, _, err := RenderMap(bytes.NewReader(world[:n])); err == nil {
+			t.Fatalf("world cut to %d of %d tile bytes was drawn", n-tilesAt, chestsAt-tilesAt)
+		}
+	}
+}
+
+func TestThumbnail(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 640, 100))
+	if got := Thumbnail(img, ThumbnailWidth, ThumbnailHeight).Bounds(); got != image.Rect(0, 0, 320, 50) {
+		t.Errorf("wide thumbnail = %v, want 320x50", got)
+	}
+	img = image.NewRGBA(image.Rect(0, 0, 100, 600))
+	if got := Thumbnail(img, ThumbnailWidth, ThumbnailHeight).Bounds(); got != image.Rect(0, 0, 20, 120) {
+		t.Errorf("tall thumbnail = %v, want 20x120", got)
+	}
+}
+
+func cacheFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+func TestMapCache(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	first := writeTemp(t, "first.wld", testWorld{name: "First"}.build())
+	second := writeTemp(t, "second.wld", testWorld{name: "Second"}.build())
+
+	// Any limit is exceeded by two maps, so only the latest one is kept.
+	c := NewMapCache(dir, 1)
+	firstMap, firstSum, err := c.Map(first)
+	if err != nil {
+		t.Fatalf("Map: %v", err)
+	}
+	if want := filepath.Join(dir, firstSum+".png"); firstMap != want {
+		t.Errorf("Map = %s, want %s", firstMap, want)
+	}
+	thumb, sum, err := c.Thumbnail(first)
+	if err != nil || sum != firstSum || thumb != filepath.Join(dir, firstSum+"-thumb.png") {
+		t.Errorf("Thumbnail = %s, %s, %v", thumb, sum, err)
+	}
+
+	_, secondSum, err := c.Map(second)
+	if err != nil {
+		t.Fatalf("Map: %v", err)
+	}
+	if got, want := cacheFiles(t, dir), []string{secondSum + "-thumb.png", secondSum + ".png"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("cache after two maps = %v, want %v", got, want)
+	}
+
+	// A pruned map is drawn again when asked for.
+	if _, err := os.Stat(firstMap); !os.IsNotExist(err) {
+		t.Fatalf("first map kept: %v", err)
+	}
+	if path, _, err := c.Map(first); err != nil || path != firstMap {
+		t.Fatalf("Map after pruning = %s, %v", path, err)
+	}
+	if _, err := os.Stat(firstMap); err != nil {
+		t.Errorf("map not drawn again: %v", err)
+	}
+}
+
+func TestMapCacheDiff(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	before := writeTemp(t, "before.wld", testWorld{}.build())
+	after := writeTemp(t, "after.wld", testWorld{
+		tile: func(x, y int) Tile { return Tile{Active: x == 0 && y == 0} },
+	}.build())
+
+	c := NewMapCache(dir, 0)
+	path, d, err := c.Diff(before, after)
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	if d.Added != 1 {
+		t.Errorf("added = %d, want 1", d.Added)
+	}
+
+	// Without its image, a cached summary is not used.
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.cachedDiff(path[:len(path)-len(".png")]); ok {
+		t.Error("cached diff used without its image")
+	}
+	if again, d, err := c.Diff(before, after); err != nil || again != path || d.Added != 1 {
+		t.Fatalf("Diff again = %s, %+v, %v", again, d, err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("diff image not drawn again: %v", err)
+	}
+}
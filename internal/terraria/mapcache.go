@@ -0,0 +1,295 @@
+package terraria
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Thumbnail bounds used by MapCache.
+const (
+	ThumbnailWidth  = 320
+	ThumbnailHeight = 120
+)
+
+// maxHashes bounds how many file hashes MapCache remembers.
+const maxHashes = 4096
+
+// MapCache renders world maps and keeps the PNGs on disk, named after the
+// SHA-256 of the world file, so a world is only drawn once however many
+// records point at it. Once the cache holds more than MaxBytes, the files
+// used least recently are removed.
+type MapCache struct {
+	Dir      string
+	MaxBytes int64
+
+	mu     sync.Mutex
+	hashes map[string]hashEntry
+	// render serialises rendering, which holds a whole world in memory.
+	render sync.Mutex
+}
+
+type hashEntry struct {
+	size    int64
+	modTime time.Time
+	sum     string
+}
+
+func NewMapCache(dir string, maxBytes int64) *MapCache {
+	return &MapCache{Dir: dir, MaxBytes: maxBytes, hashes: make(map[string]hashEntry)}
+}
+
+// Map returns the path of the full-size map PNG of the world at path and
+// the world's content hash, rendering the map first if needed.
+func (c *MapCache) Map(path string) (string, string, error) {
+	return c.get(path, "")
+}
+
+// Thumbnail is like Map for the thumbnail PNG.
+func (c *MapCache) Thumbnail(path string) (string, string, error) {
+	return c.get(path, "-thumb")
+}
+
+func (c *MapCache) get(path, suffix string) (string, string, error) {
+	sum, err := c.hash(path)
+	if err != nil {
+		return "", "", err
+	}
+
+	out := filepath.Join(c.Dir, sum+suffix+".png")
+	if c.used(out) {
+		return out, sum, nil
+	}
+
+	c.render.Lock()
+	defer c.render.Unlock()
+
+	// Another request may have drawn it while this one waited.
+	if c.used(out) {
+		return out, sum, nil
+	}
+
+	img, _, err := RenderMapFile(path)
+	if err != nil {
+		return "", "", err
+	}
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return "", "", err
+	}
+	if err := writePNG(filepath.Join(c.Dir, sum+".png"), img); err != nil {
+		return "", "", err
+	}
+	thumb := Thumbnail(img, ThumbnailWidth, ThumbnailHeight)
+	if err := writePNG(filepath.Join(c.Dir, sum+"-thumb.png"), thumb); err != nil {
+		return "", "", err
+	}
+	c.prune(filepath.Join(c.Dir, sum+".png"), filepath.Join(c.Dir, sum+"-thumb.png"))
+	return out, sum, nil
+}
+
+// Diff compares the worlds at oldPath and newPath, returning the path of the
+// overlay PNG and the summary. Both are kept on disk under the pair of
+// content hashes.
+func (c *MapCache) Diff(oldPath, newPath string) (string, *Diff, error) {
+	oldSum, err := c.hash(oldPath)
+	if err != nil {
+		return "", nil, err
+	}
+	newSum, err := c.hash(newPath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	base := filepath.Join(c.Dir, oldSum+"-"+newSum+"-diff")
+	if d, ok := c.cachedDiff(base); ok {
+		return base + ".png", d, nil
+	}
+
+	c.render.Lock()
+	defer c.render.Unlock()
+
+	if d, ok := c.cachedDiff(base); ok {
+		return base + ".png", d, nil
+	}
+
+	d, img, err := DiffFiles(oldPath, newPath)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return "", nil, err
+	}
+	if err := writePNG(base+".png", img); err != nil {
+		return "", nil, err
+	}
+	// The summary is written last, so its presence means both are complete.
+	data, err := json.Marshal(d)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := writeFile(base+".json", data); err != nil {
+		return "", nil, err
+	}
+	c.prune(base+".png", base+".json")
+	return base + ".png", d, nil
+}
+
+// cachedDiff returns the diff kept under base, if both its files are there.
+func (c *MapCache) cachedDiff(base string) (*Diff, bool) {
+	d, err := readDiff(base + ".json")
+	if err != nil || !c.used(base+".png") {
+		return nil, false
+	}
+	c.used(base + ".json")
+	return d, true
+}
+
+// used reports whether the cached file at path exists, and marks it as
+// recently used so pruning keeps it.
+func (c *MapCache) used(path string) bool {
+	now := time.Now()
+	return os.Chtimes(path, now, now) == nil
+}
+
+// prune removes the least recently used files until the cache fits in
+// MaxBytes. The files in keep, just written, are never removed.
+func (c *MapCache) prune(keep ...string) {
+	if c.MaxBytes <= 0 {
+		return
+	}
+	entries, err := os.ReadDir(c.Dir)
+	if err != nil {
+		log.Printf("Failed to list map cache %s: %v", c.Dir, err)
+		return
+	}
+
+	type cached struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var files []cached
+	var total int64
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		files = append(files, cached{filepath.Join(c.Dir, e.Name()), info.Size(), info.ModTime()})
+		total += info.Size()
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+
+	for _, f := range files {
+		if total <= c.MaxBytes {
+			break
+		}
+		if slices.Contains(keep, f.path) {
+			continue
+		}
+		if err := os.Remove(f.path); err != nil {
+			log.Printf("Failed to prune map cache: %v", err)
+			continue
+		}
+		total -= f.size
+	}
+}
+
+func readDiff(path string) (*Diff, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var d Diff
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// hash returns the SHA-256 of the file at path, remembered until the file's
+// size or modification time changes.
+func (c *MapCache) hash(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	entry, ok := c.hashes[path]
+	c.mu.Unlock()
+	if ok && entry.size == info.Size() && entry.modTime.Equal(info.ModTime()) {
+		return entry.sum, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	sum := hex.EncodeToString(h.Sum(nil))
+
+	c.mu.Lock()
+	if len(c.hashes) >= maxHashes {
+		for p := range c.hashes {
+			delete(c.hashes, p)
+			break
+		}
+	}
+	c.hashes[path] = hashEntry{size: info.Size(), modTime: info.ModTime(), sum: sum}
+	c.mu.Unlock()
+	return sum, nil
+}
+
+// writePNG writes img through a temporary file so readers never see a
+// partial image.
+func writePNG(path string, img image.Image) error {
+	return writeAtomic(path, func(w io.Writer) error {
+		if err := png.Encode(w, img); err != nil {
+			return fmt.Errorf("encode %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+func writeFile(path string, data []byte) error {
+	return writeAtomic(path, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+func writeAtomic(path string, write func(io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".map-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,1091 @@
+// Code generated by mapcolors_gen.js from static/terramap/resources/js/MapHelper.js; DO NOT EDIT.
+
+package terraria
+
+import "image/color"
+
+// tileColors and wallColors give the map colour of each tile and wall type.
+// Types without a colour are left transparent.
+var tileColors = [753]color.RGBA{
+	0:   {151, 107, 75, 255},
+	1:   {128, 128, 128, 255},
+	2:   {28, 216, 94, 255},
+	3:   {26, 196, 84, 255},
+	4:   {253, 221, 3, 255},
+	5:   {151, 107, 75, 255},
+	6:   {140, 101, 80, 255},
+	7:   {150, 67, 22, 255},
+	8:   {185, 164, 23, 255},
+	9:   {185, 194, 195, 255},
+	10:  {119, 105, 79, 255},
+	11:  {119, 105, 79, 255},
+	12:  {174, 24, 69, 255},
+	13:  {133, 213, 247, 255},
+	14:  {191, 142, 111, 255},
+	15:  {191, 142, 111, 255},
+	16:  {140, 130, 116, 255},
+	17:  {144, 148, 144, 255},
+	18:  {191, 142, 111, 255},
+	19:  {191, 142, 111, 255},
+	20:  {163, 116, 81, 255},
+	21:  {174, 129, 92, 255},
+	22:  {98, 95, 167, 255},
+	23:  {141, 137, 223, 255},
+	24:  {122, 116, 218, 255},
+	25:  {109, 90, 128, 255},
+	26:  {119, 101, 125, 255},
+	27:  {54, 154, 54, 255},
+	28:  {151, 79, 80, 255},
+	29:  {175, 105, 128, 255},
+	30:  {151, 107, 75, 255},
+	31:  {141, 120, 168, 255},
+	32:  {151, 135, 183, 255},
+	33:  {253, 221, 3, 255},
+	34:  {235, 166, 135, 255},
+	35:  {226, 145, 30, 255},
+	36:  {230, 89, 92, 255},
+	37:  {104, 86, 84, 255},
+	38:  {128, 128, 128, 255},
+	39:  {181, 62, 59, 255},
+	40:  {146, 81, 68, 255},
+	41:  {66, 84, 109, 255},
+	42:  {251, 235, 127, 255},
+	43:  {84, 100, 63, 255},
+	44:  {107, 68, 99, 255},
+	45:  {185, 164, 23, 255},
+	46:  {185, 194, 195, 255},
+	47:  {150, 67, 22, 255},
+	48:  {128, 128, 128, 255},
+	49:  {89, 201, 255, 255},
+	50:  {170, 48, 114, 255},
+	51:  {192, 202, 203, 255},
+	52:  {23, 177, 76, 255},
+	53:  {186, 168, 84, 255},
+	54:  {200, 246, 254, 255},
+	55:  {191, 142, 111, 255},
+	56:  {43, 40, 84, 255},
+	57:  {68, 68, 76, 255},
+	58:  {142, 66, 66, 255},
+	59:  {92, 68, 73, 255},
+	60:  {143, 215, 29, 255},
+	61:  {135, 196, 26, 255},
+	62:  {121, 176, 24, 255},
+	63:  {110, 140, 182, 255},
+	64:  {196, 96, 114, 255},
+	65:  {56, 150, 97, 255},
+	66:  {160, 118, 58, 255},
+	67:  {140, 58, 166, 255},
+	68:  {125, 191, 197, 255},
+	69:  {190, 150, 92, 255},
+	70:  {93, 127, 255, 255},
+	71:  {182, 175, 130, 255},
+	72:  {182, 175, 130, 255},
+	73:  {27, 197, 109, 255},
+	74:  {96, 197, 27, 255},
+	75:  {26, 26, 26, 255},
+	76:  {142, 66, 66, 255},
+	77:  {238, 85, 70, 255},
+	78:  {121, 110, 97, 255},
+	79:  {191, 142, 111, 255},
+	80:  {73, 120, 17, 255},
+	81:  {245, 133, 191, 255},
+	82:  {246, 197, 26, 255},
+	83:  {246, 197, 26, 255},
+	84:  {246, 197, 26, 255},
+	85:  {192, 192, 192, 255},
+	86:  {191, 142, 111, 255},
+	87:  {191, 142, 111, 255},
+	88:  {191, 142, 111, 255},
+	89:  {191, 142, 111, 255},
+	90:  {144, 148, 144, 255},
+	91:  {13, 88, 130, 255},
+	92:  {213, 229, 237, 255},
+	93:  {253, 221, 3, 255},
+	94:  {191, 142, 111, 255},
+	95:  {255, 162, 31, 255},
+	96:  {144, 148, 144, 255},
+	97:  {144, 148, 144, 255},
+	98:  {253, 221, 3, 255},
+	99:  {144, 148, 144, 255},
+	100: {253, 221, 3, 255},
+	101: {191, 142, 111, 255},
+	102: {229, 212, 73, 255},
+	103: {141, 98, 77, 255},
+	104: {191, 142, 111, 255},
+	105: {144, 148, 144, 255},
+	106: {191, 142, 111, 255},
+	107: {11, 80, 143, 255},
+	108: {91, 169, 169, 255},
+	109: {78, 193, 227, 255},
+	110: {48, 186, 135, 255},
+	111: {128, 26, 52, 255},
+	112: {103, 98, 122, 255},
+	113: {48, 208, 234, 255},
+	114: {191, 142, 111, 255},
+	115: {33, 171, 207, 255},
+	116: {238, 225, 218, 255},
+	117: {181, 172, 190, 255},
+	118: {238, 225, 218, 255},
+	119: {107, 92, 108, 255},
+	120: {92, 68, 73, 255},
+	121: {11, 80, 143, 255},
+	122: {91, 169, 169, 255},
+	123: {106, 107, 118, 255},
+	124: {73, 51, 36, 255},
+	125: {141, 175, 255, 255},
+	126: {159, 209, 229, 255},
+	128: {191, 142, 111, 255},
+	129: {255, 117, 224, 255},
+	130: {128, 128, 128, 255},
+	131: {52, 52, 52, 255},
+	132: {144, 148, 144, 255},
+	133: {231, 53, 56, 255},
+	134: {166, 187, 153, 255},
+	136: {213, 203, 204, 255},
+	137: {144, 148, 144, 255},
+	138: {128, 128, 128, 255},
+	139: {191, 142, 111, 255},
+	140: {98, 95, 167, 255},
+	141: {192, 59, 59, 255},
+	142: {144, 148, 144, 255},
+	143: {144, 148, 144, 255},
+	144: {144, 148, 144, 255},
+	145: {192, 30, 30, 255},
+	146: {43, 192, 30, 255},
+	147: {211, 236, 241, 255},
+	148: {211, 236, 241, 255},
+	149: {220, 50, 50, 255},
+	150: {128, 26, 52, 255},
+	151: {190, 171, 94, 255},
+	152: {128, 133, 184, 255},
+	153: {239, 141, 126, 255},
+	154: {190, 171, 94, 255},
+	155: {131, 162, 161, 255},
+	156: {170, 171, 157, 255},
+	157: {104, 100, 126, 255},
+	158: {145, 81, 85, 255},
+	159: {148, 133, 98, 255},
+	161: {144, 195, 232, 255},
+	162: {184, 219, 240, 255},
+	163: {174, 145, 214, 255},
+	164: {218, 182, 204, 255},
+	165: {115, 173, 229, 255},
+	166: {129, 125, 93, 255},
+	167: {62, 82, 114, 255},
+	168: {132, 157, 127, 255},
+	169: {152, 171, 198, 255},
+	170: {27, 109, 69, 255},
+	171: {33, 135, 85, 255},
+	172: {191, 142, 111, 255},
+	173: {253, 221, 3, 255},
+	174: {253, 221, 3, 255},
+	175: {129, 125, 93, 255},
+	176: {132, 157, 127, 255},
+	177: {152, 171, 198, 255},
+	178: {208, 94, 201, 255},
+	179: {49, 134, 114, 255},
+	180: {126, 134, 49, 255},
+	181: {134, 59, 49, 255},
+	182: {43, 86, 140, 255},
+	183: {121, 49, 134, 255},
+	184: {29, 106, 88, 255},
+	185: {99, 99, 99, 255},
+	186: {99, 99, 99, 255},
+	187: {99, 99, 99, 255},
+	188: {73, 120, 17, 255},
+	189: {223, 255, 255, 255},
+	190: {182, 175, 130, 255},
+	191: {151, 107, 75, 255},
+	192: {26, 196, 84, 255},
+	193: {56, 121, 255, 255},
+	194: {157, 157, 107, 255},
+	195: {134, 22, 34, 255},
+	196: {147, 144, 178, 255},
+	197: {97, 200, 225, 255},
+	198: {62, 61, 52, 255},
+	199: {208, 80, 80, 255},
+	200: {216, 152, 144, 255},
+	201: {203, 61, 64, 255},
+	202: {213, 178, 28, 255},
+	203: {128, 44, 45, 255},
+	204: {125, 55, 65, 255},
+	205: {186, 50, 52, 255},
+	206: {124, 175, 201, 255},
+	207: {144, 148, 144, 255},
+	208: {88, 105, 118, 255},
+	209: {144, 148, 144, 255},
+	211: {191, 233, 115, 255},
+	212: {144, 148, 144, 255},
+	213: {137, 120, 67, 255},
+	214: {103, 103, 103, 255},
+	215: {254, 121, 2, 255},
+	216: {191, 142, 111, 255},
+	217: {144, 148, 144, 255},
+	218: {144, 148, 144, 255},
+	219: {144, 148, 144, 255},
+	220: {144, 148, 144, 255},
+	221: {239, 90, 50, 255},
+	222: {231, 96, 228, 255},
+	223: {57, 85, 101, 255},
+	224: {107, 132, 139, 255},
+	225: {227, 125, 22, 255},
+	226: {141, 56, 0, 255},
+	227: {74, 197, 155, 255},
+	228: {144, 148, 144, 255},
+	229: {255, 156, 12, 255},
+	230: {131, 79, 13, 255},
+	231: {224, 194, 101, 255},
+	232: {145, 81, 85, 255},
+	233: {107, 182, 29, 255},
+	234: {53, 44, 41, 255},
+	235: {214, 184, 46, 255},
+	236: {149, 232, 87, 255},
+	237: {255, 241, 51, 255},
+	238: {225, 128, 206, 255},
+	239: {224, 194, 101, 255},
+	240: {120, 85, 60, 255},
+	241: {77, 74, 72, 255},
+	242: {99, 50, 30, 255},
+	243: {198, 196, 170, 255},
+	244: {200, 245, 253, 255},
+	245: {99, 50, 30, 255},
+	246: {99, 50, 30, 255},
+	247: {140, 150, 150, 255},
+	248: {219, 71, 38, 255},
+	249: {235, 38, 231, 255},
+	250: {86, 85, 92, 255},
+	251: {235, 150, 23, 255},
+	252: {153, 131, 44, 255},
+	253: {57, 48, 97, 255},
+	254: {248, 158, 92, 255},
+	255: {107, 49, 154, 255},
+	256: {154, 148, 49, 255},
+	257: {49, 49, 154, 255},
+	258: {49, 154, 68, 255},
+	259: {154, 49, 77, 255},
+	260: {85, 89, 118, 255},
+	261: {154, 83, 49, 255},
+	262: {221, 79, 255, 255},
+	263: {250, 255, 79, 255},
+	264: {79, 102, 255, 255},
+	265: {79, 255, 89, 255},
+	266: {255, 79, 79, 255},
+	267: {240, 240, 247, 255},
+	268: {255, 145, 79, 255},
+	269: {191, 142, 111, 255},
+	270: {187, 255, 107, 255},
+	271: {107, 250, 255, 255},
+	272: {121, 119, 101, 255},
+	273: {128, 128, 128, 255},
+	274: {190, 171, 94, 255},
+	275: {122, 217, 232, 255},
+	276: {122, 217, 232, 255},
+	277: {122, 217, 232, 255},
+	278: {122, 217, 232, 255},
+	279: {122, 217, 232, 255},
+	280: {122, 217, 232, 255},
+	281: {122, 217, 232, 255},
+	282: {122, 217, 232, 255},
+	283: {128, 128, 128, 255},
+	284: {150, 67, 22, 255},
+	285: {122, 217, 232, 255},
+	286: {122, 217, 232, 255},
+	287: {79, 128, 17, 255},
+	288: {122, 217, 232, 255},
+	289: {122, 217, 232, 255},
+	290: {122, 217, 232, 255},
+	291: {122, 217, 232, 255},
+	292: {122, 217, 232, 255},
+	293: {122, 217, 232, 255},
+	294: {122, 217, 232, 255},
+	295: {122, 217, 232, 255},
+	296: {122, 217, 232, 255},
+	297: {122, 217, 232, 255},
+	298: {122, 217, 232, 255},
+	299: {122, 217, 232, 255},
+	300: {144, 148, 144, 255},
+	301: {144, 148, 144, 255},
+	302: {144, 148, 144, 255},
+	303: {144, 148, 144, 255},
+	304: {144, 148, 144, 255},
+	305: {144, 148, 144, 255},
+	306: {144, 148, 144, 255},
+	307: {144, 148, 144, 255},
+	308: {144, 148, 144, 255},
+	309: {122, 217, 232, 255},
+	310: {122, 217, 232, 255},
+	311: {117, 61, 25, 255},
+	312: {204, 93, 73, 255},
+	313: {87, 150, 154, 255},
+	314: {181, 164, 125, 255},
+	315: {235, 114, 80, 255},
+	316: {157, 176, 226, 255},
+	317: {118, 227, 129, 255},
+	318: {227, 118, 215, 255},
+	319: {96, 68, 48, 255},
+	320: {203, 185, 151, 255},
+	321: {96, 77, 64, 255},
+	322: {198, 170, 104, 255},
+	323: {182, 141, 86, 255},
+	324: {228, 213, 173, 255},
+	325: {129, 125, 93, 255},
+	326: {9, 61, 191, 255},
+	327: {253, 32, 3, 255},
+	328: {200, 246, 254, 255},
+	329: {15, 15, 15, 255},
+	330: {226, 118, 76, 255},
+	331: {161, 172, 173, 255},
+	332: {204, 181, 72, 255},
+	333: {190, 190, 178, 255},
+	334: {191, 142, 111, 255},
+	335: {217, 174, 137, 255},
+	336: {253, 62, 3, 255},
+	337: {144, 148, 144, 255},
+	338: {85, 255, 160, 255},
+	339: {122, 217, 232, 255},
+	340: {96, 248, 2, 255},
+	341: {105, 74, 202, 255},
+	342: {29, 240, 255, 255},
+	343: {254, 202, 80, 255},
+	344: {131, 252, 245, 255},
+	345: {255, 156, 12, 255},
+	346: {149, 212, 89, 255},
+	347: {236, 74, 79, 255},
+	348: {44, 26, 233, 255},
+	349: {144, 148, 144, 255},
+	350: {55, 97, 155, 255},
+	351: {31, 31, 31, 255},
+	352: {238, 97, 94, 255},
+	353: {28, 216, 94, 255},
+	354: {141, 107, 89, 255},
+	355: {141, 107, 89, 255},
+	356: {233, 203, 24, 255},
+	357: {168, 178, 204, 255},
+	358: {122, 217, 232, 255},
+	359: {122, 217, 232, 255},
+	360: {122, 217, 232, 255},
+	361: {122, 217, 232, 255},
+	362: {122, 217, 232, 255},
+	363: {122, 217, 232, 255},
+	364: {122, 217, 232, 255},
+	365: {146, 136, 205, 255},
+	366: {223, 232, 233, 255},
+	367: {168, 178, 204, 255},
+	368: {50, 46, 104, 255},
+	369: {50, 46, 104, 255},
+	370: {127, 116, 194, 255},
+	371: {249, 101, 189, 255},
+	372: {252, 128, 201, 255},
+	373: {9, 61, 191, 255},
+	374: {253, 32, 3, 255},
+	375: {255, 156, 12, 255},
+	376: {160, 120, 92, 255},
+	377: {191, 142, 111, 255},
+	378: {160, 120, 100, 255},
+	379: {251, 209, 240, 255},
+	380: {191, 142, 111, 255},
+	381: {254, 121, 2, 255},
+	382: {28, 216, 94, 255},
+	383: {221, 136, 144, 255},
+	384: {131, 206, 12, 255},
+	385: {87, 21, 144, 255},
+	386: {127, 92, 69, 255},
+	387: {127, 92, 69, 255},
+	388: {127, 92, 69, 255},
+	389: {127, 92, 69, 255},
+	390: {253, 32, 3, 255},
+	391: {122, 217, 232, 255},
+	392: {122, 217, 232, 255},
+	393: {122, 217, 232, 255},
+	394: {122, 217, 232, 255},
+	395: {191, 142, 111, 255},
+	396: {198, 124, 78, 255},
+	397: {212, 192, 100, 255},
+	398: {100, 82, 126, 255},
+	399: {77, 76, 66, 255},
+	400: {96, 68, 117, 255},
+	401: {68, 60, 51, 255},
+	402: {174, 168, 186, 255},
+	403: {205, 152, 186, 255},
+	404: {212, 148, 88, 255},
+	405: {140, 140, 140, 255},
+	406: {120, 120, 120, 255},
+	407: {255, 227, 132, 255},
+	408: {85, 83, 82, 255},
+	409: {85, 83, 82, 255},
+	410: {75, 139, 166, 255},
+	411: {227, 46, 46, 255},
+	412: {75, 139, 166, 255},
+	413: {122, 217, 232, 255},
+	414: {122, 217, 232, 255},
+	415: {249, 75, 7, 255},
+	416: {0, 160, 170, 255},
+	417: {160, 87, 234, 255},
+	418: {22, 173, 254, 255},
+	419: {88, 95, 114, 255},
+	420: {99, 255, 107, 255},
+	421: {65, 75, 90, 255},
+	422: {65, 75, 90, 255},
+	423: {245, 197, 1, 255},
+	424: {146, 155, 187, 255},
+	425: {146, 155, 187, 255},
+	426: {168, 38, 47, 255},
+	427: {183, 53, 62, 255},
+	429: {220, 220, 220, 255},
+	430: {39, 168, 96, 255},
+	431: {39, 94, 168, 255},
+	432: {242, 221, 100, 255},
+	433: {224, 100, 242, 255},
+	434: {197, 193, 216, 255},
+	435: {54, 183, 111, 255},
+	436: {54, 109, 183, 255},
+	437: {255, 236, 115, 255},
+	438: {239, 115, 255, 255},
+	439: {212, 208, 231, 255},
+	440: {238, 51, 53, 255},
+	441: {174, 129, 92, 255},
+	442: {3, 144, 201, 255},
+	443: {144, 148, 144, 255},
+	444: {191, 176, 124, 255},
+	445: {240, 240, 240, 255},
+	446: {255, 66, 152, 255},
+	447: {179, 132, 255, 255},
+	448: {0, 206, 180, 255},
+	449: {91, 186, 240, 255},
+	450: {92, 240, 91, 255},
+	451: {240, 91, 147, 255},
+	452: {255, 150, 181, 255},
+	453: {179, 132, 255, 255},
+	454: {174, 16, 176, 255},
+	455: {48, 225, 110, 255},
+	456: {179, 132, 255, 255},
+	457: {150, 164, 206, 255},
+	458: {211, 198, 111, 255},
+	459: {190, 223, 232, 255},
+	460: {141, 163, 181, 255},
+	461: {212, 192, 100, 255},
+	462: {231, 178, 28, 255},
+	463: {155, 214, 240, 255},
+	464: {233, 183, 128, 255},
+	465: {51, 84, 195, 255},
+	466: {205, 153, 73, 255},
+	467: {129, 56, 121, 255},
+	468: {129, 56, 121, 255},
+	469: {191, 142, 111, 255},
+	470: {191, 142, 111, 255},
+	471: {191, 142, 111, 255},
+	472: {190, 160, 140, 255},
+	473: {85, 114, 123, 255},
+	474: {116, 94, 97, 255},
+	475: {191, 142, 111, 255},
+	476: {160, 160, 160, 255},
+	477: {28, 216, 94, 255},
+	478: {108, 34, 35, 255},
+	479: {178, 114, 68, 255},
+	480: {120, 50, 50, 255},
+	481: {66, 84, 109, 255},
+	482: {84, 100, 63, 255},
+	483: {107, 68, 99, 255},
+	484: {73, 120, 17, 255},
+	485: {198, 134, 88, 255},
+	486: {191, 142, 111, 255},
+	487: {191, 142, 111, 255},
+	488: {127, 92, 69, 255},
+	489: {255, 29, 136, 255},
+	490: {211, 211, 211, 255},
+	491: {60, 20, 160, 255},
+	492: {78, 193, 227, 255},
+	493: {250, 249, 252, 255},
+	494: {224, 219, 236, 255},
+	495: {253, 227, 215, 255},
+	496: {165, 159, 153, 255},
+	497: {191, 142, 111, 255},
+	498: {202, 174, 165, 255},
+	499: {160, 187, 142, 255},
+	500: {254, 158, 35, 255},
+	501: {34, 221, 151, 255},
+	502: {249, 170, 236, 255},
+	503: {35, 200, 254, 255},
+	505: {122, 217, 232, 255},
+	506: {61, 61, 61, 255},
+	507: {5, 5, 5, 255},
+	508: {5, 5, 5, 255},
+	509: {50, 50, 60, 255},
+	510: {191, 142, 111, 255},
+	511: {187, 68, 74, 255},
+	512: {49, 134, 114, 255},
+	513: {126, 134, 49, 255},
+	514: {134, 59, 49, 255},
+	515: {43, 86, 140, 255},
+	516: {121, 49, 134, 255},
+	517: {254, 121, 2, 255},
+	518: {26, 196, 84, 255},
+	519: {28, 216, 109, 255},
+	520: {224, 219, 236, 255},
+	521: {122, 217, 232, 255},
+	522: {122, 217, 232, 255},
+	523: {122, 217, 232, 255},
+	524: {122, 217, 232, 255},
+	525: {122, 217, 232, 255},
+	526: {122, 217, 232, 255},
+	527: {122, 217, 232, 255},
+	528: {182, 175, 130, 255},
+	529: {99, 150, 8, 255},
+	530: {107, 182, 0, 255},
+	531: {144, 148, 144, 255},
+	532: {122, 217, 232, 255},
+	533: {122, 217, 232, 255},
+	534: {114, 254, 2, 255},
+	535: {114, 254, 2, 255},
+	536: {0, 197, 208, 255},
+	537: {0, 197, 208, 255},
+	538: {122, 217, 232, 255},
+	539: {208, 0, 126, 255},
+	540: {208, 0, 126, 255},
+	542: {122, 217, 232, 255},
+	543: {122, 217, 232, 255},
+	544: {122, 217, 232, 255},
+	545: {255, 126, 145, 255},
+	546: {60, 60, 60, 255},
+	547: {120, 110, 100, 255},
+	548: {120, 110, 100, 255},
+	549: {54, 83, 20, 255},
+	550: {122, 217, 232, 255},
+	551: {122, 217, 232, 255},
+	552: {186, 168, 84, 255},
+	553: {122, 217, 232, 255},
+	554: {122, 217, 232, 255},
+	555: {122, 217, 232, 255},
+	556: {122, 217, 232, 255},
+	557: {60, 60, 60, 255},
+	558: {122, 217, 232, 255},
+	559: {122, 217, 232, 255},
+	560: {150, 67, 22, 255},
+	561: {148, 158, 184, 255},
+	562: {165, 168, 26, 255},
+	563: {165, 168, 26, 255},
+	564: {87, 127, 220, 255},
+	565: {99, 99, 99, 255},
+	566: {233, 180, 90, 255},
+	567: {144, 148, 144, 255},
+	568: {248, 203, 233, 255},
+	569: {203, 248, 218, 255},
+	570: {160, 242, 255, 255},
+	571: {165, 168, 26, 255},
+	572: {255, 186, 212, 255},
+	573: {191, 142, 111, 255},
+	574: {76, 57, 44, 255},
+	575: {125, 61, 65, 255},
+	576: {30, 26, 84, 255},
+	577: {178, 104, 58, 255},
+	578: {172, 155, 110, 255},
+	579: {99, 99, 99, 255},
+	580: {122, 217, 232, 255},
+	581: {255, 150, 150, 255},
+	582: {122, 217, 232, 255},
+	583: {127, 127, 127, 255},
+	584: {127, 127, 127, 255},
+	585: {127, 127, 127, 255},
+	586: {127, 127, 127, 255},
+	587: {127, 127, 127, 255},
+	588: {127, 127, 127, 255},
+	589: {127, 127, 127, 255},
+	590: {127, 127, 127, 255},
+	591: {114, 81, 56, 255},
+	592: {254, 121, 2, 255},
+	593: {119, 105, 79, 255},
+	594: {119, 105, 79, 255},
+	595: {151, 107, 75, 255},
+	596: {151, 107, 75, 255},
+	597: {28, 216, 94, 255},
+	598: {122, 217, 232, 255},
+	599: {122, 217, 232, 255},
+	600: {122, 217, 232, 255},
+	601: {122, 217, 232, 255},
+	602: {122, 217, 232, 255},
+	603: {122, 217, 232, 255},
+	604: {122, 217, 232, 255},
+	605: {122, 217, 232, 255},
+	606: {122, 217, 232, 255},
+	607: {122, 217, 232, 255},
+	608: {122, 217, 232, 255},
+	609: {122, 217, 232, 255},
+	610: {122, 217, 232, 255},
+	611: {122, 217, 232, 255},
+	612: {122, 217, 232, 255},
+	613: {250, 100, 50, 255},
+	614: {250, 100, 50, 255},
+	615: {151, 107, 75, 255},
+	616: {151, 107, 75, 255},
+	617: {233, 207, 94, 255},
+	618: {128, 128, 128, 255},
+	619: {122, 217, 232, 255},
+	620: {122, 217, 232, 255},
+	621: {250, 250, 250, 255},
+	622: {235, 235, 249, 255},
+	623: {220, 210, 245, 255},
+	624: {210, 91, 77, 255},
+	625: {220, 12, 237, 255},
+	626: {220, 12, 237, 255},
+	627: {255, 76, 76, 255},
+	629: {122, 217, 232, 255},
+	630: {117, 145, 73, 255},
+	631: {122, 234, 225, 255},
+	632: {122, 217, 232, 255},
+	633: {210, 140, 100, 255},
+	634: {145, 120, 120, 255},
+	635: {145, 120, 120, 255},
+	636: {122, 116, 218, 255},
+	637: {200, 120, 75, 255},
+	638: {200, 120, 75, 255},
+	639: {110, 105, 255, 255},
+	640: {122, 217, 232, 255},
+	641: {235, 125, 150, 255},
+	642: {149, 212, 89, 255},
+	643: {122, 217, 232, 255},
+	644: {122, 217, 232, 255},
+	645: {122, 217, 232, 255},
+	646: {108, 133, 140, 255},
+	651: {107, 182, 29, 255},
+	652: {107, 182, 29, 255},
+	654: {200, 44, 28, 255},
+	655: {225, 128, 206, 255},
+	656: {21, 124, 212, 255},
+	657: {35, 205, 215, 255},
+	658: {200, 105, 230, 255},
+	659: {247, 228, 254, 255},
+	660: {255, 150, 150, 255},
+	661: {141, 137, 223, 255},
+	662: {208, 80, 80, 255},
+	663: {24, 203, 233, 255},
+	664: {128, 128, 128, 255},
+	665: {174, 24, 69, 255},
+	666: {115, 60, 40, 255},
+	667: {247, 228, 254, 255},
+	668: {151, 107, 75, 255},
+	669: {83, 46, 57, 255},
+	670: {91, 87, 167, 255},
+	671: {23, 33, 81, 255},
+	672: {53, 133, 103, 255},
+	673: {11, 67, 80, 255},
+	674: {40, 49, 60, 255},
+	675: {21, 13, 77, 255},
+	676: {195, 201, 215, 255},
+	677: {66, 84, 109, 255},
+	678: {84, 100, 63, 255},
+	679: {107, 68, 99, 255},
+	680: {185, 164, 23, 255},
+	681: {185, 194, 195, 255},
+	682: {150, 67, 22, 255},
+	683: {100, 90, 190, 255},
+	684: {142, 66, 66, 255},
+	685: {11, 80, 143, 255},
+	686: {91, 169, 169, 255},
+	687: {254, 121, 2, 255},
+	688: {208, 0, 126, 255},
+	689: {114, 254, 2, 255},
+	690: {0, 197, 208, 255},
+	691: {220, 12, 237, 255},
+	695: {119, 101, 125, 255},
+	696: {141, 120, 168, 255},
+	697: {192, 202, 203, 255},
+	698: {200, 200, 200, 255},
+	699: {141, 107, 89, 255},
+	700: {210, 91, 77, 255},
+	701: {21, 124, 212, 255},
+	702: {149, 232, 87, 255},
+	703: {135, 196, 26, 255},
+	704: {127, 92, 69, 255},
+	705: {107, 182, 0, 255},
+	707: {170, 48, 114, 255},
+	708: {237, 218, 244, 255},
+	709: {247, 228, 254, 255},
+	710: {122, 217, 232, 255},
+	711: {128, 128, 128, 255},
+	712: {128, 128, 128, 255},
+	713: {128, 128, 128, 255},
+	714: {128, 128, 128, 255},
+	715: {128, 128, 128, 255},
+	716: {128, 128, 128, 255},
+	717: {126, 102, 118, 255},
+	718: {245, 215, 196, 255},
+	719: {223, 255, 255, 255},
+	720: {164, 175, 175, 255},
+	721: {77, 176, 144, 255},
+	722: {185, 190, 20, 255},
+	723: {185, 190, 20, 255},
+	724: {185, 190, 20, 255},
+	725: {229, 65, 65, 255},
+	726: {30, 30, 36, 255},
+	727: {119, 22, 52, 255},
+	728: {23, 119, 79, 255},
+	729: {23, 54, 119, 255},
+	730: {119, 68, 23, 255},
+	731: {74, 23, 119, 255},
+	732: {78, 82, 109, 255},
+	733: {115, 69, 27, 255},
+	734: {160, 115, 80, 255},
+	735: {235, 200, 20, 255},
+	736: {240, 130, 160, 255},
+	737: {65, 75, 65, 255},
+	738: {210, 170, 215, 255},
+	739: {90, 125, 235, 255},
+	740: {211, 211, 211, 255},
+	741: {160, 90, 50, 255},
+	742: {26, 141, 204, 255},
+	743: {227, 227, 227, 255},
+	744: {106, 103, 96, 255},
+	745: {120, 114, 108, 255},
+	746: {122, 78, 21, 255},
+	747: {231, 178, 28, 255},
+	748: {2, 114, 206, 255},
+	751: {128, 128, 128, 255},
+	752: {128, 128, 128, 255},
+}
+
+var wallColors = [367]color.RGBA{
+	1:   {52, 52, 52, 255},
+	2:   {88, 61, 46, 255},
+	3:   {61, 58, 78, 255},
+	4:   {73, 51, 36, 255},
+	5:   {52, 52, 52, 255},
+	6:   {91, 30, 30, 255},
+	7:   {27, 31, 42, 255},
+	8:   {31, 39, 26, 255},
+	9:   {41, 28, 36, 255},
+	10:  {74, 62, 12, 255},
+	11:  {46, 56, 59, 255},
+	12:  {75, 32, 11, 255},
+	13:  {67, 37, 37, 255},
+	14:  {15, 15, 15, 255},
+	15:  {52, 43, 45, 255},
+	16:  {88, 61, 46, 255},
+	17:  {27, 31, 42, 255},
+	18:  {31, 39, 26, 255},
+	19:  {41, 28, 36, 255},
+	20:  {15, 15, 15, 255},
+	22:  {113, 99, 99, 255},
+	23:  {38, 38, 43, 255},
+	24:  {53, 39, 41, 255},
+	25:  {11, 35, 62, 255},
+	26:  {21, 63, 70, 255},
+	27:  {88, 61, 46, 255},
+	28:  {81, 84, 101, 255},
+	29:  {88, 23, 23, 255},
+	30:  {28, 88, 23, 255},
+	31:  {78, 87, 99, 255},
+	32:  {86, 17, 40, 255},
+	33:  {49, 47, 83, 255},
+	34:  {69, 67, 41, 255},
+	35:  {51, 51, 70, 255},
+	36:  {87, 59, 55, 255},
+	37:  {69, 67, 41, 255},
+	38:  {49, 57, 49, 255},
+	39:  {78, 79, 73, 255},
+	40:  {85, 102, 103, 255},
+	41:  {52, 50, 62, 255},
+	42:  {71, 42, 44, 255},
+	43:  {73, 66, 50, 255},
+	44:  {52, 52, 52, 255},
+	45:  {60, 59, 51, 255},
+	46:  {48, 57, 47, 255},
+	47:  {71, 77, 85, 255},
+	48:  {52, 52, 52, 255},
+	49:  {52, 52, 52, 255},
+	50:  {52, 52, 52, 255},
+	51:  {52, 52, 52, 255},
+	52:  {52, 52, 52, 255},
+	53:  {52, 52, 52, 255},
+	54:  {40, 56, 50, 255},
+	55:  {49, 48, 36, 255},
+	56:  {43, 33, 32, 255},
+	57:  {31, 40, 49, 255},
+	58:  {48, 35, 52, 255},
+	59:  {88, 61, 46, 255},
+	60:  {1, 52, 20, 255},
+	61:  {55, 39, 26, 255},
+	62:  {39, 33, 26, 255},
+	63:  {30, 80, 48, 255},
+	64:  {53, 80, 30, 255},
+	65:  {30, 80, 48, 255},
+	66:  {30, 80, 48, 255},
+	67:  {53, 80, 30, 255},
+	68:  {30, 80, 48, 255},
+	69:  {43, 42, 68, 255},
+	70:  {30, 70, 80, 255},
+	71:  {78, 105, 135, 255},
+	72:  {52, 84, 12, 255},
+	73:  {190, 204, 223, 255},
+	74:  {64, 62, 80, 255},
+	75:  {65, 65, 35, 255},
+	76:  {20, 46, 104, 255},
+	77:  {61, 13, 16, 255},
+	78:  {63, 39, 26, 255},
+	79:  {51, 47, 96, 255},
+	80:  {64, 62, 80, 255},
+	81:  {101, 51, 51, 255},
+	82:  {77, 64, 34, 255},
+	83:  {62, 38, 41, 255},
+	84:  {48, 78, 93, 255},
+	85:  {54, 63, 69, 255},
+	86:  {138, 73, 38, 255},
+	87:  {50, 15, 8, 255},
+	94:  {32, 40, 45, 255},
+	95:  {44, 41, 50, 255},
+	96:  {72, 50, 77, 255},
+	97:  {78, 50, 69, 255},
+	98:  {36, 45, 44, 255},
+	99:  {38, 49, 50, 255},
+	100: {32, 40, 45, 255},
+	101: {44, 41, 50, 255},
+	102: {72, 50, 77, 255},
+	103: {78, 50, 69, 255},
+	104: {36, 45, 44, 255},
+	105: {38, 49, 50, 255},
+	108: {138, 73, 38, 255},
+	109: {94, 25, 17, 255},
+	110: {125, 36, 122, 255},
+	111: {51, 35, 27, 255},
+	112: {50, 15, 8, 255},
+	113: {135, 58, 0, 255},
+	114: {65, 52, 15, 255},
+	115: {39, 42, 51, 255},
+	116: {89, 26, 27, 255},
+	117: {126, 123, 115, 255},
+	118: {8, 50, 19, 255},
+	119: {95, 21, 24, 255},
+	120: {17, 31, 65, 255},
+	121: {192, 173, 143, 255},
+	122: {114, 114, 131, 255},
+	123: {136, 119, 7, 255},
+	124: {8, 72, 3, 255},
+	125: {117, 132, 82, 255},
+	126: {100, 102, 114, 255},
+	127: {30, 118, 226, 255},
+	128: {93, 6, 102, 255},
+	129: {64, 40, 169, 255},
+	130: {39, 34, 180, 255},
+	131: {87, 94, 125, 255},
+	132: {6, 6, 6, 255},
+	133: {69, 72, 186, 255},
+	134: {130, 62, 16, 255},
+	135: {22, 123, 163, 255},
+	136: {40, 86, 151, 255},
+	137: {183, 75, 15, 255},
+	138: {83, 80, 100, 255},
+	139: {115, 65, 68, 255},
+	140: {119, 108, 81, 255},
+	141: {59, 67, 71, 255},
+	142: {222, 216, 202, 255},
+	143: {90, 112, 105, 255},
+	144: {62, 28, 87, 255},
+	146: {120, 59, 19, 255},
+	147: {59, 59, 59, 255},
+	148: {229, 218, 161, 255},
+	149: {73, 59, 50, 255},
+	151: {102, 75, 34, 255},
+	153: {255, 145, 79, 255},
+	154: {221, 79, 255, 255},
+	155: {240, 240, 247, 255},
+	156: {79, 255, 89, 255},
+	157: {154, 83, 49, 255},
+	158: {107, 49, 154, 255},
+	159: {85, 89, 118, 255},
+	160: {49, 154, 68, 255},
+	161: {154, 49, 77, 255},
+	162: {49, 49, 154, 255},
+	163: {154, 148, 49, 255},
+	164: {255, 79, 79, 255},
+	165: {79, 102, 255, 255},
+	166: {250, 255, 79, 255},
+	167: {70, 68, 51, 255},
+	169: {5, 5, 5, 255},
+	170: {59, 39, 22, 255},
+	171: {59, 39, 22, 255},
+	172: {163, 96, 0, 255},
+	173: {94, 163, 46, 255},
+	174: {117, 32, 59, 255},
+	175: {20, 11, 203, 255},
+	176: {74, 69, 88, 255},
+	177: {60, 30, 30, 255},
+	178: {111, 117, 135, 255},
+	179: {111, 117, 135, 255},
+	180: {25, 23, 54, 255},
+	181: {25, 23, 54, 255},
+	182: {74, 71, 129, 255},
+	183: {111, 117, 135, 255},
+	184: {25, 23, 54, 255},
+	185: {52, 52, 52, 255},
+	186: {38, 9, 66, 255},
+	187: {149, 80, 51, 255},
+	188: {82, 63, 80, 255},
+	189: {65, 61, 77, 255},
+	190: {64, 65, 92, 255},
+	191: {76, 53, 84, 255},
+	192: {144, 67, 52, 255},
+	193: {149, 48, 48, 255},
+	194: {111, 32, 36, 255},
+	195: {147, 48, 55, 255},
+	196: {97, 67, 51, 255},
+	197: {112, 80, 62, 255},
+	198: {88, 61, 46, 255},
+	199: {127, 94, 76, 255},
+	200: {143, 50, 123, 255},
+	201: {136, 120, 131, 255},
+	202: {219, 92, 143, 255},
+	203: {113, 64, 150, 255},
+	204: {74, 67, 60, 255},
+	205: {60, 78, 59, 255},
+	206: {0, 54, 21, 255},
+	207: {74, 97, 72, 255},
+	208: {40, 37, 35, 255},
+	209: {77, 63, 66, 255},
+	210: {111, 6, 6, 255},
+	211: {88, 67, 59, 255},
+	212: {88, 87, 80, 255},
+	213: {71, 71, 67, 255},
+	214: {76, 52, 60, 255},
+	215: {89, 48, 59, 255},
+	216: {158, 100, 64, 255},
+	217: {62, 45, 75, 255},
+	218: {57, 14, 12, 255},
+	219: {96, 72, 133, 255},
+	220: {67, 55, 80, 255},
+	221: {64, 37, 29, 255},
+	222: {70, 51, 91, 255},
+	223: {51, 18, 4, 255},
+	224: {57, 55, 52, 255},
+	225: {68, 68, 68, 255},
+	226: {148, 138, 74, 255},
+	227: {95, 137, 191, 255},
+	228: {160, 2, 75, 255},
+	229: {100, 55, 164, 255},
+	230: {0, 117, 101, 255},
+	231: {110, 90, 78, 255},
+	232: {47, 69, 75, 255},
+	233: {91, 67, 70, 255},
+	234: {60, 36, 39, 255},
+	235: {140, 75, 48, 255},
+	236: {127, 49, 44, 255},
+	237: {200, 44, 18, 255},
+	238: {24, 93, 66, 255},
+	239: {160, 87, 234, 255},
+	240: {6, 106, 255, 255},
+	242: {5, 5, 5, 255},
+	243: {5, 5, 5, 255},
+	244: {63, 39, 26, 255},
+	245: {102, 102, 102, 255},
+	246: {61, 58, 78, 255},
+	247: {52, 43, 45, 255},
+	248: {81, 84, 101, 255},
+	249: {85, 102, 103, 255},
+	250: {52, 52, 52, 255},
+	251: {52, 52, 52, 255},
+	252: {52, 52, 52, 255},
+	253: {52, 52, 52, 255},
+	254: {52, 52, 52, 255},
+	255: {52, 52, 52, 255},
+	256: {40, 56, 50, 255},
+	257: {49, 48, 36, 255},
+	258: {43, 33, 32, 255},
+	259: {31, 40, 49, 255},
+	260: {48, 35, 52, 255},
+	261: {88, 61, 46, 255},
+	262: {55, 39, 26, 255},
+	263: {39, 33, 26, 255},
+	264: {43, 42, 68, 255},
+	265: {30, 70, 80, 255},
+	266: {78, 105, 135, 255},
+	267: {51, 47, 96, 255},
+	268: {101, 51, 51, 255},
+	269: {62, 38, 41, 255},
+	270: {59, 39, 22, 255},
+	271: {59, 39, 22, 255},
+	272: {111, 117, 135, 255},
+	273: {25, 23, 54, 255},
+	274: {52, 52, 52, 255},
+	275: {149, 80, 51, 255},
+	276: {82, 63, 80, 255},
+	277: {65, 61, 77, 255},
+	278: {64, 65, 92, 255},
+	279: {76, 53, 84, 255},
+	280: {144, 67, 52, 255},
+	281: {149, 48, 48, 255},
+	282: {111, 32, 36, 255},
+	283: {147, 48, 55, 255},
+	284: {97, 67, 51, 255},
+	285: {112, 80, 62, 255},
+	286: {88, 61, 46, 255},
+	287: {127, 94, 76, 255},
+	288: {143, 50, 123, 255},
+	289: {136, 120, 131, 255},
+	290: {219, 92, 143, 255},
+	291: {113, 64, 150, 255},
+	292: {74, 67, 60, 255},
+	293: {60, 78, 59, 255},
+	294: {0, 54, 21, 255},
+	295: {74, 97, 72, 255},
+	296: {40, 37, 35, 255},
+	297: {77, 63, 66, 255},
+	298: {111, 6, 6, 255},
+	299: {88, 67, 59, 255},
+	300: {88, 87, 80, 255},
+	301: {71, 71, 67, 255},
+	302: {76, 52, 60, 255},
+	303: {89, 48, 59, 255},
+	304: {158, 100, 64, 255},
+	305: {62, 45, 75, 255},
+	306: {57, 14, 12, 255},
+	307: {96, 72, 133, 255},
+	308: {67, 55, 80, 255},
+	309: {64, 37, 29, 255},
+	310: {70, 51, 91, 255},
+	311: {51, 18, 4, 255},
+	312: {78, 110, 51, 255},
+	313: {78, 110, 51, 255},
+	314: {52, 52, 52, 255},
+	315: {181, 230, 29, 255},
+	316: {125, 100, 100, 255},
+	317: {125, 100, 100, 255},
+	319: {105, 51, 108, 255},
+	320: {75, 30, 15, 255},
+	321: {91, 108, 130, 255},
+	322: {91, 108, 130, 255},
+	323: {55, 25, 33, 255},
+	324: {60, 55, 145, 255},
+	325: {10, 5, 50, 255},
+	326: {30, 105, 75, 255},
+	327: {5, 45, 55, 255},
+	328: {20, 25, 35, 255},
+	329: {15, 10, 50, 255},
+	330: {153, 164, 187, 255},
+	331: {27, 31, 42, 255},
+	332: {31, 39, 26, 255},
+	333: {41, 28, 36, 255},
+	334: {74, 62, 12, 255},
+	335: {46, 56, 59, 255},
+	336: {75, 32, 11, 255},
+	337: {15, 15, 15, 255},
+	338: {67, 37, 37, 255},
+	339: {11, 35, 62, 255},
+	340: {21, 63, 70, 255},
+	341: {100, 40, 1, 255},
+	342: {92, 30, 72, 255},
+	343: {42, 81, 1, 255},
+	344: {1, 81, 109, 255},
+	345: {56, 22, 97, 255},
+	346: {52, 52, 52, 255},
+	347: {100, 65, 130, 255},
+	348: {120, 75, 75, 255},
+	349: {52, 52, 52, 255},
+	350: {0, 255, 0, 255},
+	360: {73, 93, 116, 255},
+}
+
+// liquidColors is indexed by liquid type: water, lava, honey, shimmer.
+var liquidColors = [4]color.RGBA{
+	0: {9, 61, 191, 255},
+	1: {253, 32, 3, 255},
+	2: {254, 194, 20, 255},
+	3: {161, 127, 255, 255},
+}
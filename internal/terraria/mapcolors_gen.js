@@ -0,0 +1,32 @@
+// Writes mapcolors.go from the colour tables of the TerraMap viewer, which
+// were decompiled from Terraria's own map code. Run with go generate.
+const fs = require("fs");
+const vm = require("vm");
+
+const source = "../../static/terramap/resources/js/MapHelper.js";
+const ctx = {};
+vm.createContext(ctx);
+vm.runInContext(fs.readFileSync(source, "utf8"), ctx);
+vm.runInContext("this.tables = {tiles: tileColors, walls: wallColors, liquids: liquidColors}", ctx);
+
+function table(name, entries, first) {
+  let out = `var ${name} = [${entries.length}]color.RGBA{\n`;
+  entries.forEach((entry, id) => {
+    const c = first ? entry && entry[0] : entry;
+    if (c) {
+      out += `\t${id}: {${c.r}, ${c.g}, ${c.b}, 255},\n`;
+    }
+  });
+  return out + "}\n";
+}
+
+const t = ctx.tables;
+let out = "// Code generated by mapcolors_gen.js from " + source.replace("../../", "") + "; DO NOT EDIT.\n\n";
+out += "package terraria\n\nimport \"image/color\"\n\n";
+out += "// tileColors and wallColors give the map colour of each tile and wall type.\n";
+out += "// Types without a colour are left transparent.\n";
+out += table("tileColors", t.tiles, true) + "\n";
+out += table("wallColors", t.walls, true) + "\n";
+out += "// liquidColors is indexed by liquid type: water, lava, honey, shimmer.\n";
+out += table("liquidColors", t.liquids, false);
+fs.writeFileSync("mapcolors.go", out);
@@ -0,0 +1,180 @@
+package terraria
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// ErrNotNBT is returned for files that are not gzip-compressed NBT, the
+// format of tModLoader's sidecar files.
+var ErrNotNBT = errors.New("not a tModLoader data file")
+
+// NBT tag types, as tModLoader's TagIO numbers them.
+const (
+	tagEnd = iota
+	tagByte
+	tagShort
+	tagInt
+	tagLong
+	tagFloat
+	tagDouble
+	tagByteArray
+	tagString
+	tagList
+	tagCompound
+	tagIntArray
+)
+
+// Bounds on what an NBT file may make the decoder do.
+const (
+	maxNBTSize  = 512 << 20
+	maxNBTDepth = 64
+	maxNBTList  = 1 << 24
+)
+
+// nbtReader decodes the big-endian NBT tags tModLoader writes. Compounds
+// decode to map[string]interface{}, lists to []interface{}, strings to
+// string and numbers to int64 or float64. Arrays are skipped, as nothing
+// read from them is needed, and decode to nil.
+type nbtReader struct {
+	r   *bufio.Reader
+	err error
+	buf [8]byte
+}
+
+// readNBT decodes a gzip-compressed NBT file and returns its root compound.
+func readNBT(r io.Reader) (map[string]interface{}, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, ErrNotNBT
+	}
+	defer gz.Close()
+
+	nr := &nbtReader{r: bufio.NewReader(io.LimitReader(gz, maxNBTSize))}
+	if nr.u8() != tagCompound {
+		if nr.err != nil {
+			return nil, nr.err
+		}
+		return nil, ErrNotNBT
+	}
+	nr.str() // root name
+	root, _ := nr.payload(tagCompound, 0).(map[string]interface{})
+	if nr.err != nil {
+		return nil, fmt.Errorf("read NBT: %w", nr.err)
+	}
+	return root, nil
+}
+
+func (nr *nbtReader) fail(err error) {
+	if nr.err == nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		nr.err = err
+	}
+}
+
+func (nr *nbtReader) read(n int) []byte {
+	if nr.err != nil {
+		return nr.buf[:n]
+	}
+	if _, err := io.ReadFull(nr.r, nr.buf[:n]); err != nil {
+		nr.fail(err)
+	}
+	return nr.buf[:n]
+}
+
+func (nr *nbtReader) u8() byte    { return nr.read(1)[0] }
+func (nr *nbtReader) i16() int16  { return int16(binary.BigEndian.Uint16(nr.read(2))) }
+func (nr *nbtReader) i32() int32  { return int32(binary.BigEndian.Uint32(nr.read(4))) }
+func (nr *nbtReader) i64() int64  { return int64(binary.BigEndian.Uint64(nr.read(8))) }
+func (nr *nbtReader) u16() uint16 { return binary.BigEndian.Uint16(nr.read(2)) }
+
+func (nr *nbtReader) str() string {
+	n := int(nr.u16())
+	if nr.err != nil {
+		return ""
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(nr.r, b); err != nil {
+		nr.fail(err)
+		return ""
+	}
+	return string(b)
+}
+
+// count reads an array or list length.
+func (nr *nbtReader) count() int {
+	n := nr.i32()
+	if n < 0 || n > maxNBTList {
+		nr.fail(fmt.Errorf("length %d out of range", n))
+		return 0
+	}
+	return int(n)
+}
+
+func (nr *nbtReader) skip(n int64) {
+	if nr.err != nil {
+		return
+	}
+	if _, err := io.CopyN(io.Discard, nr.r, n); err != nil {
+		nr.fail(err)
+	}
+}
+
+func (nr *nbtReader) payload(tag byte, depth int) interface{} {
+	if depth > maxNBTDepth {
+		nr.fail(errors.New("tags nested too deeply"))
+		return nil
+	}
+
+	switch tag {
+	case tagByte:
+		return int64(int8(nr.u8()))
+	case tagShort:
+		return int64(nr.i16())
+	case tagInt:
+		return int64(nr.i32())
+	case tagLong:
+		return nr.i64()
+	case tagFloat:
+		return float64(math.Float32frombits(uint32(nr.i32())))
+	case tagDouble:
+		return math.Float64frombits(uint64(nr.i64()))
+	case tagByteArray:
+		nr.skip(int64(nr.count()))
+		return nil
+	case tagIntArray:
+		nr.skip(4 * int64(nr.count()))
+		return nil
+	case tagString:
+		return nr.str()
+	case tagList:
+		elem := nr.u8()
+		n := nr.count()
+		list := make([]interface{}, 0, min(n, 1024))
+		for i := 0; i < n && nr.err == nil; i++ {
+			list = append(list, nr.payload(elem, depth+1))
+		}
+		return list
+	case tagCompound:
+		compound := make(map[string]interface{})
+		for nr.err == nil {
+			t := nr.u8()
+			if t == tagEnd || nr.err != nil {
+				break
+			}
+			name := nr.str()
+			compound[name] = nr.payload(t, depth+1)
+		}
+		return compound
+	}
+
+	nr.fail(fmt.Errorf("unknown tag type %d", tag))
+	return nil
+}
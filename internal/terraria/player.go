@@ -0,0 +1,270 @@
+package terraria
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// MinPlayerVersion is the oldest player format the parser reads, that of
+// Terraria 1.4.0.1.
+const MinPlayerVersion = 225
+
+// ErrNotPlayer is returned for files that are not Terraria players.
+var ErrNotPlayer = errors.New("not a Terraria player file")
+
+// playerKey is both the AES key and the IV Terraria encrypts player files
+// with: "h3y_gUyZ" in UTF-16LE.
+var playerKey = []byte("h\x003\x00y\x00_\x00g\x00U\x00y\x00Z\x00")
+
+// maxPlayerFile bounds the player files read into memory. Real ones are a
+// few kilobytes.
+const maxPlayerFile = 4 << 20
+
+// Difficulty is a character's difficulty.
+type Difficulty uint8
+
+const (
+	Softcore Difficulty = iota
+	Mediumcore
+	Hardcore
+	JourneyCharacter
+)
+
+func (d Difficulty) String() string {
+	switch d {
+	case Softcore:
+		return "Classic"
+	case Mediumcore:
+		return "Mediumcore"
+	case Hardcore:
+		return "Hardcore"
+	case JourneyCharacter:
+		return "Journey"
+	}
+	return fmt.Sprintf("Difficulty %d", uint8(d))
+}
+
+func (d Difficulty) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// EquipSlot is one equipment slot with the vanity item and dye worn over
+// it. Slots without a vanity item have a zero Vanity.
+type EquipSlot struct {
+	Label  string `json:"label"`
+	Item   Item   `json:"item"`
+	Vanity Item   `json:"vanity"`
+	Dye    Item   `json:"dye"`
+}
+
+// Player is what a character save holds. Item lists leave out empty slots.
+type Player struct {
+	Version    int32         `json:"version"`
+	Name       string        `json:"name"`
+	Difficulty Difficulty    `json:"difficulty"`
+	PlayTime   time.Duration `json:"play_time_ns"`
+	Health     int32         `json:"health"`
+	MaxHealth  int32         `json:"max_health"`
+	Mana       int32         `json:"mana"`
+	MaxMana    int32         `json:"max_mana"`
+
+	// Equipment is the armor and accessory slots of the current loadout;
+	// Misc the pet, light pet, minecart, mount and hook slots.
+	Equipment []EquipSlot `json:"equipment"`
+	Misc      []EquipSlot `json:"misc"`
+
+	Inventory []Item `json:"inventory"`
+	Coins     []Item `json:"coins"`
+	Ammo      []Item `json:"ammo"`
+
+	// The four personal storages: the Piggy Bank, Safe, Defender's Forge
+	// and Void Vault.
+	PiggyBank []Item `json:"piggy_bank"`
+	Safe      []Item `json:"safe"`
+	Forge     []Item `json:"forge"`
+	VoidVault []Item `json:"void_vault"`
+}
+
+// PlayTimeText formats the play time as hours and minutes.
+func (p *Player) PlayTimeText() string {
+	return fmt.Sprintf("%dh %02dm", int(p.PlayTime.Hours()), int(p.PlayTime.Minutes())%60)
+}
+
+var armorLabels = []string{"Head", "Body", "Legs"}
+
+var miscLabels = []string{"Pet", "Light Pet", "Minecart", "Mount", "Grappling Hook"}
+
+// ReadPlayerFile reads the player file at path.
+func ReadPlayerFile(path string) (*Player, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadPlayer(f)
+}
+
+// ReadPlayer decrypts and decodes a player file.
+func ReadPlayer(r io.Reader) (*Player, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxPlayerFile+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 || len(data) > maxPlayerFile || len(data)%aes.BlockSize != 0 {
+		return nil, ErrNotPlayer
+	}
+
+	block, err := aes.NewCipher(playerKey)
+	if err != nil {
+		return nil, err
+	}
+	cipher.NewCBCDecrypter(block, playerKey).CryptBlocks(data, data)
+
+	return readPlayer(newReader(bytes.NewReader(data)))
+}
+
+func readPlayer(rd *reader) (*Player, error) {
+	p := &Player{Version: rd.i32()}
+	magic := rd.bytes(7)
+	kind := rd.u8()
+	if rd.err != nil || string(magic) != "relogic" || kind != fileTypePlayer {
+		return nil, ErrNotPlayer
+	}
+	if p.Version < MinPlayerVersion {
+		return nil, ErrUnsupportedVersion
+	}
+	rd.u32() // revision
+	rd.u64() // favourite flag
+
+	p.Name = rd.str()
+	p.Difficulty = Difficulty(rd.u8())
+	p.PlayTime = time.Duration(rd.i64()) * 100 // .NET ticks
+	rd.i32()                                   // hair style
+	rd.u8()                                    // hair dye
+	rd.skip(2)                                 // hidden accessories
+	rd.u8()                                    // hidden misc slots
+	rd.u8()                                    // skin variant
+
+	p.Health = rd.i32()
+	p.MaxHealth = rd.i32()
+	p.Mana = rd.i32()
+	p.MaxMana = rd.i32()
+
+	rd.bool() // extra accessory slot
+	if p.Version >= 229 {
+		rd.bool() // biome torches unlocked
+		rd.bool() // biome torches in use
+	}
+	if p.Version >= 256 {
+		rd.bool() // ate Artisan Loaf
+	}
+	if p.Version >= 260 {
+		rd.skip(6) // permanent upgrades used
+	}
+	rd.bool() // defeated the Old One's Army
+	rd.i32()  // tax collected
+	if p.Version >= 254 {
+		rd.i32() // PvE deaths
+		rd.i32() // PvP deaths
+	}
+	rd.skip(7 * 3) // hair, skin, eye, shirt, undershirt, pants and shoe colours
+
+	armor := make([]Item, 20)
+	for i := range armor {
+		armor[i] = Item{ID: rd.i32(), Stack: 1, Prefix: rd.u8()}
+	}
+	dyes := make([]Item, 10)
+	for i := range dyes {
+		dyes[i] = Item{ID: rd.i32(), Stack: 1, Prefix: rd.u8()}
+	}
+	for i := 0; i < 10; i++ {
+		label := fmt.Sprintf("Accessory %d", i-2)
+		if i < len(armorLabels) {
+			label = armorLabels[i]
+		}
+		slot := EquipSlot{Label: label, Item: armor[i], Vanity: armor[i+10], Dye: dyes[i]}
+		if !slot.Item.Empty() || !slot.Vanity.Empty() || !slot.Dye.Empty() {
+			p.Equipment = append(p.Equipment, slot)
+		}
+	}
+
+	inventory := make([]Item, 58)
+	for i := range inventory {
+		inventory[i] = Item{ID: rd.i32(), Stack: int(rd.i32()), Prefix: rd.u8()}
+		rd.bool() // favourited
+	}
+	p.Inventory = nonEmpty(inventory[:50])
+	p.Coins = nonEmpty(inventory[50:54])
+	p.Ammo = nonEmpty(inventory[54:])
+
+	for _, label := range miscLabels {
+		slot := EquipSlot{Label: label}
+		slot.Item = Item{ID: rd.i32(), Stack: 1, Prefix: rd.u8()}
+		slot.Dye = Item{ID: rd.i32(), Stack: 1, Prefix: rd.u8()}
+		if !slot.Item.Empty() || !slot.Dye.Empty() {
+			p.Misc = append(p.Misc, slot)
+		}
+	}
+
+	p.PiggyBank = readBank(rd, false)
+	p.Safe = readBank(rd, false)
+	p.Forge = readBank(rd, false)
+	p.VoidVault = readBank(rd, p.Version >= 255)
+
+	if rd.err != nil {
+		return nil, fmt.Errorf("read player: %w", rd.err)
+	}
+	if err := p.check(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// readBank reads the 40 slots of a personal storage.
+func readBank(rd *reader, favourites bool) []Item {
+	items := make([]Item, 40)
+	for i := range items {
+		items[i] = Item{ID: rd.i32(), Stack: int(rd.i32()), Prefix: rd.u8()}
+		if favourites {
+			rd.bool()
+		}
+	}
+	return nonEmpty(items)
+}
+
+// check rejects values no real character has. The format carries no
+// section offsets, so a layout the parser does not know shows up as
+// nonsense rather than as an error.
+func (p *Player) check() error {
+	if p.MaxHealth <= 0 || p.MaxHealth > 1000 || p.MaxMana < 0 || p.MaxMana > 1000 {
+		return fmt.Errorf("%w: health %d, mana %d", ErrUnsupportedVersion, p.MaxHealth, p.MaxMana)
+	}
+	lists := [][]Item{p.Inventory, p.Coins, p.Ammo, p.PiggyBank, p.Safe, p.Forge, p.VoidVault}
+	for _, slot := range append(p.Equipment, p.Misc...) {
+		lists = append(lists, []Item{slot.Item, slot.Vanity, slot.Dye})
+	}
+	for _, items := range lists {
+		for _, item := range items {
+			if item.ID < 0 || item.ID > 1<<16 || item.Stack < 0 || item.Stack > 1<<20 {
+				return fmt.Errorf("%w: item %d x%d", ErrUnsupportedVersion, item.ID, item.Stack)
+			}
+		}
+	}
+	return nil
+}
+
+func nonEmpty(items []Item) []Item {
+	var out []Item
+	for _, item := range items {
+		if !item.Empty() {
+			out = append(out, item)
+		}
+	}
+	return out
+}
@@ -0,0 +1,257 @@
+package terraria
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testPlayer describes a player file for build to write. Equipment items
+// are written without their stacks, as in the format.
+type testPlayer struct {
+	version    int32
+	name       string
+	difficulty Difficulty
+	playTime   time.Duration
+	health     int32
+	maxHealth  int32
+	mana       int32
+	maxMana    int32
+	// armor holds the armor and accessories followed by their vanity
+	// items; misc the item and dye of each misc slot in turn.
+	armor     [20]Item
+	dyes      [10]Item
+	inventory [58]Item
+	misc      [10]Item
+	banks     [4][40]Item
+}
+
+// plaintext writes p the way readPlayer reads it.
+func (p testPlayer) plaintext() []byte {
+	if p.version == 0 {
+		p.version = 279
+	}
+	w := &writer{}
+	w.i32(p.version)
+	w.WriteString("relogic")
+	w.u8(fileTypePlayer)
+	w.u32(1) // revision
+	w.u64(0) // favourite
+
+	w.str(p.name)
+	w.u8(uint8(p.difficulty))
+	w.i64(int64(p.playTime / 100))
+	w.i32(5)  // hair style
+	w.u8(0)   // hair dye
+	w.zero(2) // hidden accessories
+	w.u8(0)   // hidden misc slots
+	w.u8(3)   // skin variant
+	w.i32(p.health)
+	w.i32(p.maxHealth)
+	w.i32(p.mana)
+	w.i32(p.maxMana)
+
+	w.bool(true) // extra accessory slot
+	if p.version >= 229 {
+		w.bool(true)
+		w.bool(false)
+	}
+	if p.version >= 256 {
+		w.bool(true)
+	}
+	if p.version >= 260 {
+		w.zero(6)
+	}
+	w.bool(false)
+	w.i32(0)
+	if p.version >= 254 {
+		w.i32(3)
+		w.i32(0)
+	}
+	w.zero(7 * 3)
+
+	for _, item := range p.armor {
+		w.i32(item.ID)
+		w.u8(item.Prefix)
+	}
+	for _, item := range p.dyes {
+		w.i32(item.ID)
+		w.u8(item.Prefix)
+	}
+	for i, item := range p.inventory {
+		w.i32(item.ID)
+		w.i32(int32(item.Stack))
+		w.u8(item.Prefix)
+		w.bool(i%2 == 0) // favourited
+	}
+	for _, item := range p.misc {
+		w.i32(item.ID)
+		w.u8(item.Prefix)
+	}
+	for i, bank := range p.banks {
+		for _, item := range bank {
+			w.i32(item.ID)
+			w.i32(int32(item.Stack))
+			w.u8(item.Prefix)
+			if i == 3 && p.version >= 255 {
+				w.bool(true)
+			}
+		}
+	}
+	return w.Bytes()
+}
+
+// encrypt pads and encrypts plaintext as Terraria does.
+func encrypt(plaintext []byte) []byte {
+	n := aes.BlockSize - len(plaintext)%aes.BlockSize
+	data := append(append([]byte(nil), plaintext...), bytes.Repeat([]byte{byte(n)}, n)...)
+	block, err := aes.NewCipher(playerKey)
+	if err != nil {
+		panic(err)
+	}
+	cipher.NewCBCEncrypter(block, playerKey).CryptBlocks(data, data)
+	return data
+}
+
+func (p testPlayer) build() []byte {
+	return encrypt(p.plaintext())
+}
+
+func newTestPlayer() testPlayer {
+	p := testPlayer{
+		name:       "Ada",
+		difficulty: JourneyCharacter,
+		playTime:   3*time.Hour + 7*time.Minute,
+		health:     380,
+		maxHealth:  400,
+		mana:       120,
+		maxMana:    200,
+	}
+	p.armor[0] = Item{ID: 4, Prefix: 0} // head
+	p.armor[4] = Item{ID: 5, Prefix: 1} // accessory 2
+	p.armor[11] = Item{ID: 6}           // body vanity
+	p.dyes[2] = Item{ID: 8}             // legs dye
+	p.inventory[0] = Item{ID: 1, Stack: 1, Prefix: 1}
+	p.inventory[49] = Item{ID: 2, Stack: 999}
+	p.inventory[52] = Item{ID: 73, Stack: 42}
+	p.inventory[57] = Item{ID: 9, Stack: 7}
+	p.misc[2] = Item{ID: 8} // light pet
+	p.misc[9] = Item{ID: 9} // hook dye
+	p.banks[0][0] = Item{ID: 73, Stack: 3}
+	p.banks[1][39] = Item{ID: 3, Stack: 250}
+	p.banks[2][5] = Item{ID: 4, Stack: 1, Prefix: 1}
+	p.banks[3][10] = Item{ID: 2, Stack: 500}
+	return p
+}
+
+func TestReadPlayer(t *testing.T) {
+	for _, version := range []int32{MinPlayerVersion, 254, 279} {
+		tp := newTestPlayer()
+		tp.version = version
+		p, err := ReadPlayer(bytes.NewReader(tp.build()))
+		if err != nil {
+			t.Fatalf("version %d: ReadPlayer: %v", version, err)
+		}
+
+		if p.Version != version || p.Name != "Ada" || p.Difficulty != JourneyCharacter || p.PlayTime != tp.playTime {
+			t.Errorf("version %d: player %d %q %v %v", version, p.Version, p.Name, p.Difficulty, p.PlayTime)
+		}
+		if p.PlayTimeText() != "3h 07m" {
+			t.Errorf("version %d: play time text %q", version, p.PlayTimeText())
+		}
+		if p.Health != 380 || p.MaxHealth != 400 || p.Mana != 120 || p.MaxMana != 200 {
+			t.Errorf("version %d: health %d/%d, mana %d/%d", version, p.Health, p.MaxHealth, p.Mana, p.MaxMana)
+		}
+
+		// Equipment slots are read with a stack of one, empty or not.
+		worn := func(id int32, prefix uint8) Item { return Item{ID: id, Stack: 1, Prefix: prefix} }
+		empty := worn(0, 0)
+		equipment := []EquipSlot{
+			{Label: "Head", Item: worn(4, 0), Vanity: empty, Dye: empty},
+			{Label: "Body", Item: empty, Vanity: worn(6, 0), Dye: empty},
+			{Label: "Legs", Item: empty, Vanity: empty, Dye: worn(8, 0)},
+			{Label: "Accessory 2", Item: worn(5, 1), Vanity: empty, Dye: empty},
+		}
+		if !reflect.DeepEqual(p.Equipment, equipment) {
+			t.Errorf("version %d: equipment = %+v, want %+v", version, p.Equipment, equipment)
+		}
+		misc := []EquipSlot{
+			{Label: "Light Pet", Item: worn(8, 0), Dye: empty},
+			{Label: "Grappling Hook", Item: empty, Dye: worn(9, 0)},
+		}
+		if !reflect.DeepEqual(p.Misc, misc) {
+			t.Errorf("version %d: misc = %+v, want %+v", version, p.Misc, misc)
+		}
+
+		lists := []struct {
+			name      string
+			got, want []Item
+		}{
+			{"inventory", p.Inventory, []Item{tp.inventory[0], tp.inventory[49]}},
+			{"coins", p.Coins, []Item{tp.inventory[52]}},
+			{"ammo", p.Ammo, []Item{tp.inventory[57]}},
+			{"piggy bank", p.PiggyBank, []Item{tp.banks[0][0]}},
+			{"safe", p.Safe, []Item{tp.banks[1][39]}},
+			{"forge", p.Forge, []Item{tp.banks[2][5]}},
+			{"void vault", p.VoidVault, []Item{tp.banks[3][10]}},
+		}
+		for _, l := range lists {
+			if !reflect.DeepEqual(l.got, l.want) {
+				t.Errorf("version %d: %s = %+v, want %+v", version, l.name, l.got, l.want)
+			}
+		}
+	}
+}
+
+func TestReadPlayerRejects(t *testing.T) {
+	valid := newTestPlayer().build()
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"empty", nil, ErrNotPlayer},
+		{"partial block", valid[:len(valid)-1], ErrNotPlayer},
+		{"not encrypted", newTestPlayer().plaintext()[:64], ErrNotPlayer},
+		{"world", encrypt(testWorld{}.build()), ErrNotPlayer},
+		{"old version", testPlayer{version: MinPlayerVersion - 1, maxHealth: 100}.build(), ErrUnsupportedVersion},
+		{"absurd health", testPlayer{maxHealth: 5000}.build(), ErrUnsupportedVersion},
+	}
+	for _, tt := range tests {
+		if _, err := ReadPlayer(bytes.NewReader(tt.data)); !errors.Is(err, tt.want) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+
+	// A negative stack means the layout is not the one expected.
+	tp := newTestPlayer()
+	tp.banks[1][0] = Item{ID: 2, Stack: -3}
+	if _, err := ReadPlayer(bytes.NewReader(tp.build())); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("negative stack: err = %v, want ErrUnsupportedVersion", err)
+	}
+}
+
+func TestReadPlayerTruncated(t *testing.T) {
+	tp := newTestPlayer()
+	size := len(tp.plaintext())
+	data := tp.build()
+	// CBC decrypts each block on its own, so cutting whole blocks off the
+	// end gives a cut-off player.
+	for n := aes.BlockSize; n < size; n += aes.BlockSize {
+		if _, err := ReadPlayer(bytes.NewReader(data[:n])); err == nil {
+			t.Fatalf("player cut to %d of %d bytes was read", n, size)
+		}
+	}
+}
+
+func TestDifficultyString(t *testing.T) {
+	for d, want := range map[Difficulty]string{Softcore: "Classic", Hardcore: "Hardcore", JourneyCharacter: "Journey", 9: "Difficulty 9"} {
+		if got := d.String(); got != want {
+			t.Errorf("Difficulty(%d) = %q, want %q", d, got, want)
+		}
+	}
+}
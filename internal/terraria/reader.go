@@ -0,0 +1,142 @@
+package terraria
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// reader decodes the little-endian .NET BinaryWriter encoding Terraria saves
+// with. The first error sticks: later reads return zero values and err
+// reports it, so a parser can read a run of fields and check once.
+type reader struct {
+	r   *bufio.Reader
+	pos int64
+	err error
+	buf [8]byte
+}
+
+func newReader(r io.Reader) *reader {
+	return &reader{r: bufio.NewReaderSize(r, 64*1024)}
+}
+
+func (r *reader) read(n int) []byte {
+	if r.err != nil {
+		return r.buf[:n]
+	}
+	if _, err := io.ReadFull(r.r, r.buf[:n]); err != nil {
+		r.fail(err)
+		return r.buf[:n]
+	}
+	r.pos += int64(n)
+	return r.buf[:n]
+}
+
+func (r *reader) fail(err error) {
+	if r.err != nil {
+		return
+	}
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	r.err = fmt.Errorf("at offset %d: %w", r.pos, err)
+	// Zero the scratch buffer so failed reads decode as zero.
+	r.buf = [8]byte{}
+}
+
+func (r *reader) u8() uint8 { return r.read(1)[0] }
+
+func (r *reader) bool() bool { return r.u8() != 0 }
+
+func (r *reader) i16() int16 { return int16(binary.LittleEndian.Uint16(r.read(2))) }
+
+func (r *reader) u16() uint16 { return binary.LittleEndian.Uint16(r.read(2)) }
+
+func (r *reader) i32() int32 { return int32(binary.LittleEndian.Uint32(r.read(4))) }
+
+func (r *reader) u32() uint32 { return binary.LittleEndian.Uint32(r.read(4)) }
+
+func (r *reader) i64() int64 { return int64(binary.LittleEndian.Uint64(r.read(8))) }
+
+func (r *reader) u64() uint64 { return binary.LittleEndian.Uint64(r.read(8)) }
+
+func (r *reader) f32() float32 { return math.Float32frombits(r.u32()) }
+
+func (r *reader) f64() float64 { return math.Float64frombits(r.u64()) }
+
+// maxString bounds string lengths so a corrupt file cannot make the parser
+// allocate gigabytes.
+const maxString = 1 << 16
+
+// str reads a string prefixed with its byte length as a 7-bit varint.
+func (r *reader) str() string {
+	var n, shift int
+	for {
+		b := r.u8()
+		n |= int(b&0x7f) << shift
+		if b&0x80 == 0 || r.err != nil {
+			break
+		}
+		shift += 7
+		if shift > 28 {
+			r.fail(fmt.Errorf("string length too long"))
+			return ""
+		}
+	}
+	if n > maxString {
+		r.fail(fmt.Errorf("string of %d bytes", n))
+		return ""
+	}
+	return string(r.bytes(n))
+}
+
+func (r *reader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r.r, b); err != nil {
+		r.fail(err)
+		return nil
+	}
+	r.pos += int64(n)
+	return b
+}
+
+func (r *reader) skip(n int64) {
+	if r.err != nil || n <= 0 {
+		return
+	}
+	skipped, err := r.r.Discard(int(n))
+	r.pos += int64(skipped)
+	if err != nil {
+		r.fail(err)
+	}
+}
+
+// seek skips forward to the absolute offset pos.
+func (r *reader) seek(pos int64) {
+	if r.err == nil && pos < r.pos {
+		r.fail(fmt.Errorf("cannot seek back to %d", pos))
+		return
+	}
+	r.skip(pos - r.pos)
+}
+
+// netEpochTicks is the number of 100ns ticks from 0001-01-01 to 1970-01-01.
+const netEpochTicks = 621355968000000000
+
+// dateTime decodes a .NET DateTime.ToBinary value. Local times are stored
+// as UTC ticks with a flag in the top bits, so masking the flags gives UTC
+// for both kinds.
+func (r *reader) dateTime() time.Time {
+	ticks := r.i64() & 0x3fffffffffffffff
+	if ticks == 0 {
+		return time.Time{}
+	}
+	ticks -= netEpochTicks
+	return time.Unix(ticks/1e7, (ticks%1e7)*100).UTC()
+}
@@ -0,0 +1,127 @@
+package terraria
+
+import "fmt"
+
+// Liquid types stored in Tile.Liquid.
+const (
+	LiquidNone uint8 = iota
+	LiquidWater
+	LiquidLava
+	LiquidHoney
+	LiquidShimmer
+)
+
+// Tile is one cell of a world's tile grid.
+type Tile struct {
+	// Active is set when the cell holds a block or a piece of furniture
+	// of the given Type.
+	Active bool
+	Type   uint16
+	// U and V locate framed tiles, such as furniture, in their sprite
+	// sheet. They are -1 for other tiles.
+	U, V int16
+	// Inactive is set for blocks switched off by an actuator.
+	Inactive     bool
+	Wall         uint16
+	Liquid       uint8
+	LiquidAmount uint8
+}
+
+// checkWorldSize rejects dimensions no Terraria world has, before anything
+// is allocated for them.
+func checkWorldSize(h *WorldHeader) error {
+	if h.Width <= 0 || h.Height <= 0 || h.Width > 1<<14 || h.Height > 1<<14 {
+		return fmt.Errorf("world of %dx%d tiles", h.Width, h.Height)
+	}
+	return nil
+}
+
+// readColumn decodes the next column of the tile section into col, which
+// must hold h.Height tiles. Columns are stored left to right, each top to
+// bottom, with runs of identical tiles stored once.
+func readColumn(rd *reader, h *WorldHeader, col []Tile) error {
+	height := len(col)
+	for y := 0; y < height; {
+		var t Tile
+		t.U, t.V = -1, -1
+
+		flags1 := rd.u8()
+		var flags2, flags3 uint8
+		if flags1&1 != 0 {
+			flags2 = rd.u8()
+			if flags2&1 != 0 {
+				flags3 = rd.u8()
+				if flags3&1 != 0 {
+					rd.u8() // coatings, which are not needed
+				}
+			}
+		}
+
+		if flags1&2 != 0 {
+			t.Active = true
+			if flags1&32 != 0 {
+				t.Type = rd.u16()
+			} else {
+				t.Type = uint16(rd.u8())
+			}
+			if int(t.Type) < len(h.importance) && h.importance[t.Type] {
+				t.U = rd.i16()
+				t.V = rd.i16()
+			}
+			if flags3&8 != 0 {
+				rd.u8() // paint
+			}
+		}
+
+		if flags1&4 != 0 {
+			t.Wall = uint16(rd.u8())
+			if flags3&16 != 0 {
+				rd.u8() // wall paint
+			}
+		}
+
+		if liquid := (flags1 & 0x18) >> 3; liquid != 0 {
+			t.LiquidAmount = rd.u8()
+			t.Liquid = liquid
+			if flags3&128 != 0 {
+				t.Liquid = LiquidShimmer
+			}
+		}
+
+		if flags3&4 != 0 {
+			t.Inactive = true
+		}
+		if flags3&64 != 0 {
+			t.Wall |= uint16(rd.u8()) << 8
+		}
+
+		n := 1
+		switch flags1 >> 6 {
+		case 1:
+			n += int(rd.u8())
+		case 2, 3:
+			n += int(rd.u16())
+		}
+
+		if rd.err != nil {
+			return fmt.Errorf("read tiles: %w", rd.err)
+		}
+		if y+n > height {
+			return fmt.Errorf("read tiles: run of %d at row %d overflows the world", n, y)
+		}
+		for i := y; i < y+n; i++ {
+			col[i] = t
+		}
+		y += n
+	}
+	return nil
+}
+
+// checkSection reports an error unless rd has just read all of section i,
+// which catches format changes the parser does not know about.
+func checkSection(rd *reader, h *WorldHeader, i int, name string) error {
+	if len(h.sections) > i && rd.pos != int64(h.sections[i]) {
+		return fmt.Errorf("read %s: section ends at %d, expected %d", name, rd.pos, h.sections[i])
+	}
+	return nil
+}
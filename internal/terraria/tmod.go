@@ -0,0 +1,147 @@
+package terraria
+
+import (
+	"os"
+	"sort"
+	"strings"
+)
+
+// Mod is a tModLoader mod a save uses, with how much of its content the
+// save holds.
+type Mod struct {
+	Name string `json:"name"`
+	// TileTypes and WallTypes count the mod's kinds of tile and wall the
+	// world has placed.
+	TileTypes int `json:"tile_types"`
+	WallTypes int `json:"wall_types"`
+	// Items counts the mod's items, by stack, in chests or the inventory.
+	Items int `json:"items"`
+}
+
+// ModData is what a tModLoader sidecar (.twld or .tplr) records about the
+// mods its world or player uses.
+type ModData struct {
+	Mods      []Mod `json:"mods"`
+	TileTypes int   `json:"tile_types"`
+	WallTypes int   `json:"wall_types"`
+	Items     int   `json:"items"`
+}
+
+// ReadModDataFile reads the tModLoader sidecar at path.
+func ReadModDataFile(path string) (*ModData, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	root, err := readNBT(f)
+	if err != nil {
+		return nil, err
+	}
+
+	mods := make(map[string]*Mod)
+	mod := func(name string) *Mod {
+		m, ok := mods[name]
+		if !ok {
+			m = &Mod{Name: name}
+			mods[name] = m
+		}
+		return m
+	}
+	walkModData(root, "", func(name, kind string, n int) {
+		// Vanilla content is saved under the game's own name.
+		if name == "" || name == "Terraria" {
+			return
+		}
+		m := mod(name)
+		switch kind {
+		case "tile":
+			m.TileTypes += n
+		case "wall":
+			m.WallTypes += n
+		case "item":
+			m.Items += n
+		}
+	})
+
+	d := &ModData{Mods: []Mod{}}
+	for _, m := range mods {
+		d.Mods = append(d.Mods, *m)
+		d.TileTypes += m.TileTypes
+		d.WallTypes += m.WallTypes
+		d.Items += m.Items
+	}
+	sort.Slice(d.Mods, func(i, j int) bool {
+		return strings.ToLower(d.Mods[i].Name) < strings.ToLower(d.Mods[j].Name)
+	})
+	return d, nil
+}
+
+// walkModData calls found for every mod reference under v, the value at key.
+// tModLoader saves modded content as compounds with "mod" and "name"
+// entries; which kind of content they are follows from where they sit:
+// the tile and wall maps, or anything with a stack or under an item list.
+// The "usedMods" list names mods without content.
+func walkModData(v interface{}, key string, found func(mod, kind string, n int)) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if mod, ok := v["mod"].(string); ok {
+			if _, ok := v["name"].(string); ok {
+				found(mod, modContentKind(key, v), modStack(v))
+			}
+		}
+		if used, ok := v["usedMods"].([]interface{}); ok {
+			for _, name := range used {
+				if name, ok := name.(string); ok {
+					found(name, "", 0)
+				}
+			}
+		}
+		for k, child := range v {
+			walkModData(child, contextKey(key, k), found)
+		}
+	case []interface{}:
+		for _, child := range v {
+			walkModData(child, key, found)
+		}
+	}
+}
+
+// contextKey keeps the innermost key that says what a subtree holds, so
+// entries of the tile map are still known as tiles inside nested lists.
+func contextKey(parent, key string) string {
+	switch strings.ToLower(key) {
+	case "tilemap", "wallmap", "items", "item", "inventory", "armor", "dye", "miscequips", "miscdyes", "bank", "bank2", "bank3", "bank4", "chests", "npcs", "tileentities", "modbuffs":
+		return strings.ToLower(key)
+	}
+	return parent
+}
+
+func modContentKind(key string, tag map[string]interface{}) string {
+	switch key {
+	case "tilemap":
+		return "tile"
+	case "wallmap":
+		return "wall"
+	case "npcs", "tileentities", "modbuffs":
+		return ""
+	}
+	if _, ok := tag["stack"]; ok {
+		return "item"
+	}
+	switch key {
+	case "items", "item", "inventory", "armor", "dye", "miscequips", "miscdyes", "bank", "bank2", "bank3", "bank4", "chests":
+		return "item"
+	}
+	return ""
+}
+
+// modStack is how many of a piece of content a tag stands for: an item's
+// stack, or one.
+func modStack(tag map[string]interface{}) int {
+	if n, ok := tag["stack"].(int64); ok && n > 0 {
+		return int(n)
+	}
+	return 1
+}
@@ -0,0 +1,277 @@
+package terraria
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"math"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// Values for nbtWriter: compounds keep their order, lists name the type of
+// their elements.
+type (
+	nbtCompound []nbtField
+	nbtField    struct {
+		name  string
+		value interface{}
+	}
+	nbtList struct {
+		elem  byte
+		items []interface{}
+	}
+)
+
+// nbtWriter encodes tags the way nbtReader decodes them.
+type nbtWriter struct {
+	bytes.Buffer
+}
+
+func nbtTag(v interface{}) byte {
+	switch v.(type) {
+	case int8:
+		return tagByte
+	case int16:
+		return tagShort
+	case int32:
+		return tagInt
+	case int64:
+		return tagLong
+	case float32:
+		return tagFloat
+	case float64:
+		return tagDouble
+	case []byte:
+		return tagByteArray
+	case string:
+		return tagString
+	case nbtList:
+		return tagList
+	case nbtCompound:
+		return tagCompound
+	case []int32:
+		return tagIntArray
+	}
+	panic("no NBT tag for value")
+}
+
+func (w *nbtWriter) str(s string) {
+	w.Write(binary.BigEndian.AppendUint16(nil, uint16(len(s))))
+	w.WriteString(s)
+}
+
+func (w *nbtWriter) i32(n int32) {
+	w.Write(binary.BigEndian.AppendUint32(nil, uint32(n)))
+}
+
+func (w *nbtWriter) payload(v interface{}) {
+	switch v := v.(type) {
+	case int8:
+		w.WriteByte(byte(v))
+	case int16:
+		w.Write(binary.BigEndian.AppendUint16(nil, uint16(v)))
+	case int32:
+		w.i32(v)
+	case int64:
+		w.Write(binary.BigEndian.AppendUint64(nil, uint64(v)))
+	case float32:
+		w.i32(int32(math.Float32bits(v)))
+	case float64:
+		w.Write(binary.BigEndian.AppendUint64(nil, math.Float64bits(v)))
+	case []byte:
+		w.i32(int32(len(v)))
+		w.Write(v)
+	case string:
+		w.str(v)
+	case nbtList:
+		w.WriteByte(v.elem)
+		w.i32(int32(len(v.items)))
+		for _, item := range v.items {
+			w.payload(item)
+		}
+	case nbtCompound:
+		for _, f := range v {
+			w.WriteByte(nbtTag(f.value))
+			w.str(f.name)
+			w.payload(f.value)
+		}
+		w.WriteByte(tagEnd)
+	case []int32:
+		w.i32(int32(len(v)))
+		for _, n := range v {
+			w.i32(n)
+		}
+	}
+}
+
+// nbtBytes encodes root as an uncompressed NBT file.
+func nbtBytes(root nbtCompound) []byte {
+	w := &nbtWriter{}
+	w.WriteByte(tagCompound)
+	w.str("")
+	w.payload(root)
+	return w.Bytes()
+}
+
+func gzipBytes(data []byte) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	gz.Write(data)
+	gz.Close()
+	return buf.Bytes()
+}
+
+func modEntry(mod, name string, extra ...nbtField) nbtCompound {
+	return append(nbtCompound{{"mod", mod}, {"name", name}}, extra...)
+}
+
+func compounds(items ...nbtCompound) nbtList {
+	l := nbtList{elem: tagCompound}
+	for _, item := range items {
+		l.items = append(l.items, item)
+	}
+	return l
+}
+
+// testSidecar is a .twld with the parts of a world tModLoader saves mods
+// in, and values of every tag type.
+var testSidecar = nbtCompound{
+	{"usedMods", nbtList{elem: tagString, items: []interface{}{"CalamityMod", "Unused"}}},
+	{"tiles", nbtCompound{
+		{"tileMap", compounds(
+			modEntry("CalamityMod", "AstralOre", nbtField{"value", int16(700)}),
+			modEntry("Terraria", "Dirt"),
+		)},
+		{"wallMap", compounds(modEntry("ThoriumMod", "SmoothWall"))},
+		{"data", []byte{1, 2, 3, 4}},
+	}},
+	{"chests", compounds(nbtCompound{
+		{"x", int32(10)},
+		{"ids", []int32{7, 8, 9}},
+		{"items", compounds(
+			modEntry("CalamityMod", "Murasama", nbtField{"stack", int32(5)}),
+			modEntry("ThoriumMod", "Pearl", nbtField{"stack", int32(2)}),
+		)},
+	})},
+	// Modded NPCs are not content the save holds.
+	{"npcs", compounds(modEntry("CalamityMod", "Yharon", nbtField{"stack", int32(1)}))},
+	{"misc", nbtCompound{
+		{"byte", int8(-2)},
+		{"long", int64(1) << 40},
+		{"float", float32(1.5)},
+		{"double", 2.25},
+	}},
+}
+
+func TestReadNBT(t *testing.T) {
+	root, err := readNBT(bytes.NewReader(gzipBytes(nbtBytes(testSidecar))))
+	if err != nil {
+		t.Fatalf("readNBT: %v", err)
+	}
+	misc := root["misc"].(map[string]interface{})
+	want := map[string]interface{}{"byte": int64(-2), "long": int64(1) << 40, "float": 1.5, "double": 2.25}
+	if !reflect.DeepEqual(misc, want) {
+		t.Errorf("misc = %v, want %v", misc, want)
+	}
+	tiles := root["tiles"].(map[string]interface{})
+	if tiles["data"] != nil {
+		t.Errorf("byte array decoded to %v, want nil", tiles["data"])
+	}
+	tileMap := tiles["tileMap"].([]interface{})
+	if len(tileMap) != 2 || tileMap[0].(map[string]interface{})["value"] != int64(700) {
+		t.Errorf("tile map = %v", tileMap)
+	}
+}
+
+func TestReadNBTRejects(t *testing.T) {
+	raw := nbtBytes(testSidecar)
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"not gzip", raw},
+		{"empty", gzipBytes(nil)},
+		{"string root", gzipBytes([]byte{tagString, 0, 0, 0, 0})},
+		{"unknown tag", gzipBytes([]byte{tagCompound, 0, 0, 42, 0, 0})},
+		{"negative list", gzipBytes([]byte{tagCompound, 0, 0, tagList, 0, 0, tagInt, 0xff, 0xff, 0xff, 0xff})},
+	}
+	for _, tt := range tests {
+		if _, err := readNBT(bytes.NewReader(tt.data)); err == nil {
+			t.Errorf("%s: accepted", tt.name)
+		}
+	}
+	if _, err := readNBT(bytes.NewReader(raw)); !errors.Is(err, ErrNotNBT) {
+		t.Errorf("not gzip: err = %v, want ErrNotNBT", err)
+	}
+
+	for n := 1; n < len(raw); n++ {
+		if _, err := readNBT(bytes.NewReader(gzipBytes(raw[:n]))); err == nil {
+			t.Fatalf("NBT cut to %d of %d bytes was read", n, len(raw))
+		}
+	}
+
+	var deep interface{} = nbtCompound{}
+	for i := 0; i < maxNBTDepth+1; i++ {
+		deep = nbtList{elem: nbtTag(deep), items: []interface{}{deep}}
+	}
+	if _, err := readNBT(bytes.NewReader(gzipBytes(nbtBytes(nbtCompound{{"deep", deep}})))); err == nil {
+		t.Error("tags nested too deeply were read")
+	}
+}
+
+func TestReadModDataFile(t *testing.T) {
+	path := writeTemp(t, "world.twld", gzipBytes(nbtBytes(testSidecar)))
+	d, err := ReadModDataFile(path)
+	if err != nil {
+		t.Fatalf("ReadModDataFile: %v", err)
+	}
+	want := &ModData{
+		Mods: []Mod{
+			{Name: "CalamityMod", TileTypes: 1, Items: 5},
+			{Name: "ThoriumMod", WallTypes: 1, Items: 2},
+			{Name: "Unused"},
+		},
+		TileTypes: 1,
+		WallTypes: 1,
+		Items:     7,
+	}
+	if !reflect.DeepEqual(d, want) {
+		t.Errorf("mod data = %+v, want %+v", d, want)
+	}
+
+	// A player's sidecar holds items under its inventory and banks.
+	player := nbtCompound{
+		{"inventory", compounds(modEntry("ThoriumMod", "Pearl", nbtField{"stack", int32(3)}))},
+		{"bank2", compounds(modEntry("CalamityMod", "Murasama"))},
+	}
+	if err := os.WriteFile(path, gzipBytes(nbtBytes(player)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	d, err = ReadModDataFile(path)
+	if err != nil {
+		t.Fatalf("ReadModDataFile of a player: %v", err)
+	}
+	if want := []Mod{{Name: "CalamityMod", Items: 1}, {Name: "ThoriumMod", Items: 3}}; !reflect.DeepEqual(d.Mods, want) {
+		t.Errorf("player mods = %+v, want %+v", d.Mods, want)
+	}
+}
+
+func TestModDataCache(t *testing.T) {
+	path := writeTemp(t, "world.twld", gzipBytes(nbtBytes(nbtCompound{
+		{"usedMods", nbtList{elem: tagString, items: []interface{}{"OnlyMod"}}},
+	})))
+	cache := NewModDataCache()
+	if d, err := cache.Get(path); err != nil || len(d.Mods) != 1 || d.Mods[0].Name != "OnlyMod" {
+		t.Fatalf("Get = %+v, %v", d, err)
+	}
+
+	if err := os.WriteFile(path, []byte("no longer a sidecar"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cache.Get(path); !errors.Is(err, ErrNotNBT) {
+		t.Errorf("Get of a broken file: err = %v, want ErrNotNBT", err)
+	}
+}
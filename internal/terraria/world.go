@@ -0,0 +1,414 @@
+// Package terraria reads Terraria save files on the server, so pages can
+// show what a backup holds without sending it to the browser first.
+package terraria
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// MinWorldVersion is the oldest world format the parser reads, that of
+// Terraria 1.4.0.1. Older worlds are reported as unsupported.
+const MinWorldVersion = 225
+
+var (
+	// ErrNotWorld is returned for files that are not Terraria worlds.
+	ErrNotWorld = errors.New("not a Terraria world file")
+	// ErrUnsupportedVersion is returned for worlds and players saved by
+	// a Terraria release the parser does not know.
+	ErrUnsupportedVersion = errors.New("unsupported world version")
+)
+
+const (
+	fileTypeWorld  = 2
+	fileTypePlayer = 3
+)
+
+// GameMode is a world's difficulty.
+type GameMode int32
+
+const (
+	Classic GameMode = iota
+	Expert
+	Master
+	Journey
+)
+
+func (m GameMode) String() string {
+	switch m {
+	case Classic:
+		return "Classic"
+	case Expert:
+		return "Expert"
+	case Master:
+		return "Master"
+	case Journey:
+		return "Journey"
+	}
+	return fmt.Sprintf("Mode %d", int32(m))
+}
+
+func (m GameMode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// Boss records whether a world's players have defeated a boss.
+type Boss struct {
+	Name     string `json:"name"`
+	Defeated bool   `json:"defeated"`
+}
+
+// WorldHeader is the summary Terraria stores at the start of a .wld file.
+type WorldHeader struct {
+	Version  int32    `json:"version"`
+	Revision uint32   `json:"revision"`
+	Name     string   `json:"name"`
+	Seed     string   `json:"seed"`
+	WorldID  int32    `json:"world_id"`
+	Width    int32    `json:"width"`
+	Height   int32    `json:"height"`
+	GameMode GameMode `json:"difficulty"`
+	// SpecialSeeds names the secret seeds the world was generated with.
+	SpecialSeeds []string  `json:"special_seeds"`
+	Created      time.Time `json:"created"`
+	Hardmode     bool      `json:"hardmode"`
+	// Evil is Corruption or Crimson.
+	Evil   string `json:"evil"`
+	Bosses []Boss `json:"bosses"`
+
+	SpawnX       int32   `json:"spawn_x"`
+	SpawnY       int32   `json:"spawn_y"`
+	SurfaceLevel float64 `json:"surface_level"`
+	CavernLevel  float64 `json:"cavern_level"`
+	DungeonX     int32   `json:"dungeon_x"`
+	DungeonY     int32   `json:"dungeon_y"`
+
+	// sections holds the file offsets of the world's sections: header,
+	// tiles, chests, signs, NPCs, tile entities and so on.
+	sections []int32
+	// importance marks the tile types that store frame coordinates.
+	importance []bool
+}
+
+// DefeatedBosses counts the bosses in h.Bosses that were defeated.
+func (h *WorldHeader) DefeatedBosses() int {
+	n := 0
+	for _, b := range h.Bosses {
+		if b.Defeated {
+			n++
+		}
+	}
+	return n
+}
+
+// ReadWorldHeaderFile reads the header of the world file at path.
+func ReadWorldHeaderFile(path string) (*WorldHeader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadWorldHeader(f)
+}
+
+// ReadWorldHeader reads the header from the start of a world file.
+func ReadWorldHeader(r io.Reader) (*WorldHeader, error) {
+	return readWorldHeader(newReader(r))
+}
+
+// readWorldHeader leaves rd at the end of the header section, where the
+// tile data starts.
+func readWorldHeader(rd *reader) (*WorldHeader, error) {
+	h := &WorldHeader{}
+	if err := readFileMetadata(rd, h, fileTypeWorld); err != nil {
+		return nil, err
+	}
+	if h.Version < MinWorldVersion {
+		return nil, fmt.Errorf("%w %d (Terraria 1.4 or later is required)", ErrUnsupportedVersion, h.Version)
+	}
+
+	readHeaderFields(rd, h)
+	if rd.err != nil {
+		return nil, fmt.Errorf("read world header: %w", rd.err)
+	}
+
+	// The header is checked against the offset the file records for the
+	// next section, which catches a Terraria release that added fields
+	// this parser does not know about.
+	if len(h.sections) > 1 && rd.pos != int64(h.sections[1]) {
+		return nil, fmt.Errorf("%w %d: header ends at %d, expected %d", ErrUnsupportedVersion, h.Version, rd.pos, h.sections[1])
+	}
+	return h, nil
+}
+
+// readFileMetadata reads the version, file type, section offsets and tile
+// importance table that precede a world's header.
+func readFileMetadata(rd *reader, h *WorldHeader, fileType byte) error {
+	h.Version = rd.i32()
+	magic := rd.bytes(7)
+	kind := rd.u8()
+	if rd.err != nil || string(magic) != "relogic" || kind != fileType {
+		return ErrNotWorld
+	}
+	h.Revision = rd.u32()
+	rd.u64() // favourite flag
+
+	n := rd.i16()
+	if n < 0 || n > 64 {
+		return ErrNotWorld
+	}
+	h.sections = make([]int32, n)
+	for i := range h.sections {
+		h.sections[i] = rd.i32()
+	}
+
+	n = rd.i16()
+	if n < 0 {
+		return ErrNotWorld
+	}
+	h.importance = make([]bool, n)
+	var bits byte
+	for i := range h.importance {
+		if i%8 == 0 {
+			bits = rd.u8()
+		}
+		h.importance[i] = bits&(1<<(i%8)) != 0
+	}
+
+	if rd.err != nil {
+		return fmt.Errorf("read world metadata: %w", rd.err)
+	}
+	return nil
+}
+
+// readHeaderFields follows the field order of Terraria's WorldFile, as the
+// TerraMap viewer in static/terramap does. Most fields are only skipped.
+func readHeaderFields(rd *reader, h *WorldHeader) {
+	v := h.Version
+
+	h.Name = rd.str()
+	h.Seed = rd.str()
+	rd.u64()    // world generator version
+	rd.skip(16) // unique ID
+	h.WorldID = rd.i32()
+	rd.skip(4 * 4) // left, right, top and bottom edges in pixels
+	h.Height = rd.i32()
+	h.Width = rd.i32()
+
+	h.GameMode = GameMode(rd.i32())
+	seeds := []struct {
+		since int32
+		name  string
+	}{
+		{222, "Drunk World"},
+		{227, "For the Worthy"},
+		{238, "Celebrationmk10"},
+		{239, "The Constant"},
+		{241, "Not the Bees"},
+		{249, "Don't Dig Up"},
+		{266, "No Traps"},
+		{267, "Get Fixed Boi"},
+		{302, "Skyblock"},
+	}
+	for _, s := range seeds {
+		if v >= s.since && rd.bool() {
+			h.SpecialSeeds = append(h.SpecialSeeds, s.name)
+		}
+	}
+
+	h.Created = rd.dateTime()
+	rd.i64() // last played
+
+	rd.u8()         // moon type
+	rd.skip(14 * 4) // tree and cave background positions and styles
+	rd.skip(3 * 4)  // ice, jungle and underworld backgrounds
+	h.SpawnX = rd.i32()
+	h.SpawnY = rd.i32()
+	h.SurfaceLevel = rd.f64()
+	h.CavernLevel = rd.f64()
+	rd.f64()  // time of day
+	rd.bool() // daytime
+	rd.i32()  // moon phase
+	rd.bool() // blood moon
+	rd.bool() // eclipse
+	h.DungeonX = rd.i32()
+	h.DungeonY = rd.i32()
+
+	h.Evil = "Corruption"
+	if rd.bool() {
+		h.Evil = "Crimson"
+	}
+
+	eye := rd.bool()
+	evilBoss := rd.bool()
+	skeletron := rd.bool()
+	queenBee := rd.bool()
+	destroyer := rd.bool()
+	twins := rd.bool()
+	prime := rd.bool()
+	rd.bool() // any mechanical boss
+	plantera := rd.bool()
+	golem := rd.bool()
+	kingSlime := rd.bool()
+
+	rd.skip(4) // goblin tinkerer, wizard and mechanic rescued, goblin army
+	rd.skip(3) // clown, frost legion and pirates defeated
+	rd.skip(2) // shadow orb smashed, meteor spawned
+	rd.u8()    // shadow orbs smashed
+	rd.i32()   // altars smashed
+	h.Hardmode = rd.bool()
+	rd.bool()      // party of doom
+	rd.skip(3 * 4) // invasion delay, size and type
+	rd.f64()       // invasion position
+	rd.f64()       // slime rain time
+	rd.u8()        // sundial cooldown
+	rd.bool()      // raining
+	rd.i32()       // rain time
+	rd.f32()       // max rain
+	rd.skip(3 * 4) // hardmode ore types
+	rd.skip(8)     // biome background styles
+	rd.i32()       // cloud background
+	rd.i16()       // number of clouds
+	rd.f32()       // wind speed
+
+	anglers := rd.i32()
+	if anglers < 0 || anglers > 1000 {
+		rd.fail(fmt.Errorf("%d anglers", anglers))
+	}
+	for i := int32(0); i < anglers && rd.err == nil; i++ {
+		rd.str()
+	}
+	rd.bool() // angler rescued
+	rd.i32()  // angler quest
+	rd.bool() // stylist rescued
+	rd.bool() // tax collector rescued
+	rd.bool() // golfer rescued
+	rd.i32()  // invasion start size
+	rd.i32()  // cultist delay
+
+	kills := rd.i16()
+	rd.skip(int64(kills) * 4)
+	claimable := rd.i16()
+	rd.skip(int64(claimable) * 2)
+	rd.bool() // fast forward time
+
+	fishron := rd.bool()
+	rd.bool() // martian madness
+	cultist := rd.bool()
+	moonLord := rd.bool()
+	pumpking := rd.bool()
+	mourningWood := rd.bool()
+	iceQueen := rd.bool()
+	santank := rd.bool()
+	everscream := rd.bool()
+	rd.skip(4) // celestial pillars defeated
+	rd.skip(4) // celestial pillars active
+	rd.bool()  // lunar events
+
+	rd.skip(2) // party manual and genuine
+	rd.i32()   // party cooldown
+	partying := rd.i32()
+	rd.skip(int64(partying) * 4)
+
+	rd.bool()  // sandstorm
+	rd.i32()   // sandstorm time left
+	rd.f32()   // sandstorm severity
+	rd.f32()   // sandstorm intended severity
+	rd.bool()  // bartender rescued
+	rd.skip(3) // old one's army tiers
+	rd.skip(1) // mushroom background
+	rd.skip(1) // underworld background
+	rd.skip(3) // tree backgrounds
+	rd.bool()  // combat book used
+	rd.i32()   // lantern night cooldown
+	rd.skip(3) // lantern night flags
+
+	treeTops := rd.i32()
+	if treeTops > 13 {
+		treeTops = 13
+	}
+	rd.skip(int64(treeTops) * 4)
+	rd.skip(2)     // forced halloween and christmas
+	rd.skip(4 * 4) // saved pre-hardmode ore types
+	rd.skip(3)     // pets bought
+
+	empress := rd.bool()
+	queenSlime := rd.bool()
+	deerclops := v >= 240 && rd.bool()
+
+	if v >= 250 {
+		rd.skip(1) // blue slime unlocked
+	}
+	if v >= 251 {
+		rd.skip(8) // town NPCs unlocked
+	}
+	if v >= 259 {
+		rd.skip(1) // second combat book used
+	}
+	if v >= 260 {
+		rd.skip(1) // peddler's satchel used
+	}
+	if v >= 261 {
+		rd.skip(7) // town slimes unlocked
+	}
+	if v >= 264 {
+		rd.skip(2) // moondial
+	}
+	if v >= 287 {
+		rd.skip(2) // forced halloween and christmas forever
+	}
+	if v >= 288 {
+		rd.skip(1) // vampire seed
+	}
+	if v >= 296 {
+		rd.skip(1) // infected seed
+	}
+	if v >= 291 {
+		rd.skip(2 * 4) // meteor shower and coin rain counters
+	}
+	if v >= 297 {
+		rd.skip(1) // team based spawns
+		spawns := rd.u8()
+		rd.skip(int64(spawns) * 4)
+	}
+	if v >= 304 {
+		rd.skip(1) // dual dungeons seed
+	}
+	if v >= 299 && v < 313 {
+		rd.skip(4)
+	}
+	if v >= 299 {
+		rd.str() // manifest
+	}
+
+	evilBossName := "Eater of Worlds"
+	if h.Evil == "Crimson" {
+		evilBossName = "Brain of Cthulhu"
+	}
+	h.Bosses = []Boss{
+		{"King Slime", kingSlime},
+		{"Eye of Cthulhu", eye},
+		{evilBossName, evilBoss},
+		{"Queen Bee", queenBee},
+		{"Skeletron", skeletron},
+		{"Deerclops", deerclops},
+		{"Queen Slime", queenSlime},
+		{"The Twins", twins},
+		{"The Destroyer", destroyer},
+		{"Skeletron Prime", prime},
+		{"Plantera", plantera},
+		{"Golem", golem},
+		{"Duke Fishron", fishron},
+		{"Empress of Light", empress},
+		{"Lunatic Cultist", cultist},
+		{"Moon Lord", moonLord},
+		{"Mourning Wood", mourningWood},
+		{"Pumpking", pumpking},
+		{"Everscream", everscream},
+		{"Santa-NK1", santank},
+		{"Ice Queen", iceQueen},
+	}
+}
@@ -0,0 +1,508 @@
+package terraria
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// writer encodes values the way reader decodes them, for building saves in
+// tests.
+type writer struct {
+	bytes.Buffer
+}
+
+func (w *writer) u8(v uint8) { w.WriteByte(v) }
+
+func (w *writer) bool(v bool) {
+	if v {
+		w.u8(1)
+	} else {
+		w.u8(0)
+	}
+}
+
+func (w *writer) i16(v int16)   { w.u16(uint16(v)) }
+func (w *writer) u16(v uint16)  { w.Write(binary.LittleEndian.AppendUint16(nil, v)) }
+func (w *writer) i32(v int32)   { w.u32(uint32(v)) }
+func (w *writer) u32(v uint32)  { w.Write(binary.LittleEndian.AppendUint32(nil, v)) }
+func (w *writer) i64(v int64)   { w.u64(uint64(v)) }
+func (w *writer) u64(v uint64)  { w.Write(binary.LittleEndian.AppendUint64(nil, v)) }
+func (w *writer) f32(v float32) { w.u32(math.Float32bits(v)) }
+func (w *writer) f64(v float64) { w.u64(math.Float64bits(v)) }
+func (w *writer) zero(n int)    { w.Write(make([]byte, n)) }
+
+func (w *writer) str(s string) {
+	n := len(s)
+	for n >= 0x80 {
+		w.u8(byte(n) | 0x80)
+		n >>= 7
+	}
+	w.u8(byte(n))
+	w.WriteString(s)
+}
+
+// dateTime writes t as a .NET DateTime.ToBinary value of kind Local, whose
+// flag bits the reader must mask off.
+func (w *writer) dateTime(t time.Time) {
+	ticks := t.Unix()*1e7 + int64(t.Nanosecond()/100) + netEpochTicks
+	w.u64(uint64(ticks) | 1<<63)
+}
+
+// testWorld describes a world file for build to write. Fields left zero
+// get plausible defaults where the format needs them.
+type testWorld struct {
+	version       int32
+	name, seed    string
+	worldID       int32
+	width, height int32
+	mode          GameMode
+	drunk         bool
+	created       time.Time
+	hardmode      bool
+	crimson       bool
+	// defeated names the bosses, as in WorldHeader.Bosses, to mark as
+	// defeated.
+	defeated        map[string]bool
+	spawnX, spawnY  int32
+	surface, cavern float64
+	// importance marks the tile types stored with frame coordinates.
+	importance []bool
+	// tile returns the tile at x, y; nil leaves the world empty.
+	tile func(x, y int) Tile
+	// chests are written with a slot count per chest when slotsPerChest
+	// is set, as Terraria does since 1.4.5, or with one shared count.
+	chests        []Chest
+	slotsPerChest bool
+}
+
+// build writes w as a world file.
+func (tw testWorld) build() []byte {
+	if tw.version == 0 {
+		tw.version = 279
+	}
+	if tw.width == 0 {
+		tw.width, tw.height = 8, 6
+	}
+	if tw.surface == 0 {
+		tw.surface, tw.cavern = 2, 4
+	}
+
+	w := &writer{}
+	w.i32(tw.version)
+	w.WriteString("relogic")
+	w.u8(fileTypeWorld)
+	w.u32(1) // revision
+	w.u64(0) // favourite
+
+	// The offsets of the header, tiles, chests and signs sections are
+	// filled in once they are known.
+	const sections = 4
+	w.i16(sections)
+	offsetsAt := w.Len()
+	w.zero(4 * sections)
+
+	w.i16(int16(len(tw.importance)))
+	var bits byte
+	for i, important := range tw.importance {
+		if important {
+			bits |= 1 << (i % 8)
+		}
+		if i%8 == 7 || i == len(tw.importance)-1 {
+			w.u8(bits)
+			bits = 0
+		}
+	}
+
+	offsets := []int{w.Len()}
+	tw.writeHeader(w)
+	offsets = append(offsets, w.Len())
+	tw.writeTiles(w)
+	offsets = append(offsets, w.Len())
+	tw.writeChests(w)
+	offsets = append(offsets, w.Len())
+	w.zero(16) // signs and the rest, which are never read
+
+	data := w.Bytes()
+	for i, off := range offsets {
+		binary.LittleEndian.PutUint32(data[offsetsAt+4*i:], uint32(off))
+	}
+	return data
+}
+
+// writeHeader follows readHeaderFields.
+func (tw testWorld) writeHeader(w *writer) {
+	v := tw.version
+	boss := func(name string) bool { return tw.defeated[name] }
+
+	w.str(tw.name)
+	w.str(tw.seed)
+	w.u64(0)
+	w.zero(16)
+	w.i32(tw.worldID)
+	w.zero(4 * 4)
+	w.i32(tw.height)
+	w.i32(tw.width)
+	w.i32(int32(tw.mode))
+	for i, since := range []int32{222, 227, 238, 239, 241, 249, 266, 267, 302} {
+		if v >= since {
+			w.bool(i == 0 && tw.drunk)
+		}
+	}
+	w.dateTime(tw.created)
+	w.i64(0) // last played
+
+	w.u8(0)
+	w.zero(14*4 + 3*4)
+	w.i32(tw.spawnX)
+	w.i32(tw.spawnY)
+	w.f64(tw.surface)
+	w.f64(tw.cavern)
+	w.f64(0)
+	w.bool(true)
+	w.i32(0)
+	w.bool(false)
+	w.bool(false)
+	w.i32(0) // dungeon x
+	w.i32(0) // dungeon y
+	w.bool(tw.crimson)
+
+	evilBoss := "Eater of Worlds"
+	if tw.crimson {
+		evilBoss = "Brain of Cthulhu"
+	}
+	for _, name := range []string{"Eye of Cthulhu", evilBoss, "Skeletron", "Queen Bee", "The Destroyer", "The Twins", "Skeletron Prime", "", "Plantera", "Golem", "King Slime"} {
+		w.bool(boss(name))
+	}
+
+	w.zero(4 + 3 + 2 + 1 + 4)
+	w.bool(tw.hardmode)
+	w.bool(false)
+	w.zero(3 * 4)
+	w.f64(0)
+	w.f64(0)
+	w.u8(0)
+	w.bool(false)
+	w.i32(0)
+	w.f32(0)
+	w.zero(3*4 + 8 + 4 + 2 + 4)
+
+	w.i32(1) // anglers
+	w.str("Angler")
+	w.zero(1 + 4 + 1 + 1 + 1 + 4 + 4)
+	w.i16(0) // kill counts
+	w.i16(0) // claimable banners
+	w.bool(false)
+
+	for _, name := range []string{"Duke Fishron", "", "Lunatic Cultist", "Moon Lord", "Pumpking", "Mourning Wood", "Ice Queen", "Santa-NK1", "Everscream"} {
+		w.bool(boss(name))
+	}
+	w.zero(4 + 4 + 1 + 2 + 4)
+	w.i32(0) // partying NPCs
+	w.bool(false)
+	w.i32(0)
+	w.f32(0)
+	w.f32(0)
+	w.zero(1 + 3 + 1 + 1 + 3 + 1 + 4 + 3)
+	w.i32(0) // tree tops
+	w.zero(2 + 4*4 + 3)
+
+	w.bool(boss("Empress of Light"))
+	w.bool(boss("Queen Slime"))
+	if v >= 240 {
+		w.bool(boss("Deerclops"))
+	}
+	for _, field := range []struct {
+		since int32
+		size  int
+	}{{250, 1}, {251, 8}, {259, 1}, {260, 1}, {261, 7}, {264, 2}, {287, 2}, {288, 1}, {296, 1}, {291, 8}} {
+		if v >= field.since {
+			w.zero(field.size)
+		}
+	}
+	if v >= 297 {
+		w.u8(0)
+		w.u8(1) // team spawns
+		w.zero(4)
+	}
+	if v >= 304 {
+		w.zero(1)
+	}
+	if v >= 299 && v < 313 {
+		w.zero(4)
+	}
+	if v >= 299 {
+		w.str("{}")
+	}
+}
+
+// writeTiles stores the columns left to right, with runs of identical
+// tiles stored once, as readColumn expects.
+func (tw testWorld) writeTiles(w *writer) {
+	for x := 0; x < int(tw.width); x++ {
+		for y := 0; y < int(tw.height); {
+			t := tw.at(x, y)
+			run := 1
+			for y+run < int(tw.height) && run < 1<<16 && tw.at(x, y+run) == t {
+				run++
+			}
+			tw.writeTile(w, t, run)
+			y += run
+		}
+	}
+}
+
+func (tw testWorld) at(x, y int) Tile {
+	if tw.tile == nil {
+		return Tile{U: -1, V: -1}
+	}
+	t := tw.tile(x, y)
+	if !t.Active || int(t.Type) >= len(tw.importance) || !tw.importance[t.Type] {
+		t.U, t.V = -1, -1
+	}
+	return t
+}
+
+func (tw testWorld) writeTile(w *writer, t Tile, run int) {
+	var flags1, flags2, flags3 uint8
+	if t.Active {
+		flags1 |= 2
+		if t.Type > 0xff {
+			flags1 |= 32
+		}
+	}
+	if t.Wall != 0 {
+		flags1 |= 4
+		if t.Wall > 0xff {
+			flags3 |= 64
+		}
+	}
+	if t.Liquid != LiquidNone {
+		liquid := t.Liquid
+		if liquid == LiquidShimmer {
+			liquid = LiquidWater
+			flags3 |= 128
+		}
+		flags1 |= liquid << 3
+	}
+	if t.Inactive {
+		flags3 |= 4
+	}
+	switch {
+	case run > 256:
+		flags1 |= 2 << 6
+	case run > 1:
+		flags1 |= 1 << 6
+	}
+	if flags3 != 0 {
+		flags2 |= 1
+	}
+	if flags2 != 0 {
+		flags1 |= 1
+	}
+
+	w.u8(flags1)
+	if flags1&1 != 0 {
+		w.u8(flags2)
+		if flags2&1 != 0 {
+			w.u8(flags3)
+		}
+	}
+	if t.Active {
+		if t.Type > 0xff {
+			w.u16(t.Type)
+		} else {
+			w.u8(uint8(t.Type))
+		}
+		if int(t.Type) < len(tw.importance) && tw.importance[t.Type] {
+			w.i16(t.U)
+			w.i16(t.V)
+		}
+	}
+	if t.Wall != 0 {
+		w.u8(uint8(t.Wall))
+	}
+	if t.Liquid != LiquidNone {
+		w.u8(t.LiquidAmount)
+	}
+	if t.Wall > 0xff {
+		w.u8(uint8(t.Wall >> 8))
+	}
+	switch {
+	case run > 256:
+		w.u16(uint16(run - 1))
+	case run > 1:
+		w.u8(uint8(run - 1))
+	}
+}
+
+func (tw testWorld) writeChests(w *writer) {
+	const sharedSlots = 40
+	w.i16(int16(len(tw.chests)))
+	if !tw.slotsPerChest {
+		w.i16(sharedSlots)
+	}
+	for _, c := range tw.chests {
+		w.i32(c.X)
+		w.i32(c.Y)
+		w.str(c.Name)
+		slots := sharedSlots
+		if tw.slotsPerChest {
+			slots = len(c.Items)
+			w.i32(int32(slots))
+		}
+		for i := 0; i < slots; i++ {
+			if i >= len(c.Items) {
+				w.i16(0)
+				continue
+			}
+			item := c.Items[i]
+			w.i16(int16(item.Stack))
+			w.i32(item.ID)
+			w.u8(item.Prefix)
+		}
+	}
+}
+
+func writeTemp(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestReadWorldHeader(t *testing.T) {
+	created := time.Date(2024, 3, 9, 18, 30, 15, 0, time.UTC)
+	for _, version := range []int32{MinWorldVersion, 279, 315} {
+		tw := testWorld{
+			version:  version,
+			name:     "Süderland",
+			seed:     "05.drunk",
+			worldID:  123456,
+			width:    8400,
+			height:   2400,
+			mode:     Master,
+			drunk:    true,
+			created:  created,
+			hardmode: true,
+			crimson:  true,
+			defeated: map[string]bool{"Brain of Cthulhu": true, "Moon Lord": true, "Deerclops": true},
+			spawnX:   4200,
+			spawnY:   300,
+			surface:  420.5,
+			cavern:   700,
+		}
+		h, err := ReadWorldHeader(bytes.NewReader(tw.build()))
+		if err != nil {
+			t.Fatalf("version %d: ReadWorldHeader: %v", version, err)
+		}
+
+		if h.Version != version || h.Name != tw.name || h.Seed != tw.seed || h.WorldID != tw.worldID {
+			t.Errorf("version %d: header = %d %q %q %d", version, h.Version, h.Name, h.Seed, h.WorldID)
+		}
+		if h.Width != 8400 || h.Height != 2400 || h.GameMode != Master || !h.Hardmode || h.Evil != "Crimson" {
+			t.Errorf("version %d: %dx%d %v hardmode %v %s", version, h.Width, h.Height, h.GameMode, h.Hardmode, h.Evil)
+		}
+		if !h.Created.Equal(created) {
+			t.Errorf("version %d: created %v, want %v", version, h.Created, created)
+		}
+		if !reflect.DeepEqual(h.SpecialSeeds, []string{"Drunk World"}) {
+			t.Errorf("version %d: special seeds %v", version, h.SpecialSeeds)
+		}
+		if h.SpawnX != 4200 || h.SpawnY != 300 || h.SurfaceLevel != 420.5 || h.CavernLevel != 700 {
+			t.Errorf("version %d: spawn %d,%d levels %v %v", version, h.SpawnX, h.SpawnY, h.SurfaceLevel, h.CavernLevel)
+		}
+
+		var defeated []string
+		for _, b := range h.Bosses {
+			if b.Defeated {
+				defeated = append(defeated, b.Name)
+			}
+		}
+		// Deerclops was added in version 240.
+		want := []string{"Brain of Cthulhu", "Deerclops", "Moon Lord"}
+		if version < 240 {
+			want = []string{"Brain of Cthulhu", "Moon Lord"}
+		}
+		if !reflect.DeepEqual(defeated, want) || h.DefeatedBosses() != len(want) {
+			t.Errorf("version %d: defeated %v, want %v", version, defeated, want)
+		}
+	}
+}
+
+func TestReadWorldHeaderRejects(t *testing.T) {
+	world := testWorld{}.build()
+
+	notWorld := append([]byte(nil), world...)
+	copy(notWorld[4:], "RELOGIC")
+	if _, err := ReadWorldHeader(bytes.NewReader(notWorld)); !errors.Is(err, ErrNotWorld) {
+		t.Errorf("bad magic: err = %v, want ErrNotWorld", err)
+	}
+
+	player := append([]byte(nil), world...)
+	player[11] = fileTypePlayer
+	if _, err := ReadWorldHeader(bytes.NewReader(player)); !errors.Is(err, ErrNotWorld) {
+		t.Errorf("player file type: err = %v, want ErrNotWorld", err)
+	}
+
+	old := testWorld{version: MinWorldVersion - 1}.build()
+	if _, err := ReadWorldHeader(bytes.NewReader(old)); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("version %d: err = %v, want ErrUnsupportedVersion", MinWorldVersion-1, err)
+	}
+
+	// A header longer than the parser expects, as a newer release might
+	// write, is caught by the recorded offset of the tile section.
+	data := testWorld{}.build()
+	tilesAt := binary.LittleEndian.Uint32(data[26+4:])
+	binary.LittleEndian.PutUint32(data[26+4:], tilesAt+1)
+	if _, err := ReadWorldHeader(bytes.NewReader(data)); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("header of unexpected length: err = %v, want ErrUnsupportedVersion", err)
+	}
+}
+
+func TestReadWorldHeaderTruncated(t *testing.T) {
+	world := testWorld{name: "Short"}.build()
+	tilesAt := int(binary.LittleEndian.Uint32(world[26+4:]))
+	for n := 0; n < tilesAt; n++ {
+		if _, err := ReadWorldHeader(bytes.NewReader(world[:n])); err == nil {
+			t.Fatalf("header cut to %d of %d bytes was accepted", n, tilesAt)
+		}
+	}
+	if _, err := ReadWorldHeader(bytes.NewReader(world[:tilesAt])); err != nil {
+		t.Errorf("complete header: %v", err)
+	}
+}
+
+func TestHeaderCache(t *testing.T) {
+	path := writeTemp(t, "world.wld", testWorld{name: "First"}.build())
+	cache := NewHeaderCache()
+
+	h, err := cache.Get(path)
+	if err != nil || h.Name != "First" {
+		t.Fatalf("Get = %+v, %v", h, err)
+	}
+
+	// A changed file is read again; the size differs, so the mtime's
+	// resolution does not matter.
+	if err := os.WriteFile(path, testWorld{name: "Second world"}.build(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if h, err := cache.Get(path); err != nil || h.Name != "Second world" {
+		t.Errorf("Get after a change = %+v, %v", h, err)
+	}
+
+	if err := os.WriteFile(path, []byte("not a world at all"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cache.Get(path); !errors.Is(err, ErrNotWorld) {
+		t.Errorf("Get of a broken file: err = %v, want ErrNotWorld", err)
+	}
+}